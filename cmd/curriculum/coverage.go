@@ -0,0 +1,92 @@
+package main
+
+import (
+	"bitbucket.org/noon-micro/curriculum/pkg/domain"
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+)
+
+// runCoverage prints the translation coverage of a subtree for release checklists, the exit code is 1 when a
+// locale is below -min-percent or no locale has a translation, and 2 when the report could not be built
+func runCoverage(ats domain.AdminTagsService, args []string) int {
+	flags := flag.NewFlagSet("coverage", flag.ContinueOnError)
+	hierarchy := flags.String("hierarchy", "", "parent path of the subtree, e.g. 9.2")
+	countryId := flags.String("country", "", "country id of the translations, all countries when empty")
+	locale := flags.String("locale", "", "locale of the translations, all locales when empty")
+	tagType := flags.String("type", "", "only list missing tags of this type")
+	missing := flags.Bool("missing", false, "list the untranslated tags")
+	format := flags.String("format", "text", "output format, text or json")
+	minPercent := flags.Float64("min-percent", 0, "fail when a locale is translated below this percent")
+	if err := flags.Parse(args); err != nil {
+		return 2
+	}
+	if *hierarchy == "" || (*format != "text" && *format != "json") {
+		flags.Usage()
+		return 2
+	}
+	coverageRequest := &domain.TranslationCoverageRequest{Hierarchy: hierarchy, Missing: *missing}
+	if *countryId != "" {
+		coverageRequest.CountryId = countryId
+	}
+	if *locale != "" {
+		coverageRequest.Locale = locale
+	}
+	if *tagType != "" {
+		coverageRequest.TagType = tagType
+	}
+	coverage, err := ats.GetTranslationCoverage(context.Background(), coverageRequest)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "coverage: "+err.Error())
+		return 2
+	}
+	if *format == "json" {
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		err = encoder.Encode(coverage)
+	} else {
+		err = writeCoverage(os.Stdout, coverage)
+	}
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "coverage: "+err.Error())
+		return 2
+	}
+	if *minPercent > 0 && len(coverage.Locales) == 0 {
+		return 1
+	}
+	for _, v := range coverage.Locales {
+		if v.Percent < *minPercent {
+			return 1
+		}
+	}
+	return 0
+}
+
+func writeCoverage(w io.Writer, coverage *domain.TranslationCoverage) error {
+	if _, err := fmt.Fprintf(w, "hierarchy %s: %d published tags\n", *coverage.Hierarchy, coverage.Total); err != nil {
+		return err
+	}
+	for _, v := range coverage.Locales {
+		label := v.Locale
+		if v.CountryId != "" {
+			label += "/" + v.CountryId
+		}
+		if _, err := fmt.Fprintf(w, "\n%s\t%d/%d\t%.2f%%\n", label, v.Translated, coverage.Total, v.Percent); err != nil {
+			return err
+		}
+		for _, tagType := range v.Types {
+			if _, err := fmt.Fprintf(w, "  %-12s\t%d translated\t%d missing\n", tagType.TagType, tagType.Translated, tagType.Untranslated); err != nil {
+				return err
+			}
+		}
+		for _, unit := range v.Missing {
+			if _, err := fmt.Fprintf(w, "  missing %s\t%s\t%s\n", *unit.TagID, *unit.TagType, *unit.Source); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
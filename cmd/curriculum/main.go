@@ -4,15 +4,20 @@ import (
 	"bitbucket.org/noon-go/auth"
 	translation "bitbucket.org/noon-go/translator"
 	"bitbucket.org/noon-micro/curriculum/config"
+	"bitbucket.org/noon-micro/curriculum/pkg/domain"
 	"bitbucket.org/noon-micro/curriculum/pkg/external"
+	"bitbucket.org/noon-micro/curriculum/pkg/graphqlresource"
+	"bitbucket.org/noon-micro/curriculum/pkg/grpcresource"
 	"bitbucket.org/noon-micro/curriculum/pkg/lib/helper"
 	"bitbucket.org/noon-micro/curriculum/pkg/lib/httplib"
 	"bitbucket.org/noon-micro/curriculum/pkg/lib/logger"
 	"bitbucket.org/noon-micro/curriculum/pkg/lib/middleware"
+	memory "bitbucket.org/noon-micro/curriculum/pkg/repository/memory"
 	"bitbucket.org/noon-micro/curriculum/pkg/repository/mysql"
 	redis "bitbucket.org/noon-micro/curriculum/pkg/repository/redis"
 	"bitbucket.org/noon-micro/curriculum/pkg/resource"
 	"bitbucket.org/noon-micro/curriculum/pkg/service"
+	"bitbucket.org/noon-micro/curriculum/pkg/service/constant"
 	"github.com/gorilla/handlers"
 	"net/http"
 	"strconv"
@@ -36,9 +41,9 @@ func main() {
 	settingsFileName := os.Args[1]
 
 	allowedHeaders := []string{"country", "x-client-time", "browser", "locale", "Authorization", "Accept", "Content-Type", "timezone" ,
-		"Referer", "platform", "User-Agent", "device-details", "api-version", "os-details", "x-device-id", "resolution", "device_details", "os_details"}
+		"Referer", "platform", "User-Agent", "device-details", "api-version", "os-details", "x-device-id", "resolution", "device_details", "os_details", "If-None-Match", "If-Match"}
 
-	allowedMethods := []string{"GET", "POST", "PUT", "HEAD", "OPTIONS"}
+	allowedMethods := []string{"GET", "POST", "PUT", "DELETE", "HEAD", "OPTIONS"}
 
 	logger.New()
 
@@ -65,34 +70,81 @@ func main() {
 	}
 
 	helper.InitializeValidator()
-	httpClient := noonhttp.Initialize(noonhttp.Config{Timeout: time.Duration(requestTimeout) * time.Second})
-	noonAuthenticateEntity := auth.AuthenticateEntity{
-		Client:      httpClient,
-		BifrostHost: configFile.AuthHost,
+	if err := helper.InitializeTrustedProxies(configFile.TrustedProxies); err != nil {
+		logger.Client.Fatal("Invalid trusted proxies " + configFile.TrustedProxies + " : " + err.Error())
 	}
+	httpClient := noonhttp.Initialize(noonhttp.Config{Timeout: time.Duration(requestTimeout) * time.Second})
 	httplib.InitializeHttp(httpClient)
-	translation.Initialize(httpClient, configFile.TranslationHost)
-	middleware.InitializeMiddleware(&noonAuthenticateEntity)
-	repo := repository.InitializeMysql(configFile)
-	redis.InitializeRedisClient(configFile.RedisHost, configFile.RedisPort)
-	elastic := external.NewElasticExternal(httplib.Client)
-	tagsService := service.NewTagsService(repo.Tags, repo.ParentTagMapping, repo.TagLocaleMapping, repo.LegacyTagMapping, repo.GradeProduct)
-	adminTagsService := service.NewAdminTagsService(tagsService, elastic)
-	geo := external.NewGeoIpExternal(httplib.Client)
+	httplib.InitializeContextHttp(time.Duration(requestTimeout) * time.Second)
+	var repo *repository.Repositories
+	var elastic domain.Elastic
+	var geo domain.GeoIp
+	if settingsFileName == "memory" {
+		fixture, err := memory.LoadFixture(configFile.FixturePath)
+		if err != nil {
+			logger.Client.Fatal("Unable to load fixture " + configFile.FixturePath + " : " + err.Error())
+		}
+		middleware.InitializeMiddleware(middleware.MemoryAuthenticator{})
+		repo = memory.InitializeMemory(fixture)
+		redis.InitializeMemoryRedisClient()
+		elastic = external.NewMemoryElasticExternal(fixture)
+		geo = external.NewGeoIpProvider(httplib.CtxClient, external.NewMemoryGeoIpExternal(fixture))
+	} else {
+		noonAuthenticateEntity := auth.AuthenticateEntity{
+			Client:      httpClient,
+			BifrostHost: configFile.AuthHost,
+		}
+		translation.Initialize(httpClient, configFile.TranslationHost)
+		middleware.InitializeMiddleware(&noonAuthenticateEntity)
+		repo = repository.InitializeMysql(configFile)
+		redis.InitializeRedisClient(configFile.RedisHost, configFile.RedisPort)
+		elastic = external.NewElasticExternal(httplib.CtxClient)
+		geoIpDatabasePath := configFile.GeoIpDatabasePath
+		if geoIpDatabasePath == "" {
+			geoIpDatabasePath = config.DefaultGeoIpDatabasePath
+		}
+		maxmindGeo := external.NewMaxmindGeoIpExternal(geoIpDatabasePath, helper.Timeout(configFile.GeoIpReloadInterval, config.DefaultGeoIpReloadInterval))
+		geo = external.NewGeoIpProvider(httplib.CtxClient, maxmindGeo)
+	}
+	elastic = service.NewSearchElastic(elastic, configFile.SearchKeyQueries == "true")
+	tagsService := service.NewTagsService(repo.Tags, repo.ParentTagMapping, repo.TagLocaleMapping, repo.TagAttributeLocale, repo.TagCountryRule, repo.RegionCountry, repo.LegacyTagMapping, repo.GradeProduct)
+	middleware.InitializeLoader(tagsService.NewTagLoader)
+	middleware.InitializeLocaleResolver(tagsService.FetchCountryLocales, constant.DefaultLocale)
+	middleware.InitializeConditionalGet(helper.Timeout(configFile.ListingMaxAge, config.DefaultListingMaxAge), tagsService.FetchCacheGenerations)
+	webhookService := service.NewWebhookService(repo.WebhookSubscription, repo.WebhookDelivery, external.NewHttpWebhookSender(httplib.CtxClient))
+	tagChangeService := service.NewTagChangeService(repo.TagChange)
+	tagEventPublisher := service.NewTagEventPublisher(tagsService, repo.TagEventOutbox, repo.TagChange, external.NewTagEventBroker(redis.RedisClient, webhookService))
+	adminTagsService := service.NewAdminTagsService(tagsService, elastic, external.NewTranslationProvider(httplib.CtxClient), tagEventPublisher)
+	if len(os.Args) > 2 && os.Args[2] == "coverage" {
+		os.Exit(runCoverage(adminTagsService, os.Args[3:]))
+	}
 	studentTagsService := service.NewStudentTagsService(tagsService, elastic, geo)
-	rpcTagsService := service.NewRpcTagsService(tagsService, elastic)
+	rpcTagsService := service.NewRpcTagsService(tagsService, elastic, tagEventPublisher)
 	teacherTagsService := service.NewTeacherTagsService(tagsService, elastic, geo)
+	tagGraphService := service.NewTagGraphService(tagsService, elastic)
 	r := httptrace.NewRouter(httptrace.WithServiceName("curriculum")).StrictSlash(false)
 	mainRoutes := r.PathPrefix("/curriculum/v1/").Subrouter()
 	//resource.NewTagsResource(mainRoutes, tagsService)
 	resource.NewAdminTagsResource(mainRoutes, adminTagsService)
+	resource.NewAdminWebhookResource(mainRoutes, webhookService)
 	resource.NewStudentTagsResource(mainRoutes, studentTagsService)
 	resource.NewRpcTagsResource(r.Router, rpcTagsService)
+	resource.NewRpcChangesResource(r.Router, tagChangeService)
 	resource.NewHealthResource(r.Router, repo.Db)
 	resource.NewTeacherTagsResource(mainRoutes, teacherTagsService)
+	graphqlresource.NewGraphqlResource(mainRoutes, tagGraphService)
+	webhookService.Start()
+	tagChangeService.Start()
+	tagEventPublisher.Start()
+	grpcServer := grpcresource.NewServer(rpcTagsService, repo.Db)
+	go func() {
+		logger.Client.Info("Grpc Server Listens On Port " + configFile.GrpcPort)
+		logger.Client.Fatal(grpcServer.Serve(configFile.GrpcPort, config.DefaultGrpcHealthInterval))
+	}()
 	logger.Client.Info("Http Server Listens On Public Port " + configFile.PublicAppPort)
 	logger.Client.Fatal(http.ListenAndServe(":"+configFile.PublicAppPort, handlers.CORS(
 		handlers.AllowedHeaders(allowedHeaders),
 		handlers.AllowedMethods(allowedMethods),
+		handlers.ExposedHeaders([]string{"ETag", "Content-Language"}),
 		handlers.AllowedOrigins([]string{"*"}))(r)))
 }
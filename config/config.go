@@ -1,10 +1,58 @@
 package config
 
+import "time"
+
+// Default per operation deadlines, used when the matching timeout is not configured
+const (
+	DefaultMySqlTimeout   = 5 * time.Second
+	DefaultRedisTimeout   = 500 * time.Millisecond
+	DefaultElasticTimeout = 3 * time.Second
+	DefaultGeoIpTimeout   = time.Second
+
+	DefaultGeoIpDatabaseTimeout = 100 * time.Millisecond
+	// DefaultGeoIpReloadInterval is how often the geo ip database file is checked for changes
+	DefaultGeoIpReloadInterval = time.Minute
+	DefaultGeoIpDatabasePath   = "GeoIP2-Country.mmdb"
+	DefaultGeoIpHost           = "http://api.ipstack.com"
+	DefaultGeoIpProviders      = "mmdb,http,header,default"
+	// FallbackCountryCode is the country selected when DefaultCountryCode is not configured
+	FallbackCountryCode = "SA"
+
+	DefaultTranslationProviderTimeout = 10 * time.Second
+
+	DefaultGrpcPort = "9002"
+	// DefaultGrpcHealthInterval is how often the database is pinged for the grpc health status
+	DefaultGrpcHealthInterval = 10 * time.Second
+
+	DefaultTagEventStream = "curriculum:tag_events"
+	// DefaultTagEventRelayInterval is how often the outbox is checked for committed events
+	DefaultTagEventRelayInterval = 500 * time.Millisecond
+
+	DefaultWebhookTimeout = 10 * time.Second
+	// DefaultWebhookPollInterval is how often the webhook worker looks for due deliveries when it is not woken up
+	DefaultWebhookPollInterval = 5 * time.Second
+	// DefaultWebhookRetryBackoff is the wait before the second attempt of a delivery, it doubles after every attempt
+	DefaultWebhookRetryBackoff = 30 * time.Second
+	DefaultWebhookMaxAttempts  = 8
+
+	// DefaultTagChangeRetention is how long the changes feed keeps a change, older cursors have to resync
+	DefaultTagChangeRetention = 30 * 24 * time.Hour
+	// DefaultTagChangePollInterval is how often a long poll on the changes feed looks for changes of other instances
+	DefaultTagChangePollInterval = time.Second
+
+	// DefaultListingMaxAge is how long clients may reuse the student and teacher listings before revalidating them
+	DefaultListingMaxAge = 5 * time.Minute
+)
+
 // Configuration main struct
 type Configuration struct {
 	AuthHost               string
 	TranslationHost        string
 	RequestTimeout         string
+	MySqlTimeout           string
+	RedisTimeout           string
+	ElasticTimeout         string
+	GeoIpTimeout           string
 	MySqlHost              string
 	MySqlDatabaseName      string
 	MySqlUserName          string
@@ -30,6 +78,50 @@ type Configuration struct {
 	UniversitySectionTagId string
 	DefaultColor           string
 	DefaultPic             string
+	FixturePath            string
+	LocaleFallbacks        string
+	// TranslationProviders is the comma separated order in which providers are asked for suggestions, e.g. glossary,http
+	TranslationProviders       string
+	TranslationProviderHost    string
+	TranslationProviderKey     string
+	TranslationProviderTimeout string
+	GlossaryPath               string
+	GeoIpDatabasePath          string
+	GeoIpReloadInterval        string
+	GeoIpDatabaseTimeout       string
+	GeoIpKey                   string
+	// GeoIpProviders is the comma separated order in which providers resolve the country of a client, e.g.
+	// mmdb,http,header,default
+	GeoIpProviders string
+	// DefaultCountryCode is the iso code of the country selected when the client country is unknown
+	DefaultCountryCode string
+	// TrustedProxies is the comma separated list of proxy CIDRs whose forwarding headers are believed
+	TrustedProxies string
+	// GrpcPort is the port of the grpc api served next to the http one
+	GrpcPort string
+	// TagEventBrokers is the comma separated list of brokers every tag event is published to, e.g. redis,log
+	TagEventBrokers string
+	// TagEventLogPath is the file the log broker appends events to, the service log when empty
+	TagEventLogPath string
+	// TagEventStream is the redis stream the redis broker adds events to
+	TagEventStream string
+	// TagEventRelayInterval is in milliseconds like the other timeouts
+	TagEventRelayInterval string
+	// WebhookTimeout bounds a single webhook delivery attempt
+	WebhookTimeout      string
+	WebhookPollInterval string
+	WebhookRetryBackoff string
+	// WebhookMaxAttempts is the number of attempts after which a delivery is marked failed
+	WebhookMaxAttempts int
+	// TagChangeRetention and TagChangePollInterval are in milliseconds like the other timeouts
+	TagChangeRetention    string
+	TagChangePollInterval string
+	// ListingMaxAge is the max-age of the listings in milliseconds
+	ListingMaxAge string
+	// SearchKeyQueries is "true" once every document of the search index carries the search keys of its names, until
+	// then the text is searched as typed. The keys of existing documents are written by running /admin/elastic/migrate
+	// over the whole tag id range.
+	SearchKeyQueries string
 }
 
 type Config interface {
@@ -51,6 +143,8 @@ func LoadConfiguration(settings string) *Configuration {
 	switch settings {
 	case "local":
 		config = &LocalConfig{&conf}
+	case "memory":
+		config = &MemoryConfig{&conf}
 	default:
 		config = &ServerConfig{&conf}
 	}
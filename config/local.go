@@ -10,6 +10,10 @@ func (conf *LocalConfig) ConfigManager() *Configuration {
 	conf.AuthHost = "http://bifrost.prod-rpc.non.sa"
 	conf.TranslationHost = "http://translations.prod-rpc.non.sa"
 	conf.RequestTimeout = "10000"
+	conf.MySqlTimeout = "5000"
+	conf.RedisTimeout = "500"
+	conf.ElasticTimeout = "3000"
+	conf.GeoIpTimeout = "1000"
 	conf.MySqlHost = "aurora-prod-micro-cluster.cluster-ro-cres8iqjkrdw.eu-central-1.rds.amazonaws.com:3306"
 	conf.MySqlDatabaseName = "folders_srv"
 	conf.MySqlUserName = "bhavik_reader"
@@ -20,8 +24,25 @@ func (conf *LocalConfig) ConfigManager() *Configuration {
 	conf.RedisHost = "redis-qa-001.fdmtkw.0001.euw1.cache.amazonaws.com."
 	conf.RedisPort = "6379"
 	conf.PublicAppPort = "8002"
+	conf.GrpcPort = DefaultGrpcPort
+	conf.TagEventBrokers = "log"
+	conf.TagEventRelayInterval = "500"
+	conf.WebhookTimeout = "10000"
+	conf.WebhookPollInterval = "5000"
+	conf.WebhookRetryBackoff = "30000"
+	conf.WebhookMaxAttempts = DefaultWebhookMaxAttempts
+	conf.TagChangeRetention = "2592000000"
+	conf.TagChangePollInterval = "1000"
+	conf.ListingMaxAge = "300000"
+	conf.SearchKeyQueries = "false"
 	conf.ElasticHost = "http://elastic.prod-rpc.non.sa"
-	conf.GeoIpHost = "http://api.ipstack.com"
+	conf.GeoIpHost = DefaultGeoIpHost
+	conf.GeoIpProviders = DefaultGeoIpProviders
+	conf.GeoIpDatabaseTimeout = "100"
+	conf.DefaultCountryCode = FallbackCountryCode
+	conf.GeoIpDatabasePath = "GeoIP2-Country.mmdb"
+	conf.GeoIpReloadInterval = "60000"
+	conf.TrustedProxies = "127.0.0.1/32,::1/128"
 	conf.MiscTagId = "22678"
 	conf.ResourceTagId = "22677"
 	conf.BoardTagId = "24681"
@@ -0,0 +1,57 @@
+package config
+
+import "os"
+
+// MemoryConfig struct
+type MemoryConfig struct {
+	*Configuration
+}
+
+// ConfigManager for the memory environment, which runs without mysql, redis or any remote service
+func (conf *MemoryConfig) ConfigManager() *Configuration {
+	conf.RequestTimeout = "10000"
+	conf.PublicAppPort = "8002"
+	if port := os.Getenv("PORT"); port != "" {
+		conf.PublicAppPort = port
+	}
+	conf.GrpcPort = DefaultGrpcPort
+	if grpcPort := os.Getenv("GRPC_PORT"); grpcPort != "" {
+		conf.GrpcPort = grpcPort
+	}
+	conf.FixturePath = "fixtures/memory.json"
+	if fixturePath := os.Getenv("FIXTURE_PATH"); fixturePath != "" {
+		conf.FixturePath = fixturePath
+	}
+	conf.TranslationProviders = "glossary"
+	conf.GlossaryPath = "fixtures/glossary.json"
+	if glossaryPath := os.Getenv("GLOSSARY_PATH"); glossaryPath != "" {
+		conf.GlossaryPath = glossaryPath
+	}
+	conf.TagEventBrokers = "memory"
+	if tagEventBrokers := os.Getenv("TAG_EVENT_BROKERS"); tagEventBrokers != "" {
+		conf.TagEventBrokers = tagEventBrokers
+	}
+	conf.TagEventLogPath = os.Getenv("TAG_EVENT_LOG_PATH")
+	conf.TagEventRelayInterval = "200"
+	conf.WebhookPollInterval = "1000"
+	conf.WebhookRetryBackoff = "1000"
+	conf.WebhookMaxAttempts = 3
+	conf.TagChangePollInterval = "200"
+	conf.SearchKeyQueries = "true"
+	conf.TrustedProxies = "127.0.0.1/32,::1/128"
+	conf.GeoIpProviders = "mmdb,header,default"
+	conf.DefaultCountryCode = FallbackCountryCode
+	if defaultCountryCode := os.Getenv("DEFAULT_COUNTRY_CODE"); defaultCountryCode != "" {
+		conf.DefaultCountryCode = defaultCountryCode
+	}
+	conf.MiscTagId = "900"
+	conf.ResourceTagId = "901"
+	conf.BoardTagId = "2"
+	conf.DegreeTagId = "40"
+	conf.MajorTagId = "41"
+	conf.CourseTagId = "42"
+	conf.UniversitySectionTagId = "43"
+	conf.DefaultColor = "#1A8DFF"
+	conf.DefaultPic = "http://cdn.non.sa/product/default.png"
+	return conf.Configuration
+}
@@ -15,6 +15,10 @@ func (conf *ServerConfig) ConfigManager() *Configuration {
 	conf.AuthHost = os.Getenv("BIFROST_HOST")
 	conf.TranslationHost = os.Getenv("TRANSLATION_HOST")
 	conf.RequestTimeout = os.Getenv("API_TIMEOUT")
+	conf.MySqlTimeout = os.Getenv("DB_TIMEOUT")
+	conf.RedisTimeout = os.Getenv("REDIS_TIMEOUT")
+	conf.ElasticTimeout = os.Getenv("ELASTIC_TIMEOUT")
+	conf.GeoIpTimeout = os.Getenv("GEO_IP_TIMEOUT")
 	conf.MySqlPassword = os.Getenv("DB_PASS_WRITE")
 	conf.MySqlHost = os.Getenv("DB_HOST_WRITE")
 	conf.MySqlDatabaseName = os.Getenv("DB_NAME")
@@ -28,6 +32,22 @@ func (conf *ServerConfig) ConfigManager() *Configuration {
 	conf.RedisHost = os.Getenv("REDIS_HOST")
 	conf.RedisPort = os.Getenv("REDIS_PORT")
 	conf.PublicAppPort = os.Getenv("PORT")
+	conf.TagEventBrokers = os.Getenv("TAG_EVENT_BROKERS")
+	conf.TagEventLogPath = os.Getenv("TAG_EVENT_LOG_PATH")
+	conf.TagEventStream = os.Getenv("TAG_EVENT_STREAM")
+	conf.TagEventRelayInterval = os.Getenv("TAG_EVENT_RELAY_INTERVAL")
+	conf.WebhookTimeout = os.Getenv("WEBHOOK_TIMEOUT")
+	conf.WebhookPollInterval = os.Getenv("WEBHOOK_POLL_INTERVAL")
+	conf.WebhookRetryBackoff = os.Getenv("WEBHOOK_RETRY_BACKOFF")
+	conf.WebhookMaxAttempts, _ = strconv.Atoi(os.Getenv("WEBHOOK_MAX_ATTEMPTS"))
+	conf.TagChangeRetention = os.Getenv("TAG_CHANGE_RETENTION")
+	conf.TagChangePollInterval = os.Getenv("TAG_CHANGE_POLL_INTERVAL")
+	conf.ListingMaxAge = os.Getenv("LISTING_MAX_AGE")
+	conf.SearchKeyQueries = os.Getenv("SEARCH_KEY_QUERIES")
+	conf.GrpcPort = os.Getenv("GRPC_PORT")
+	if conf.GrpcPort == "" {
+		conf.GrpcPort = DefaultGrpcPort
+	}
 	conf.DataDogDEnabled = os.Getenv("DD_ENABLED")
 	conf.DataDogAgentHost = os.Getenv("DD_AGENT_HOST")
 	conf.DataDogVersion = os.Getenv("DD_VERSION")
@@ -40,8 +60,27 @@ func (conf *ServerConfig) ConfigManager() *Configuration {
 	conf.MajorTagId = os.Getenv("MAJOR_TAG_ID")
 	conf.CourseTagId = os.Getenv("COURSE_TAG_ID")
 	conf.UniversitySectionTagId = os.Getenv("UNIVERSITY_SECTION_TAG_ID")
-	conf.GeoIpHost = "http://api.ipstack.com"
+	conf.GeoIpHost = os.Getenv("GEO_IP_HOST")
+	if conf.GeoIpHost == "" {
+		conf.GeoIpHost = DefaultGeoIpHost
+	}
+	conf.GeoIpKey = os.Getenv("GEO_IP_KEY")
+	conf.GeoIpProviders = os.Getenv("GEO_IP_PROVIDERS")
+	conf.GeoIpDatabaseTimeout = os.Getenv("GEO_IP_DATABASE_TIMEOUT")
+	conf.DefaultCountryCode = os.Getenv("DEFAULT_COUNTRY_CODE")
+	if conf.DefaultCountryCode == "" {
+		conf.DefaultCountryCode = FallbackCountryCode
+	}
 	conf.DefaultColor = os.Getenv("DEFAULT_COLOR")
 	conf.DefaultPic = os.Getenv("DEFAULT_PIC")
+	conf.LocaleFallbacks = os.Getenv("LOCALE_FALLBACKS")
+	conf.TranslationProviders = os.Getenv("TRANSLATION_PROVIDERS")
+	conf.TranslationProviderHost = os.Getenv("TRANSLATION_PROVIDER_HOST")
+	conf.TranslationProviderKey = os.Getenv("TRANSLATION_PROVIDER_KEY")
+	conf.TranslationProviderTimeout = os.Getenv("TRANSLATION_PROVIDER_TIMEOUT")
+	conf.GlossaryPath = os.Getenv("GLOSSARY_PATH")
+	conf.GeoIpDatabasePath = os.Getenv("GEO_IP_DATABASE_PATH")
+	conf.GeoIpReloadInterval = os.Getenv("GEO_IP_RELOAD_INTERVAL")
+	conf.TrustedProxies = os.Getenv("TRUSTED_PROXIES")
 	return conf.Configuration
 }
@@ -0,0 +1,6 @@
+-- Drafts are translations waiting for review, they are never served as publish stays 0 until they are approved.
+-- Rows written before this migration are not drafts.
+ALTER TABLE tag_locale_mapping
+    ADD COLUMN draft tinyint(1) NOT NULL DEFAULT 0,
+    ADD COLUMN suggested_by varchar(64) NULL,
+    ADD INDEX idx_tag_locale_mapping_draft (country_id, locale, draft, tag_id);
@@ -0,0 +1,16 @@
+-- Localized values of the string attributes of a tag, per country and locale. Removed values keep their row with
+-- publish = 0.
+CREATE TABLE IF NOT EXISTS tag_attribute_locale_mapping (
+    id         bigint       NOT NULL AUTO_INCREMENT,
+    tag_id     varchar(64)  NOT NULL,
+    tag_type   varchar(64)  NOT NULL,
+    attribute  varchar(64)  NOT NULL,
+    locale     varchar(16)  NOT NULL,
+    country_id varchar(64)  NOT NULL,
+    `value`    text         NOT NULL,
+    publish    tinyint(1)   NOT NULL DEFAULT 1,
+    created_at bigint       NOT NULL,
+    updated_at bigint       NOT NULL,
+    PRIMARY KEY (id),
+    INDEX idx_tag_attribute_locale_mapping_tag (tag_id, publish)
+) ENGINE = InnoDB DEFAULT CHARSET = utf8mb4;
@@ -0,0 +1,14 @@
+-- Country rules restrict where a tag is available. An exclude rule hides the tag in its country, include rules
+-- limit the tag to the included countries. Removed rules keep their row with publish = 0.
+CREATE TABLE IF NOT EXISTS tag_country_rule (
+    id         bigint      NOT NULL AUTO_INCREMENT,
+    tag_id     varchar(64) NOT NULL,
+    tag_type   varchar(64) NOT NULL,
+    country_id varchar(64) NOT NULL,
+    rule       varchar(16) NOT NULL,
+    publish    tinyint(1)  NOT NULL DEFAULT 1,
+    created_at bigint      NOT NULL,
+    updated_at bigint      NOT NULL,
+    PRIMARY KEY (id),
+    INDEX idx_tag_country_rule_tag (tag_id, publish)
+) ENGINE = InnoDB DEFAULT CHARSET = utf8mb4;
@@ -0,0 +1,12 @@
+-- Member countries of a region tag, listed in order. Replacing the members unpublishes the previous rows.
+CREATE TABLE IF NOT EXISTS region_country_mapping (
+    id         bigint      NOT NULL AUTO_INCREMENT,
+    region_id  varchar(64) NOT NULL,
+    country_id varchar(64) NOT NULL,
+    `order`    int         NOT NULL DEFAULT 0,
+    publish    tinyint(1)  NOT NULL DEFAULT 1,
+    created_at bigint      NOT NULL,
+    updated_at bigint      NOT NULL,
+    PRIMARY KEY (id),
+    INDEX idx_region_country_mapping_region (region_id, publish, `order`)
+) ENGINE = InnoDB DEFAULT CHARSET = utf8mb4;
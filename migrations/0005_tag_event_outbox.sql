@@ -0,0 +1,16 @@
+-- The outbox keeps every tag event from the transaction of its change until the relay has handed it to the brokers.
+-- The id is the sequence of the event, relayed_at stays NULL until the event is relayed.
+CREATE TABLE IF NOT EXISTS tag_event_outbox (
+    id             bigint      NOT NULL AUTO_INCREMENT,
+    event_id       varchar(64) NOT NULL,
+    event_type     varchar(64) NOT NULL,
+    schema_version int         NOT NULL,
+    tag_id         varchar(64) NOT NULL,
+    tag_type       varchar(64) NOT NULL,
+    data           text        NULL,
+    occurred_at    bigint      NOT NULL,
+    relayed_at     bigint      NULL,
+    PRIMARY KEY (id),
+    UNIQUE KEY uk_tag_event_outbox_event (event_id),
+    INDEX idx_tag_event_outbox_relayed (relayed_at, id)
+) ENGINE = InnoDB DEFAULT CHARSET = utf8mb4;
@@ -0,0 +1,36 @@
+-- Webhook subscriptions and their deliveries. Removed subscriptions keep their row with publish = 0, the list
+-- columns hold comma separated values. Times are in milliseconds like the other tables.
+CREATE TABLE IF NOT EXISTS webhook_subscription (
+    id             bigint        NOT NULL AUTO_INCREMENT,
+    url            varchar(2048) NOT NULL,
+    secret         varchar(255)  NOT NULL,
+    subtree_prefix varchar(255)  NULL,
+    tag_types      varchar(1024) NOT NULL DEFAULT '',
+    event_types    varchar(1024) NOT NULL DEFAULT '',
+    active         tinyint(1)    NOT NULL DEFAULT 1,
+    publish        tinyint(1)    NOT NULL DEFAULT 1,
+    created_at     bigint        NOT NULL,
+    updated_at     bigint        NOT NULL,
+    PRIMARY KEY (id)
+) ENGINE = InnoDB DEFAULT CHARSET = utf8mb4;
+
+-- The due deliveries are polled by status and next_attempt_at in that order, a worker claims one by moving its
+-- next_attempt_at. The subscription index serves the delivery log of a subscription, newest first.
+CREATE TABLE IF NOT EXISTS webhook_delivery (
+    id              bigint      NOT NULL AUTO_INCREMENT,
+    subscription_id bigint      NOT NULL,
+    event_id        varchar(64) NOT NULL,
+    event_type      varchar(64) NOT NULL,
+    tag_id          varchar(64) NOT NULL,
+    payload         mediumtext  NOT NULL,
+    status          varchar(16) NOT NULL,
+    attempts        int         NOT NULL DEFAULT 0,
+    response_status int         NULL,
+    error           text        NULL,
+    next_attempt_at bigint      NOT NULL,
+    created_at      bigint      NOT NULL,
+    updated_at      bigint      NOT NULL,
+    PRIMARY KEY (id),
+    INDEX idx_webhook_delivery_due (status, next_attempt_at, id),
+    INDEX idx_webhook_delivery_subscription (subscription_id, status, id)
+) ENGINE = InnoDB DEFAULT CHARSET = utf8mb4;
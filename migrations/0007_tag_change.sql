@@ -0,0 +1,17 @@
+-- The changes feed, the relay records every tag event here in the transaction which claims it.
+-- The relays take turns on their claims of the outbox rows, so the ids commit in order and a cursor never passes a
+-- change still committing. The unique event_id keeps an event relayed again from being recorded twice.
+CREATE TABLE IF NOT EXISTS tag_change (
+    id          bigint      NOT NULL AUTO_INCREMENT,
+    event_id    varchar(64) NOT NULL,
+    event_type  varchar(64) NOT NULL,
+    entity      varchar(64) NOT NULL,
+    tag_id      varchar(64) NOT NULL,
+    tag_type    varchar(64) NOT NULL,
+    parent_tags text        NULL,
+    changed_at  bigint      NOT NULL,
+    recorded_at bigint      NOT NULL,
+    PRIMARY KEY (id),
+    UNIQUE KEY uk_tag_change_event (event_id),
+    INDEX idx_tag_change_recorded (recorded_at)
+) ENGINE = InnoDB DEFAULT CHARSET = utf8mb4;
@@ -0,0 +1,13 @@
+-- Admin edits carry the version they were made against and are rejected once the row moved past it.
+-- Tags start at version 0, a sibling set gets its tag_order_version row on its first reorder or new mapping and is at
+-- version 0 until then.
+ALTER TABLE tags
+    ADD COLUMN version int NOT NULL DEFAULT 0;
+
+CREATE TABLE IF NOT EXISTS tag_order_version (
+    parent_tag_id varchar(255) NOT NULL,
+    tag_type      varchar(64)  NOT NULL,
+    version       int          NOT NULL,
+    updated_at    bigint       NOT NULL,
+    PRIMARY KEY (parent_tag_id, tag_type)
+) ENGINE = InnoDB DEFAULT CHARSET = utf8mb4;
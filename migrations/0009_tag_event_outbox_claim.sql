@@ -0,0 +1,6 @@
+-- The relay claims the pending events in a short transaction and hands them to the brokers after its commit.
+-- claimed_by is the relay holding the claim and claimed_until the time the claim runs out at, another relay takes the
+-- events over once it has. Both are cleared when the events are relayed or released.
+ALTER TABLE tag_event_outbox
+    ADD COLUMN claimed_by    varchar(64) NULL,
+    ADD COLUMN claimed_until bigint      NULL;
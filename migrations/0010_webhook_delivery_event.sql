@@ -0,0 +1,13 @@
+-- A tag event relayed again queues its delivery to a subscription once. redelivery_of is 0 on the delivery the event
+-- queued and the id of the redelivered delivery on the ones queued by hand, a delivery is redelivered once and its
+-- redelivery is redelivered after it. Deliveries queued twice before this migration keep their log under their own id.
+ALTER TABLE webhook_delivery
+    ADD COLUMN redelivery_of bigint NOT NULL DEFAULT 0;
+
+UPDATE webhook_delivery d
+    JOIN (SELECT subscription_id, event_id, MIN(id) AS id FROM webhook_delivery GROUP BY subscription_id, event_id) o
+    ON o.subscription_id = d.subscription_id AND o.event_id = d.event_id AND o.id < d.id
+SET d.redelivery_of = d.id;
+
+ALTER TABLE webhook_delivery
+    ADD UNIQUE KEY uk_webhook_delivery_event (subscription_id, event_id, redelivery_of);
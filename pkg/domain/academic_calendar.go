@@ -0,0 +1,59 @@
+package domain
+
+// AcademicCalendar is kept on the country tag under the academic_calendar attribute. Dates are yyyy-mm-dd, the
+// promotion date is the mm-dd on which students move up a grade every year.
+type AcademicCalendar struct {
+	PromotionDate *string         `json:"promotion_date"`
+	Years         []*AcademicYear `json:"years"`
+}
+
+type AcademicYear struct {
+	Name      *string            `json:"name"`
+	StartDate *string            `json:"start_date"`
+	EndDate   *string            `json:"end_date"`
+	Terms     []*AcademicTerm    `json:"terms"`
+	Holidays  []*AcademicHoliday `json:"holidays"`
+}
+
+type AcademicTerm struct {
+	Name      *string `json:"name"`
+	StartDate *string `json:"start_date"`
+	EndDate   *string `json:"end_date"`
+}
+
+type AcademicHoliday struct {
+	Name      *string `json:"name"`
+	StartDate *string `json:"start_date"`
+	EndDate   *string `json:"end_date"`
+}
+
+type GetCurrentTerm struct {
+	CountryId *string `json:"country_id"`
+	Date      *string `json:"date"`
+}
+
+// CurrentTerm leaves Current unset between terms and Next unset after the last configured term
+type CurrentTerm struct {
+	CountryId *string       `json:"country_id"`
+	Date      *string       `json:"date"`
+	Year      *string       `json:"year"`
+	Current   *AcademicTerm `json:"current"`
+	Next      *AcademicTerm `json:"next"`
+	Holiday   *string       `json:"holiday"`
+}
+
+type GetExpectedGrade struct {
+	CountryId  *string `json:"country_id"`
+	BoardId    *string `json:"board_id"`
+	GradeId    *string `json:"grade_id"`
+	EnrolledAt *string `json:"enrolled_at"`
+	Date       *string `json:"date"`
+}
+
+// ExpectedGrade stops at the last grade of the board, Graduated tells the student has moved past it
+type ExpectedGrade struct {
+	GradeId    *string `json:"grade_id"`
+	Name       *string `json:"name"`
+	Promotions int     `json:"promotions"`
+	Graduated  bool    `json:"graduated"`
+}
@@ -1,19 +1,39 @@
 package domain
 
+import "context"
+
 type AdminTagsService interface {
-	CreateAdminTags(*string, *CreateTags) (*TagResponse, error)
-	UpdateAdminTags(*string, *UpdateTags) (*TagResponse, error)
-	UpdateMultipleAdminTags(*UpdateMultipleTags) ([]*string, error)
-	UpdateTagOrder(*string, *UpdateTagOrder) error
-	RemoveAdminTagFromHierarchy(*string, *RemoveHierarchy) (*TagResponse, error)
-	RemoveIdentifierTag(*string) error
-	MigrateToElastic(*string, *string) error
-	GetTags(tags *GetTags) (*GetTagsResponse, error)
-	GetTag(id *string) (tagResponse *TagResponse, err error)
-	GetTagsSearch(tags *GetTags) (*GetTagsResponse, error)
-	UpdateTagLocale(*string, *TagLocale) error
-	UpdateTag(*UpdateTag) error
-	GetAdminTags(tags *GetAdminTags) (getTagResponse *GetTagsResponse, err error)
-	GetTestsSkillsForLibrary(gtt *GetAdminTags) (*GetTagsResponse, error)
-	GetCountriesTagsNew(tags *GetCountriesNew) (getTagResponse *GetCountriesNewResponse, err error)
+	CreateAdminTags(context.Context, *string, *CreateTags) (*TagResponse, error)
+	UpdateAdminTags(context.Context, *string, *UpdateTags) (*TagResponse, error)
+	UpdateMultipleAdminTags(context.Context, *UpdateMultipleTags) ([]*string, error)
+	UpdateTagOrder(context.Context, *string, *UpdateTagOrder) (*TagOrders, error)
+	RemoveAdminTagFromHierarchy(context.Context, *string, *RemoveHierarchy) (*TagResponse, error)
+	RemoveIdentifierTag(context.Context, *string) error
+	MigrateToElastic(context.Context, *string, *string) error
+	GetTags(ctx context.Context, tags *GetTags) (*GetTagsResponse, error)
+	GetTag(ctx context.Context, id *string) (tagResponse *TagResponse, err error)
+	GetTagsSearch(ctx context.Context, tags *GetTags) (*GetTagsResponse, error)
+	UpdateTagLocale(context.Context, *string, *TagLocale) error
+	ExportTagLocales(context.Context, *TranslationExport) ([]*TranslationUnit, error)
+	ImportTagLocales(context.Context, *TranslationImport) (*TranslationImportResult, error)
+	GetTranslationCoverage(context.Context, *TranslationCoverageRequest) (*TranslationCoverage, error)
+	SuggestTagLocales(context.Context, *TranslationSuggest) (*TranslationSuggestResult, error)
+	GetTagLocaleDrafts(context.Context, *TranslationDraftRequest) ([]*TranslationDraft, error)
+	ReviewTagLocaleDrafts(context.Context, *TranslationReview) (*TranslationReviewResult, error)
+	GetTagAttributeLocales(context.Context, *string) ([]*TagAttributeLocale, error)
+	UpdateTagAttributeLocales(context.Context, *string, *UpdateTagAttributeLocales) ([]*TagAttributeLocale, error)
+	GetTagAvailability(context.Context, *string) (*TagAvailability, error)
+	UpdateTagAvailability(context.Context, *string, *TagAvailability) (*TagAvailability, error)
+	GetRegions(context.Context) ([]*Region, error)
+	UpdateRegionCountries(context.Context, *string, *UpdateRegionCountries) (*Region, error)
+	GetAcademicCalendar(context.Context, *string) (*AcademicCalendar, error)
+	UpdateAcademicCalendar(context.Context, *string, *AcademicCalendar) (*AcademicCalendar, error)
+	UpdateTag(context.Context, *UpdateTag) (*TagResponse, error)
+	GetAdminTags(ctx context.Context, tags *GetAdminTags) (getTagResponse *GetTagsResponse, err error)
+	GetTestsSkillsForLibrary(ctx context.Context, gtt *GetAdminTags) (*GetTagsResponse, error)
+	GetCountriesTagsNew(ctx context.Context, tags *GetCountriesNew) (getTagResponse *GetCountriesNewResponse, err error)
+	GetTagCache(context.Context, *string) ([]*CacheEntry, error)
+	GetHierarchyCache(context.Context, *string) ([]*CacheEntry, error)
+	EvictCache(context.Context, []*string) ([]string, error)
+	FlushHierarchyCache(context.Context, *string) ([]string, error)
 }
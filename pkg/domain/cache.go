@@ -0,0 +1,10 @@
+package domain
+
+// CacheEntry describes one redis key together with what MySQL currently holds for it
+type CacheEntry struct {
+	Key    string      `json:"key"`
+	Ttl    int64       `json:"ttl"`
+	Cached interface{} `json:"cached"`
+	Source interface{} `json:"source,omitempty"`
+	Stale  bool        `json:"stale"`
+}
@@ -1,17 +1,26 @@
 package domain
 
+import "context"
+
 type Elastic interface {
-	CreateTag(*CreateTagElastic) error
-	GetTags(*GetTagsElastic) ([]*string, *int, error)
-	GetTagsSearch(*GetTagsElastic) ([]*string, *int, error)
-	UpdateTag(*string, *bool, []*TagName) error
-	AddParentTags(*string, []*string) error
-	RemoveParentTags(*string, []*string) error
-	HideParentTags(*string, []*string) error
+	CreateTag(context.Context, *CreateTagElastic) error
+	GetTags(context.Context, *GetTagsElastic) ([]*string, *int, error)
+	GetTagsSearch(context.Context, *GetTagsElastic) ([]*string, *int, error)
+	UpdateTag(context.Context, *string, *bool, []*TagName) error
+	AddParentTags(context.Context, *string, []*string) error
+	RemoveParentTags(context.Context, *string, []*string) error
+	HideParentTags(context.Context, *string, []*string) error
 }
 
 type GeoIp interface {
-	GetGeoIp(*GetGeoIp) (*string, error)
+	GetGeoIp(context.Context, *GetGeoIp) (*string, error)
+}
+
+// TranslationProvider suggests names in the target locale, the suggestions are returned in the order of the texts
+// with a nil entry for every text the provider has no translation for
+type TranslationProvider interface {
+	Name() string
+	Suggest(context.Context, *TranslationRequest) ([]*TranslationSuggestion, error)
 }
 
 type CreateTagElastic struct {
@@ -43,8 +52,22 @@ type GetTagsElastic struct {
 	Limit          int       `json:"limit"`
 }
 
+type TranslationRequest struct {
+	SourceLocale string   `json:"source_locale"`
+	TargetLocale string   `json:"target_locale"`
+	Texts        []string `json:"texts"`
+}
+
+type TranslationSuggestion struct {
+	Text     *string `json:"text"`
+	Provider string  `json:"provider"`
+}
+
+// GetGeoIp asks for the country of an address, country is the iso code the client declared through the country
+// header, if any
 type GetGeoIp struct {
-	Ip *string `json:"ip_address"`
+	Ip      *string `json:"ip_address"`
+	Country *string `json:"country,omitempty"`
 }
 
 type TagName struct {
@@ -0,0 +1,14 @@
+package domain
+
+// Fixture seeds the in memory repositories, search index and geo ip lookups of the memory environment
+type Fixture struct {
+	Tags                  []*Tags                 `json:"tags"`
+	ParentTagMappings     []*ParentTagMapping     `json:"parent_tag_mappings"`
+	TagLocaleMappings     []*TagLocaleMapping     `json:"tag_locale_mappings"`
+	TagAttributeLocales   []*TagAttributeLocale   `json:"tag_attribute_locales"`
+	TagCountryRules       []*TagCountryRule       `json:"tag_country_rules"`
+	RegionCountryMappings []*RegionCountryMapping `json:"region_country_mappings"`
+	LegacyTagMappings     []*LegacyTagMapping     `json:"legacy_tag_mappings"`
+	GradeProducts         []*GradeProduct         `json:"grade_products"`
+	GeoIp                 map[string]string       `json:"geo_ip"`
+}
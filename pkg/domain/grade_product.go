@@ -1,6 +1,7 @@
 package domain
 
 import (
+	"context"
 	"time"
 )
 
@@ -14,5 +15,5 @@ type GradeProduct struct {
 }
 
 type GradeProductRepository interface {
-	FetchGradesFromProductId(*string) ([]*GradeProduct, error)
+	FetchGradesFromProductId(context.Context, *string) ([]*GradeProduct, error)
 }
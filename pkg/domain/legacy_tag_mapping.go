@@ -1,6 +1,7 @@
 package domain
 
 import (
+	"context"
 	"time"
 )
 
@@ -15,7 +16,7 @@ type LegacyTagMapping struct {
 }
 
 type LegacyTagMappingRepository interface {
-	FetchTagIdFromLegacyId(*string, *string) ([]*LegacyTagMapping, error)
-	FetchLegacyIdFromTagId(*string) ([]*LegacyTagMapping, error)
-	FetchLegacyIdFromTagIds([]*string) ([]*LegacyTagMapping, error)
+	FetchTagIdFromLegacyId(context.Context, *string, *string) ([]*LegacyTagMapping, error)
+	FetchLegacyIdFromTagId(context.Context, *string) ([]*LegacyTagMapping, error)
+	FetchLegacyIdFromTagIds(context.Context, []*string) ([]*LegacyTagMapping, error)
 }
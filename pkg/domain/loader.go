@@ -0,0 +1,31 @@
+package domain
+
+import "context"
+
+// TagLoader is a request scoped cache which batches tag, parent mapping and locale lookups
+type TagLoader interface {
+	LoadTags([]*string) ([]*Tags, error)
+	LoadParentTagMappings([]*string) ([]*ParentTagMapping, error)
+	LoadTagOrders(*string, *string) ([]*ParentTagMapping, error)
+	LoadTagLocales([]*Tags, *string, *string) ([]*Tags, error)
+	LoadServedLocales([]*string, *string, *string) (map[string]*TagLocaleMapping, error)
+	LoadTagLocaleMappings([]*string) ([]*TagLocaleMapping, error)
+	LoadTagAvailability([]*Tags, *string) ([]*Tags, error)
+	OrderTags([]*Tags, *string, *string, *string, *string) ([]*Tags, error)
+}
+
+type tagLoaderKey struct{}
+
+// WithTagLoader returns a copy of ctx carrying loader
+func WithTagLoader(ctx context.Context, loader TagLoader) context.Context {
+	return context.WithValue(ctx, tagLoaderKey{}, loader)
+}
+
+// TagLoaderFromContext returns the loader attached to ctx, if any
+func TagLoaderFromContext(ctx context.Context) (TagLoader, bool) {
+	if ctx == nil {
+		return nil, false
+	}
+	loader, ok := ctx.Value(tagLoaderKey{}).(TagLoader)
+	return loader, ok
+}
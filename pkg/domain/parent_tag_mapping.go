@@ -1,6 +1,7 @@
 package domain
 
 import (
+	"context"
 	"database/sql"
 	"time"
 )
@@ -19,17 +20,20 @@ type ParentTagMapping struct {
 }
 
 type ParentTagMappingRepository interface {
-	CreateParentTagMapping(*sql.Tx, *ParentTagMapping) error
-	FetchParentTagMappings(*string) ([]*ParentTagMapping, error)
-	FetchByInParentTagMappings([]*string) ([]*ParentTagMapping, error)
-	FetchFilteredParentTagMappings(*string, *string) ([]*ParentTagMapping, error)
-	FetchParentTagMappingsByParentTagIds(*string, *string) ([]*ParentTagMapping, error)
-	FetchParentTagMappingByParentTagIdTagId(*string, *string) (*ParentTagMapping, error)
-	FetchByInParentTagMappingsByParentTagIdTagIds([]*string, *string) ([]*ParentTagMapping, error)
-	ToggleHideParentTagMapping(*sql.Tx, bool, *string) error
-	UpdateTagOrder(*sql.Tx, *int, *string) error
-	DeleteParentTagMapping(*sql.Tx, *string) error
-	IsCollegePresent(*string, *string) (bool, error)
+	CreateParentTagMapping(context.Context, *sql.Tx, *ParentTagMapping) error
+	FetchParentTagMappings(context.Context, *string) ([]*ParentTagMapping, error)
+	FetchByInParentTagMappings(context.Context, []*string) ([]*ParentTagMapping, error)
+	FetchFilteredParentTagMappings(context.Context, *string, *string) ([]*ParentTagMapping, error)
+	FetchParentTagMappingsByParentTagIds(context.Context, *string, *string) ([]*ParentTagMapping, error)
+	FetchParentTagMappingByParentTagIdTagId(context.Context, *string, *string) (*ParentTagMapping, error)
+	FetchByInParentTagMappingsByParentTagIdTagIds(context.Context, []*string, *string) ([]*ParentTagMapping, error)
+	ToggleHideParentTagMapping(context.Context, *sql.Tx, bool, *string) error
+	UpdateTagOrder(context.Context, *sql.Tx, *int, *string) error
+	FetchTagOrderVersion(context.Context, *string, *string) (int, error)
+	UpdateTagOrderVersion(context.Context, *sql.Tx, *string, *string, *int) error
+	DeleteParentTagMapping(context.Context, *sql.Tx, *string) error
+	IsCollegePresent(context.Context, *string, *string) (bool, error)
+	FetchTagIdsByParentTagPrefix(context.Context, *string) ([]*string, error)
 }
 
 type ParentTagMappingService interface {
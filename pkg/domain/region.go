@@ -0,0 +1,36 @@
+package domain
+
+import (
+	"context"
+	"database/sql"
+	"time"
+)
+
+// RegionCountryMapping makes a country a member of a region tag. Listings under a region resolve to the listings
+// under its member countries.
+type RegionCountryMapping struct {
+	ID        *string   `json:"id"`
+	RegionID  *string   `json:"region_id"`
+	CountryId *string   `json:"country_id"`
+	Order     *int      `json:"order"`
+	Publish   bool      `json:"publish"`
+	UpdatedAt time.Time `json:"updated_at"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+type RegionCountryMappingRepository interface {
+	CreateRegionCountryMapping(context.Context, *sql.Tx, *RegionCountryMapping) error
+	FetchByInRegionCountryMappings(context.Context, []*string) ([]*RegionCountryMapping, error)
+	DeleteRegionCountryMapping(context.Context, *sql.Tx, *string) error
+}
+
+// Region lists the member countries of a region tag in order
+type Region struct {
+	ID        *string   `json:"id"`
+	Name      *string   `json:"name"`
+	Countries []*string `json:"countries"`
+}
+
+type UpdateRegionCountries struct {
+	Countries []*string `json:"countries"`
+}
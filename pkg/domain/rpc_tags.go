@@ -1,15 +1,19 @@
 package domain
 
+import "context"
+
 type RpcTagsService interface {
-	GetTags(tags *GetTags) (*GetTagsResponse, error)
-	CreateTags(*CreateMultipleTags) ([]*TagResponse, error)
-	GetTagsByIds(*GetTagsByIds, bool) ([]*TagResponse, error)
-	ValidateHierarchy(*ValidateHierarchy) error
-	GetDefaultTags() (*DefaultTags, error)
-	GetSuggestedCurriculum(*GetSuggestedTags) ([]*SuggestedTags, error)
-	GetLegacyDataFromTagId(*string) ([]*LegacyResponse, error)
-	GetLegacyDataFromTagIds([]*string) ([]*LegacyResponse, error)
-	GetTagDataFromLegacyId(*string, *string) (legacyResponse []*LegacyResponse, err error)
-	GetGradeTags(*string, *string) ([]*LegacyResponse, error)
-	GetRpcTags(tags *GetRpcTags) (*GetTagsResponseForProduct, error)
+	GetTags(ctx context.Context, tags *GetTags) (*GetTagsResponse, error)
+	CreateTags(context.Context, *CreateMultipleTags) ([]*TagResponse, error)
+	GetTagsByIds(context.Context, *GetTagsByIds, bool) ([]*TagResponse, error)
+	ValidateHierarchy(context.Context, *ValidateHierarchy) error
+	GetDefaultTags(context.Context) (*DefaultTags, error)
+	GetSuggestedCurriculum(context.Context, *GetSuggestedTags) ([]*SuggestedTags, error)
+	GetLegacyDataFromTagId(context.Context, *string) ([]*LegacyResponse, error)
+	GetLegacyDataFromTagIds(context.Context, []*string) ([]*LegacyResponse, error)
+	GetTagDataFromLegacyId(context.Context, *string, *string) (legacyResponse []*LegacyResponse, err error)
+	GetGradeTags(context.Context, *string, *string) ([]*LegacyResponse, error)
+	GetRpcTags(ctx context.Context, tags *GetRpcTags) (*GetTagsResponseForProduct, error)
+	GetCurrentTerm(context.Context, *GetCurrentTerm) (*CurrentTerm, error)
+	GetExpectedGrade(context.Context, *GetExpectedGrade) (*ExpectedGrade, error)
 }
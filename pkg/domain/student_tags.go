@@ -1,10 +1,12 @@
 package domain
 
+import "context"
+
 type StudentTagsService interface {
-	GetCountries(tags *GetCountries) (*GetCountriesResponse, error)
-	GetCountriesNew(tags *GetCountriesNew) (*GetCountriesNewResponse, error)
-	GetGrades(tags *GetTags) (*GetGradesResponse, error)
-	GetBoards(tags *GetTags) (*GetBoardsResponse, error)
-	GetDegrees(tags *GetTags) (*GetDegreesResponse, error)
-	GetMajors(tags *GetTags) (*GetMajorsResponse, error)
+	GetCountries(ctx context.Context, tags *GetCountries) (*GetCountriesResponse, error)
+	GetCountriesNew(ctx context.Context, tags *GetCountriesNew) (*GetCountriesNewResponse, error)
+	GetGrades(ctx context.Context, tags *GetTags) (*GetGradesResponse, error)
+	GetBoards(ctx context.Context, tags *GetTags) (*GetBoardsResponse, error)
+	GetDegrees(ctx context.Context, tags *GetTags) (*GetDegreesResponse, error)
+	GetMajors(ctx context.Context, tags *GetTags) (*GetMajorsResponse, error)
 }
@@ -0,0 +1,39 @@
+package domain
+
+import (
+	"context"
+	"database/sql"
+	"time"
+)
+
+// TagAttributeLocale is the value of a localizable attribute of a tag for a locale and country
+type TagAttributeLocale struct {
+	ID        *string   `json:"id"`
+	TagID     *string   `json:"tag_id"`
+	TagType   *string   `json:"tag_type"`
+	Attribute *string   `json:"attribute"`
+	CountryId *string   `json:"country_id"`
+	Locale    *string   `json:"locale"`
+	Value     *string   `json:"value"`
+	Publish   bool      `json:"publish"`
+	UpdatedAt time.Time `json:"updated_at"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+type TagAttributeLocaleRepository interface {
+	CreateTagAttributeLocale(context.Context, *sql.Tx, *TagAttributeLocale) error
+	FetchByInTagAttributeLocales(context.Context, []*string) ([]*TagAttributeLocale, error)
+	DeleteTagAttributeLocale(context.Context, *sql.Tx, *string) error
+}
+
+// UpdateTagAttributeLocales sets the localized attribute values of a tag, an empty value removes the translation
+type UpdateTagAttributeLocales struct {
+	Attributes []*AttributeLocale `json:"attributes"`
+}
+
+type AttributeLocale struct {
+	Attribute *string `json:"attribute"`
+	CountryId *string `json:"country_id"`
+	Locale    *string `json:"locale"`
+	Value     *string `json:"value"`
+}
@@ -0,0 +1,64 @@
+package domain
+
+import (
+	"context"
+	"database/sql"
+	"time"
+)
+
+// TagChange is one entry of the changes feed. The relay records the changes one transaction after the other, so
+// Sequence grows in the order the changes become visible. ChangedAt is when the change was made and RecordedAt when it
+// reached the feed.
+type TagChange struct {
+	Sequence   int64     `json:"sequence"`
+	EventID    *string   `json:"event_id"`
+	EventType  *string   `json:"event_type"`
+	Entity     *string   `json:"entity"`
+	TagId      *string   `json:"tag_id"`
+	TagType    *string   `json:"tag_type"`
+	ParentTags []string  `json:"parent_tags"`
+	ChangedAt  time.Time `json:"changed_at"`
+	RecordedAt time.Time `json:"recorded_at"`
+}
+
+type TagChangeRepository interface {
+	// CreateTagChange skips changes whose EventID is already recorded, so retried events are recorded once
+	CreateTagChange(context.Context, *sql.Tx, *TagChange) error
+	// FetchTagChanges returns the changes after the sequence, oldest first
+	FetchTagChanges(context.Context, int64, int) ([]*TagChange, error)
+	// DeleteTagChanges removes the changes recorded before the given time
+	DeleteTagChanges(context.Context, time.Time) error
+}
+
+type TagChangeService interface {
+	GetTagChanges(context.Context, *GetTagChanges) (*TagChanges, error)
+}
+
+// GetTagChanges reads the feed after Cursor, from the oldest retained change when it is unset. With a Wait the call
+// blocks until a change arrives or the wait is over.
+type GetTagChanges struct {
+	Cursor *string       `json:"cursor"`
+	Limit  int           `json:"limit"`
+	Wait   time.Duration `json:"wait"`
+}
+
+// TagChanges is a page of the feed, Cursor resumes after its last change and is kept when the page is empty
+type TagChanges struct {
+	Changes []*TagChange `json:"changes"`
+	Cursor  string       `json:"cursor"`
+	HasMore bool         `json:"has_more"`
+}
+
+type tagChangeEntityList struct {
+	Tag     string
+	Mapping string
+	Locale  string
+}
+
+// TagChangeEntityEnum tells which rows of the tag a change touched, its own row, its parent_tag_mapping rows or its
+// locales
+var TagChangeEntityEnum = &tagChangeEntityList{
+	Tag:     "tag",
+	Mapping: "mapping",
+	Locale:  "locale",
+}
@@ -0,0 +1,42 @@
+package domain
+
+import (
+	"context"
+	"database/sql"
+	"time"
+)
+
+type tagCountryRuleList struct {
+	Include string
+	Exclude string
+}
+
+var TagCountryRuleEnum = &tagCountryRuleList{
+	Include: "include",
+	Exclude: "exclude",
+}
+
+// TagCountryRule includes or excludes a country for a tag. A tag with include rules is only available in the
+// included countries, a tag is never available in an excluded country.
+type TagCountryRule struct {
+	ID        *string   `json:"id"`
+	TagID     *string   `json:"tag_id"`
+	TagType   *string   `json:"tag_type"`
+	CountryId *string   `json:"country_id"`
+	Rule      *string   `json:"rule"`
+	Publish   bool      `json:"publish"`
+	UpdatedAt time.Time `json:"updated_at"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+type TagCountryRuleRepository interface {
+	CreateTagCountryRule(context.Context, *sql.Tx, *TagCountryRule) error
+	FetchByInTagCountryRules(context.Context, []*string) ([]*TagCountryRule, error)
+	DeleteTagCountryRule(context.Context, *sql.Tx, *string) error
+}
+
+// TagAvailability lists the countries included and excluded for a tag, empty lists make it available everywhere
+type TagAvailability struct {
+	Include []*string `json:"include"`
+	Exclude []*string `json:"exclude"`
+}
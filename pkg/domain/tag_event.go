@@ -0,0 +1,136 @@
+package domain
+
+import (
+	"context"
+	"database/sql"
+	"time"
+)
+
+// TagEventSchemaVersion is bumped whenever a field of TagEvent or of one of its data payloads changes meaning or
+// is removed, adding fields keeps the version
+const TagEventSchemaVersion = 1
+
+// TagEvent is published once a change of a tag is committed. Events of the same tag are published in the order of
+// their Sequence, which is the id of the event in the outbox. An event is published at least once, consumers skip
+// the IDs they already received.
+type TagEvent struct {
+	ID            string    `json:"id"`
+	Type          string    `json:"type"`
+	SchemaVersion int       `json:"schema_version"`
+	Sequence      int64     `json:"sequence"`
+	OccurredAt    time.Time `json:"occurred_at"`
+	TagId         string    `json:"tag_id"`
+	TagType       string    `json:"tag_type"`
+	// ParentTags are the hierarchies the tag sits under when the event is published, e.g. 12.24681.251
+	ParentTags []string    `json:"parent_tags"`
+	Data       interface{} `json:"data"`
+}
+
+type TagCreatedEventData struct {
+	Name           *string   `json:"name"`
+	TagGroup       *string   `json:"tag_group"`
+	CurriculumType *string   `json:"curriculum_type"`
+	Hierarchy      []*string `json:"hierarchy"`
+}
+
+type TagRenamedEventData struct {
+	PreviousName *string `json:"previous_name"`
+	Name         *string `json:"name"`
+}
+
+// TagAttributesChangedEventData carries every attribute of the tag after the change, Keys lists the changed ones
+type TagAttributesChangedEventData struct {
+	Keys       []string               `json:"keys"`
+	Attributes map[string]interface{} `json:"attributes"`
+}
+
+type TagReorderedEventData struct {
+	ParentTags *string `json:"parent_tags"`
+	Order      *int    `json:"order"`
+}
+
+type TagHierarchyEventData struct {
+	Hierarchy  []*string `json:"hierarchy"`
+	Identifier []*string `json:"identifier"`
+}
+
+// TagLocaleChangedEventData lists the changed names, Attribute is set for localized attribute values and Name is
+// unset for removed ones
+type TagLocaleChangedEventData struct {
+	Action  string            `json:"action"`
+	Locales []*TagEventLocale `json:"locales"`
+}
+
+type TagEventLocale struct {
+	Locale    *string `json:"locale"`
+	CountryId *string `json:"country_id"`
+	Attribute *string `json:"attribute,omitempty"`
+	Name      *string `json:"name"`
+}
+
+type TagDeletedEventData struct {
+	Name *string `json:"name"`
+}
+
+// TagEventBroker delivers events to the consumers outside the service
+type TagEventBroker interface {
+	Name() string
+	Publish(ctx context.Context, event *TagEvent) error
+}
+
+// TagEventPublisher writes the events to the outbox in the transaction of their change, they reach the broker once
+// the change is committed
+type TagEventPublisher interface {
+	Publish(ctx context.Context, tx *sql.Tx, eventType string, tagId *string, tagType *string, data interface{}) error
+}
+
+// TagEventOutboxRepository keeps the events until they are relayed to the broker. Data of the fetched events is
+// the json the event was written with.
+type TagEventOutboxRepository interface {
+	CreateTagEvent(context.Context, *sql.Tx, *TagEvent) error
+	// ClaimPendingTagEvents claims the oldest events not relayed yet for the relay until claimedUntil and returns
+	// them. It returns none while another relay holds a live claim on them, so that the relays take turns.
+	ClaimPendingTagEvents(ctx context.Context, tx *sql.Tx, relayId string, claimedAt time.Time, claimedUntil time.Time, limit int) ([]*TagEvent, error)
+	// MarkTagEventsRelayed marks the events still claimed by the relay relayed and releases the rest of its claim
+	MarkTagEventsRelayed(ctx context.Context, tx *sql.Tx, relayId string, sequences []int64, relayedAt time.Time) error
+	// DeleteRelayedTagEvents removes the events relayed before the given time
+	DeleteRelayedTagEvents(context.Context, time.Time) error
+}
+
+type tagEventTypeList struct {
+	Created           string
+	Renamed           string
+	AttributesChanged string
+	Hidden            string
+	Unhidden          string
+	Reordered         string
+	HierarchyAdded    string
+	HierarchyRemoved  string
+	LocaleChanged     string
+	Deleted           string
+}
+
+var TagEventTypeEnum = &tagEventTypeList{
+	Created:           "tag.created",
+	Renamed:           "tag.renamed",
+	AttributesChanged: "tag.attributes_changed",
+	Hidden:            "tag.hidden",
+	Unhidden:          "tag.unhidden",
+	Reordered:         "tag.reordered",
+	HierarchyAdded:    "tag.hierarchy_added",
+	HierarchyRemoved:  "tag.hierarchy_removed",
+	LocaleChanged:     "tag.locale_changed",
+	Deleted:           "tag.deleted",
+}
+
+type tagLocaleActionList struct {
+	Add    string
+	Remove string
+	Update string
+}
+
+var TagLocaleActionEnum = &tagLocaleActionList{
+	Add:    "add",
+	Remove: "remove",
+	Update: "update",
+}
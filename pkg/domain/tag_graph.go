@@ -0,0 +1,32 @@
+package domain
+
+import "context"
+
+// TagGraphService resolves the tag graph served over graphql. Every call goes through the TagLoader of the request,
+// so the resolvers of one query share their batches instead of loading tag by tag.
+type TagGraphService interface {
+	GetTagNodes(context.Context, []*string, *string, *string, *string) ([]*TagNode, error)
+	GetTagChildren(context.Context, *GetTagChildren) ([]*TagNode, *int, error)
+	GetTagParents(context.Context, *string, *string, *string) ([]*TagNode, error)
+	GetTagIdentifiers(context.Context, *string, *string, *string) ([]*Tags, error)
+	GetTagLocales(context.Context, *string) ([]*LocaleResponse, error)
+	GetCountryNodes(context.Context, *string, *string, int, int) ([]*Tags, *int, error)
+}
+
+// TagNode is a tag reached through one of its parent_tag_mapping rows. Path is the hierarchy of the tag itself such as
+// 9.2.251, it is nil when the tag has several parents and none of them was asked for.
+type TagNode struct {
+	Tag    *Tags
+	Path   *string
+	Hidden bool
+}
+
+// GetTagChildren pages through the children of the tag at Parent, a path such as 9.2.251
+type GetTagChildren struct {
+	Parent    *string `json:"parent"`
+	Type      *string `json:"type"`
+	CountryId *string `json:"country_id"`
+	Locale    *string `json:"locale"`
+	Start     int     `json:"start"`
+	Limit     int     `json:"limit"`
+}
@@ -1,6 +1,7 @@
 package domain
 
 import (
+	"context"
 	"database/sql"
 	"time"
 )
@@ -15,14 +16,23 @@ type TagLocaleMapping struct {
 	TagType   *string   `json:"tag_type"`
 	UpdatedAt time.Time `json:"updated_at"`
 	CreatedAt time.Time `json:"created_at"`
+	// Draft rows are unpublished suggestions waiting for an admin, SuggestedBy names the translation provider
+	Draft       bool    `json:"draft"`
+	SuggestedBy *string `json:"suggested_by"`
 }
 
 type TagLocaleMappingRepository interface {
-	CreateTagLocaleMapping(*sql.Tx, *TagLocaleMapping) error
-	FetchTagLocaleMappings(*string) ([]*TagLocaleMapping, error)
-	FetchTagLocaleMappingByLocale(*string, *string, *string) (*TagLocaleMapping, error)
-	DeleteTagLocaleMapping(*sql.Tx, *string) error
-	FetchTagLocalesByTagIds(ids []*string, locale *string, countryId *string) ([]*TagLocaleMapping, error)
+	CreateTagLocaleMapping(context.Context, *sql.Tx, *TagLocaleMapping) error
+	FetchTagLocaleMappings(context.Context, *string) ([]*TagLocaleMapping, error)
+	FetchByInTagLocaleMappings(context.Context, []*string) ([]*TagLocaleMapping, error)
+	FetchTagLocaleMappingByLocale(context.Context, *string, *string, *string) (*TagLocaleMapping, error)
+	DeleteTagLocaleMapping(context.Context, *sql.Tx, *string) error
+	FetchTagLocalesByTagIds(ctx context.Context, ids []*string, locale *string, countryId *string) ([]*TagLocaleMapping, error)
+	CreateTagLocaleDraft(context.Context, *sql.Tx, *TagLocaleMapping) error
+	FetchTagLocaleDrafts(ctx context.Context, ids []*string, locale *string, countryId *string) ([]*TagLocaleMapping, error)
+	FetchTagLocaleDraftsByIds(context.Context, []*string) ([]*TagLocaleMapping, error)
+	PublishTagLocaleDraft(context.Context, *sql.Tx, *string, *string) error
+	DiscardTagLocaleDraft(context.Context, *sql.Tx, *string) error
 }
 
 type TagLocaleTagMappingService interface {
@@ -0,0 +1,132 @@
+package domain
+
+// TranslationUnit is one tag name handed to translators, a csv row or an xliff trans-unit
+type TranslationUnit struct {
+	TagID   *string `json:"tag_id"`
+	TagType *string `json:"tag_type"`
+	Source  *string `json:"source"`
+	Target  *string `json:"target"`
+	Line    int     `json:"line,omitempty"`
+}
+
+type TranslationExport struct {
+	Hierarchy *string `json:"hierarchy"`
+	CountryId *string `json:"country_id"`
+	Locale    *string `json:"locale"`
+}
+
+type TranslationImport struct {
+	CountryId *string            `json:"country_id"`
+	Locale    *string            `json:"locale"`
+	DryRun    bool               `json:"dry_run"`
+	Units     []*TranslationUnit `json:"units"`
+}
+
+type TranslationChange struct {
+	TagID    *string `json:"tag_id"`
+	Previous *string `json:"previous,omitempty"`
+	Name     *string `json:"name"`
+}
+
+type TranslationError struct {
+	TagID   *string `json:"tag_id,omitempty"`
+	DraftID *string `json:"draft_id,omitempty"`
+	Line    int     `json:"line,omitempty"`
+	Message string  `json:"message"`
+}
+
+// TranslationImportResult is the diff of an import, it is only applied when no unit is invalid. Units left
+// without a target are skipped.
+type TranslationImportResult struct {
+	Created      []*TranslationChange `json:"created"`
+	Updated      []*TranslationChange `json:"updated"`
+	Unchanged    int                  `json:"unchanged"`
+	Skipped      int                  `json:"skipped"`
+	Invalid      []*TranslationError  `json:"invalid"`
+	Applied      bool                 `json:"applied"`
+	SearchFailed []*string            `json:"search_failed,omitempty"`
+}
+
+type TranslationCoverageRequest struct {
+	Hierarchy *string `json:"hierarchy"`
+	CountryId *string `json:"country_id"`
+	Locale    *string `json:"locale"`
+	TagType   *string `json:"type"`
+	Missing   bool    `json:"missing"`
+}
+
+// TranslationCoverage counts the published tags of a subtree translated for every locale and country pair
+type TranslationCoverage struct {
+	Hierarchy *string                      `json:"hierarchy"`
+	Total     int                          `json:"total"`
+	Locales   []*TranslationCoverageLocale `json:"locales"`
+}
+
+type TranslationCoverageLocale struct {
+	Locale       string                     `json:"locale"`
+	CountryId    string                     `json:"country_id"`
+	Translated   int                        `json:"translated"`
+	Untranslated int                        `json:"untranslated"`
+	Percent      float64                    `json:"percent"`
+	Types        []*TranslationCoverageType `json:"types"`
+	Missing      []*TranslationUnit         `json:"missing,omitempty"`
+}
+
+type TranslationCoverageType struct {
+	TagType      string `json:"type"`
+	Translated   int    `json:"translated"`
+	Untranslated int    `json:"untranslated"`
+}
+
+type TranslationSuggest struct {
+	Hierarchy *string `json:"hierarchy"`
+	CountryId *string `json:"country_id"`
+	Locale    *string `json:"locale"`
+	TagType   *string `json:"type"`
+}
+
+// TranslationSuggestResult lists the drafts stored by a suggestion run, tags which already have a draft are pending
+// and tags no provider could translate are unsuggested
+type TranslationSuggestResult struct {
+	Created     []*TranslationDraft `json:"created"`
+	Pending     int                 `json:"pending"`
+	Unsuggested int                 `json:"unsuggested"`
+}
+
+type TranslationDraft struct {
+	ID          *string `json:"id,omitempty"`
+	TagID       *string `json:"tag_id"`
+	TagType     *string `json:"tag_type"`
+	Source      *string `json:"source"`
+	Current     *string `json:"current,omitempty"`
+	Name        *string `json:"name"`
+	SuggestedBy *string `json:"suggested_by"`
+}
+
+type TranslationDraftRequest struct {
+	Hierarchy *string `json:"hierarchy"`
+	CountryId *string `json:"country_id"`
+	Locale    *string `json:"locale"`
+}
+
+type TranslationApproval struct {
+	ID   *string `json:"id"`
+	Name *string `json:"name"`
+}
+
+// TranslationReview approves drafts, optionally with a corrected name, and rejects others. It is only applied when
+// every id is a draft of the locale and country.
+type TranslationReview struct {
+	CountryId *string                `json:"country_id"`
+	Locale    *string                `json:"locale"`
+	Approve   []*TranslationApproval `json:"approve"`
+	Reject    []*string              `json:"reject"`
+}
+
+type TranslationReviewResult struct {
+	Approved     []*TranslationChange `json:"approved"`
+	Rejected     int                  `json:"rejected"`
+	Invalid      []*TranslationError  `json:"invalid"`
+	Applied      bool                 `json:"applied"`
+	SearchFailed []*string            `json:"search_failed,omitempty"`
+}
@@ -1,26 +1,31 @@
 package domain
 
 import (
+	"context"
 	"database/sql"
 	"time"
 )
 
 type Tags struct {
-	ID              *string                `json:"id"`
-	Type            *string                `json:"type" validate:"required"`
-	Name            *string                `json:"name" validate:"required"`
-	LocaleName      *string                `json:"locale_name"`
-	CurriculumType  string                 `json:"curriculum_type"`
-	CreatorId       *int64                 `json:"creator_id"`
-	CreatorType     string                 `json:"creator_type"`
-	Access          string                 `json:"access"`
-	TagGroup        string                 `json:"tag_group"`
-	LocaleAvailable bool                   `json:"locale_available"`
-	CountryId       string                 `json:"country_id"`
-	Publish         bool                   `json:"publish"`
-	Attributes      map[string]interface{} `json:"attributes"`
-	UpdatedAt       time.Time              `json:"updated_at"`
-	CreatedAt       time.Time              `json:"created_at"`
+	ID               *string                `json:"id"`
+	Type             *string                `json:"type" validate:"required"`
+	Name             *string                `json:"name" validate:"required"`
+	LocaleName       *string                `json:"locale_name"`
+	ServedLocale     *string                `json:"served_locale,omitempty"`
+	LocaleAttributes map[string]interface{} `json:"locale_attributes,omitempty"`
+	Available        *bool                  `json:"available,omitempty"`
+	CurriculumType   string                 `json:"curriculum_type"`
+	CreatorId        *int64                 `json:"creator_id"`
+	CreatorType      string                 `json:"creator_type"`
+	Access           string                 `json:"access"`
+	TagGroup         string                 `json:"tag_group"`
+	LocaleAvailable  bool                   `json:"locale_available"`
+	CountryId        string                 `json:"country_id"`
+	Publish          bool                   `json:"publish"`
+	Attributes       map[string]interface{} `json:"attributes"`
+	Version          int                    `json:"version"`
+	UpdatedAt        time.Time              `json:"updated_at"`
+	CreatedAt        time.Time              `json:"created_at"`
 }
 
 type CreateTags struct {
@@ -59,6 +64,8 @@ type UpdateTag struct {
 	Attributes map[string]interface{} `json:"attributes"`
 	Name       *string                `json:"name"`
 	Hidden     *bool                  `json:"hidden"`
+	// Version is the version the update was made against, the update is rejected once the tag moved past it
+	Version *int `json:"version"`
 }
 
 type UpdateTagOrder struct {
@@ -67,6 +74,8 @@ type UpdateTagOrder struct {
 	TagGroup       *string   `json:"tag_group"`
 	Hierarchy      []*string `json:"hierarchy"`
 	Orders         []*Order  `json:"orders"`
+	// Version is the version of the sibling set the orders were made against
+	Version *int `json:"version"`
 }
 
 type Order struct {
@@ -75,6 +84,12 @@ type Order struct {
 	Order *int    `json:"order"`
 }
 
+// TagOrders is the order of the tags of one type under one parent, Version changes with every reorder of the set
+type TagOrders struct {
+	Version int      `json:"version"`
+	Orders  []*Order `json:"orders"`
+}
+
 type RemoveHierarchy struct {
 	ID             *string   `json:"id"`
 	CurriculumType *string   `json:"curriculum_type"`
@@ -139,6 +154,7 @@ type GetCountriesNew struct {
 	ISOCode   string  `json:iso_code`
 	CountryId *string `json:"country_id"`
 	Locale    *string `json:"locale"`
+	GroupBy   *string `json:"group_by"`
 	Start     int     `json:"start"`
 	Limit     int     `json:"limit"`
 }
@@ -264,11 +280,13 @@ type MetaResponse struct {
 	IsIdentifier *bool `json:"is_identifier"`
 	IsOrdered    *bool `json:"is_ordered"`
 	Next         *int  `json:"next,omitempty"`
+	OrderVersion *int  `json:"order_version,omitempty"`
 }
 
 type CountriesNewMetaResponse struct {
 	Next            *int                         `json:"next,omitempty"`
 	SelectedCountry *CountriesAttributesResponse `json:"selected_country,omitempty"`
+	Regions         []*Region                    `json:"regions,omitempty"`
 
 }
 
@@ -288,33 +306,39 @@ type MajorsMetaResponse struct {
 }
 
 type TagResponse struct {
-	ID             *string                `json:"id"`
-	Type           *string                `json:"type"`
-	CurriculumType *string                `json:"curriculum_type,omitempty"`
-	Grade          *int                   `json:"grade,omitempty"`
-	Name           *string                `json:"name"`
-	LocaleName     *string                `json:"locale_name"`
-	Hidden         bool                   `json:"hidden"`
-	Root           *string                `json:"root"`
-	Attributes     map[string]interface{} `json:"attributes"`
-	Identifiers    []*IdentifierResponse  `json:"identifiers,omitempty"`
-	Locale         []*LocaleResponse      `json:"locales,omitempty"`
+	ID               *string                `json:"id"`
+	Type             *string                `json:"type"`
+	CurriculumType   *string                `json:"curriculum_type,omitempty"`
+	Grade            *int                   `json:"grade,omitempty"`
+	Name             *string                `json:"name"`
+	LocaleName       *string                `json:"locale_name"`
+	ServedLocale     *string                `json:"served_locale,omitempty"`
+	Hidden           bool                   `json:"hidden"`
+	Root             *string                `json:"root"`
+	Attributes       map[string]interface{} `json:"attributes"`
+	LocaleAttributes map[string]interface{} `json:"locale_attributes,omitempty"`
+	Available        *bool                  `json:"available,omitempty"`
+	Identifiers      []*IdentifierResponse  `json:"identifiers,omitempty"`
+	Locale           []*LocaleResponse      `json:"locales,omitempty"`
+	Version          *int                   `json:"version,omitempty"`
 }
 
 type TagResponseForProduct struct {
-	ID             *string               `json:"id"`
-	Type           *string               `json:"type"`
-	CurriculumType *string               `json:"curriculum_type,omitempty"`
-	Name           *string               `json:"name"`
-	LocaleName     *string               `json:"locale_name"`
-	Hidden         bool                  `json:"hidden"`
-	Root           *string               `json:"root"`
-	BackgroundPic  *string               `json:"background_pic"`
-	Color          *string               `json:"color"`
-	Pic            *string               `json:"pic"`
-	NegativePic    *string               `json:"negative_pic"`
-	Identifiers    []*IdentifierResponse `json:"identifiers,omitempty"`
-	Locale         []*LocaleResponse     `json:"locales,omitempty"`
+	ID               *string                `json:"id"`
+	Type             *string                `json:"type"`
+	CurriculumType   *string                `json:"curriculum_type,omitempty"`
+	Name             *string                `json:"name"`
+	LocaleName       *string                `json:"locale_name"`
+	ServedLocale     *string                `json:"served_locale,omitempty"`
+	Hidden           bool                   `json:"hidden"`
+	Root             *string                `json:"root"`
+	BackgroundPic    *string                `json:"background_pic"`
+	Color            *string                `json:"color"`
+	Pic              *string                `json:"pic"`
+	NegativePic      *string                `json:"negative_pic"`
+	LocaleAttributes map[string]interface{} `json:"locale_attributes,omitempty"`
+	Identifiers      []*IdentifierResponse  `json:"identifiers,omitempty"`
+	Locale           []*LocaleResponse      `json:"locales,omitempty"`
 }
 
 type CountriesNewResponse struct {
@@ -387,23 +411,27 @@ type CollegeAttributesResponse struct {
 	Name *string `json:"name"`
 }
 type BoardsAttributesResponse struct {
-	ID    *string `json:"id"`
-	Name  *string `json:"name,omitempty"`
+	ID           *string `json:"id"`
+	Name         *string `json:"name,omitempty"`
+	ServedLocale *string `json:"served_locale,omitempty"`
 }
 type GradesAttributesResponse struct {
-	ID    *string `json:"id"`
-	Grade *int    `json:"grade"`
-	Name  *string `json:"name,omitempty"`
+	ID           *string `json:"id"`
+	Grade        *int    `json:"grade"`
+	Name         *string `json:"name,omitempty"`
+	ServedLocale *string `json:"served_locale,omitempty"`
 }
 
 type DegreesAttributesResponse struct {
-	ID   *string `json:"id"`
-	Name *string `json:"name,omitempty"`
+	ID           *string `json:"id"`
+	Name         *string `json:"name,omitempty"`
+	ServedLocale *string `json:"served_locale,omitempty"`
 }
 
 type MajorsAttributesResponse struct {
-	ID   *string `json:"id"`
-	Name *string `json:"name,omitempty"`
+	ID           *string `json:"id"`
+	Name         *string `json:"name,omitempty"`
+	ServedLocale *string `json:"served_locale,omitempty"`
 }
 
 type LegacyResponse struct {
@@ -442,6 +470,7 @@ type tagTypeList struct {
 	Chapter    string `json:"chapter"`
 	Topic      string `json:"topic"`
 	Board      string `json:"board"`
+	Region     string `json:"region"`
 }
 
 type tagGroupList struct {
@@ -490,6 +519,7 @@ var TagTypeEnum = &tagTypeList{
 	Chapter:    "chapter",
 	Topic:      "topic",
 	Board:		"board",
+	Region:     "region",
 }
 
 var TagGroupEnum = &tagGroupList{
@@ -504,52 +534,82 @@ var AccessEnum = &accessList{
 }
 
 type TagsRepository interface {
-	FetchTags(*string) (*Tags, error)
-	FetchFilteredTags(*string, *string) ([]*Tags, error)
-	FetchByInTags([]*string) ([]*Tags, error)
-	FetchByTagGroup(*string, *string) ([]*Tags, error)
-	CreateTags(*sql.Tx, *Tags) (*string, error)
-	DeleteTags(*sql.Tx, *string) error
-	UpdateLocale(*sql.Tx, bool, *string) error
-	UpdateTag(*UpdateTag) error
-	ToggleTags(bool, []*string) error
-	FetchFilteredTagsPaginated(*string, *string, *int, *int) ([]*Tags, error)
-	FetchFilteredTagsPaginatedForAdmin(*string, *string, *int, *int) ([]*Tags, error)
+	FetchTags(context.Context, *string) (*Tags, error)
+	FetchFilteredTags(context.Context, *string, *string) ([]*Tags, error)
+	FetchByInTags(context.Context, []*string) ([]*Tags, error)
+	FetchByTagGroup(context.Context, *string, *string) ([]*Tags, error)
+	CreateTags(context.Context, *sql.Tx, *Tags) (*string, error)
+	DeleteTags(context.Context, *sql.Tx, *string) error
+	UpdateLocale(context.Context, *sql.Tx, bool, *string) error
+	UpdateTag(context.Context, *sql.Tx, *UpdateTag) error
+	ToggleTags(context.Context, bool, []*string) error
+	FetchFilteredTagsPaginated(context.Context, *string, *string, *int, *int) ([]*Tags, error)
+	FetchFilteredTagsPaginatedForAdmin(context.Context, *string, *string, *int, *int) ([]*Tags, error)
 }
 
 type TagsService interface {
-	FetchTags(*string) (*Tags, error)
-	GetTagsConcurrent([]*string) ([]*Tags, error)
-	FetchFilteredTags(*string, *string) ([]*Tags, error)
-	FetchByTagGroup(*string, *string) ([]*Tags, error)
-	FetchByInTags([]*string) ([]*Tags, error)
-	CreateTags(*sql.Tx, *Tags) (*string, error)
-	CreateParentTagMapping(*sql.Tx, *ParentTagMapping) error
-	FetchParentTagMappings(*string) ([]*ParentTagMapping, error)
-	FetchByInParentTagMappings([]*string) ([]*ParentTagMapping, error)
-	FetchFilteredParentTagMappings(*string, *string) ([]*ParentTagMapping, error)
-	FetchParentTagMappingByParentTagIdTagId(*string, *string) (*ParentTagMapping, error)
-	FetchByInParentTagMappingsByParentTagIdTagIds([]*string, *string) ([]*ParentTagMapping, error)
-	DeleteTags(*sql.Tx, *string) error
-	UpdateLocale(*sql.Tx, bool, *string) error
-	ToggleHideParentTagMapping(*sql.Tx, bool, *string, *string) error
-	DeleteParentTagMapping(*sql.Tx, *string) error
-	CreateTagLocaleMapping(*sql.Tx, *TagLocaleMapping) error
-	FetchTagLocaleMappings(*string) ([]*TagLocaleMapping, error)
-	FetchTagLocalesByTagIds(ids []*string, locale *string, countryId *string) ([]*TagLocaleMapping, error)
-	FetchFilteredTagsPaginated(*string, *string, *int, *int) ([]*Tags, error)
-	FetchFilteredTagsPaginatedForAdmin(*string, *string, *int, *int) ([]*Tags, error)
-	IsCollegePresent(*string, *string) (bool, error)
-	FetchTagLocaleMappingsByLocale([]*Tags, *string, *string) ([]*Tags, error)
-	FetchTagLocaleMappingsByLocaleForContext([]*Tags, *string, *string) ([]*Tags, error)
-	DeleteTagLocaleMapping(*sql.Tx, *TagLocaleMapping) error
-	UpdateTag(*UpdateTag) error
-	ToggleTags(bool, []*string) error
-	FetchTagOrders(*string, *string) ([]*ParentTagMapping, error)
-	UpdateTagOrders(*sql.Tx, []*Order, *string, *string) error
-	OrderTags(tags []*Tags, tagType *string, curriculumType *string, hierarchy *string) ([]*Tags, error)
-	FetchTagIdFromLegacyId(*string, *string) ([]*LegacyTagMapping, error)
-	FetchLegacyIdFromTagId(*string) ([]*LegacyTagMapping, error)
-	FetchLegacyIdFromTagIds([]*string) ([]*LegacyTagMapping, error)
-	FetchGradesFromProductId(*string) ([]*GradeProduct, error)
+	FetchTags(context.Context, *string) (*Tags, error)
+	GetTagsConcurrent(context.Context, []*string) ([]*Tags, error)
+	FetchFilteredTags(context.Context, *string, *string) ([]*Tags, error)
+	FetchByTagGroup(context.Context, *string, *string) ([]*Tags, error)
+	FetchByInTags(context.Context, []*string) ([]*Tags, error)
+	CreateTags(context.Context, *sql.Tx, *Tags) (*string, error)
+	CreateParentTagMapping(context.Context, *sql.Tx, *ParentTagMapping) error
+	FetchParentTagMappings(context.Context, *string) ([]*ParentTagMapping, error)
+	FetchByInParentTagMappings(context.Context, []*string) ([]*ParentTagMapping, error)
+	FetchFilteredParentTagMappings(context.Context, *string, *string) ([]*ParentTagMapping, error)
+	FetchParentTagMappingByParentTagIdTagId(context.Context, *string, *string) (*ParentTagMapping, error)
+	FetchByInParentTagMappingsByParentTagIdTagIds(context.Context, []*string, *string) ([]*ParentTagMapping, error)
+	DeleteTags(context.Context, *sql.Tx, *string) error
+	UpdateLocale(context.Context, *sql.Tx, bool, *string) error
+	ToggleHideParentTagMapping(context.Context, *sql.Tx, bool, *string, *string) error
+	DeleteParentTagMapping(context.Context, *sql.Tx, *string) error
+	CreateTagLocaleMapping(context.Context, *sql.Tx, *TagLocaleMapping) error
+	FetchTagLocaleMappings(context.Context, *string) ([]*TagLocaleMapping, error)
+	FetchTagLocalesByTagIds(ctx context.Context, ids []*string, locale *string, countryId *string) ([]*TagLocaleMapping, error)
+	FetchSubtreeTags(context.Context, *string) ([]*Tags, error)
+	FetchByInTagLocaleMappings(context.Context, []*string) ([]*TagLocaleMapping, error)
+	CreateTagLocaleDraft(context.Context, *sql.Tx, *TagLocaleMapping) error
+	FetchTagLocaleDrafts(ctx context.Context, ids []*string, locale *string, countryId *string) ([]*TagLocaleMapping, error)
+	FetchTagLocaleDraftsByIds(context.Context, []*string) ([]*TagLocaleMapping, error)
+	PublishTagLocaleDraft(context.Context, *sql.Tx, *TagLocaleMapping, *string) error
+	DiscardTagLocaleDraft(context.Context, *sql.Tx, *string) error
+	FetchFilteredTagsPaginated(context.Context, *string, *string, *int, *int) ([]*Tags, error)
+	FetchFilteredTagsPaginatedForAdmin(context.Context, *string, *string, *int, *int) ([]*Tags, error)
+	IsCollegePresent(context.Context, *string, *string) (bool, error)
+	FetchTagLocaleMappingsByLocale(context.Context, []*Tags, *string, *string) ([]*Tags, error)
+	FetchTagLocaleMappingsByLocaleForContext(context.Context, []*Tags, *string, *string) ([]*Tags, error)
+	FetchAttributeLocalesByLocale(context.Context, []*Tags, *string, *string) ([]*Tags, error)
+	FetchTagAttributeLocales(context.Context, *string) ([]*TagAttributeLocale, error)
+	CreateTagAttributeLocale(context.Context, *sql.Tx, *TagAttributeLocale) error
+	DeleteTagAttributeLocale(context.Context, *sql.Tx, *TagAttributeLocale) error
+	FetchTagCountryRules(context.Context, *string) ([]*TagCountryRule, error)
+	CreateTagCountryRule(context.Context, *sql.Tx, *TagCountryRule) error
+	DeleteTagCountryRule(context.Context, *sql.Tx, *TagCountryRule) error
+	FetchTagAvailability(context.Context, []*Tags, *string) ([]*Tags, error)
+	FilterAvailableTags(context.Context, []*Tags, *string) ([]*Tags, error)
+	FetchRegions(context.Context) ([]*Region, error)
+	FetchRegionCountryMappings(context.Context, *string) ([]*RegionCountryMapping, error)
+	CreateRegionCountryMapping(context.Context, *sql.Tx, *RegionCountryMapping) error
+	DeleteRegionCountryMapping(context.Context, *sql.Tx, *RegionCountryMapping) error
+	ResolveRegionParents(context.Context, []*string) ([]*string, error)
+	GroupCountriesByRegion(context.Context, []*Tags) ([]*Region, error)
+	FetchAcademicCalendar(context.Context, *string) (*AcademicCalendar, error)
+	DeleteTagLocaleMapping(context.Context, *sql.Tx, *TagLocaleMapping) error
+	UpdateTag(context.Context, *sql.Tx, *UpdateTag) error
+	ToggleTags(context.Context, bool, []*string) error
+	FetchTagOrders(context.Context, *string, *string) ([]*ParentTagMapping, error)
+	UpdateTagOrders(context.Context, *sql.Tx, []*Order, *string, *string, *int) error
+	FetchTagOrderVersion(context.Context, *string, *string) (int, error)
+	FetchCurrentTagOrders(context.Context, *string, *string) (*TagOrders, error)
+	OrderTags(ctx context.Context, tags []*Tags, tagType *string, curriculumType *string, hierarchy *string, locale *string) ([]*Tags, error)
+	FetchTagIdFromLegacyId(context.Context, *string, *string) ([]*LegacyTagMapping, error)
+	FetchLegacyIdFromTagId(context.Context, *string) ([]*LegacyTagMapping, error)
+	FetchLegacyIdFromTagIds(context.Context, []*string) ([]*LegacyTagMapping, error)
+	FetchGradesFromProductId(context.Context, *string) ([]*GradeProduct, error)
+	NewTagLoader(context.Context) TagLoader
+	FetchTagCacheEntries(context.Context, *string) ([]*CacheEntry, error)
+	FetchHierarchyCacheEntries(context.Context, *string) ([]*CacheEntry, error)
+	EvictCacheKeys(context.Context, []*string) ([]string, error)
+	FlushHierarchyCache(context.Context, *string) ([]string, error)
 }
@@ -1,8 +1,10 @@
 package domain
 
+import "context"
+
 type TeacherTagsService interface {
-	GetTeacherTags(tags *GetTeacherTags) (*GetTagsResponse, error)
-	GetTestsSkillsForLibrary(gtt *GetTeacherTags) (*GetTagsResponse, error)
-	SearchTeacherTags(tags *GetTeacherTags) ([]*TagResponse, error)
-	GetCountriesTagsNew(tags *GetCountriesNew) (getTagResponse *GetCountriesNewResponse, err error)
+	GetTeacherTags(ctx context.Context, tags *GetTeacherTags) (*GetTagsResponse, error)
+	GetTestsSkillsForLibrary(ctx context.Context, gtt *GetTeacherTags) (*GetTagsResponse, error)
+	SearchTeacherTags(ctx context.Context, tags *GetTeacherTags) ([]*TagResponse, error)
+	GetCountriesTagsNew(ctx context.Context, tags *GetCountriesNew) (getTagResponse *GetCountriesNewResponse, err error)
 }
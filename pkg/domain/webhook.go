@@ -0,0 +1,111 @@
+package domain
+
+import (
+	"context"
+	"time"
+)
+
+// WebhookSubscription posts the tag events it matches to Url. An empty SubtreePrefix, TagTypes or EventTypes
+// matches every event.
+type WebhookSubscription struct {
+	ID  *string `json:"id"`
+	Url *string `json:"url"`
+	// Secret signs the deliveries, it is never returned by the api
+	Secret *string `json:"-"`
+	// SubtreePrefix is a parent path such as 12.24681.251, the tag at the end of it and every tag under it match
+	SubtreePrefix *string   `json:"subtree_prefix"`
+	TagTypes      []string  `json:"tag_types"`
+	EventTypes    []string  `json:"event_types"`
+	Active        bool      `json:"active"`
+	Publish       bool      `json:"publish"`
+	UpdatedAt     time.Time `json:"updated_at"`
+	CreatedAt     time.Time `json:"created_at"`
+}
+
+// WebhookDelivery is one event posted to one subscription, it keeps the outcome of the latest attempt and is retried
+// until it is delivered or runs out of attempts
+type WebhookDelivery struct {
+	ID             *string   `json:"id"`
+	SubscriptionID *string   `json:"subscription_id"`
+	EventID        *string   `json:"event_id"`
+	EventType      *string   `json:"event_type"`
+	TagId          *string   `json:"tag_id"`
+	Payload        *string   `json:"payload"`
+	Status         *string   `json:"status"`
+	Attempts       int       `json:"attempts"`
+	ResponseStatus *int      `json:"response_status"`
+	Error          *string   `json:"error"`
+	NextAttemptAt  time.Time `json:"next_attempt_at"`
+	UpdatedAt      time.Time `json:"updated_at"`
+	CreatedAt      time.Time `json:"created_at"`
+	// RedeliveryOf is the delivery a delivery queued by hand repeats, unset on the one queued by the event
+	RedeliveryOf *string `json:"redelivery_of"`
+}
+
+type WebhookSubscriptionRepository interface {
+	CreateWebhookSubscription(context.Context, *WebhookSubscription) (*string, error)
+	UpdateWebhookSubscription(context.Context, *WebhookSubscription) error
+	FetchWebhookSubscription(context.Context, *string) (*WebhookSubscription, error)
+	FetchWebhookSubscriptions(context.Context) ([]*WebhookSubscription, error)
+	DeleteWebhookSubscription(context.Context, *string) error
+}
+
+type WebhookDeliveryRepository interface {
+	// CreateWebhookDelivery returns a nil id when the subscription has the delivery already, the one of the event or
+	// the redelivery of the same delivery
+	CreateWebhookDelivery(context.Context, *WebhookDelivery) (*string, error)
+	FetchWebhookDelivery(context.Context, *string) (*WebhookDelivery, error)
+	FetchWebhookDeliveries(context.Context, *GetWebhookDeliveries) ([]*WebhookDelivery, error)
+	FetchDueWebhookDeliveries(context.Context, time.Time, int) ([]*WebhookDelivery, error)
+	// ClaimWebhookDelivery moves the next attempt of a due delivery to the given time, it returns false when another
+	// worker claimed the delivery first
+	ClaimWebhookDelivery(context.Context, *WebhookDelivery, time.Time) (bool, error)
+	UpdateWebhookDelivery(context.Context, *WebhookDelivery) error
+}
+
+// WebhookSender posts a signed payload, the status is the http status of the response, 0 when none was received
+type WebhookSender interface {
+	Send(ctx context.Context, url string, headers map[string]string, payload []byte) (int, error)
+}
+
+type WebhookService interface {
+	CreateWebhookSubscription(context.Context, *SaveWebhookSubscription) (*WebhookSubscription, error)
+	UpdateWebhookSubscription(context.Context, *string, *SaveWebhookSubscription) (*WebhookSubscription, error)
+	GetWebhookSubscription(context.Context, *string) (*WebhookSubscription, error)
+	GetWebhookSubscriptions(context.Context) ([]*WebhookSubscription, error)
+	DeleteWebhookSubscription(context.Context, *string) error
+	GetWebhookDeliveries(context.Context, *GetWebhookDeliveries) ([]*WebhookDelivery, error)
+	RedeliverWebhook(context.Context, *string) (*WebhookDelivery, error)
+}
+
+// SaveWebhookSubscription creates or updates a subscription, unset fields keep their value on update and an empty
+// list clears the filter
+type SaveWebhookSubscription struct {
+	Url           *string  `json:"url"`
+	Secret        *string  `json:"secret"`
+	SubtreePrefix *string  `json:"subtree_prefix"`
+	TagTypes      []string `json:"tag_types"`
+	EventTypes    []string `json:"event_types"`
+	Active        *bool    `json:"active"`
+}
+
+// GetWebhookDeliveries pages through the delivery log of a subscription, latest first
+type GetWebhookDeliveries struct {
+	SubscriptionID *string `json:"subscription_id"`
+	Status         *string `json:"status"`
+	Start          int     `json:"start"`
+	Limit          int     `json:"limit"`
+}
+
+type webhookDeliveryStatusList struct {
+	Pending   string
+	Delivered string
+	Failed    string
+}
+
+// WebhookDeliveryStatusEnum for public use
+var WebhookDeliveryStatusEnum = &webhookDeliveryStatusList{
+	Pending:   "pending",
+	Delivered: "delivered",
+	Failed:    "failed",
+}
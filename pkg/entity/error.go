@@ -36,6 +36,10 @@ func HandleError(rw http.ResponseWriter, msg string, err error, locale string, t
 		rw.WriteHeader(http.StatusBadRequest)
 		response.Message = msg
 		response.Status = http.StatusText(http.StatusBadRequest)
+	case noonerror.ErrConflict:
+		rw.WriteHeader(http.StatusConflict)
+		response.Message = msg
+		response.Status = http.StatusText(http.StatusConflict)
 	case noonerror.ErrInternalServer:
 		rw.WriteHeader(http.StatusInternalServerError)
 		response.Message = msg
@@ -24,6 +24,9 @@ func (entity Response) SendResponse(rw http.ResponseWriter, data interface{}, me
 	case http.StatusAccepted:
 		rw.WriteHeader(http.StatusAccepted)
 		entity.Status = http.StatusText(http.StatusAccepted)
+	case http.StatusConflict:
+		rw.WriteHeader(http.StatusConflict)
+		entity.Status = http.StatusText(http.StatusConflict)
 	default:
 		rw.WriteHeader(http.StatusOK)
 		entity.Status = http.StatusText(http.StatusOK)
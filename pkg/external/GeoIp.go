@@ -1,75 +1,46 @@
 package external
 
 import (
-	"bitbucket.org/noon-go/noonhttp"
 	"bitbucket.org/noon-micro/curriculum/config"
 	"bitbucket.org/noon-micro/curriculum/pkg/domain"
 	noonerror "bitbucket.org/noon-micro/curriculum/pkg/lib/error"
-	"bitbucket.org/noon-micro/curriculum/pkg/lib/helper"
+	"bitbucket.org/noon-micro/curriculum/pkg/lib/httplib"
 	"bitbucket.org/noon-micro/curriculum/pkg/lib/logger"
+	"context"
 	"encoding/json"
-	"github.com/sirupsen/logrus"
-	"strconv"
 )
 
+// GeoIpStruct resolves addresses through the ipstack api, addresses are left unresolved when no key is configured
 type GeoIpStruct struct {
-	client *noonhttp.ClientEntity
+	client *httplib.ContextClient
 }
 
-const (
-	getGeoIp = "/rpc/add_curriculum_tag"
-)
-
-func NewGeoIpExternal(client *noonhttp.ClientEntity) *GeoIpStruct {
+func NewGeoIpExternal(client *httplib.ContextClient) *GeoIpStruct {
 	return &GeoIpStruct{client: client}
 }
 
-func (e *GeoIpStruct) GetGeoIp(getGeoIpRequest *domain.GetGeoIp) (countryCode *string, err error) {
-	if *getGeoIpRequest.Ip == "" {
-		countryCode := "SA"
-		return &countryCode, nil
-	}
-	t1 := helper.MakeTimestamp()
-	out, err := json.Marshal(getGeoIpRequest)
-	if err != nil {
-		logger.Client.Error("getGeoIpRequestError", err, logger.GetErrorStack())
-		return nil, noonerror.New(noonerror.ErrInternalServer, "getGeoIpRequestError")
+func (e *GeoIpStruct) GetGeoIp(ctx context.Context, getGeoIpRequest *domain.GetGeoIp) (countryCode *string, err error) {
+	if stringValue(getGeoIpRequest.Ip) == "" || config.GetConfig().GeoIpKey == "" {
+		return nil, nil
 	}
-	contextLogger := logger.Client.WithFields(logrus.Fields{
-		"getGeoIpRequestDto": string(out),
-	})
-	contextLogger.Info("get Tags Elastic Request")
-	strPointerValue := *getGeoIpRequest.Ip
-	var pathParam = "/" + strPointerValue
-	url := config.GetConfig().GeoIpHost + pathParam
-	payload := make(map[string]string)
-	payload["access_key"] = "e5bf2c82292aa79f569d3aec5a040bc7"
-	payload["format"] = "1"
+	url := config.GetConfig().GeoIpHost + "/" + *getGeoIpRequest.Ip
+	payload := map[string]string{"access_key": config.GetConfig().GeoIpKey, "format": "1"}
+	resp, err := e.client.ServeGet(ctx, url, getHeaders1(), payload)
 	if err != nil {
-		countryCode := "SA"
-		return &countryCode, nil
+		logger.Client.Error("getGeoIpError", err, logger.GetErrorStack())
+		return nil, noonerror.New(noonerror.ErrInternalServer, "getGeoIpError")
 	}
-	resp, err := e.client.ServeGet(url, getHeaders1(), payload)
-	if err != nil {
-		logger.Client.Error("hideParentTagsElasticError", err, logger.GetErrorStack())
-		countryCode := "SA"
-		return &countryCode, nil
-	}
-	contextLogger.Info("get Tags Elastic Response", string(resp))
-	respBody := make(map[string]interface{})
-	err = json.Unmarshal(resp, &respBody)
-	if err != nil {
-		//logger.Client.Error("getTagsElasticError", err, logger.GetErrorStack())
-		countryCode := "SA"
-		return &countryCode, nil
+	respBody := struct {
+		CountryCode string `json:"country_code"`
+	}{}
+	if err = json.Unmarshal(resp, &respBody); err != nil {
+		logger.Client.Error("getGeoIpResponseError", string(resp))
+		return nil, noonerror.New(noonerror.ErrInternalServer, "getGeoIpResponseError")
 	}
-	if respBody["country_code"] != nil {
-		countryCode := respBody["country_code"].(string)
-		return &countryCode, nil
+	if respBody.CountryCode == "" {
+		return nil, nil
 	}
-	t2 := helper.MakeTimestamp()
-	contextLogger.Info("Time Taken: ", strconv.FormatInt(t2-t1, 10))
-	return
+	return &respBody.CountryCode, nil
 }
 
 func getHeaders1() map[string]string {
@@ -1,19 +1,20 @@
 package external
 
 import (
-	"bitbucket.org/noon-go/noonhttp"
 	"bitbucket.org/noon-micro/curriculum/config"
 	"bitbucket.org/noon-micro/curriculum/pkg/domain"
 	noonerror "bitbucket.org/noon-micro/curriculum/pkg/lib/error"
 	"bitbucket.org/noon-micro/curriculum/pkg/lib/helper"
+	"bitbucket.org/noon-micro/curriculum/pkg/lib/httplib"
 	"bitbucket.org/noon-micro/curriculum/pkg/lib/logger"
+	"context"
 	"encoding/json"
 	"github.com/sirupsen/logrus"
 	"strconv"
 )
 
 type ElasticStruct struct {
-	client *noonhttp.ClientEntity
+	client *httplib.ContextClient
 }
 
 const (
@@ -26,11 +27,11 @@ const (
 	hideParentTagsURL   = "/rpc/manage_parent_tags"
 )
 
-func NewElasticExternal(client *noonhttp.ClientEntity) *ElasticStruct {
+func NewElasticExternal(client *httplib.ContextClient) *ElasticStruct {
 	return &ElasticStruct{client: client}
 }
 
-func (e *ElasticStruct) CreateTag(createTagElastic *domain.CreateTagElastic) (err error) {
+func (e *ElasticStruct) CreateTag(ctx context.Context, createTagElastic *domain.CreateTagElastic) (err error) {
 	t1 := helper.MakeTimestamp()
 	out, err := json.Marshal(createTagElastic)
 	if err != nil {
@@ -48,7 +49,9 @@ func (e *ElasticStruct) CreateTag(createTagElastic *domain.CreateTagElastic) (er
 		logger.Client.Error("createTagElasticError", err, logger.GetErrorStack())
 		return noonerror.New(noonerror.ErrInternalServer, "elasticPayloadMappingError")
 	}
-	resp, err := e.client.ServePost(url, getHeaders(), payload)
+	ctx, cancel := helper.WithTimeout(ctx, config.GetConfig().ElasticTimeout, config.DefaultElasticTimeout)
+	defer cancel()
+	resp, err := e.client.ServePost(ctx, url, getHeaders(), payload)
 	if err != nil {
 		logger.Client.Error("createTagElasticError", err, logger.GetErrorStack())
 		return noonerror.New(noonerror.ErrInternalServer, "createTagElasticError")
@@ -59,9 +62,9 @@ func (e *ElasticStruct) CreateTag(createTagElastic *domain.CreateTagElastic) (er
 	return
 }
 
-func (e *ElasticStruct) GetTags(getTagsElastic *domain.GetTagsElastic) (tags []*string, next *int, err error) {
+func (e *ElasticStruct) GetTags(ctx context.Context, getTagsElastic *domain.GetTagsElastic) (tags []*string, next *int, err error) {
 	if getTagsElastic.Text != nil {
-		return e.GetTagsSearch(getTagsElastic)
+		return e.GetTagsSearch(ctx, getTagsElastic)
 	}
 	t1 := helper.MakeTimestamp()
 	if getTagsElastic.Limit == 0 {
@@ -83,7 +86,9 @@ func (e *ElasticStruct) GetTags(getTagsElastic *domain.GetTagsElastic) (tags []*
 		logger.Client.Error("getTagsElasticError", err, logger.GetErrorStack())
 		return nil, nil, noonerror.New(noonerror.ErrInternalServer, "elasticPayloadMappingError")
 	}
-	resp, err := e.client.ServePost(url, getHeaders(), payload)
+	ctx, cancel := helper.WithTimeout(ctx, config.GetConfig().ElasticTimeout, config.DefaultElasticTimeout)
+	defer cancel()
+	resp, err := e.client.ServePost(ctx, url, getHeaders(), payload)
 	if err != nil {
 		logger.Client.Error("getTagsElasticError", err, logger.GetErrorStack())
 		return nil, nil, noonerror.New(noonerror.ErrInternalServer, "getTagsElasticError")
@@ -135,7 +140,7 @@ func (e *ElasticStruct) GetTags(getTagsElastic *domain.GetTagsElastic) (tags []*
 	return
 }
 
-func (e *ElasticStruct) GetTagsSearch(getTagsElastic *domain.GetTagsElastic) (tags []*string, next *int, err error) {
+func (e *ElasticStruct) GetTagsSearch(ctx context.Context, getTagsElastic *domain.GetTagsElastic) (tags []*string, next *int, err error) {
 	t1 := helper.MakeTimestamp()
 	if getTagsElastic.Limit == 0 {
 		getTagsElastic.Limit = 100
@@ -156,7 +161,9 @@ func (e *ElasticStruct) GetTagsSearch(getTagsElastic *domain.GetTagsElastic) (ta
 		logger.Client.Error("getTagsElasticSearchError", err, logger.GetErrorStack())
 		return nil, nil, noonerror.New(noonerror.ErrInternalServer, "elasticPayloadMappingError")
 	}
-	resp, err := e.client.ServePost(url, getHeaders(), payload)
+	ctx, cancel := helper.WithTimeout(ctx, config.GetConfig().ElasticTimeout, config.DefaultElasticTimeout)
+	defer cancel()
+	resp, err := e.client.ServePost(ctx, url, getHeaders(), payload)
 	if err != nil {
 		logger.Client.Error("getTagsElasticSearchError", err, logger.GetErrorStack())
 		return nil, nil, noonerror.New(noonerror.ErrInternalServer, "getTagsElasticError")
@@ -208,7 +215,7 @@ func (e *ElasticStruct) GetTagsSearch(getTagsElastic *domain.GetTagsElastic) (ta
 	return
 }
 
-func (e *ElasticStruct) UpdateTag(tagId *string, delete *bool, names []*domain.TagName) (err error) {
+func (e *ElasticStruct) UpdateTag(ctx context.Context, tagId *string, delete *bool, names []*domain.TagName) (err error) {
 	t1 := helper.MakeTimestamp()
 	contextLogger := logger.Client.WithFields(logrus.Fields{
 		"tagId":  tagId,
@@ -228,7 +235,9 @@ func (e *ElasticStruct) UpdateTag(tagId *string, delete *bool, names []*domain.T
 	if len(names) > 0 {
 		payload["name"] = names
 	}
-	resp, err := e.client.ServePost(url, getHeaders(), payload)
+	ctx, cancel := helper.WithTimeout(ctx, config.GetConfig().ElasticTimeout, config.DefaultElasticTimeout)
+	defer cancel()
+	resp, err := e.client.ServePost(ctx, url, getHeaders(), payload)
 	if err != nil {
 		logger.Client.Error("updateTagElasticError", err, logger.GetErrorStack())
 		return noonerror.New(noonerror.ErrInternalServer, "updateTagElasticError")
@@ -239,7 +248,7 @@ func (e *ElasticStruct) UpdateTag(tagId *string, delete *bool, names []*domain.T
 	return
 }
 
-func (e *ElasticStruct) AddParentTags(tagId *string, parents []*string) (err error) {
+func (e *ElasticStruct) AddParentTags(ctx context.Context, tagId *string, parents []*string) (err error) {
 	t1 := helper.MakeTimestamp()
 	contextLogger := logger.Client.WithFields(logrus.Fields{
 		"tagId":   tagId,
@@ -255,7 +264,9 @@ func (e *ElasticStruct) AddParentTags(tagId *string, parents []*string) (err err
 		return
 	}
 	payload["add"] = parents
-	resp, err := e.client.ServePost(url, getHeaders(), payload)
+	ctx, cancel := helper.WithTimeout(ctx, config.GetConfig().ElasticTimeout, config.DefaultElasticTimeout)
+	defer cancel()
+	resp, err := e.client.ServePost(ctx, url, getHeaders(), payload)
 	if err != nil {
 		logger.Client.Error("addParentTagsElasticError", err, logger.GetErrorStack())
 		return noonerror.New(noonerror.ErrInternalServer, "addParentTagsElasticError")
@@ -266,7 +277,7 @@ func (e *ElasticStruct) AddParentTags(tagId *string, parents []*string) (err err
 	return
 }
 
-func (e *ElasticStruct) RemoveParentTags(tagId *string, parents []*string) (err error) {
+func (e *ElasticStruct) RemoveParentTags(ctx context.Context, tagId *string, parents []*string) (err error) {
 	t1 := helper.MakeTimestamp()
 	contextLogger := logger.Client.WithFields(logrus.Fields{
 		"tagId":   tagId,
@@ -282,7 +293,9 @@ func (e *ElasticStruct) RemoveParentTags(tagId *string, parents []*string) (err
 		return
 	}
 	payload["remove"] = parents
-	resp, err := e.client.ServePost(url, getHeaders(), payload)
+	ctx, cancel := helper.WithTimeout(ctx, config.GetConfig().ElasticTimeout, config.DefaultElasticTimeout)
+	defer cancel()
+	resp, err := e.client.ServePost(ctx, url, getHeaders(), payload)
 	if err != nil {
 		logger.Client.Error("removeParentTagsElasticError", err, logger.GetErrorStack())
 		return noonerror.New(noonerror.ErrInternalServer, "removeParentTagsElasticError")
@@ -293,7 +306,7 @@ func (e *ElasticStruct) RemoveParentTags(tagId *string, parents []*string) (err
 	return
 }
 
-func (e *ElasticStruct) HideParentTags(tagId *string, parents []*string) (err error) {
+func (e *ElasticStruct) HideParentTags(ctx context.Context, tagId *string, parents []*string) (err error) {
 	t1 := helper.MakeTimestamp()
 	contextLogger := logger.Client.WithFields(logrus.Fields{
 		"tagId":   tagId,
@@ -309,7 +322,9 @@ func (e *ElasticStruct) HideParentTags(tagId *string, parents []*string) (err er
 		return
 	}
 	payload["hide"] = parents
-	resp, err := e.client.ServePost(url, getHeaders(), payload)
+	ctx, cancel := helper.WithTimeout(ctx, config.GetConfig().ElasticTimeout, config.DefaultElasticTimeout)
+	defer cancel()
+	resp, err := e.client.ServePost(ctx, url, getHeaders(), payload)
 	if err != nil {
 		logger.Client.Error("hideParentTagsElasticError", err, logger.GetErrorStack())
 		return noonerror.New(noonerror.ErrInternalServer, "hideParentTagsElasticError")
@@ -0,0 +1,254 @@
+package external
+
+import (
+	"bitbucket.org/noon-micro/curriculum/pkg/domain"
+	"bitbucket.org/noon-micro/curriculum/pkg/lib/flow"
+	"bitbucket.org/noon-micro/curriculum/pkg/lib/searchkey"
+	"bitbucket.org/noon-micro/curriculum/pkg/service/constant"
+	"context"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// MemoryElasticStruct is an in process stand in for the elastic rpc service. Parents match hidden and
+// active mappings while hidden active parents only match active ones, text search is a case insensitive substring
+// match on every tag name.
+type MemoryElasticStruct struct {
+	mu   sync.RWMutex
+	docs map[string]*elasticDocument
+}
+
+type elasticDocument struct {
+	tag           domain.CreateTagElastic
+	parents       map[string]struct{}
+	hiddenParents map[string]struct{}
+}
+
+// NewMemoryElasticExternal indexes the fixture tags with their published parent mappings
+func NewMemoryElasticExternal(fixture *domain.Fixture) *MemoryElasticStruct {
+	e := &MemoryElasticStruct{docs: make(map[string]*elasticDocument)}
+	if fixture == nil {
+		return e
+	}
+	for _, v := range fixture.Tags {
+		if v.ID == nil {
+			continue
+		}
+		locale := constant.DefaultLocale
+		curriculumType, _ := flow.CurriculumMapper(&v.CurriculumType)
+		doc := newElasticDocument(domain.CreateTagElastic{ID: v.ID, Type: v.Type, CurriculumType: curriculumType,
+			CreatorId: v.CreatorId, CreatorType: optionalString(v.CreatorType), Access: optionalString(v.Access),
+			TagGroup: optionalString(v.TagGroup), CountryId: v.CountryId, Deleted: !v.Publish,
+			Name: []*domain.TagName{{Value: v.Name, Locale: &locale}}})
+		e.docs[*v.ID] = doc
+	}
+	for _, v := range fixture.TagLocaleMappings {
+		if doc, ok := e.docs[stringValue(v.TagID)]; ok && v.Publish {
+			doc.tag.Name = append(doc.tag.Name, &domain.TagName{Value: v.Name, Locale: v.Locale})
+		}
+	}
+	// the fixture is indexed the way the search decorator of the service indexes names
+	for _, doc := range e.docs {
+		doc.tag.Name = searchkey.Names(doc.tag.Name)
+	}
+	for _, v := range fixture.ParentTagMappings {
+		doc, ok := e.docs[stringValue(v.TagID)]
+		if !ok || !v.Publish || v.ParentTagID == nil {
+			continue
+		}
+		if v.Hidden {
+			doc.hiddenParents[*v.ParentTagID] = struct{}{}
+		} else {
+			doc.parents[*v.ParentTagID] = struct{}{}
+		}
+	}
+	return e
+}
+
+func newElasticDocument(tag domain.CreateTagElastic) *elasticDocument {
+	return &elasticDocument{tag: tag, parents: make(map[string]struct{}), hiddenParents: make(map[string]struct{})}
+}
+
+func (e *MemoryElasticStruct) CreateTag(ctx context.Context, createTagElastic *domain.CreateTagElastic) (err error) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	doc := newElasticDocument(*createTagElastic)
+	for _, v := range createTagElastic.Parents {
+		if v != nil {
+			doc.parents[*v] = struct{}{}
+		}
+	}
+	e.docs[stringValue(createTagElastic.ID)] = doc
+	return
+}
+
+func (e *MemoryElasticStruct) GetTags(ctx context.Context, getTagsElastic *domain.GetTagsElastic) (tags []*string, next *int, err error) {
+	return e.search(getTagsElastic, nil)
+}
+
+func (e *MemoryElasticStruct) GetTagsSearch(ctx context.Context, getTagsElastic *domain.GetTagsElastic) (tags []*string, next *int, err error) {
+	var text *string
+	if getTagsElastic.Text != nil {
+		lower := strings.ToLower(*getTagsElastic.Text)
+		text = &lower
+	}
+	return e.search(getTagsElastic, text)
+}
+
+func (e *MemoryElasticStruct) UpdateTag(ctx context.Context, tagId *string, delete *bool, names []*domain.TagName) (err error) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	doc, ok := e.docs[stringValue(tagId)]
+	if !ok {
+		return
+	}
+	if delete != nil {
+		doc.tag.Deleted = *delete
+	}
+	for _, name := range names {
+		replaced := false
+		for i, v := range doc.tag.Name {
+			if stringValue(v.Locale) == stringValue(name.Locale) {
+				doc.tag.Name[i] = name
+				replaced = true
+			}
+		}
+		if !replaced {
+			doc.tag.Name = append(doc.tag.Name, name)
+		}
+	}
+	return
+}
+
+func (e *MemoryElasticStruct) AddParentTags(ctx context.Context, tagId *string, parents []*string) (err error) {
+	e.updateParents(tagId, parents, func(doc *elasticDocument, parent string) {
+		delete(doc.hiddenParents, parent)
+		doc.parents[parent] = struct{}{}
+	})
+	return
+}
+
+func (e *MemoryElasticStruct) RemoveParentTags(ctx context.Context, tagId *string, parents []*string) (err error) {
+	e.updateParents(tagId, parents, func(doc *elasticDocument, parent string) {
+		delete(doc.hiddenParents, parent)
+		delete(doc.parents, parent)
+	})
+	return
+}
+
+func (e *MemoryElasticStruct) HideParentTags(ctx context.Context, tagId *string, parents []*string) (err error) {
+	e.updateParents(tagId, parents, func(doc *elasticDocument, parent string) {
+		delete(doc.parents, parent)
+		doc.hiddenParents[parent] = struct{}{}
+	})
+	return
+}
+
+func (e *MemoryElasticStruct) updateParents(tagId *string, parents []*string, update func(*elasticDocument, string)) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	doc, ok := e.docs[stringValue(tagId)]
+	if !ok {
+		return
+	}
+	for _, v := range parents {
+		if v != nil {
+			update(doc, *v)
+		}
+	}
+}
+
+// search returns ids ordered numerically, next is -1 once the last page is reached like the rpc service
+func (e *MemoryElasticStruct) search(query *domain.GetTagsElastic, text *string) (tags []*string, next *int, err error) {
+	limit := query.Limit
+	if limit == 0 {
+		limit = 100
+	}
+	e.mu.RLock()
+	var matched []string
+	for id, doc := range e.docs {
+		if doc.matches(query, text) {
+			matched = append(matched, id)
+		}
+	}
+	e.mu.RUnlock()
+	sort.Slice(matched, func(i, j int) bool {
+		a, errA := strconv.Atoi(matched[i])
+		b, errB := strconv.Atoi(matched[j])
+		if errA != nil || errB != nil {
+			return matched[i] < matched[j]
+		}
+		return a < b
+	})
+	for i := query.Start; i < len(matched) && i < query.Start+limit; i++ {
+		id := matched[i]
+		tags = append(tags, &id)
+	}
+	nextVal := -1
+	if query.Start+limit < len(matched) {
+		nextVal = query.Start + limit
+	}
+	return tags, &nextVal, nil
+}
+
+func (doc *elasticDocument) matches(query *domain.GetTagsElastic, text *string) bool {
+	tag := doc.tag
+	if tag.Deleted {
+		return false
+	}
+	if !optionalEqual(query.Type, tag.Type) || !optionalEqual(query.CurriculumType, tag.CurriculumType) ||
+		!optionalEqual(query.TagGroup, tag.TagGroup) || !optionalEqual(query.Access, tag.Access) {
+		return false
+	}
+	if query.CreatorId != nil && (tag.CreatorId == nil || *tag.CreatorId != *query.CreatorId) {
+		return false
+	}
+	if query.CreatorType != nil && tag.CreatorType != nil && *query.CreatorType != *tag.CreatorType {
+		return false
+	}
+	if query.CountryId != "" && tag.CountryId != "" && query.CountryId != tag.CountryId {
+		return false
+	}
+	for _, v := range query.Parents {
+		_, active := doc.parents[stringValue(v)]
+		_, hidden := doc.hiddenParents[stringValue(v)]
+		if !active && !hidden {
+			return false
+		}
+	}
+	for _, v := range query.HiddenParents {
+		if _, ok := doc.parents[stringValue(v)]; !ok {
+			return false
+		}
+	}
+	if text == nil || *text == "" {
+		return true
+	}
+	for _, name := range tag.Name {
+		if strings.Contains(strings.ToLower(stringValue(name.Value)), *text) {
+			return true
+		}
+	}
+	return false
+}
+
+// optionalEqual treats a missing filter as a match
+func optionalEqual(filter *string, value *string) bool {
+	return filter == nil || (value != nil && *filter == *value)
+}
+
+func optionalString(value string) *string {
+	if value == "" {
+		return nil
+	}
+	return &value
+}
+
+func stringValue(value *string) string {
+	if value == nil {
+		return ""
+	}
+	return *value
+}
@@ -0,0 +1,150 @@
+package external
+
+import (
+	"bitbucket.org/noon-micro/curriculum/config"
+	"bitbucket.org/noon-micro/curriculum/pkg/domain"
+	"bitbucket.org/noon-micro/curriculum/pkg/lib/helper"
+	"bitbucket.org/noon-micro/curriculum/pkg/lib/httplib"
+	"bitbucket.org/noon-micro/curriculum/pkg/lib/logger"
+	"context"
+	"gopkg.in/DataDog/dd-trace-go.v1/ddtrace/tracer"
+	"strings"
+	"time"
+)
+
+const (
+	MmdbGeoIpProviderName    = "mmdb"
+	HttpGeoIpProviderName    = "http"
+	HeaderGeoIpProviderName  = "header"
+	DefaultGeoIpProviderName = "default"
+
+	geoIpSpanName = "geoip.lookup"
+)
+
+// NewGeoIpProvider chains the providers configured in GeoIpProviders, database is the lookup used for mmdb. The
+// http provider is left out when no GeoIpKey is configured.
+func NewGeoIpProvider(client *httplib.ContextClient, database domain.GeoIp) domain.GeoIp {
+	providers := config.GetConfig().GeoIpProviders
+	if providers == "" {
+		providers = config.DefaultGeoIpProviders
+	}
+	chain := &ChainGeoIpStruct{}
+	for _, name := range strings.Split(providers, ",") {
+		switch strings.TrimSpace(name) {
+		case MmdbGeoIpProviderName:
+			chain.add(MmdbGeoIpProviderName, database, helper.Timeout(config.GetConfig().GeoIpDatabaseTimeout, config.DefaultGeoIpDatabaseTimeout))
+		case HttpGeoIpProviderName:
+			if config.GetConfig().GeoIpKey == "" {
+				logger.Client.Info("Geo ip http provider disabled, GEO_IP_KEY is not set")
+				continue
+			}
+			chain.add(HttpGeoIpProviderName, NewGeoIpExternal(client), helper.Timeout(config.GetConfig().GeoIpTimeout, config.DefaultGeoIpTimeout))
+		case HeaderGeoIpProviderName:
+			chain.add(HeaderGeoIpProviderName, &HeaderGeoIpStruct{}, 0)
+		case DefaultGeoIpProviderName:
+			chain.add(DefaultGeoIpProviderName, &DefaultGeoIpStruct{countryCode: config.GetConfig().DefaultCountryCode}, 0)
+		case "":
+		default:
+			logger.Client.Fatal("Unknown geo ip provider " + name)
+		}
+	}
+	return chain
+}
+
+// ChainGeoIpStruct asks the providers in order until one resolves the address. A provider failing or running past
+// its timeout counts as unresolved. Every lookup is traced as a geoip.lookup span named after the provider and
+// tagged with its outcome, which gives the per provider hit, error and latency metrics.
+type ChainGeoIpStruct struct {
+	providers []*geoIpProvider
+}
+
+type geoIpProvider struct {
+	name    string
+	geo     domain.GeoIp
+	timeout time.Duration
+}
+
+type geoIpResult struct {
+	countryCode *string
+	err         error
+}
+
+func (e *ChainGeoIpStruct) add(name string, geo domain.GeoIp, timeout time.Duration) {
+	e.providers = append(e.providers, &geoIpProvider{name: name, geo: geo, timeout: timeout})
+}
+
+func (e *ChainGeoIpStruct) GetGeoIp(ctx context.Context, getGeoIpRequest *domain.GetGeoIp) (countryCode *string, err error) {
+	for _, v := range e.providers {
+		if countryCode = v.lookup(ctx, getGeoIpRequest); countryCode != nil {
+			return countryCode, nil
+		}
+	}
+	return nil, nil
+}
+
+func (p *geoIpProvider) lookup(ctx context.Context, getGeoIpRequest *domain.GetGeoIp) *string {
+	span, ctx := tracer.StartSpanFromContext(ctx, geoIpSpanName, tracer.ResourceName(p.name))
+	outcome, result := "unresolved", p.call(ctx, getGeoIpRequest)
+	switch {
+	case result.err == context.DeadlineExceeded:
+		outcome = "timeout"
+		logger.Client.Error("geoIpProviderTimeout", p.name)
+	case result.err != nil:
+		outcome = "error"
+	case result.countryCode != nil:
+		outcome = "resolved"
+		span.SetTag("geoip.country", *result.countryCode)
+	}
+	span.SetTag("geoip.outcome", outcome)
+	span.Finish(tracer.WithError(result.err))
+	if result.err != nil {
+		return nil
+	}
+	return result.countryCode
+}
+
+// call runs providers with a timeout in the background so that lookups which ignore the context, like the
+// database ones, are abandoned on time as well
+func (p *geoIpProvider) call(ctx context.Context, getGeoIpRequest *domain.GetGeoIp) geoIpResult {
+	if p.timeout <= 0 {
+		countryCode, err := p.geo.GetGeoIp(ctx, getGeoIpRequest)
+		return geoIpResult{countryCode: countryCode, err: err}
+	}
+	ctx, cancel := context.WithTimeout(ctx, p.timeout)
+	defer cancel()
+	done := make(chan geoIpResult, 1)
+	go func() {
+		countryCode, err := p.geo.GetGeoIp(ctx, getGeoIpRequest)
+		done <- geoIpResult{countryCode: countryCode, err: err}
+	}()
+	select {
+	case result := <-done:
+		return result
+	case <-ctx.Done():
+		return geoIpResult{err: ctx.Err()}
+	}
+}
+
+// HeaderGeoIpStruct trusts the iso code the client declared through the country header
+type HeaderGeoIpStruct struct{}
+
+func (e *HeaderGeoIpStruct) GetGeoIp(ctx context.Context, getGeoIpRequest *domain.GetGeoIp) (countryCode *string, err error) {
+	code := strings.ToUpper(strings.TrimSpace(stringValue(getGeoIpRequest.Country)))
+	if len(code) != 2 || code[0] < 'A' || code[0] > 'Z' || code[1] < 'A' || code[1] > 'Z' {
+		return nil, nil
+	}
+	return &code, nil
+}
+
+// DefaultGeoIpStruct resolves every address to the country configured for the deployment
+type DefaultGeoIpStruct struct {
+	countryCode string
+}
+
+func (e *DefaultGeoIpStruct) GetGeoIp(ctx context.Context, getGeoIpRequest *domain.GetGeoIp) (countryCode *string, err error) {
+	if e.countryCode == "" {
+		return nil, nil
+	}
+	code := e.countryCode
+	return &code, nil
+}
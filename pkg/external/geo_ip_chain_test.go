@@ -0,0 +1,139 @@
+package external
+
+import (
+	"bitbucket.org/noon-micro/curriculum/config"
+	"bitbucket.org/noon-micro/curriculum/pkg/domain"
+	"bitbucket.org/noon-micro/curriculum/pkg/lib/logger"
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+)
+
+// fakeGeoIp answers after delay whatever the context, like the database lookups
+type fakeGeoIp struct {
+	code  string
+	err   error
+	delay time.Duration
+	calls int
+}
+
+func (e *fakeGeoIp) GetGeoIp(ctx context.Context, getGeoIpRequest *domain.GetGeoIp) (countryCode *string, err error) {
+	e.calls++
+	time.Sleep(e.delay)
+	if e.code == "" {
+		return nil, e.err
+	}
+	code := e.code
+	return &code, e.err
+}
+
+func TestChainGeoIpOrder(t *testing.T) {
+	if logger.Client == nil {
+		logger.New()
+	}
+	failure := errors.New("lookup failed")
+	tests := []struct {
+		name      string
+		providers []*fakeGeoIp
+		timeouts  []time.Duration
+		want      string
+		calls     []int
+	}{
+		{name: "first provider wins", providers: []*fakeGeoIp{{code: "SA"}, {code: "AE"}}, want: "SA", calls: []int{1, 0}},
+		{name: "unresolved falls through", providers: []*fakeGeoIp{{}, {code: "AE"}}, want: "AE", calls: []int{1, 1}},
+		{name: "error falls through", providers: []*fakeGeoIp{{code: "SA", err: failure}, {code: "AE"}}, want: "AE", calls: []int{1, 1}},
+		{name: "timeout falls through", providers: []*fakeGeoIp{{code: "SA", delay: 200 * time.Millisecond}, {code: "AE"}},
+			timeouts: []time.Duration{10 * time.Millisecond, 0}, want: "AE", calls: []int{1, 1}},
+		{name: "lookup within its timeout", providers: []*fakeGeoIp{{code: "SA", delay: time.Millisecond}, {code: "AE"}},
+			timeouts: []time.Duration{time.Second, 0}, want: "SA", calls: []int{1, 0}},
+		{name: "nothing resolves", providers: []*fakeGeoIp{{}, {err: failure}}, calls: []int{1, 1}},
+	}
+	for _, test := range tests {
+		chain := &ChainGeoIpStruct{}
+		for i, v := range test.providers {
+			var timeout time.Duration
+			if test.timeouts != nil {
+				timeout = test.timeouts[i]
+			}
+			chain.add(test.name, v, timeout)
+		}
+		started := time.Now()
+		countryCode, err := chain.GetGeoIp(context.Background(), &domain.GetGeoIp{Ip: str("198.51.100.2")})
+		if err != nil || stringValue(countryCode) != test.want {
+			t.Errorf("%s: got %q err %v, want %q", test.name, stringValue(countryCode), err, test.want)
+		}
+		if elapsed := time.Since(started); elapsed > 150*time.Millisecond {
+			t.Errorf("%s: the chain waited %v on a provider past its timeout", test.name, elapsed)
+		}
+		for i, v := range test.providers {
+			if v.calls != test.calls[i] {
+				t.Errorf("%s: provider %d called %d times, want %d", test.name, i, v.calls, test.calls[i])
+			}
+		}
+	}
+}
+
+func TestNewGeoIpProviderOrder(t *testing.T) {
+	if logger.Client == nil {
+		logger.New()
+	}
+	if config.GetConfig() == nil {
+		config.LoadConfiguration("memory")
+	}
+	conf := config.GetConfig()
+	providers, key := conf.GeoIpProviders, conf.GeoIpKey
+	defer func() { conf.GeoIpProviders, conf.GeoIpKey = providers, key }()
+	tests := []struct {
+		providers string
+		key       string
+		want      []string
+	}{
+		{providers: "", want: []string{"mmdb", "header", "default"}},
+		{providers: "", key: "secret", want: []string{"mmdb", "http", "header", "default"}},
+		{providers: "default, header", want: []string{"default", "header"}},
+		{providers: "header,,mmdb", want: []string{"header", "mmdb"}},
+	}
+	for _, test := range tests {
+		conf.GeoIpProviders, conf.GeoIpKey = test.providers, test.key
+		chain := NewGeoIpProvider(nil, &fakeGeoIp{}).(*ChainGeoIpStruct)
+		var names []string
+		for _, v := range chain.providers {
+			names = append(names, v.name)
+		}
+		if !reflect.DeepEqual(names, test.want) {
+			t.Errorf("%q: got providers %v, want %v", test.providers, names, test.want)
+		}
+	}
+}
+
+func TestHeaderGeoIp(t *testing.T) {
+	tests := []struct {
+		country *string
+		want    string
+	}{
+		{country: str("SA"), want: "SA"},
+		{country: str(" ae "), want: "AE"},
+		{country: str("SAU")},
+		{country: str("1A")},
+		{country: str("")},
+		{country: nil},
+	}
+	for _, test := range tests {
+		countryCode, err := (&HeaderGeoIpStruct{}).GetGeoIp(context.Background(), &domain.GetGeoIp{Country: test.country})
+		if err != nil || stringValue(countryCode) != test.want {
+			t.Errorf("header %q: got %q err %v, want %q", stringValue(test.country), stringValue(countryCode), err, test.want)
+		}
+	}
+	if countryCode, _ := (&DefaultGeoIpStruct{}).GetGeoIp(context.Background(), &domain.GetGeoIp{}); countryCode != nil {
+		t.Errorf("default provider without a country resolved %q", *countryCode)
+	}
+	if countryCode, _ := (&DefaultGeoIpStruct{countryCode: "SA"}).GetGeoIp(context.Background(), &domain.GetGeoIp{}); stringValue(countryCode) != "SA" {
+		t.Errorf("default provider resolved %q, want SA", stringValue(countryCode))
+	}
+}
+
+func str(s string) *string {
+	return &s
+}
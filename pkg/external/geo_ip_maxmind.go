@@ -0,0 +1,108 @@
+package external
+
+import (
+	"bitbucket.org/noon-micro/curriculum/pkg/domain"
+	"bitbucket.org/noon-micro/curriculum/pkg/lib/logger"
+	"context"
+	"github.com/oschwald/maxminddb-golang"
+	"net"
+	"os"
+	"sync"
+	"time"
+)
+
+// MaxmindGeoIpStruct resolves addresses from a local maxmind country database. The database is opened once and
+// swapped for a fresh reader when the file changes, lookups in flight finish on the reader they started with.
+// The reader maps the file into memory, so a new database has to be written next to the file and renamed over
+// it. Writing the file in place changes the pages the current reader is serving from.
+type MaxmindGeoIpStruct struct {
+	path    string
+	mu      sync.RWMutex
+	reader  *maxminddb.Reader
+	modTime time.Time
+	size    int64
+}
+
+type maxmindCountryRecord struct {
+	Country struct {
+		ISOCode string `maxminddb:"iso_code"`
+	} `maxminddb:"country"`
+}
+
+// NewMaxmindGeoIpExternal opens the database at path and checks it for changes every interval. A database which
+// cannot be opened is logged and leaves every address unresolved until a readable file shows up at path.
+func NewMaxmindGeoIpExternal(path string, interval time.Duration) *MaxmindGeoIpStruct {
+	e := &MaxmindGeoIpStruct{path: path}
+	if err := e.reload(); err != nil {
+		logger.Client.Error("geoIpDatabaseOpenError:path:"+path, err)
+	}
+	go e.watch(interval)
+	return e
+}
+
+// GetGeoIp leaves addresses missing from the database unresolved, e.g. ipv6 addresses on an ipv4 only database
+func (e *MaxmindGeoIpStruct) GetGeoIp(ctx context.Context, getGeoIpRequest *domain.GetGeoIp) (countryCode *string, err error) {
+	ip := net.ParseIP(stringValue(getGeoIpRequest.Ip))
+	if ip == nil {
+		return nil, nil
+	}
+	var record maxmindCountryRecord
+	e.mu.RLock()
+	if e.reader == nil {
+		e.mu.RUnlock()
+		return nil, nil
+	}
+	err = e.reader.Lookup(ip, &record)
+	e.mu.RUnlock()
+	if err != nil {
+		logger.Client.Error("geoIpLookupError", err, logger.GetErrorStack())
+		return nil, nil
+	}
+	if record.Country.ISOCode == "" {
+		return nil, nil
+	}
+	return &record.Country.ISOCode, nil
+}
+
+func (e *MaxmindGeoIpStruct) watch(interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for range ticker.C {
+		info, err := os.Stat(e.path)
+		if err != nil {
+			logger.Client.Error("geoIpDatabaseStatError", err, logger.GetErrorStack())
+			continue
+		}
+		e.mu.RLock()
+		changed := !info.ModTime().Equal(e.modTime) || info.Size() != e.size
+		e.mu.RUnlock()
+		if !changed {
+			continue
+		}
+		if err = e.reload(); err != nil {
+			logger.Client.Error("geoIpDatabaseReloadError", err, logger.GetErrorStack())
+			continue
+		}
+		logger.Client.Info("Reloaded geo ip database " + e.path)
+	}
+}
+
+// reload opens the file before taking the lock so that a broken database leaves the current reader in place
+func (e *MaxmindGeoIpStruct) reload() error {
+	info, err := os.Stat(e.path)
+	if err != nil {
+		return err
+	}
+	reader, err := maxminddb.Open(e.path)
+	if err != nil {
+		return err
+	}
+	e.mu.Lock()
+	previous := e.reader
+	e.reader, e.modTime, e.size = reader, info.ModTime(), info.Size()
+	e.mu.Unlock()
+	if previous != nil {
+		return previous.Close()
+	}
+	return nil
+}
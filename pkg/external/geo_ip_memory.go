@@ -0,0 +1,38 @@
+package external
+
+import (
+	"bitbucket.org/noon-micro/curriculum/pkg/domain"
+	"context"
+	"net"
+)
+
+// MemoryGeoIpStruct resolves addresses from the fixture in place of the geo ip database, ipv6 addresses are
+// matched in their canonical form
+type MemoryGeoIpStruct struct {
+	countries map[string]string
+}
+
+func NewMemoryGeoIpExternal(fixture *domain.Fixture) *MemoryGeoIpStruct {
+	countries := make(map[string]string)
+	if fixture != nil {
+		for ip, countryCode := range fixture.GeoIp {
+			countries[canonicalIp(ip)] = countryCode
+		}
+	}
+	return &MemoryGeoIpStruct{countries: countries}
+}
+
+func (e *MemoryGeoIpStruct) GetGeoIp(ctx context.Context, getGeoIpRequest *domain.GetGeoIp) (countryCode *string, err error) {
+	code, ok := e.countries[canonicalIp(stringValue(getGeoIpRequest.Ip))]
+	if !ok {
+		return nil, nil
+	}
+	return &code, nil
+}
+
+func canonicalIp(address string) string {
+	if ip := net.ParseIP(address); ip != nil {
+		return ip.String()
+	}
+	return address
+}
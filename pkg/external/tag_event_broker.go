@@ -0,0 +1,68 @@
+package external
+
+import (
+	"bitbucket.org/noon-micro/curriculum/config"
+	"bitbucket.org/noon-micro/curriculum/pkg/domain"
+	"bitbucket.org/noon-micro/curriculum/pkg/lib/logger"
+	"context"
+	redistrace "gopkg.in/DataDog/dd-trace-go.v1/contrib/go-redis/redis"
+	"strings"
+)
+
+const (
+	MemoryTagEventBrokerName = "memory"
+	LogTagEventBrokerName    = "log"
+	RedisTagEventBrokerName  = "redis"
+)
+
+// NewTagEventBroker fans the events out to every broker configured in TagEventBrokers and to the in process
+// subscribers, redisClient backs the redis stream broker. Events are dropped when there is neither.
+func NewTagEventBroker(redisClient *redistrace.Client, subscribers ...domain.TagEventBroker) domain.TagEventBroker {
+	fanOut := &FanOutTagEventBroker{brokers: subscribers}
+	for _, name := range strings.Split(config.GetConfig().TagEventBrokers, ",") {
+		switch strings.TrimSpace(name) {
+		case MemoryTagEventBrokerName:
+			fanOut.brokers = append(fanOut.brokers, NewMemoryTagEventBroker(defaultMemoryTagEvents))
+		case LogTagEventBrokerName:
+			broker, err := NewLogTagEventBroker(config.GetConfig().TagEventLogPath)
+			if err != nil {
+				logger.Client.Fatal("Unable to open tag event log " + config.GetConfig().TagEventLogPath + " : " + err.Error())
+			}
+			fanOut.brokers = append(fanOut.brokers, broker)
+		case RedisTagEventBrokerName:
+			stream := config.GetConfig().TagEventStream
+			if stream == "" {
+				stream = config.DefaultTagEventStream
+			}
+			fanOut.brokers = append(fanOut.brokers, NewRedisTagEventBroker(redisClient, stream))
+		case "":
+		default:
+			logger.Client.Fatal("Unknown tag event broker " + name)
+		}
+	}
+	return fanOut
+}
+
+// FanOutTagEventBroker publishes every event to all of its brokers, one broker failing does not stop the others
+type FanOutTagEventBroker struct {
+	brokers []domain.TagEventBroker
+}
+
+func (b *FanOutTagEventBroker) Name() string {
+	var names []string
+	for _, v := range b.brokers {
+		names = append(names, v.Name())
+	}
+	return strings.Join(names, ",")
+}
+
+// Publish returns the last error, brokers which already received the event get it again when the publisher retries
+func (b *FanOutTagEventBroker) Publish(ctx context.Context, event *domain.TagEvent) (err error) {
+	for _, v := range b.brokers {
+		if publishErr := v.Publish(ctx, event); publishErr != nil {
+			logger.Client.Error("tagEventBrokerError:broker:"+v.Name()+":id:"+event.ID, publishErr)
+			err = publishErr
+		}
+	}
+	return err
+}
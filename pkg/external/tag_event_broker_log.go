@@ -0,0 +1,47 @@
+package external
+
+import (
+	"bitbucket.org/noon-micro/curriculum/pkg/domain"
+	"bitbucket.org/noon-micro/curriculum/pkg/lib/logger"
+	"context"
+	"encoding/json"
+	"os"
+	"sync"
+)
+
+// LogTagEventBroker appends every event as a json line to a file, or to the service log when no file is
+// configured
+type LogTagEventBroker struct {
+	mu   sync.Mutex
+	file *os.File
+}
+
+func NewLogTagEventBroker(path string) (*LogTagEventBroker, error) {
+	if path == "" {
+		return &LogTagEventBroker{}, nil
+	}
+	file, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		return nil, err
+	}
+	return &LogTagEventBroker{file: file}, nil
+}
+
+func (b *LogTagEventBroker) Name() string {
+	return LogTagEventBrokerName
+}
+
+func (b *LogTagEventBroker) Publish(ctx context.Context, event *domain.TagEvent) error {
+	eventByte, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+	if b.file == nil {
+		logger.Client.Info("tagEvent " + string(eventByte))
+		return nil
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	_, err = b.file.Write(append(eventByte, '\n'))
+	return err
+}
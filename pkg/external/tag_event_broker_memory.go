@@ -0,0 +1,49 @@
+package external
+
+import (
+	"bitbucket.org/noon-micro/curriculum/pkg/domain"
+	"context"
+	"sync"
+)
+
+// defaultMemoryTagEvents is the number of events the memory broker keeps
+const defaultMemoryTagEvents = 1000
+
+// MemoryTagEventBroker keeps the latest events in process, used by the memory environment and to inspect what
+// was published
+type MemoryTagEventBroker struct {
+	mu     sync.RWMutex
+	limit  int
+	events []*domain.TagEvent
+}
+
+func NewMemoryTagEventBroker(limit int) *MemoryTagEventBroker {
+	return &MemoryTagEventBroker{limit: limit}
+}
+
+func (b *MemoryTagEventBroker) Name() string {
+	return MemoryTagEventBrokerName
+}
+
+func (b *MemoryTagEventBroker) Publish(ctx context.Context, event *domain.TagEvent) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.events = append(b.events, event)
+	if len(b.events) > b.limit {
+		b.events = b.events[len(b.events)-b.limit:]
+	}
+	return nil
+}
+
+// Events returns the kept events of the tag in the order they were published, all of them when tagId is empty
+func (b *MemoryTagEventBroker) Events(tagId string) []*domain.TagEvent {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	var events []*domain.TagEvent
+	for _, v := range b.events {
+		if tagId == "" || v.TagId == tagId {
+			events = append(events, v)
+		}
+	}
+	return events
+}
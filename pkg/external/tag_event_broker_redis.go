@@ -0,0 +1,47 @@
+package external
+
+import (
+	"bitbucket.org/noon-micro/curriculum/pkg/domain"
+	"context"
+	"encoding/json"
+	"github.com/go-redis/redis"
+	redistrace "gopkg.in/DataDog/dd-trace-go.v1/contrib/go-redis/redis"
+	"strconv"
+)
+
+// redisTagEventStreamLength is roughly how many events the stream keeps, older ones are trimmed as new ones come
+const redisTagEventStreamLength = 100000
+
+// RedisTagEventBroker adds the events to a redis stream which consumers read through consumer groups. The stream
+// keeps the order of publishing, and so the order of the events of each tag.
+type RedisTagEventBroker struct {
+	client *redistrace.Client
+	stream string
+}
+
+func NewRedisTagEventBroker(client *redistrace.Client, stream string) *RedisTagEventBroker {
+	return &RedisTagEventBroker{client: client, stream: stream}
+}
+
+func (b *RedisTagEventBroker) Name() string {
+	return RedisTagEventBrokerName
+}
+
+// Publish stores the event under the event field, the type, tag and sequence are repeated as fields so that
+// consumers can skip events without decoding them
+func (b *RedisTagEventBroker) Publish(ctx context.Context, event *domain.TagEvent) error {
+	eventByte, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+	return b.client.WithContext(ctx).XAdd(&redis.XAddArgs{
+		Stream:       b.stream,
+		MaxLenApprox: redisTagEventStreamLength,
+		Values: map[string]interface{}{
+			"type":     event.Type,
+			"tag_id":   event.TagId,
+			"sequence": strconv.FormatInt(event.Sequence, 10),
+			"event":    string(eventByte),
+		},
+	}).Err()
+}
@@ -0,0 +1,203 @@
+package external
+
+import (
+	"bitbucket.org/noon-micro/curriculum/config"
+	"bitbucket.org/noon-micro/curriculum/pkg/domain"
+	noonerror "bitbucket.org/noon-micro/curriculum/pkg/lib/error"
+	"bitbucket.org/noon-micro/curriculum/pkg/lib/helper"
+	"bitbucket.org/noon-micro/curriculum/pkg/lib/httplib"
+	"bitbucket.org/noon-micro/curriculum/pkg/lib/logger"
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"strings"
+)
+
+const (
+	HttpTranslationProviderName     = "http"
+	GlossaryTranslationProviderName = "glossary"
+
+	translateURL = "/translate"
+	// translateBatchSize bounds the texts sent in one request to the http provider
+	translateBatchSize = 100
+)
+
+// NewTranslationProvider chains the providers configured in TranslationProviders, a text is only sent to the next
+// provider when the previous ones have no suggestion for it
+func NewTranslationProvider(client *httplib.ContextClient) domain.TranslationProvider {
+	chain := &ChainTranslationProvider{}
+	for _, name := range strings.Split(config.GetConfig().TranslationProviders, ",") {
+		switch strings.TrimSpace(name) {
+		case HttpTranslationProviderName:
+			chain.providers = append(chain.providers, NewHttpTranslationProvider(client))
+		case GlossaryTranslationProviderName:
+			glossary, err := NewGlossaryTranslationProvider(config.GetConfig().GlossaryPath)
+			if err != nil {
+				logger.Client.Fatal("Unable to load glossary " + config.GetConfig().GlossaryPath + " : " + err.Error())
+			}
+			chain.providers = append(chain.providers, glossary)
+		case "":
+		default:
+			logger.Client.Fatal("Unknown translation provider " + name)
+		}
+	}
+	return chain
+}
+
+type ChainTranslationProvider struct {
+	providers []domain.TranslationProvider
+}
+
+func (p *ChainTranslationProvider) Name() string {
+	var names []string
+	for _, v := range p.providers {
+		names = append(names, v.Name())
+	}
+	return strings.Join(names, ",")
+}
+
+func (p *ChainTranslationProvider) Suggest(ctx context.Context, translationRequest *domain.TranslationRequest) (suggestions []*domain.TranslationSuggestion, err error) {
+	if len(p.providers) == 0 {
+		return nil, noonerror.New(noonerror.ErrInternalServer, "translationProviderMissing")
+	}
+	suggestions = make([]*domain.TranslationSuggestion, len(translationRequest.Texts))
+	pending := make([]int, len(translationRequest.Texts))
+	for i := range pending {
+		pending[i] = i
+	}
+	for _, provider := range p.providers {
+		if len(pending) == 0 {
+			break
+		}
+		texts := make([]string, len(pending))
+		for i, v := range pending {
+			texts[i] = translationRequest.Texts[v]
+		}
+		providerSuggestions, err := provider.Suggest(ctx, &domain.TranslationRequest{SourceLocale: translationRequest.SourceLocale,
+			TargetLocale: translationRequest.TargetLocale, Texts: texts})
+		if err != nil {
+			return nil, err
+		}
+		var next []int
+		for i, v := range pending {
+			if providerSuggestions[i] == nil {
+				next = append(next, v)
+				continue
+			}
+			suggestions[v] = providerSuggestions[i]
+		}
+		pending = next
+	}
+	return suggestions, nil
+}
+
+// HttpTranslationProviderStruct posts batches of texts to a machine translation service answering
+// {"translations": [...]} in the order of the texts, an empty translation means no suggestion
+type HttpTranslationProviderStruct struct {
+	client *httplib.ContextClient
+}
+
+func NewHttpTranslationProvider(client *httplib.ContextClient) *HttpTranslationProviderStruct {
+	return &HttpTranslationProviderStruct{client: client}
+}
+
+func (p *HttpTranslationProviderStruct) Name() string {
+	return HttpTranslationProviderName
+}
+
+func (p *HttpTranslationProviderStruct) Suggest(ctx context.Context, translationRequest *domain.TranslationRequest) (suggestions []*domain.TranslationSuggestion, err error) {
+	for start := 0; start < len(translationRequest.Texts); start += translateBatchSize {
+		end := start + translateBatchSize
+		if end > len(translationRequest.Texts) {
+			end = len(translationRequest.Texts)
+		}
+		translations, err := p.translate(ctx, translationRequest.SourceLocale, translationRequest.TargetLocale, translationRequest.Texts[start:end])
+		if err != nil {
+			return nil, err
+		}
+		for _, v := range translations {
+			if strings.TrimSpace(v) == "" {
+				suggestions = append(suggestions, nil)
+				continue
+			}
+			text := strings.TrimSpace(v)
+			suggestions = append(suggestions, &domain.TranslationSuggestion{Text: &text, Provider: HttpTranslationProviderName})
+		}
+	}
+	return suggestions, nil
+}
+
+func (p *HttpTranslationProviderStruct) translate(ctx context.Context, sourceLocale string, targetLocale string, texts []string) (translations []string, err error) {
+	payload := map[string]interface{}{"source_locale": sourceLocale, "target_locale": targetLocale, "texts": texts}
+	headers := getHeaders()
+	if config.GetConfig().TranslationProviderKey != "" {
+		headers["Authorization"] = "Bearer " + config.GetConfig().TranslationProviderKey
+	}
+	ctx, cancel := helper.WithTimeout(ctx, config.GetConfig().TranslationProviderTimeout, config.DefaultTranslationProviderTimeout)
+	defer cancel()
+	resp, err := p.client.ServePost(ctx, config.GetConfig().TranslationProviderHost+translateURL, headers, payload)
+	if err != nil {
+		logger.Client.Error("translationProviderError", err, logger.GetErrorStack())
+		return nil, noonerror.New(noonerror.ErrInternalServer, "translationProviderError")
+	}
+	respBody := struct {
+		Translations []string `json:"translations"`
+	}{}
+	if err = json.Unmarshal(resp, &respBody); err != nil || len(respBody.Translations) != len(texts) {
+		logger.Client.Error("translationProviderResponseError", string(resp))
+		return nil, noonerror.New(noonerror.ErrInternalServer, "translationProviderResponseError")
+	}
+	return respBody.Translations, nil
+}
+
+// GlossaryTranslationProviderStruct looks texts up in a curated json glossary keyed by locale, e.g.
+// {"ar": {"Mathematics": "الرياضيات"}}. Lookups ignore case and fall back from a regional locale to its language.
+type GlossaryTranslationProviderStruct struct {
+	terms map[string]map[string]string
+}
+
+func NewGlossaryTranslationProvider(path string) (*GlossaryTranslationProviderStruct, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	glossary := make(map[string]map[string]string)
+	if err = json.Unmarshal(data, &glossary); err != nil {
+		return nil, err
+	}
+	terms := make(map[string]map[string]string, len(glossary))
+	for locale, entries := range glossary {
+		localeTerms := make(map[string]string, len(entries))
+		for source, target := range entries {
+			localeTerms[glossaryKey(source)] = target
+		}
+		terms[strings.ToLower(locale)] = localeTerms
+	}
+	return &GlossaryTranslationProviderStruct{terms: terms}, nil
+}
+
+func (p *GlossaryTranslationProviderStruct) Name() string {
+	return GlossaryTranslationProviderName
+}
+
+func (p *GlossaryTranslationProviderStruct) Suggest(ctx context.Context, translationRequest *domain.TranslationRequest) (suggestions []*domain.TranslationSuggestion, err error) {
+	locale := strings.ToLower(translationRequest.TargetLocale)
+	localeTerms, ok := p.terms[locale]
+	if !ok {
+		if i := strings.IndexAny(locale, "-_"); i > 0 {
+			localeTerms = p.terms[locale[:i]]
+		}
+	}
+	suggestions = make([]*domain.TranslationSuggestion, len(translationRequest.Texts))
+	for i, v := range translationRequest.Texts {
+		if target, ok := localeTerms[glossaryKey(v)]; ok {
+			text := target
+			suggestions[i] = &domain.TranslationSuggestion{Text: &text, Provider: GlossaryTranslationProviderName}
+		}
+	}
+	return suggestions, nil
+}
+
+func glossaryKey(text string) string {
+	return strings.ToLower(strings.Join(strings.Fields(text), " "))
+}
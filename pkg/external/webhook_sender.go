@@ -0,0 +1,23 @@
+package external
+
+import (
+	"bitbucket.org/noon-micro/curriculum/config"
+	"bitbucket.org/noon-micro/curriculum/pkg/lib/helper"
+	"bitbucket.org/noon-micro/curriculum/pkg/lib/httplib"
+	"context"
+)
+
+// HttpWebhookSender posts deliveries to the subscribers, each attempt is bounded by WebhookTimeout
+type HttpWebhookSender struct {
+	client *httplib.ContextClient
+}
+
+func NewHttpWebhookSender(client *httplib.ContextClient) *HttpWebhookSender {
+	return &HttpWebhookSender{client: client}
+}
+
+func (s *HttpWebhookSender) Send(ctx context.Context, url string, headers map[string]string, payload []byte) (int, error) {
+	ctx, cancel := helper.WithTimeout(ctx, config.GetConfig().WebhookTimeout, config.DefaultWebhookTimeout)
+	defer cancel()
+	return s.client.PostBytes(ctx, url, headers, payload)
+}
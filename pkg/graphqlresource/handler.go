@@ -0,0 +1,115 @@
+package graphqlresource
+
+import (
+	"bitbucket.org/noon-micro/curriculum/pkg/domain"
+	"bitbucket.org/noon-micro/curriculum/pkg/entity"
+	noonerror "bitbucket.org/noon-micro/curriculum/pkg/lib/error"
+	"bitbucket.org/noon-micro/curriculum/pkg/lib/middleware"
+	"bitbucket.org/noon-micro/curriculum/pkg/service/constant"
+	"context"
+	"encoding/json"
+	"github.com/gorilla/mux"
+	"github.com/graph-gophers/graphql-go"
+	"net/http"
+	"sync/atomic"
+)
+
+const (
+	// maxQueryDepth fits countries, nodes and tag followed by maxTagHops children and nodes and the fields of the last
+	// tag
+	maxQueryDepth = 3 + 2*maxTagHops + 1
+	// maxParallelism bounds the resolvers running at once for a single query
+	maxParallelism = 32
+	// maxQueryTags bounds the tags a single query loads, pages are counted at their full size before they are read
+	maxQueryTags = 2000
+)
+
+// studentTagTypes are the tag types the student routes serve
+var studentTagTypes = map[string]struct{}{
+	domain.TagTypeEnum.Country: {},
+	domain.TagTypeEnum.Board:   {},
+	domain.TagTypeEnum.Grade:   {},
+	domain.TagTypeEnum.Degree:  {},
+	domain.TagTypeEnum.Major:   {},
+}
+
+// Handler executes graphql queries for one audience
+type Handler struct {
+	schema *graphql.Schema
+}
+
+type queryRequest struct {
+	Query         string                 `json:"query"`
+	OperationName string                 `json:"operationName"`
+	Variables     map[string]interface{} `json:"variables"`
+}
+
+type scopeKey struct{}
+
+// requestScope is the country and the negotiated locale the names are served in, budget is the number of tags the
+// query can still load
+type requestScope struct {
+	countryId *string
+	locale    *string
+	budget    int32
+}
+
+// spend takes n tags from the budget of the query, it fails once the budget is spent
+func (s *requestScope) spend(n int) error {
+	if atomic.AddInt32(&s.budget, -int32(n)) < 0 {
+		return graphError(noonerror.New(noonerror.ErrBadRequest, "queryTooComplex"))
+	}
+	return nil
+}
+
+// NewGraphqlResource serves the tag graph next to the rest routes of every audience, behind the same roles
+func NewGraphqlResource(route *mux.Router, tgs domain.TagGraphService) {
+	student := NewHandler(tgs, studentTagTypes)
+	all := NewHandler(tgs, nil)
+	route.HandleFunc("/student/graphql", middleware.UnAuthWrapMiddleware(middleware.LocaleMiddleware(student.serve, constant.StudentDefaultLocale))).Methods("POST")
+	route.HandleFunc("/teacher/graphql", middleware.AuthWrapMiddleware(middleware.LocaleMiddleware(all.serve, constant.DefaultLocale), "teacher")).Methods("POST")
+	route.HandleFunc("/admin/graphql", middleware.AuthWrapMiddleware(all.serve, "admin.supply")).Methods("POST")
+}
+
+// NewHandler parses the schema for an audience limited to the given tag types, nil allows every type
+func NewHandler(tgs domain.TagGraphService, types map[string]struct{}) *Handler {
+	schema := graphql.MustParseSchema(schema, &queryResolver{tgs: tgs, types: types},
+		graphql.MaxDepth(maxQueryDepth),
+		graphql.MaxParallelism(maxParallelism),
+	)
+	return &Handler{schema: schema}
+}
+
+func (h *Handler) serve(rw http.ResponseWriter, req *http.Request) {
+	var query queryRequest
+	if err := json.NewDecoder(req.Body).Decode(&query); err != nil {
+		entity.HandleError(rw, "badRequest", noonerror.ErrInvalidRequest, req.Header.Get("locale"), true)
+		return
+	}
+	countryId := req.URL.Query().Get("country_id")
+	if countryId == "" {
+		countryId = req.Header.Get("country")
+	}
+	// the admin route is not negotiated and serves names in the locale asked for
+	locale := req.Header.Get("locale")
+	if locale == "" {
+		locale = constant.DefaultLocale
+	}
+	scope := &requestScope{locale: &locale, budget: maxQueryTags}
+	if countryId != "" {
+		scope.countryId = &countryId
+	}
+	ctx := context.WithValue(req.Context(), scopeKey{}, scope)
+	response := h.schema.Exec(ctx, query.Query, query.OperationName, query.Variables)
+	rw.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(rw).Encode(response); err != nil {
+		entity.HandleError(rw, "internalServerError", noonerror.ErrInternalServer, req.Header.Get("locale"), true)
+	}
+}
+
+func scopeFromContext(ctx context.Context) *requestScope {
+	if scope, ok := ctx.Value(scopeKey{}).(*requestScope); ok {
+		return scope
+	}
+	return &requestScope{budget: maxQueryTags}
+}
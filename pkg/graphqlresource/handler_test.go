@@ -0,0 +1,117 @@
+package graphqlresource
+
+import (
+	"bitbucket.org/noon-micro/curriculum/config"
+	"bitbucket.org/noon-micro/curriculum/pkg/external"
+	"bitbucket.org/noon-micro/curriculum/pkg/lib/logger"
+	memory "bitbucket.org/noon-micro/curriculum/pkg/repository/memory"
+	repository "bitbucket.org/noon-micro/curriculum/pkg/repository/redis"
+	"bitbucket.org/noon-micro/curriculum/pkg/service"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"github.com/alicebob/miniredis/v2"
+	"github.com/go-redis/redis"
+	redistrace "gopkg.in/DataDog/dd-trace-go.v1/contrib/go-redis/redis"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+type queryResponse struct {
+	Data   json.RawMessage `json:"data"`
+	Errors []struct {
+		Message string `json:"message"`
+	} `json:"errors"`
+}
+
+// newTestHandler serves the graph of the memory environment to every tag type
+func newTestHandler(t *testing.T) *Handler {
+	if logger.Client == nil {
+		logger.New()
+	}
+	if config.GetConfig() == nil {
+		config.LoadConfiguration("memory")
+	}
+	fixture, err := memory.LoadFixture("../../fixtures/memory.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	server, err := miniredis.Run()
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(server.Close)
+	repository.RedisClient = redistrace.NewClient(&redis.Options{Addr: server.Addr()})
+	repo := memory.InitializeMemory(fixture)
+	tagsService := service.NewTagsService(repo.Tags, repo.ParentTagMapping, repo.TagLocaleMapping, repo.TagAttributeLocale, repo.TagCountryRule, repo.RegionCountry, repo.LegacyTagMapping, repo.GradeProduct)
+	elastic := service.NewSearchElastic(external.NewMemoryElasticExternal(fixture), true)
+	return NewHandler(service.NewTagGraphService(tagsService, elastic), nil)
+}
+
+func (h *Handler) query(t *testing.T, query string) *queryResponse {
+	body, err := json.Marshal(queryRequest{Query: query})
+	if err != nil {
+		t.Fatal(err)
+	}
+	rw := httptest.NewRecorder()
+	h.serve(rw, httptest.NewRequest(http.MethodPost, "/admin/graphql?country_id=9", bytes.NewReader(body)))
+	response := new(queryResponse)
+	if err = json.Unmarshal(rw.Body.Bytes(), response); err != nil {
+		t.Fatalf("%s: %s", err, rw.Body.String())
+	}
+	return response
+}
+
+func TestQueryLimits(t *testing.T) {
+	handler := newTestHandler(t)
+	var ids []string
+	for i := 1; i <= maxTagIds; i++ {
+		ids = append(ids, fmt.Sprintf("%q", fmt.Sprint(i)))
+	}
+	var aliases []string
+	for i := 0; i <= maxQueryTags/maxTagIds; i++ {
+		aliases = append(aliases, fmt.Sprintf("t%d: tags(ids: [%s]) { id }", i, strings.Join(ids, ",")))
+	}
+	cases := []struct {
+		name  string
+		query string
+		data  string
+		want  string
+	}{
+		{"country down to a topic", `{ tag(id: "9") { children { nodes { children { nodes { children { nodes { children { nodes {
+			children { nodes { children { nodes { id } } } } } } } } } } } } } }`, `"id":"51"`, ""},
+		{"topic up to the country", `{ tag(id: "51", parent: "9.2.251.20.30.50") { parents { parents { parents { id } } } } }`, `"id":"20"`, ""},
+		{"children of parents", `{ tag(id: "50", parent: "9.2.251.20.30") { parents { children { nodes { id } } } } }`, "", "queryCycle"},
+		{"parents of children", `{ tag(id: "9") { children { nodes { parents { id } } } } }`, "", "queryCycle"},
+		{"deeper than any curriculum", `{ countries { nodes { tag { ` + strings.Repeat("children { nodes { ", maxTagHops+1) + "id" +
+			strings.Repeat(" } }", maxTagHops+1) + ` } } } }`, "", "exceeds max depth"},
+		{"more tags than a query loads", "{ " + strings.Join(aliases, " ") + " }", "", "queryTooComplex"},
+	}
+	for _, c := range cases {
+		response := handler.query(t, c.query)
+		var messages []string
+		for _, v := range response.Errors {
+			messages = append(messages, v.Message)
+		}
+		got := strings.Join(messages, "; ")
+		if c.want == "" && got != "" || !strings.Contains(got, c.want) {
+			t.Errorf("%s: got errors %q, want %q", c.name, got, c.want)
+		}
+		if !strings.Contains(string(response.Data), c.data) {
+			t.Errorf("%s: got %s, want %s in it", c.name, response.Data, c.data)
+		}
+	}
+}
+
+func TestWalkStopsAtMaxTagHops(t *testing.T) {
+	tag := &tagResolver{edge: childrenEdge, hops: maxTagHops - 1}
+	if err := tag.walk(childrenEdge); err != nil {
+		t.Errorf("walk below the limit returned %v", err)
+	}
+	tag.hops = maxTagHops
+	if err := tag.walk(childrenEdge); err == nil || err.Error() != "queryTooDeep" {
+		t.Errorf("walk at the limit returned %v", err)
+	}
+}
@@ -0,0 +1,422 @@
+package graphqlresource
+
+import (
+	"bitbucket.org/noon-micro/curriculum/pkg/domain"
+	noonerror "bitbucket.org/noon-micro/curriculum/pkg/lib/error"
+	"context"
+	"github.com/graph-gophers/graphql-go"
+	"strconv"
+)
+
+const (
+	// maxPageSize is the largest page of children or countries, the limit of a single search
+	maxPageSize = 100
+	// maxTagIds bounds the ids of a single tags query, every id is loaded with its mappings and locales
+	maxTagIds = 100
+	// maxTagHops bounds the parents or children walked from a tag, the deepest curricula are six hops from the country
+	// to the topic
+	maxTagHops = 8
+)
+
+// the edges a tag is reached through, a query walks one way from the tag it starts at so that it cannot loop between
+// tags and their parents
+const (
+	parentsEdge  = "parents"
+	childrenEdge = "children"
+)
+
+type queryResolver struct {
+	tgs domain.TagGraphService
+	// types are the tag types the audience can reach, nil allows every type
+	types map[string]struct{}
+}
+
+type pageArgs struct {
+	First int32
+	After *string
+}
+
+func (r *queryResolver) Countries(ctx context.Context, args pageArgs) (*countryConnectionResolver, error) {
+	if !r.allowed(&domain.TagTypeEnum.Country) {
+		return &countryConnectionResolver{pageInfo: &pageInfoResolver{}}, nil
+	}
+	start, limit, err := page(args)
+	if err != nil {
+		return nil, err
+	}
+	scope := scopeFromContext(ctx)
+	if err = scope.spend(limit); err != nil {
+		return nil, err
+	}
+	tags, next, err := r.tgs.GetCountryNodes(ctx, scope.countryId, scope.locale, start, limit)
+	if err != nil {
+		return nil, graphError(err)
+	}
+	connection := &countryConnectionResolver{pageInfo: newPageInfo(next)}
+	for _, v := range tags {
+		connection.nodes = append(connection.nodes, &countryResolver{root: r, tag: v})
+	}
+	return connection, nil
+}
+
+func (r *queryResolver) Tag(ctx context.Context, args struct {
+	ID     graphql.ID
+	Parent *string
+}) (*tagResolver, error) {
+	id := string(args.ID)
+	scope := scopeFromContext(ctx)
+	if err := scope.spend(1); err != nil {
+		return nil, err
+	}
+	nodes, err := r.tgs.GetTagNodes(ctx, []*string{&id}, args.Parent, scope.countryId, scope.locale)
+	if err != nil {
+		return nil, graphError(err)
+	}
+	tags := r.tagResolvers(nodes)
+	if len(tags) == 0 {
+		return nil, nil
+	}
+	return tags[0], nil
+}
+
+func (r *queryResolver) Tags(ctx context.Context, args struct{ IDs []graphql.ID }) ([]*tagResolver, error) {
+	if len(args.IDs) > maxTagIds {
+		return nil, graphError(noonerror.New(noonerror.ErrBadRequest, "tooManyIds"))
+	}
+	ids := make([]*string, len(args.IDs))
+	for i := range args.IDs {
+		id := string(args.IDs[i])
+		ids[i] = &id
+	}
+	scope := scopeFromContext(ctx)
+	if err := scope.spend(len(ids)); err != nil {
+		return nil, err
+	}
+	nodes, err := r.tgs.GetTagNodes(ctx, ids, nil, scope.countryId, scope.locale)
+	if err != nil {
+		return nil, graphError(err)
+	}
+	return r.tagResolvers(nodes), nil
+}
+
+func (r *queryResolver) allowed(tagType *string) bool {
+	if r.types == nil {
+		return true
+	}
+	if tagType == nil {
+		return false
+	}
+	_, ok := r.types[*tagType]
+	return ok
+}
+
+func (r *queryResolver) tagResolvers(nodes []*domain.TagNode) []*tagResolver {
+	tags := []*tagResolver{}
+	for _, v := range nodes {
+		if r.allowed(v.Tag.Type) {
+			tags = append(tags, &tagResolver{root: r, node: v})
+		}
+	}
+	return tags
+}
+
+type tagResolver struct {
+	root *queryResolver
+	node *domain.TagNode
+	// edge is the way the tag was reached from the tag the query started at and hops how far it is from it
+	edge string
+	hops int
+}
+
+// walk checks the tags reached through edge can be walked on from this one
+func (r *tagResolver) walk(edge string) error {
+	if r.edge != "" && r.edge != edge {
+		return graphError(noonerror.New(noonerror.ErrBadRequest, "queryCycle"))
+	}
+	if r.hops >= maxTagHops {
+		return graphError(noonerror.New(noonerror.ErrBadRequest, "queryTooDeep"))
+	}
+	return nil
+}
+
+// reached marks the tags as reached through edge from this one
+func (r *tagResolver) reached(tags []*tagResolver, edge string) []*tagResolver {
+	for _, v := range tags {
+		v.edge, v.hops = edge, r.hops+1
+	}
+	return tags
+}
+
+func (r *tagResolver) ID() graphql.ID {
+	return graphql.ID(*r.node.Tag.ID)
+}
+
+func (r *tagResolver) Type() string {
+	return stringValue(r.node.Tag.Type)
+}
+
+func (r *tagResolver) CurriculumType() string {
+	return r.node.Tag.CurriculumType
+}
+
+func (r *tagResolver) TagGroup() string {
+	return r.node.Tag.TagGroup
+}
+
+func (r *tagResolver) Name() string {
+	return stringValue(r.node.Tag.Name)
+}
+
+func (r *tagResolver) LocaleName() *string {
+	return r.node.Tag.LocaleName
+}
+
+func (r *tagResolver) ServedLocale() *string {
+	return r.node.Tag.ServedLocale
+}
+
+func (r *tagResolver) Path() *string {
+	return r.node.Path
+}
+
+func (r *tagResolver) Hidden() bool {
+	return r.node.Hidden
+}
+
+func (r *tagResolver) Locales(ctx context.Context) ([]*localeResolver, error) {
+	locales, err := r.root.tgs.GetTagLocales(ctx, r.node.Tag.ID)
+	if err != nil {
+		return nil, graphError(err)
+	}
+	resolvers := []*localeResolver{}
+	for _, v := range locales {
+		resolvers = append(resolvers, &localeResolver{v})
+	}
+	return resolvers, nil
+}
+
+func (r *tagResolver) Identifiers(ctx context.Context) ([]*identifierResolver, error) {
+	scope := scopeFromContext(ctx)
+	tags, err := r.root.tgs.GetTagIdentifiers(ctx, r.node.Tag.ID, scope.countryId, scope.locale)
+	if err != nil {
+		return nil, graphError(err)
+	}
+	resolvers := []*identifierResolver{}
+	for _, v := range tags {
+		if r.root.allowed(v.Type) {
+			resolvers = append(resolvers, &identifierResolver{v})
+		}
+	}
+	return resolvers, nil
+}
+
+func (r *tagResolver) Parents(ctx context.Context) ([]*tagResolver, error) {
+	if err := r.walk(parentsEdge); err != nil {
+		return nil, err
+	}
+	scope := scopeFromContext(ctx)
+	nodes, err := r.root.tgs.GetTagParents(ctx, r.node.Tag.ID, scope.countryId, scope.locale)
+	if err != nil {
+		return nil, graphError(err)
+	}
+	if err = scope.spend(len(nodes)); err != nil {
+		return nil, err
+	}
+	return r.reached(r.root.tagResolvers(nodes), parentsEdge), nil
+}
+
+func (r *tagResolver) Children(ctx context.Context, args struct {
+	Type  *string
+	First int32
+	After *string
+}) (*tagConnectionResolver, error) {
+	if r.node.Path == nil {
+		return nil, graphError(noonerror.New(noonerror.ErrBadRequest, "parentRequired"))
+	}
+	if args.Type != nil && !r.root.allowed(args.Type) {
+		return nil, graphError(noonerror.New(noonerror.ErrBadRequest, "typeInvalid"))
+	}
+	if err := r.walk(childrenEdge); err != nil {
+		return nil, err
+	}
+	start, limit, err := page(pageArgs{First: args.First, After: args.After})
+	if err != nil {
+		return nil, err
+	}
+	scope := scopeFromContext(ctx)
+	if err = scope.spend(limit); err != nil {
+		return nil, err
+	}
+	nodes, next, err := r.root.tgs.GetTagChildren(ctx, &domain.GetTagChildren{
+		Parent:    r.node.Path,
+		Type:      args.Type,
+		CountryId: scope.countryId,
+		Locale:    scope.locale,
+		Start:     start,
+		Limit:     limit,
+	})
+	if err != nil {
+		return nil, graphError(err)
+	}
+	return &tagConnectionResolver{nodes: r.reached(r.root.tagResolvers(nodes), childrenEdge), pageInfo: newPageInfo(next)}, nil
+}
+
+type tagConnectionResolver struct {
+	nodes    []*tagResolver
+	pageInfo *pageInfoResolver
+}
+
+func (r *tagConnectionResolver) Nodes() []*tagResolver {
+	return r.nodes
+}
+
+func (r *tagConnectionResolver) PageInfo() *pageInfoResolver {
+	return r.pageInfo
+}
+
+type countryResolver struct {
+	root *queryResolver
+	tag  *domain.Tags
+}
+
+func (r *countryResolver) ID() graphql.ID {
+	return graphql.ID(*r.tag.ID)
+}
+
+func (r *countryResolver) Name() string {
+	return stringValue(r.tag.Name)
+}
+
+func (r *countryResolver) LocaleName() *string {
+	return r.tag.LocaleName
+}
+
+func (r *countryResolver) ServedLocale() *string {
+	return r.tag.ServedLocale
+}
+
+func (r *countryResolver) IsoCode() *string {
+	isoCode, ok := r.tag.Attributes["iso_code"].(string)
+	if !ok {
+		return nil
+	}
+	return &isoCode
+}
+
+func (r *countryResolver) Tag() *tagResolver {
+	return &tagResolver{root: r.root, node: &domain.TagNode{Tag: r.tag, Path: r.tag.ID}}
+}
+
+type countryConnectionResolver struct {
+	nodes    []*countryResolver
+	pageInfo *pageInfoResolver
+}
+
+func (r *countryConnectionResolver) Nodes() []*countryResolver {
+	return r.nodes
+}
+
+func (r *countryConnectionResolver) PageInfo() *pageInfoResolver {
+	return r.pageInfo
+}
+
+type pageInfoResolver struct {
+	endCursor *string
+}
+
+func (r *pageInfoResolver) HasNextPage() bool {
+	return r.endCursor != nil
+}
+
+func (r *pageInfoResolver) EndCursor() *string {
+	return r.endCursor
+}
+
+// newPageInfo turns the next offset of the search into a cursor, -1 marks the last page
+func newPageInfo(next *int) *pageInfoResolver {
+	if next == nil || *next < 0 {
+		return &pageInfoResolver{}
+	}
+	cursor := strconv.Itoa(*next)
+	return &pageInfoResolver{endCursor: &cursor}
+}
+
+type localeResolver struct {
+	locale *domain.LocaleResponse
+}
+
+func (r *localeResolver) Locale() string {
+	return stringValue(r.locale.Locale)
+}
+
+func (r *localeResolver) Name() *string {
+	return r.locale.Name
+}
+
+func (r *localeResolver) CountryId() *string {
+	return r.locale.CountryId
+}
+
+type identifierResolver struct {
+	tag *domain.Tags
+}
+
+func (r *identifierResolver) ID() graphql.ID {
+	return graphql.ID(*r.tag.ID)
+}
+
+func (r *identifierResolver) Type() string {
+	return stringValue(r.tag.Type)
+}
+
+func (r *identifierResolver) Name() string {
+	return stringValue(r.tag.Name)
+}
+
+func (r *identifierResolver) LocaleName() *string {
+	return r.tag.LocaleName
+}
+
+// page reads the offset and size of a page, the cursor is the offset of the first item
+func page(args pageArgs) (start int, limit int, err error) {
+	limit = int(args.First)
+	if limit <= 0 || limit > maxPageSize {
+		return 0, 0, graphError(noonerror.New(noonerror.ErrBadRequest, "limitInvalid"))
+	}
+	if args.After != nil {
+		start, err = strconv.Atoi(*args.After)
+		if err != nil || start < 0 {
+			return 0, 0, graphError(noonerror.New(noonerror.ErrBadRequest, "cursorInvalid"))
+		}
+	}
+	return start, limit, nil
+}
+
+// queryError keeps the error key of the service errors as the message, like statusError does for grpc, with the
+// error class in the extensions
+type queryError struct {
+	message string
+	code    string
+}
+
+func (e *queryError) Error() string {
+	return e.message
+}
+
+func (e *queryError) Extensions() map[string]interface{} {
+	return map[string]interface{}{"code": e.code}
+}
+
+func graphError(err error) error {
+	if noonError, ok := err.(*noonerror.NoonError); ok {
+		return &queryError{message: noonError.Message, code: noonError.Err.Error()}
+	}
+	return &queryError{message: "somethingWentWrong", code: noonerror.ErrInternalServer.Error()}
+}
+
+func stringValue(value *string) string {
+	if value == nil {
+		return ""
+	}
+	return *value
+}
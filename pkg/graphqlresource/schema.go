@@ -0,0 +1,70 @@
+package graphqlresource
+
+// schema is served by every audience, the audiences only differ by the tag types they can reach. Paths are the
+// parent_tag_mapping hierarchies such as 9.2.251, cursors are the offsets returned by the previous page.
+const schema = `
+schema {
+	query: Query
+}
+
+type Query {
+	countries(first: Int = 50, after: String): CountryConnection!
+	# parent is the path the tag is reached through, it can be left out for root tags and tags with a single parent
+	tag(id: ID!, parent: String): Tag
+	# at most 100 ids
+	tags(ids: [ID!]!): [Tag!]!
+}
+
+type Country {
+	id: ID!
+	name: String!
+	localeName: String
+	servedLocale: String
+	isoCode: String
+	tag: Tag!
+}
+
+type CountryConnection {
+	nodes: [Country!]!
+	pageInfo: PageInfo!
+}
+
+type Tag {
+	id: ID!
+	type: String!
+	curriculumType: String!
+	tagGroup: String!
+	name: String!
+	localeName: String
+	servedLocale: String
+	path: String
+	hidden: Boolean!
+	locales: [Locale!]!
+	identifiers: [Identifier!]!
+	parents: [Tag!]!
+	children(type: String, first: Int = 50, after: String): TagConnection!
+}
+
+type TagConnection {
+	nodes: [Tag!]!
+	pageInfo: PageInfo!
+}
+
+type PageInfo {
+	hasNextPage: Boolean!
+	endCursor: String
+}
+
+type Locale {
+	locale: String!
+	name: String
+	countryId: String
+}
+
+type Identifier {
+	id: ID!
+	type: String!
+	name: String!
+	localeName: String
+}
+`
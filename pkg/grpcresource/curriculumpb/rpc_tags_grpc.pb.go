@@ -0,0 +1,666 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+
+package curriculumpb
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.32.0 or later.
+const _ = grpc.SupportPackageIsVersion7
+
+// RpcTagsServiceClient is the client API for RpcTagsService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type RpcTagsServiceClient interface {
+	// GetTags returns the tags of at most 100 ids, like /rpc/getTags
+	GetTags(ctx context.Context, in *GetTagsRequest, opts ...grpc.CallOption) (*GetTagsResponse, error)
+	// StreamTags returns the tags of any number of ids, they are loaded and sent in batches of 100
+	StreamTags(ctx context.Context, in *StreamTagsRequest, opts ...grpc.CallOption) (RpcTagsService_StreamTagsClient, error)
+	GetTagsByHierarchy(ctx context.Context, in *GetTagsByHierarchyRequest, opts ...grpc.CallOption) (*GetTagsByHierarchyResponse, error)
+	GetTagsForProducts(ctx context.Context, in *GetTagsForProductsRequest, opts ...grpc.CallOption) (*GetTagsForProductsResponse, error)
+	ValidateHierarchy(ctx context.Context, in *ValidateHierarchyRequest, opts ...grpc.CallOption) (*ValidateHierarchyResponse, error)
+	CreateTags(ctx context.Context, in *CreateTagsRequest, opts ...grpc.CallOption) (*CreateTagsResponse, error)
+	GetDefaultTags(ctx context.Context, in *GetDefaultTagsRequest, opts ...grpc.CallOption) (*GetDefaultTagsResponse, error)
+	GetSuggestedTags(ctx context.Context, in *GetSuggestedTagsRequest, opts ...grpc.CallOption) (*GetSuggestedTagsResponse, error)
+	// StreamSuggestedTags sends the suggested curriculum one tag at a time
+	StreamSuggestedTags(ctx context.Context, in *StreamSuggestedTagsRequest, opts ...grpc.CallOption) (RpcTagsService_StreamSuggestedTagsClient, error)
+	GetLegacyDataFromTagId(ctx context.Context, in *GetLegacyDataFromTagIdRequest, opts ...grpc.CallOption) (*GetLegacyDataFromTagIdResponse, error)
+	GetLegacyDataFromTagIds(ctx context.Context, in *GetLegacyDataFromTagIdsRequest, opts ...grpc.CallOption) (*GetLegacyDataFromTagIdsResponse, error)
+	GetTagDataFromLegacyId(ctx context.Context, in *GetTagDataFromLegacyIdRequest, opts ...grpc.CallOption) (*GetTagDataFromLegacyIdResponse, error)
+	GetGradeTags(ctx context.Context, in *GetGradeTagsRequest, opts ...grpc.CallOption) (*GetGradeTagsResponse, error)
+	GetCurrentTerm(ctx context.Context, in *GetCurrentTermRequest, opts ...grpc.CallOption) (*GetCurrentTermResponse, error)
+	GetExpectedGrade(ctx context.Context, in *GetExpectedGradeRequest, opts ...grpc.CallOption) (*GetExpectedGradeResponse, error)
+}
+
+type rpcTagsServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewRpcTagsServiceClient(cc grpc.ClientConnInterface) RpcTagsServiceClient {
+	return &rpcTagsServiceClient{cc}
+}
+
+func (c *rpcTagsServiceClient) GetTags(ctx context.Context, in *GetTagsRequest, opts ...grpc.CallOption) (*GetTagsResponse, error) {
+	out := new(GetTagsResponse)
+	err := c.cc.Invoke(ctx, "/curriculum.v1.RpcTagsService/GetTags", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *rpcTagsServiceClient) StreamTags(ctx context.Context, in *StreamTagsRequest, opts ...grpc.CallOption) (RpcTagsService_StreamTagsClient, error) {
+	stream, err := c.cc.NewStream(ctx, &RpcTagsService_ServiceDesc.Streams[0], "/curriculum.v1.RpcTagsService/StreamTags", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &rpcTagsServiceStreamTagsClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type RpcTagsService_StreamTagsClient interface {
+	Recv() (*StreamTagsResponse, error)
+	grpc.ClientStream
+}
+
+type rpcTagsServiceStreamTagsClient struct {
+	grpc.ClientStream
+}
+
+func (x *rpcTagsServiceStreamTagsClient) Recv() (*StreamTagsResponse, error) {
+	m := new(StreamTagsResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *rpcTagsServiceClient) GetTagsByHierarchy(ctx context.Context, in *GetTagsByHierarchyRequest, opts ...grpc.CallOption) (*GetTagsByHierarchyResponse, error) {
+	out := new(GetTagsByHierarchyResponse)
+	err := c.cc.Invoke(ctx, "/curriculum.v1.RpcTagsService/GetTagsByHierarchy", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *rpcTagsServiceClient) GetTagsForProducts(ctx context.Context, in *GetTagsForProductsRequest, opts ...grpc.CallOption) (*GetTagsForProductsResponse, error) {
+	out := new(GetTagsForProductsResponse)
+	err := c.cc.Invoke(ctx, "/curriculum.v1.RpcTagsService/GetTagsForProducts", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *rpcTagsServiceClient) ValidateHierarchy(ctx context.Context, in *ValidateHierarchyRequest, opts ...grpc.CallOption) (*ValidateHierarchyResponse, error) {
+	out := new(ValidateHierarchyResponse)
+	err := c.cc.Invoke(ctx, "/curriculum.v1.RpcTagsService/ValidateHierarchy", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *rpcTagsServiceClient) CreateTags(ctx context.Context, in *CreateTagsRequest, opts ...grpc.CallOption) (*CreateTagsResponse, error) {
+	out := new(CreateTagsResponse)
+	err := c.cc.Invoke(ctx, "/curriculum.v1.RpcTagsService/CreateTags", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *rpcTagsServiceClient) GetDefaultTags(ctx context.Context, in *GetDefaultTagsRequest, opts ...grpc.CallOption) (*GetDefaultTagsResponse, error) {
+	out := new(GetDefaultTagsResponse)
+	err := c.cc.Invoke(ctx, "/curriculum.v1.RpcTagsService/GetDefaultTags", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *rpcTagsServiceClient) GetSuggestedTags(ctx context.Context, in *GetSuggestedTagsRequest, opts ...grpc.CallOption) (*GetSuggestedTagsResponse, error) {
+	out := new(GetSuggestedTagsResponse)
+	err := c.cc.Invoke(ctx, "/curriculum.v1.RpcTagsService/GetSuggestedTags", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *rpcTagsServiceClient) StreamSuggestedTags(ctx context.Context, in *StreamSuggestedTagsRequest, opts ...grpc.CallOption) (RpcTagsService_StreamSuggestedTagsClient, error) {
+	stream, err := c.cc.NewStream(ctx, &RpcTagsService_ServiceDesc.Streams[1], "/curriculum.v1.RpcTagsService/StreamSuggestedTags", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &rpcTagsServiceStreamSuggestedTagsClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type RpcTagsService_StreamSuggestedTagsClient interface {
+	Recv() (*StreamSuggestedTagsResponse, error)
+	grpc.ClientStream
+}
+
+type rpcTagsServiceStreamSuggestedTagsClient struct {
+	grpc.ClientStream
+}
+
+func (x *rpcTagsServiceStreamSuggestedTagsClient) Recv() (*StreamSuggestedTagsResponse, error) {
+	m := new(StreamSuggestedTagsResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *rpcTagsServiceClient) GetLegacyDataFromTagId(ctx context.Context, in *GetLegacyDataFromTagIdRequest, opts ...grpc.CallOption) (*GetLegacyDataFromTagIdResponse, error) {
+	out := new(GetLegacyDataFromTagIdResponse)
+	err := c.cc.Invoke(ctx, "/curriculum.v1.RpcTagsService/GetLegacyDataFromTagId", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *rpcTagsServiceClient) GetLegacyDataFromTagIds(ctx context.Context, in *GetLegacyDataFromTagIdsRequest, opts ...grpc.CallOption) (*GetLegacyDataFromTagIdsResponse, error) {
+	out := new(GetLegacyDataFromTagIdsResponse)
+	err := c.cc.Invoke(ctx, "/curriculum.v1.RpcTagsService/GetLegacyDataFromTagIds", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *rpcTagsServiceClient) GetTagDataFromLegacyId(ctx context.Context, in *GetTagDataFromLegacyIdRequest, opts ...grpc.CallOption) (*GetTagDataFromLegacyIdResponse, error) {
+	out := new(GetTagDataFromLegacyIdResponse)
+	err := c.cc.Invoke(ctx, "/curriculum.v1.RpcTagsService/GetTagDataFromLegacyId", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *rpcTagsServiceClient) GetGradeTags(ctx context.Context, in *GetGradeTagsRequest, opts ...grpc.CallOption) (*GetGradeTagsResponse, error) {
+	out := new(GetGradeTagsResponse)
+	err := c.cc.Invoke(ctx, "/curriculum.v1.RpcTagsService/GetGradeTags", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *rpcTagsServiceClient) GetCurrentTerm(ctx context.Context, in *GetCurrentTermRequest, opts ...grpc.CallOption) (*GetCurrentTermResponse, error) {
+	out := new(GetCurrentTermResponse)
+	err := c.cc.Invoke(ctx, "/curriculum.v1.RpcTagsService/GetCurrentTerm", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *rpcTagsServiceClient) GetExpectedGrade(ctx context.Context, in *GetExpectedGradeRequest, opts ...grpc.CallOption) (*GetExpectedGradeResponse, error) {
+	out := new(GetExpectedGradeResponse)
+	err := c.cc.Invoke(ctx, "/curriculum.v1.RpcTagsService/GetExpectedGrade", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// RpcTagsServiceServer is the server API for RpcTagsService service.
+// All implementations must embed UnimplementedRpcTagsServiceServer
+// for forward compatibility
+type RpcTagsServiceServer interface {
+	// GetTags returns the tags of at most 100 ids, like /rpc/getTags
+	GetTags(context.Context, *GetTagsRequest) (*GetTagsResponse, error)
+	// StreamTags returns the tags of any number of ids, they are loaded and sent in batches of 100
+	StreamTags(*StreamTagsRequest, RpcTagsService_StreamTagsServer) error
+	GetTagsByHierarchy(context.Context, *GetTagsByHierarchyRequest) (*GetTagsByHierarchyResponse, error)
+	GetTagsForProducts(context.Context, *GetTagsForProductsRequest) (*GetTagsForProductsResponse, error)
+	ValidateHierarchy(context.Context, *ValidateHierarchyRequest) (*ValidateHierarchyResponse, error)
+	CreateTags(context.Context, *CreateTagsRequest) (*CreateTagsResponse, error)
+	GetDefaultTags(context.Context, *GetDefaultTagsRequest) (*GetDefaultTagsResponse, error)
+	GetSuggestedTags(context.Context, *GetSuggestedTagsRequest) (*GetSuggestedTagsResponse, error)
+	// StreamSuggestedTags sends the suggested curriculum one tag at a time
+	StreamSuggestedTags(*StreamSuggestedTagsRequest, RpcTagsService_StreamSuggestedTagsServer) error
+	GetLegacyDataFromTagId(context.Context, *GetLegacyDataFromTagIdRequest) (*GetLegacyDataFromTagIdResponse, error)
+	GetLegacyDataFromTagIds(context.Context, *GetLegacyDataFromTagIdsRequest) (*GetLegacyDataFromTagIdsResponse, error)
+	GetTagDataFromLegacyId(context.Context, *GetTagDataFromLegacyIdRequest) (*GetTagDataFromLegacyIdResponse, error)
+	GetGradeTags(context.Context, *GetGradeTagsRequest) (*GetGradeTagsResponse, error)
+	GetCurrentTerm(context.Context, *GetCurrentTermRequest) (*GetCurrentTermResponse, error)
+	GetExpectedGrade(context.Context, *GetExpectedGradeRequest) (*GetExpectedGradeResponse, error)
+	mustEmbedUnimplementedRpcTagsServiceServer()
+}
+
+// UnimplementedRpcTagsServiceServer must be embedded to have forward compatible implementations.
+type UnimplementedRpcTagsServiceServer struct {
+}
+
+func (UnimplementedRpcTagsServiceServer) GetTags(context.Context, *GetTagsRequest) (*GetTagsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetTags not implemented")
+}
+func (UnimplementedRpcTagsServiceServer) StreamTags(*StreamTagsRequest, RpcTagsService_StreamTagsServer) error {
+	return status.Errorf(codes.Unimplemented, "method StreamTags not implemented")
+}
+func (UnimplementedRpcTagsServiceServer) GetTagsByHierarchy(context.Context, *GetTagsByHierarchyRequest) (*GetTagsByHierarchyResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetTagsByHierarchy not implemented")
+}
+func (UnimplementedRpcTagsServiceServer) GetTagsForProducts(context.Context, *GetTagsForProductsRequest) (*GetTagsForProductsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetTagsForProducts not implemented")
+}
+func (UnimplementedRpcTagsServiceServer) ValidateHierarchy(context.Context, *ValidateHierarchyRequest) (*ValidateHierarchyResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ValidateHierarchy not implemented")
+}
+func (UnimplementedRpcTagsServiceServer) CreateTags(context.Context, *CreateTagsRequest) (*CreateTagsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateTags not implemented")
+}
+func (UnimplementedRpcTagsServiceServer) GetDefaultTags(context.Context, *GetDefaultTagsRequest) (*GetDefaultTagsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetDefaultTags not implemented")
+}
+func (UnimplementedRpcTagsServiceServer) GetSuggestedTags(context.Context, *GetSuggestedTagsRequest) (*GetSuggestedTagsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetSuggestedTags not implemented")
+}
+func (UnimplementedRpcTagsServiceServer) StreamSuggestedTags(*StreamSuggestedTagsRequest, RpcTagsService_StreamSuggestedTagsServer) error {
+	return status.Errorf(codes.Unimplemented, "method StreamSuggestedTags not implemented")
+}
+func (UnimplementedRpcTagsServiceServer) GetLegacyDataFromTagId(context.Context, *GetLegacyDataFromTagIdRequest) (*GetLegacyDataFromTagIdResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetLegacyDataFromTagId not implemented")
+}
+func (UnimplementedRpcTagsServiceServer) GetLegacyDataFromTagIds(context.Context, *GetLegacyDataFromTagIdsRequest) (*GetLegacyDataFromTagIdsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetLegacyDataFromTagIds not implemented")
+}
+func (UnimplementedRpcTagsServiceServer) GetTagDataFromLegacyId(context.Context, *GetTagDataFromLegacyIdRequest) (*GetTagDataFromLegacyIdResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetTagDataFromLegacyId not implemented")
+}
+func (UnimplementedRpcTagsServiceServer) GetGradeTags(context.Context, *GetGradeTagsRequest) (*GetGradeTagsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetGradeTags not implemented")
+}
+func (UnimplementedRpcTagsServiceServer) GetCurrentTerm(context.Context, *GetCurrentTermRequest) (*GetCurrentTermResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetCurrentTerm not implemented")
+}
+func (UnimplementedRpcTagsServiceServer) GetExpectedGrade(context.Context, *GetExpectedGradeRequest) (*GetExpectedGradeResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetExpectedGrade not implemented")
+}
+func (UnimplementedRpcTagsServiceServer) mustEmbedUnimplementedRpcTagsServiceServer() {}
+
+// UnsafeRpcTagsServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to RpcTagsServiceServer will
+// result in compilation errors.
+type UnsafeRpcTagsServiceServer interface {
+	mustEmbedUnimplementedRpcTagsServiceServer()
+}
+
+func RegisterRpcTagsServiceServer(s grpc.ServiceRegistrar, srv RpcTagsServiceServer) {
+	s.RegisterService(&RpcTagsService_ServiceDesc, srv)
+}
+
+func _RpcTagsService_GetTags_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetTagsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RpcTagsServiceServer).GetTags(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/curriculum.v1.RpcTagsService/GetTags",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RpcTagsServiceServer).GetTags(ctx, req.(*GetTagsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RpcTagsService_StreamTags_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(StreamTagsRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(RpcTagsServiceServer).StreamTags(m, &rpcTagsServiceStreamTagsServer{stream})
+}
+
+type RpcTagsService_StreamTagsServer interface {
+	Send(*StreamTagsResponse) error
+	grpc.ServerStream
+}
+
+type rpcTagsServiceStreamTagsServer struct {
+	grpc.ServerStream
+}
+
+func (x *rpcTagsServiceStreamTagsServer) Send(m *StreamTagsResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _RpcTagsService_GetTagsByHierarchy_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetTagsByHierarchyRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RpcTagsServiceServer).GetTagsByHierarchy(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/curriculum.v1.RpcTagsService/GetTagsByHierarchy",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RpcTagsServiceServer).GetTagsByHierarchy(ctx, req.(*GetTagsByHierarchyRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RpcTagsService_GetTagsForProducts_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetTagsForProductsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RpcTagsServiceServer).GetTagsForProducts(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/curriculum.v1.RpcTagsService/GetTagsForProducts",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RpcTagsServiceServer).GetTagsForProducts(ctx, req.(*GetTagsForProductsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RpcTagsService_ValidateHierarchy_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ValidateHierarchyRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RpcTagsServiceServer).ValidateHierarchy(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/curriculum.v1.RpcTagsService/ValidateHierarchy",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RpcTagsServiceServer).ValidateHierarchy(ctx, req.(*ValidateHierarchyRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RpcTagsService_CreateTags_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateTagsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RpcTagsServiceServer).CreateTags(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/curriculum.v1.RpcTagsService/CreateTags",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RpcTagsServiceServer).CreateTags(ctx, req.(*CreateTagsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RpcTagsService_GetDefaultTags_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetDefaultTagsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RpcTagsServiceServer).GetDefaultTags(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/curriculum.v1.RpcTagsService/GetDefaultTags",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RpcTagsServiceServer).GetDefaultTags(ctx, req.(*GetDefaultTagsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RpcTagsService_GetSuggestedTags_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetSuggestedTagsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RpcTagsServiceServer).GetSuggestedTags(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/curriculum.v1.RpcTagsService/GetSuggestedTags",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RpcTagsServiceServer).GetSuggestedTags(ctx, req.(*GetSuggestedTagsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RpcTagsService_StreamSuggestedTags_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(StreamSuggestedTagsRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(RpcTagsServiceServer).StreamSuggestedTags(m, &rpcTagsServiceStreamSuggestedTagsServer{stream})
+}
+
+type RpcTagsService_StreamSuggestedTagsServer interface {
+	Send(*StreamSuggestedTagsResponse) error
+	grpc.ServerStream
+}
+
+type rpcTagsServiceStreamSuggestedTagsServer struct {
+	grpc.ServerStream
+}
+
+func (x *rpcTagsServiceStreamSuggestedTagsServer) Send(m *StreamSuggestedTagsResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _RpcTagsService_GetLegacyDataFromTagId_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetLegacyDataFromTagIdRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RpcTagsServiceServer).GetLegacyDataFromTagId(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/curriculum.v1.RpcTagsService/GetLegacyDataFromTagId",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RpcTagsServiceServer).GetLegacyDataFromTagId(ctx, req.(*GetLegacyDataFromTagIdRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RpcTagsService_GetLegacyDataFromTagIds_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetLegacyDataFromTagIdsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RpcTagsServiceServer).GetLegacyDataFromTagIds(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/curriculum.v1.RpcTagsService/GetLegacyDataFromTagIds",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RpcTagsServiceServer).GetLegacyDataFromTagIds(ctx, req.(*GetLegacyDataFromTagIdsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RpcTagsService_GetTagDataFromLegacyId_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetTagDataFromLegacyIdRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RpcTagsServiceServer).GetTagDataFromLegacyId(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/curriculum.v1.RpcTagsService/GetTagDataFromLegacyId",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RpcTagsServiceServer).GetTagDataFromLegacyId(ctx, req.(*GetTagDataFromLegacyIdRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RpcTagsService_GetGradeTags_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetGradeTagsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RpcTagsServiceServer).GetGradeTags(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/curriculum.v1.RpcTagsService/GetGradeTags",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RpcTagsServiceServer).GetGradeTags(ctx, req.(*GetGradeTagsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RpcTagsService_GetCurrentTerm_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetCurrentTermRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RpcTagsServiceServer).GetCurrentTerm(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/curriculum.v1.RpcTagsService/GetCurrentTerm",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RpcTagsServiceServer).GetCurrentTerm(ctx, req.(*GetCurrentTermRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RpcTagsService_GetExpectedGrade_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetExpectedGradeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RpcTagsServiceServer).GetExpectedGrade(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/curriculum.v1.RpcTagsService/GetExpectedGrade",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RpcTagsServiceServer).GetExpectedGrade(ctx, req.(*GetExpectedGradeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// RpcTagsService_ServiceDesc is the grpc.ServiceDesc for RpcTagsService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var RpcTagsService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "curriculum.v1.RpcTagsService",
+	HandlerType: (*RpcTagsServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "GetTags",
+			Handler:    _RpcTagsService_GetTags_Handler,
+		},
+		{
+			MethodName: "GetTagsByHierarchy",
+			Handler:    _RpcTagsService_GetTagsByHierarchy_Handler,
+		},
+		{
+			MethodName: "GetTagsForProducts",
+			Handler:    _RpcTagsService_GetTagsForProducts_Handler,
+		},
+		{
+			MethodName: "ValidateHierarchy",
+			Handler:    _RpcTagsService_ValidateHierarchy_Handler,
+		},
+		{
+			MethodName: "CreateTags",
+			Handler:    _RpcTagsService_CreateTags_Handler,
+		},
+		{
+			MethodName: "GetDefaultTags",
+			Handler:    _RpcTagsService_GetDefaultTags_Handler,
+		},
+		{
+			MethodName: "GetSuggestedTags",
+			Handler:    _RpcTagsService_GetSuggestedTags_Handler,
+		},
+		{
+			MethodName: "GetLegacyDataFromTagId",
+			Handler:    _RpcTagsService_GetLegacyDataFromTagId_Handler,
+		},
+		{
+			MethodName: "GetLegacyDataFromTagIds",
+			Handler:    _RpcTagsService_GetLegacyDataFromTagIds_Handler,
+		},
+		{
+			MethodName: "GetTagDataFromLegacyId",
+			Handler:    _RpcTagsService_GetTagDataFromLegacyId_Handler,
+		},
+		{
+			MethodName: "GetGradeTags",
+			Handler:    _RpcTagsService_GetGradeTags_Handler,
+		},
+		{
+			MethodName: "GetCurrentTerm",
+			Handler:    _RpcTagsService_GetCurrentTerm_Handler,
+		},
+		{
+			MethodName: "GetExpectedGrade",
+			Handler:    _RpcTagsService_GetExpectedGrade_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "StreamTags",
+			Handler:       _RpcTagsService_StreamTags_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "StreamSuggestedTags",
+			Handler:       _RpcTagsService_StreamSuggestedTags_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "curriculum/v1/rpc_tags.proto",
+}
@@ -0,0 +1,202 @@
+package grpcresource
+
+import (
+	"bitbucket.org/noon-micro/curriculum/pkg/domain"
+	"bitbucket.org/noon-micro/curriculum/pkg/grpcresource/curriculumpb"
+	noonerror "bitbucket.org/noon-micro/curriculum/pkg/lib/error"
+	"encoding/json"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+func tagMessage(tag *domain.TagResponse) (*curriculumpb.Tag, error) {
+	attributes, err := structMessage(tag.Attributes)
+	if err != nil {
+		return nil, err
+	}
+	localeAttributes, err := structMessage(tag.LocaleAttributes)
+	if err != nil {
+		return nil, err
+	}
+	message := &curriculumpb.Tag{
+		Id:               stringValue(tag.ID),
+		Type:             stringValue(tag.Type),
+		CurriculumType:   stringValue(tag.CurriculumType),
+		Name:             stringValue(tag.Name),
+		LocaleName:       stringValue(tag.LocaleName),
+		ServedLocale:     stringValue(tag.ServedLocale),
+		Hidden:           tag.Hidden,
+		Root:             stringValue(tag.Root),
+		Attributes:       attributes,
+		LocaleAttributes: localeAttributes,
+		Available:        tag.Available,
+		Identifiers:      identifierMessages(tag.Identifiers),
+		Locales:          localeMessages(tag.Locale),
+	}
+	if tag.Grade != nil {
+		grade := int32(*tag.Grade)
+		message.Grade = &grade
+	}
+	return message, nil
+}
+
+func tagMessages(tags []*domain.TagResponse) ([]*curriculumpb.Tag, error) {
+	var messages []*curriculumpb.Tag
+	for _, v := range tags {
+		if v == nil {
+			continue
+		}
+		message, err := tagMessage(v)
+		if err != nil {
+			return nil, err
+		}
+		messages = append(messages, message)
+	}
+	return messages, nil
+}
+
+func productTagMessages(tags []*domain.TagResponseForProduct) ([]*curriculumpb.ProductTag, error) {
+	var messages []*curriculumpb.ProductTag
+	for _, v := range tags {
+		if v == nil {
+			continue
+		}
+		localeAttributes, err := structMessage(v.LocaleAttributes)
+		if err != nil {
+			return nil, err
+		}
+		messages = append(messages, &curriculumpb.ProductTag{
+			Id:               stringValue(v.ID),
+			Type:             stringValue(v.Type),
+			CurriculumType:   stringValue(v.CurriculumType),
+			Name:             stringValue(v.Name),
+			LocaleName:       stringValue(v.LocaleName),
+			ServedLocale:     stringValue(v.ServedLocale),
+			Hidden:           v.Hidden,
+			Root:             stringValue(v.Root),
+			BackgroundPic:    stringValue(v.BackgroundPic),
+			Color:            stringValue(v.Color),
+			Pic:              stringValue(v.Pic),
+			NegativePic:      stringValue(v.NegativePic),
+			LocaleAttributes: localeAttributes,
+			Identifiers:      identifierMessages(v.Identifiers),
+			Locales:          localeMessages(v.Locale),
+		})
+	}
+	return messages, nil
+}
+
+func identifierMessages(identifiers []*domain.IdentifierResponse) []*curriculumpb.Identifier {
+	var messages []*curriculumpb.Identifier
+	for _, v := range identifiers {
+		if v == nil {
+			continue
+		}
+		messages = append(messages, &curriculumpb.Identifier{Id: stringValue(v.ID), Type: stringValue(v.Type), Name: stringValue(v.Name)})
+	}
+	return messages
+}
+
+func localeMessages(locales []*domain.LocaleResponse) []*curriculumpb.Locale {
+	var messages []*curriculumpb.Locale
+	for _, v := range locales {
+		if v == nil {
+			continue
+		}
+		messages = append(messages, &curriculumpb.Locale{Locale: stringValue(v.Locale), Name: stringValue(v.Name), CountryId: stringValue(v.CountryId)})
+	}
+	return messages
+}
+
+func metaMessage(meta *domain.MetaResponse) *curriculumpb.Meta {
+	if meta == nil {
+		return nil
+	}
+	message := &curriculumpb.Meta{IsIdentifier: meta.IsIdentifier, IsOrdered: meta.IsOrdered}
+	if meta.Next != nil {
+		next := int32(*meta.Next)
+		message.Next = &next
+	}
+	return message
+}
+
+func suggestedTagMessages(tags []*domain.SuggestedTags) []*curriculumpb.SuggestedTag {
+	var messages []*curriculumpb.SuggestedTag
+	for _, v := range tags {
+		if v == nil {
+			continue
+		}
+		messages = append(messages, suggestedTagMessage(v))
+	}
+	return messages
+}
+
+func suggestedTagMessage(tag *domain.SuggestedTags) *curriculumpb.SuggestedTag {
+	return &curriculumpb.SuggestedTag{
+		Id:             stringValue(tag.ID),
+		Type:           stringValue(tag.Type),
+		CurriculumType: stringValue(tag.CurriculumType),
+		Name:           stringValue(tag.Name),
+		Topics:         suggestedTagMessages(tag.Topics),
+	}
+}
+
+func legacyDataMessages(data []*domain.LegacyResponse) []*curriculumpb.LegacyData {
+	var messages []*curriculumpb.LegacyData
+	for _, v := range data {
+		if v == nil {
+			continue
+		}
+		messages = append(messages, &curriculumpb.LegacyData{Id: v.ID, TagId: v.TagId, Type: v.Type})
+	}
+	return messages
+}
+
+func academicTermMessage(term *domain.AcademicTerm) *curriculumpb.AcademicTerm {
+	if term == nil {
+		return nil
+	}
+	return &curriculumpb.AcademicTerm{Name: stringValue(term.Name), StartDate: stringValue(term.StartDate), EndDate: stringValue(term.EndDate)}
+}
+
+// structMessage goes through json so that attributes keep the shape the http api serves them in
+func structMessage(value map[string]interface{}) (*structpb.Struct, error) {
+	if value == nil {
+		return nil, nil
+	}
+	valueByte, err := json.Marshal(value)
+	if err != nil {
+		return nil, noonerror.New(noonerror.ErrInternalServer, "mapperError")
+	}
+	message := new(structpb.Struct)
+	if err = protojson.Unmarshal(valueByte, message); err != nil {
+		return nil, noonerror.New(noonerror.ErrInternalServer, "mapperError")
+	}
+	return message, nil
+}
+
+func stringValue(value *string) string {
+	if value == nil {
+		return ""
+	}
+	return *value
+}
+
+// optionalString treats an unset proto3 string as missing, the way an absent json field is
+func optionalString(value string) *string {
+	if value == "" {
+		return nil
+	}
+	return &value
+}
+
+func optionalStrings(values []string) []*string {
+	if values == nil {
+		return nil
+	}
+	pointers := make([]*string, len(values))
+	for i := range values {
+		pointers[i] = &values[i]
+	}
+	return pointers
+}
@@ -0,0 +1,365 @@
+package grpcresource
+
+import (
+	"bitbucket.org/noon-micro/curriculum/pkg/domain"
+	"bitbucket.org/noon-micro/curriculum/pkg/grpcresource/curriculumpb"
+	noonerror "bitbucket.org/noon-micro/curriculum/pkg/lib/error"
+	"bitbucket.org/noon-micro/curriculum/pkg/lib/helper"
+	"bitbucket.org/noon-micro/curriculum/pkg/lib/middleware"
+	"bitbucket.org/noon-micro/curriculum/pkg/resource/entity/request"
+	"context"
+	"github.com/jinzhu/copier"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+// streamBatchSize is the number of tags loaded at once by StreamTags, the limit of a single GetTags call
+const streamBatchSize = 100
+
+// RpcTagsServer serves the rpc endpoints over grpc, requests are validated with the dtos of the http api
+type RpcTagsServer struct {
+	curriculumpb.UnimplementedRpcTagsServiceServer
+	rts domain.RpcTagsService
+}
+
+func (t *RpcTagsServer) GetTags(ctx context.Context, in *curriculumpb.GetTagsRequest) (*curriculumpb.GetTagsResponse, error) {
+	tag := request.GetTagsRPCDTO{
+		TagIds:    optionalStrings(in.GetTagIds()),
+		CountryId: optionalString(in.GetCountryId()),
+	}
+	tag.Locale = negotiateLocale(ctx, tag.CountryId, optionalString(in.GetLocale()))
+	var getTags domain.GetTagsByIds
+	if err := bind(&getTags, tag); err != nil {
+		return nil, statusError(err)
+	}
+	res, err := t.rts.GetTagsByIds(ctx, &getTags, in.GetWithLocales())
+	if err != nil {
+		return nil, statusError(err)
+	}
+	tags, err := tagMessages(res)
+	if err != nil {
+		return nil, statusError(err)
+	}
+	return &curriculumpb.GetTagsResponse{Tags: tags}, nil
+}
+
+func (t *RpcTagsServer) StreamTags(in *curriculumpb.StreamTagsRequest, stream curriculumpb.RpcTagsService_StreamTagsServer) error {
+	ctx := stream.Context()
+	tagIds := optionalStrings(in.GetTagIds())
+	countryId := optionalString(in.GetCountryId())
+	locale := negotiateLocale(ctx, countryId, optionalString(in.GetLocale()))
+	for start := 0; start == 0 || start < len(tagIds); start += streamBatchSize {
+		end := start + streamBatchSize
+		if end > len(tagIds) {
+			end = len(tagIds)
+		}
+		tag := request.GetTagsRPCDTO{TagIds: tagIds[start:end], Locale: locale, CountryId: countryId}
+		var getTags domain.GetTagsByIds
+		if err := bind(&getTags, tag); err != nil {
+			return statusError(err)
+		}
+		res, err := t.rts.GetTagsByIds(ctx, &getTags, in.GetWithLocales())
+		if err != nil {
+			return statusError(err)
+		}
+		tags, err := tagMessages(res)
+		if err != nil {
+			return statusError(err)
+		}
+		for _, v := range tags {
+			if err = stream.Send(&curriculumpb.StreamTagsResponse{Tag: v}); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func (t *RpcTagsServer) GetTagsByHierarchy(ctx context.Context, in *curriculumpb.GetTagsByHierarchyRequest) (*curriculumpb.GetTagsByHierarchyResponse, error) {
+	tag := request.GetTagsByHierarchyRPCDTO{
+		Type:           optionalString(in.GetType()),
+		CurriculumType: optionalString(in.GetCurriculumType()),
+		TagGroup:       optionalString(in.GetTagGroup()),
+		Hierarchy:      optionalString(in.GetHierarchy()),
+		Identifier:     optionalStrings(in.GetIdentifier()),
+	}
+	var getTags domain.GetTags
+	if err := bind(&getTags, tag); err != nil {
+		return nil, statusError(err)
+	}
+	res, err := t.rts.GetTags(ctx, &getTags)
+	if err != nil {
+		return nil, statusError(err)
+	}
+	tags, err := tagMessages(res.Tags)
+	if err != nil {
+		return nil, statusError(err)
+	}
+	return &curriculumpb.GetTagsByHierarchyResponse{Tags: tags, Meta: metaMessage(res.Meta)}, nil
+}
+
+func (t *RpcTagsServer) GetTagsForProducts(ctx context.Context, in *curriculumpb.GetTagsForProductsRequest) (*curriculumpb.GetTagsForProductsResponse, error) {
+	tag := request.GetTagsByHierarchyDTO{
+		Type:           optionalString(in.GetType()),
+		CurriculumType: optionalString(in.GetCurriculumType()),
+		TagGroup:       optionalString(in.GetTagGroup()),
+		CountryId:      optionalString(in.GetCountryId()),
+		Hierarchy:      optionalStrings(in.GetHierarchy()),
+		Start:          int(in.GetStart()),
+		Limit:          int(in.GetLimit()),
+	}
+	if tag.Limit == 0 {
+		tag.Limit = streamBatchSize
+	}
+	tag.Locale = negotiateLocale(ctx, tag.CountryId, optionalString(in.GetLocale()))
+	var getRpcTags domain.GetRpcTags
+	if err := bind(&getRpcTags, tag); err != nil {
+		return nil, statusError(err)
+	}
+	res, err := t.rts.GetRpcTags(ctx, &getRpcTags)
+	if err != nil {
+		return nil, statusError(err)
+	}
+	tags, err := productTagMessages(res.Tags)
+	if err != nil {
+		return nil, statusError(err)
+	}
+	return &curriculumpb.GetTagsForProductsResponse{Tags: tags, Meta: metaMessage(res.Meta)}, nil
+}
+
+func (t *RpcTagsServer) ValidateHierarchy(ctx context.Context, in *curriculumpb.ValidateHierarchyRequest) (*curriculumpb.ValidateHierarchyResponse, error) {
+	curriculumType := domain.CurriculumTypeEnum.Default
+	tag := request.ValidateHierarchyDTO{CurriculumType: &curriculumType}
+	for _, v := range in.GetHierarchies() {
+		tag.Hierarchies = append(tag.Hierarchies, optionalStrings(v.GetTagIds()))
+	}
+	var validateHierarchy domain.ValidateHierarchy
+	if err := bind(&validateHierarchy, tag); err != nil {
+		return nil, statusError(err)
+	}
+	if err := t.rts.ValidateHierarchy(ctx, &validateHierarchy); err != nil {
+		return nil, statusError(err)
+	}
+	return &curriculumpb.ValidateHierarchyResponse{}, nil
+}
+
+func (t *RpcTagsServer) CreateTags(ctx context.Context, in *curriculumpb.CreateTagsRequest) (*curriculumpb.CreateTagsResponse, error) {
+	curriculumType := domain.CurriculumTypeEnum.Default
+	tag := request.CreateTagsRPCDTO{
+		Type:           optionalString(in.GetType()),
+		CurriculumType: &curriculumType,
+		TagGroup:       optionalString(in.GetTagGroup()),
+		Hierarchy:      optionalStrings(in.GetHierarchy()),
+		Identifier:     optionalStrings(in.GetIdentifier()),
+	}
+	if creatorId := in.GetCreatorId(); creatorId != 0 {
+		tag.CreatorId = &creatorId
+	}
+	if in.GetAttributes() != nil {
+		tag.Attributes = in.GetAttributes().AsMap()
+	}
+	for _, v := range in.GetTags() {
+		tag.Tags = append(tag.Tags, &request.CreateTagDTO{Name: optionalString(v.GetName()), CountryId: v.GetCountryId()})
+	}
+	var createTags domain.CreateMultipleTags
+	if err := bind(&createTags, tag); err != nil {
+		return nil, statusError(err)
+	}
+	createTags.CreatorType = new(string)
+	*createTags.CreatorType = "teacher"
+	res, err := t.rts.CreateTags(ctx, &createTags)
+	if err != nil {
+		return nil, statusError(err)
+	}
+	tags, err := tagMessages(res)
+	if err != nil {
+		return nil, statusError(err)
+	}
+	return &curriculumpb.CreateTagsResponse{Tags: tags}, nil
+}
+
+func (t *RpcTagsServer) GetDefaultTags(ctx context.Context, in *curriculumpb.GetDefaultTagsRequest) (*curriculumpb.GetDefaultTagsResponse, error) {
+	res, err := t.rts.GetDefaultTags(ctx)
+	if err != nil {
+		return nil, statusError(err)
+	}
+	defaultTags := &curriculumpb.GetDefaultTagsResponse{}
+	if res.MiscellaneousTag != nil {
+		if defaultTags.DefaultChapter, err = tagMessage(res.MiscellaneousTag); err != nil {
+			return nil, statusError(err)
+		}
+	}
+	if res.ResourceTag != nil {
+		if defaultTags.DefaultTopic, err = tagMessage(res.ResourceTag); err != nil {
+			return nil, statusError(err)
+		}
+	}
+	return defaultTags, nil
+}
+
+func (t *RpcTagsServer) GetSuggestedTags(ctx context.Context, in *curriculumpb.GetSuggestedTagsRequest) (*curriculumpb.GetSuggestedTagsResponse, error) {
+	res, err := t.getSuggestedTags(ctx, in.GetTagIds(), in.GetLocale(), in.GetCountryId())
+	if err != nil {
+		return nil, statusError(err)
+	}
+	return &curriculumpb.GetSuggestedTagsResponse{Tags: suggestedTagMessages(res)}, nil
+}
+
+func (t *RpcTagsServer) StreamSuggestedTags(in *curriculumpb.StreamSuggestedTagsRequest, stream curriculumpb.RpcTagsService_StreamSuggestedTagsServer) error {
+	res, err := t.getSuggestedTags(stream.Context(), in.GetTagIds(), in.GetLocale(), in.GetCountryId())
+	if err != nil {
+		return statusError(err)
+	}
+	for _, v := range suggestedTagMessages(res) {
+		if err = stream.Send(&curriculumpb.StreamSuggestedTagsResponse{Tag: v}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (t *RpcTagsServer) getSuggestedTags(ctx context.Context, tagIds []string, locale string, countryId string) ([]*domain.SuggestedTags, error) {
+	curriculumType := domain.CurriculumTypeEnum.Default
+	tag := request.GetSuggestedTagsDTO{
+		TagIds:         optionalStrings(tagIds),
+		CurriculumType: &curriculumType,
+		CountryId:      optionalString(countryId),
+	}
+	tag.Locale = negotiateLocale(ctx, tag.CountryId, optionalString(locale))
+	var gst domain.GetSuggestedTags
+	if err := bind(&gst, tag); err != nil {
+		return nil, err
+	}
+	return t.rts.GetSuggestedCurriculum(ctx, &gst)
+}
+
+func (t *RpcTagsServer) GetLegacyDataFromTagId(ctx context.Context, in *curriculumpb.GetLegacyDataFromTagIdRequest) (*curriculumpb.GetLegacyDataFromTagIdResponse, error) {
+	tag := request.GetLegacyDataFromTagIdDTO{ID: optionalString(in.GetId())}
+	if err := validate(tag); err != nil {
+		return nil, statusError(err)
+	}
+	res, err := t.rts.GetLegacyDataFromTagId(ctx, tag.ID)
+	if err != nil {
+		return nil, statusError(err)
+	}
+	return &curriculumpb.GetLegacyDataFromTagIdResponse{Data: legacyDataMessages(res)}, nil
+}
+
+func (t *RpcTagsServer) GetLegacyDataFromTagIds(ctx context.Context, in *curriculumpb.GetLegacyDataFromTagIdsRequest) (*curriculumpb.GetLegacyDataFromTagIdsResponse, error) {
+	tag := request.GetLegacyDataFromTagIdsDTO{IDs: optionalStrings(in.GetIds())}
+	if err := validate(tag); err != nil {
+		return nil, statusError(err)
+	}
+	res, err := t.rts.GetLegacyDataFromTagIds(ctx, tag.IDs)
+	if err != nil {
+		return nil, statusError(err)
+	}
+	return &curriculumpb.GetLegacyDataFromTagIdsResponse{Data: legacyDataMessages(res)}, nil
+}
+
+func (t *RpcTagsServer) GetTagDataFromLegacyId(ctx context.Context, in *curriculumpb.GetTagDataFromLegacyIdRequest) (*curriculumpb.GetTagDataFromLegacyIdResponse, error) {
+	tag := request.GetTagDataFromLegacyIdDTO{Type: optionalString(in.GetType()), ID: optionalString(in.GetId())}
+	if err := validate(tag); err != nil {
+		return nil, statusError(err)
+	}
+	res, err := t.rts.GetTagDataFromLegacyId(ctx, tag.Type, tag.ID)
+	if err != nil {
+		return nil, statusError(err)
+	}
+	return &curriculumpb.GetTagDataFromLegacyIdResponse{Data: legacyDataMessages(res)}, nil
+}
+
+func (t *RpcTagsServer) GetGradeTags(ctx context.Context, in *curriculumpb.GetGradeTagsRequest) (*curriculumpb.GetGradeTagsResponse, error) {
+	tag := request.GetGradeTagsDTO{Grade: optionalString(in.GetGrade()), ProductId: optionalString(in.GetProductId())}
+	if err := validate(tag); err != nil {
+		return nil, statusError(err)
+	}
+	zero := "0"
+	if tag.ProductId == nil {
+		tag.ProductId = &zero
+	}
+	res, err := t.rts.GetGradeTags(ctx, tag.Grade, tag.ProductId)
+	if err != nil {
+		return nil, statusError(err)
+	}
+	return &curriculumpb.GetGradeTagsResponse{Data: legacyDataMessages(res)}, nil
+}
+
+func (t *RpcTagsServer) GetCurrentTerm(ctx context.Context, in *curriculumpb.GetCurrentTermRequest) (*curriculumpb.GetCurrentTermResponse, error) {
+	term := request.GetCurrentTermDTO{CountryId: optionalString(in.GetCountryId()), Date: optionalString(in.GetDate())}
+	var getCurrentTerm domain.GetCurrentTerm
+	if err := bind(&getCurrentTerm, term); err != nil {
+		return nil, statusError(err)
+	}
+	res, err := t.rts.GetCurrentTerm(ctx, &getCurrentTerm)
+	if err != nil {
+		return nil, statusError(err)
+	}
+	return &curriculumpb.GetCurrentTermResponse{
+		CountryId: stringValue(res.CountryId),
+		Date:      stringValue(res.Date),
+		Year:      stringValue(res.Year),
+		Current:   academicTermMessage(res.Current),
+		Next:      academicTermMessage(res.Next),
+		Holiday:   stringValue(res.Holiday),
+	}, nil
+}
+
+func (t *RpcTagsServer) GetExpectedGrade(ctx context.Context, in *curriculumpb.GetExpectedGradeRequest) (*curriculumpb.GetExpectedGradeResponse, error) {
+	grade := request.GetExpectedGradeDTO{
+		CountryId:  optionalString(in.GetCountryId()),
+		BoardId:    optionalString(in.GetBoardId()),
+		GradeId:    optionalString(in.GetGradeId()),
+		EnrolledAt: optionalString(in.GetEnrolledAt()),
+		Date:       optionalString(in.GetDate()),
+	}
+	var getExpectedGrade domain.GetExpectedGrade
+	if err := bind(&getExpectedGrade, grade); err != nil {
+		return nil, statusError(err)
+	}
+	res, err := t.rts.GetExpectedGrade(ctx, &getExpectedGrade)
+	if err != nil {
+		return nil, statusError(err)
+	}
+	return &curriculumpb.GetExpectedGradeResponse{
+		GradeId:    stringValue(res.GradeId),
+		Name:       stringValue(res.Name),
+		Promotions: int32(res.Promotions),
+		Graduated:  res.Graduated,
+	}, nil
+}
+
+func validate(dto interface{}) error {
+	if err := helper.Validate(dto); err != nil {
+		return noonerror.New(noonerror.ErrInvalidRequest, err.Error())
+	}
+	return nil
+}
+
+// bind validates the dto and copies it on the domain request
+func bind(to interface{}, dto interface{}) error {
+	if err := validate(dto); err != nil {
+		return err
+	}
+	if err := copier.Copy(to, dto); err != nil {
+		return noonerror.New(noonerror.ErrInternalServer, "mapperError")
+	}
+	return nil
+}
+
+// negotiateLocale resolves the locale like the http api, the locale and accept-language metadata stand for their
+// headers. The result is sent back in the content-language header.
+func negotiateLocale(ctx context.Context, countryId *string, explicit *string) *string {
+	var requested, acceptLanguage string
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		if values := md.Get("locale"); len(values) > 0 {
+			requested = values[0]
+		}
+		if values := md.Get("accept-language"); len(values) > 0 {
+			acceptLanguage = values[0]
+		}
+	}
+	locale := middleware.ResolveLocale(ctx, countryId, explicit, requested, acceptLanguage)
+	_ = grpc.SetHeader(ctx, metadata.Pairs("content-language", *locale))
+	return locale
+}
@@ -0,0 +1,141 @@
+//go:generate sh -c "cd ../../proto && buf generate"
+
+package grpcresource
+
+import (
+	"bitbucket.org/noon-micro/curriculum/pkg/domain"
+	"bitbucket.org/noon-micro/curriculum/pkg/grpcresource/curriculumpb"
+	noonerror "bitbucket.org/noon-micro/curriculum/pkg/lib/error"
+	"bitbucket.org/noon-micro/curriculum/pkg/lib/logger"
+	"bitbucket.org/noon-micro/curriculum/pkg/lib/middleware"
+	"context"
+	"database/sql"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/health"
+	"google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/reflection"
+	"google.golang.org/grpc/status"
+	grpctrace "gopkg.in/DataDog/dd-trace-go.v1/contrib/google.golang.org/grpc"
+	"log"
+	"net"
+	"time"
+)
+
+// Server serves the rpc tags api over grpc with health and reflection, the health status follows the database
+type Server struct {
+	server *grpc.Server
+	health *health.Server
+	db     *sql.DB
+}
+
+func NewServer(rts domain.RpcTagsService, db *sql.DB) *Server {
+	server := grpc.NewServer(
+		grpc.ChainUnaryInterceptor(
+			grpctrace.UnaryServerInterceptor(grpctrace.WithServiceName("curriculum")),
+			recoverUnaryInterceptor,
+			loaderUnaryInterceptor,
+		),
+		grpc.ChainStreamInterceptor(
+			grpctrace.StreamServerInterceptor(grpctrace.WithServiceName("curriculum")),
+			recoverStreamInterceptor,
+			loaderStreamInterceptor,
+		),
+	)
+	healthServer := health.NewServer()
+	curriculumpb.RegisterRpcTagsServiceServer(server, &RpcTagsServer{rts: rts})
+	grpc_health_v1.RegisterHealthServer(server, healthServer)
+	reflection.Register(server)
+	return &Server{server: server, health: healthServer, db: db}
+}
+
+// Serve blocks until the listener fails or the server is stopped
+func (s *Server) Serve(port string, healthInterval time.Duration) error {
+	listener, err := net.Listen("tcp", ":"+port)
+	if err != nil {
+		return err
+	}
+	return s.serve(listener, healthInterval)
+}
+
+func (s *Server) serve(listener net.Listener, healthInterval time.Duration) error {
+	s.checkHealth()
+	go s.watchHealth(healthInterval)
+	return s.server.Serve(listener)
+}
+
+func (s *Server) watchHealth(interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for range ticker.C {
+		s.checkHealth()
+	}
+}
+
+func (s *Server) checkHealth() {
+	servingStatus := grpc_health_v1.HealthCheckResponse_SERVING
+	if s.db.Ping() != nil {
+		servingStatus = grpc_health_v1.HealthCheckResponse_NOT_SERVING
+	}
+	s.health.SetServingStatus("", servingStatus)
+	s.health.SetServingStatus(curriculumpb.RpcTagsService_ServiceDesc.ServiceName, servingStatus)
+}
+
+func recoverUnaryInterceptor(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (resp interface{}, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			log.Printf("panic: %+v", r)
+			logger.Client.Error(logger.GetErrorStack())
+			err = status.Error(codes.Internal, "codePanicked")
+		}
+	}()
+	return handler(ctx, req)
+}
+
+func recoverStreamInterceptor(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			log.Printf("panic: %+v", r)
+			logger.Client.Error(logger.GetErrorStack())
+			err = status.Error(codes.Internal, "codePanicked")
+		}
+	}()
+	return handler(srv, ss)
+}
+
+// loaderUnaryInterceptor attaches a fresh tag loader to every call, like the http middleware does per request
+func loaderUnaryInterceptor(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+	return handler(middleware.WithLoader(ctx), req)
+}
+
+func loaderStreamInterceptor(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	return handler(srv, &loaderServerStream{ServerStream: ss, ctx: middleware.WithLoader(ss.Context())})
+}
+
+type loaderServerStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *loaderServerStream) Context() context.Context {
+	return s.ctx
+}
+
+// statusError maps the service errors on grpc codes the way entity.HandleError maps them on http statuses, the
+// message keeps the error key
+func statusError(err error) error {
+	message := err.Error()
+	if noonError, ok := err.(*noonerror.NoonError); ok {
+		err = noonError.Err
+		message = noonError.Message
+	}
+	switch err {
+	case noonerror.ErrUserNotFound:
+		return status.Error(codes.NotFound, message)
+	case noonerror.ErrBadRequest, noonerror.ErrRelationExists, noonerror.ErrParamMissing, noonerror.ErrInvalidRequest:
+		return status.Error(codes.InvalidArgument, message)
+	case noonerror.ErrInternalServer:
+		return status.Error(codes.Internal, message)
+	}
+	return status.Error(codes.Internal, "somethingWentWrong")
+}
@@ -0,0 +1,192 @@
+package grpcresource
+
+import (
+	"bitbucket.org/noon-micro/curriculum/config"
+	"bitbucket.org/noon-micro/curriculum/pkg/external"
+	"bitbucket.org/noon-micro/curriculum/pkg/grpcresource/curriculumpb"
+	noonerror "bitbucket.org/noon-micro/curriculum/pkg/lib/error"
+	"bitbucket.org/noon-micro/curriculum/pkg/lib/helper"
+	"bitbucket.org/noon-micro/curriculum/pkg/lib/logger"
+	memory "bitbucket.org/noon-micro/curriculum/pkg/repository/memory"
+	repository "bitbucket.org/noon-micro/curriculum/pkg/repository/redis"
+	"bitbucket.org/noon-micro/curriculum/pkg/service"
+	"context"
+	"database/sql"
+	"errors"
+	"github.com/alicebob/miniredis/v2"
+	"github.com/go-redis/redis"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+	redistrace "gopkg.in/DataDog/dd-trace-go.v1/contrib/go-redis/redis"
+	"io"
+	"net"
+	"testing"
+	"time"
+)
+
+// newTestClient serves the rpc api of the memory environment on an in-memory listener and dials it
+func newTestClient(t *testing.T) *grpc.ClientConn {
+	if logger.Client == nil {
+		logger.New()
+	}
+	if config.GetConfig() == nil {
+		config.LoadConfiguration("memory")
+	}
+	helper.InitializeValidator()
+	fixture, err := memory.LoadFixture("../../fixtures/memory.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	redisServer, err := miniredis.Run()
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(redisServer.Close)
+	repository.RedisClient = redistrace.NewClient(&redis.Options{Addr: redisServer.Addr()})
+	repo := memory.InitializeMemory(fixture)
+	tagsService := service.NewTagsService(repo.Tags, repo.ParentTagMapping, repo.TagLocaleMapping, repo.TagAttributeLocale, repo.TagCountryRule, repo.RegionCountry, repo.LegacyTagMapping, repo.GradeProduct)
+	publisher := service.NewTagEventPublisher(tagsService, repo.TagEventOutbox, repo.TagChange, external.NewMemoryTagEventBroker(100))
+	elastic := service.NewSearchElastic(external.NewMemoryElasticExternal(fixture), true)
+	server := NewServer(service.NewRpcTagsService(tagsService, elastic, publisher), repo.Db)
+	listener := bufconn.Listen(1 << 20)
+	go func() {
+		_ = server.serve(listener, time.Hour)
+	}()
+	t.Cleanup(server.server.Stop)
+	conn, err := grpc.DialContext(context.Background(), "bufnet", grpc.WithInsecure(),
+		grpc.WithContextDialer(func(context.Context, string) (net.Conn, error) {
+			return listener.Dial()
+		}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { _ = conn.Close() })
+	return conn
+}
+
+func TestServerUnaryCall(t *testing.T) {
+	client := curriculumpb.NewRpcTagsServiceClient(newTestClient(t))
+	var header metadata.MD
+	res, err := client.GetCurrentTerm(context.Background(), &curriculumpb.GetCurrentTermRequest{CountryId: "9", Date: "2026-12-30"},
+		grpc.Header(&header))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if res.GetYear() != "2026-2027" || res.GetCurrent().GetName() != "Term 2" || res.GetNext().GetName() != "Term 3" || res.GetHoliday() != "Winter break" {
+		t.Errorf("got year %q current %q next %q holiday %q", res.GetYear(), res.GetCurrent().GetName(), res.GetNext().GetName(), res.GetHoliday())
+	}
+	tags, err := client.GetTags(metadata.AppendToOutgoingContext(context.Background(), "locale", "AR"),
+		&curriculumpb.GetTagsRequest{TagIds: []string{"20"}, CountryId: "9"}, grpc.Header(&header))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(tags.GetTags()) != 1 || tags.GetTags()[0].GetId() != "20" {
+		t.Errorf("got tags %v, want tag 20", tags.GetTags())
+	}
+	if got := header.Get("content-language"); len(got) != 1 || got[0] != "ar" {
+		t.Errorf("got content-language %v, want ar", got)
+	}
+}
+
+func TestServerStream(t *testing.T) {
+	client := curriculumpb.NewRpcTagsServiceClient(newTestClient(t))
+	stream, err := client.StreamTags(context.Background(), &curriculumpb.StreamTagsRequest{TagIds: []string{"9", "2", "251"}, CountryId: "9"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	received := map[string]bool{}
+	for {
+		res, err := stream.Recv()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+		received[res.GetTag().GetId()] = true
+	}
+	for _, id := range []string{"9", "2", "251"} {
+		if !received[id] {
+			t.Errorf("tag %s was not streamed, got %v", id, received)
+		}
+	}
+	if len(received) != 3 {
+		t.Errorf("got %d tags, want 3", len(received))
+	}
+}
+
+func TestServerHealth(t *testing.T) {
+	client := grpc_health_v1.NewHealthClient(newTestClient(t))
+	for _, name := range []string{"", curriculumpb.RpcTagsService_ServiceDesc.ServiceName} {
+		res, err := client.Check(context.Background(), &grpc_health_v1.HealthCheckRequest{Service: name})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if res.GetStatus() != grpc_health_v1.HealthCheckResponse_SERVING {
+			t.Errorf("service %q: got %v, want SERVING", name, res.GetStatus())
+		}
+	}
+	// a database which no longer answers takes the server out of rotation
+	closed, err := sql.Open("curriculum-memory", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	_ = closed.Close()
+	server := NewServer(nil, closed)
+	server.checkHealth()
+	res, err := server.health.Check(context.Background(), &grpc_health_v1.HealthCheckRequest{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if res.GetStatus() != grpc_health_v1.HealthCheckResponse_NOT_SERVING {
+		t.Errorf("closed database: got %v, want NOT_SERVING", res.GetStatus())
+	}
+}
+
+func TestServerStatusMapping(t *testing.T) {
+	client := curriculumpb.NewRpcTagsServiceClient(newTestClient(t))
+	cases := []struct {
+		name    string
+		request *curriculumpb.GetCurrentTermRequest
+		code    codes.Code
+		message string
+	}{
+		{"malformed date", &curriculumpb.GetCurrentTermRequest{CountryId: "9", Date: "2026-13-01"}, codes.InvalidArgument, "invalidCalendarDate"},
+		{"country without a calendar", &curriculumpb.GetCurrentTermRequest{CountryId: "10"}, codes.InvalidArgument, "academicCalendarNotFound"},
+		{"missing country", &curriculumpb.GetCurrentTermRequest{}, codes.InvalidArgument, ""},
+	}
+	for _, c := range cases {
+		_, err := client.GetCurrentTerm(context.Background(), c.request)
+		got := status.Convert(err)
+		if got.Code() != c.code || (c.message != "" && got.Message() != c.message) {
+			t.Errorf("%s: got %v %q, want %v %q", c.name, got.Code(), got.Message(), c.code, c.message)
+		}
+	}
+}
+
+func TestStatusError(t *testing.T) {
+	cases := []struct {
+		err     error
+		code    codes.Code
+		message string
+	}{
+		{noonerror.New(noonerror.ErrUserNotFound, "tagNotFound"), codes.NotFound, "tagNotFound"},
+		{noonerror.New(noonerror.ErrBadRequest, "countryNotFound"), codes.InvalidArgument, "countryNotFound"},
+		{noonerror.New(noonerror.ErrRelationExists, "relationExists"), codes.InvalidArgument, "relationExists"},
+		{noonerror.New(noonerror.ErrParamMissing, "tagIdsMissing"), codes.InvalidArgument, "tagIdsMissing"},
+		{noonerror.New(noonerror.ErrInvalidRequest, "invalidDto"), codes.InvalidArgument, "invalidDto"},
+		{noonerror.New(noonerror.ErrInternalServer, "dbCommitError"), codes.Internal, "dbCommitError"},
+		{noonerror.ErrBadRequest, codes.InvalidArgument, "badRequest"},
+		{errors.New("driver: bad connection"), codes.Internal, "somethingWentWrong"},
+	}
+	for _, c := range cases {
+		got := status.Convert(statusError(c.err))
+		if got.Code() != c.code || got.Message() != c.message {
+			t.Errorf("%v: got %v %q, want %v %q", c.err, got.Code(), got.Message(), c.code, c.message)
+		}
+	}
+}
@@ -42,4 +42,7 @@ var (
 
 	// ErrInvalidRequest for invalid request
 	ErrInvalidRequest = errors.New("invalidRequest")
+
+	// ErrConflict sent when a write was made against a version which is no longer current
+	ErrConflict = errors.New("conflict")
 )
@@ -0,0 +1,91 @@
+package helper
+
+import (
+	"net"
+	"net/http"
+	"strings"
+)
+
+var trustedProxies []*net.IPNet
+
+// InitializeTrustedProxies parses the comma separated proxy CIDRs, a bare address is trusted on its own
+func InitializeTrustedProxies(cidrs string) error {
+	var proxies []*net.IPNet
+	for _, v := range strings.Split(cidrs, ",") {
+		cidr := strings.TrimSpace(v)
+		if cidr == "" {
+			continue
+		}
+		if !strings.Contains(cidr, "/") {
+			ip := net.ParseIP(cidr)
+			if ip == nil {
+				return &net.ParseError{Type: "IP address", Text: cidr}
+			}
+			bits := net.IPv6len * 8
+			if ip.To4() != nil {
+				ip, bits = ip.To4(), net.IPv4len*8
+			}
+			proxies = append(proxies, &net.IPNet{IP: ip, Mask: net.CIDRMask(bits, bits)})
+			continue
+		}
+		_, network, err := net.ParseCIDR(cidr)
+		if err != nil {
+			return err
+		}
+		proxies = append(proxies, network)
+	}
+	trustedProxies = proxies
+	return nil
+}
+
+// ClientIp returns the address of the client. X-Forwarded-For and X-Real-IP are only believed when the peer is a
+// trusted proxy, the forwarded chain is then walked from the right skipping the trusted proxies in it. An empty
+// string is returned when no valid address is found.
+func ClientIp(req *http.Request) string {
+	peer := parseIp(req.RemoteAddr)
+	if peer == nil {
+		return ""
+	}
+	if !isTrustedProxy(peer) {
+		return peer.String()
+	}
+	var chain []string
+	for _, v := range req.Header["X-Forwarded-For"] {
+		chain = append(chain, strings.Split(v, ",")...)
+	}
+	for i := len(chain) - 1; i >= 0; i-- {
+		ip := parseIp(chain[i])
+		if ip == nil {
+			break
+		}
+		if !isTrustedProxy(ip) {
+			return ip.String()
+		}
+	}
+	if ip := parseIp(req.Header.Get("X-Real-IP")); ip != nil {
+		return ip.String()
+	}
+	return peer.String()
+}
+
+// parseIp accepts an address with or without a port, ipv6 addresses may be bracketed
+func parseIp(address string) net.IP {
+	address = strings.TrimSpace(address)
+	if host, _, err := net.SplitHostPort(address); err == nil {
+		address = host
+	}
+	address = strings.TrimSuffix(strings.TrimPrefix(address, "["), "]")
+	if i := strings.Index(address, "%"); i > 0 {
+		address = address[:i]
+	}
+	return net.ParseIP(address)
+}
+
+func isTrustedProxy(ip net.IP) bool {
+	for _, v := range trustedProxies {
+		if v.Contains(ip) {
+			return true
+		}
+	}
+	return false
+}
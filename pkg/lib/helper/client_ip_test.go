@@ -0,0 +1,81 @@
+package helper
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestInitializeTrustedProxies(t *testing.T) {
+	defer InitializeTrustedProxies("")
+	tests := []struct {
+		cidrs   string
+		trusted []string
+		wantErr bool
+	}{
+		{cidrs: "10.0.0.0/8, 192.168.1.1", trusted: []string{"10.1.2.3", "192.168.1.1"}},
+		{cidrs: "fd00::/8,::1", trusted: []string{"fd00::1", "::1"}},
+		{cidrs: " ,10.0.0.1,", trusted: []string{"10.0.0.1"}},
+		{cidrs: "10.0.0.0/33", wantErr: true},
+		{cidrs: "proxy.local", wantErr: true},
+	}
+	for _, test := range tests {
+		err := InitializeTrustedProxies(test.cidrs)
+		if (err != nil) != test.wantErr {
+			t.Errorf("%q: got err %v, want error %v", test.cidrs, err, test.wantErr)
+			continue
+		}
+		for _, v := range test.trusted {
+			if !isTrustedProxy(parseIp(v)) {
+				t.Errorf("%q: %s is not trusted", test.cidrs, v)
+			}
+		}
+	}
+	if err := InitializeTrustedProxies("192.168.1.1"); err != nil || isTrustedProxy(parseIp("192.168.1.2")) {
+		t.Errorf("a bare address trusts its neighbours, err %v", err)
+	}
+}
+
+func TestClientIp(t *testing.T) {
+	if err := InitializeTrustedProxies("10.0.0.0/8,192.168.1.1,fd00::/8"); err != nil {
+		t.Fatal(err)
+	}
+	defer InitializeTrustedProxies("")
+	tests := []struct {
+		name      string
+		peer      string
+		forwarded []string
+		realIp    string
+		want      string
+	}{
+		{name: "untrusted peer ignores forwarding headers", peer: "203.0.113.7:1234", forwarded: []string{"198.51.100.2"}, realIp: "198.51.100.3", want: "203.0.113.7"},
+		{name: "peer without port", peer: "203.0.113.7", want: "203.0.113.7"},
+		{name: "invalid peer", peer: "unknown", want: ""},
+		{name: "trusted peer", peer: "10.0.0.1:80", forwarded: []string{"198.51.100.2"}, want: "198.51.100.2"},
+		{name: "rightmost untrusted address", peer: "10.0.0.1:80", forwarded: []string{"198.51.100.2, 203.0.113.9, 10.0.0.5"}, want: "203.0.113.9"},
+		{name: "spoofed left entries", peer: "192.168.1.1:80", forwarded: []string{"1.1.1.1,198.51.100.2"}, want: "198.51.100.2"},
+		{name: "repeated headers", peer: "10.0.0.1:80", forwarded: []string{"198.51.100.2", "203.0.113.9, 10.1.1.1"}, want: "203.0.113.9"},
+		{name: "forwarded address with port", peer: "10.0.0.1:80", forwarded: []string{"198.51.100.2:5555"}, want: "198.51.100.2"},
+		{name: "invalid entry stops the walk", peer: "10.0.0.1:80", forwarded: []string{"198.51.100.2, garbage, 10.0.0.5"}, realIp: "198.51.100.3", want: "198.51.100.3"},
+		{name: "only trusted proxies", peer: "10.0.0.1:80", forwarded: []string{"10.0.0.2"}, want: "10.0.0.1"},
+		{name: "real ip header", peer: "10.0.0.1:80", realIp: "198.51.100.3", want: "198.51.100.3"},
+		{name: "invalid real ip header", peer: "10.0.0.1:80", realIp: "nope", want: "10.0.0.1"},
+		{name: "ipv6 trusted peer", peer: "[fd00::1]:443", forwarded: []string{"2001:db8::1"}, want: "2001:db8::1"},
+		{name: "bracketed ipv6 forwarded address", peer: "[fd00::1]:443", forwarded: []string{"[2001:db8::2]:8080, fd00::2"}, want: "2001:db8::2"},
+		{name: "ipv6 peer with zone", peer: "[fe80::1%eth0]:80", want: "fe80::1"},
+		{name: "ipv6 untrusted peer", peer: "[2001:db8::3]:443", forwarded: []string{"198.51.100.2"}, want: "2001:db8::3"},
+	}
+	for _, test := range tests {
+		req := httptest.NewRequest(http.MethodGet, "/student/countries", nil)
+		req.RemoteAddr = test.peer
+		for _, v := range test.forwarded {
+			req.Header.Add("X-Forwarded-For", v)
+		}
+		if test.realIp != "" {
+			req.Header.Set("X-Real-IP", test.realIp)
+		}
+		if got := ClientIp(req); got != test.want {
+			t.Errorf("%s: got %q, want %q", test.name, got, test.want)
+		}
+	}
+}
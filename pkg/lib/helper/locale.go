@@ -0,0 +1,10 @@
+package helper
+
+import "strings"
+
+// NormalizeLocale returns the form locales are stored, cached and negotiated in, lower cased with hyphens, e.g.
+// en_US and en-US both become en-us. Every writer and the negotiation share it so that the cache keys built on
+// either side match.
+func NormalizeLocale(locale string) string {
+	return strings.ToLower(strings.Replace(strings.TrimSpace(locale), "_", "-", -1))
+}
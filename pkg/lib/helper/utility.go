@@ -2,6 +2,7 @@ package helper
 
 import (
 	"bitbucket.org/noon-micro/curriculum/pkg/lib/logger"
+	"context"
 	"encoding/json"
 	"reflect"
 	"strconv"
@@ -54,3 +55,33 @@ func Contains(data []int, datum int) bool {
 func MakeTimestamp() int64 {
 	return time.Now().UnixNano() / int64(time.Millisecond)
 }
+
+// Timeout parses a millisecond duration from config, falling back when it is unset or invalid
+func Timeout(millis string, fallback time.Duration) time.Duration {
+	timeout, err := strconv.Atoi(millis)
+	if err != nil || timeout <= 0 {
+		return fallback
+	}
+	return time.Duration(timeout) * time.Millisecond
+}
+
+// WithTimeout bounds ctx by the configured millisecond timeout, an earlier parent deadline still wins
+func WithTimeout(ctx context.Context, millis string, fallback time.Duration) (context.Context, context.CancelFunc) {
+	return context.WithTimeout(ctx, Timeout(millis, fallback))
+}
+
+type detachedContext struct {
+	context.Context
+}
+
+func (detachedContext) Deadline() (time.Time, bool) { return time.Time{}, false }
+
+func (detachedContext) Done() <-chan struct{} { return nil }
+
+func (detachedContext) Err() error { return nil }
+
+// Detach keeps the values of ctx, such as the trace span, but drops its cancellation.
+// Compensating calls made after a failure must not be abandoned along with the request.
+func Detach(ctx context.Context) context.Context {
+	return detachedContext{ctx}
+}
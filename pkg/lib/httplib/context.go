@@ -0,0 +1,96 @@
+package httplib
+
+import (
+	"bitbucket.org/noon-go/noonhttp"
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"time"
+)
+
+// ContextClient mirrors noonhttp.ClientEntity but binds every request to a context,
+// so that a cancelled or timed out caller releases the connection straight away
+type ContextClient struct {
+	client *http.Client
+}
+
+var CtxClient *ContextClient
+
+// InitializeContextHttp shares the transport tuned by noonhttp.Initialize, which tunes http.DefaultTransport in place,
+// so it has to be called after noonhttp.Initialize
+func InitializeContextHttp(timeout time.Duration) {
+	CtxClient = &ContextClient{client: &http.Client{Transport: http.DefaultTransport, Timeout: timeout}}
+}
+
+// ServePost serves POST request, errors match the ones returned by noonhttp
+func (c *ContextClient) ServePost(ctx context.Context, endpoint string, headers map[string]string, payload map[string]interface{}) ([]byte, error) {
+	reqBody, err := json.Marshal(payload)
+	if err != nil {
+		return nil, err
+	}
+	request, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, bytes.NewBuffer(reqBody))
+	if err != nil {
+		return nil, err
+	}
+	populateHeaders(request, headers)
+	return c.do(request)
+}
+
+// ServeGet serves GET request, errors match the ones returned by noonhttp
+func (c *ContextClient) ServeGet(ctx context.Context, endpoint string, headers map[string]string, queryParams map[string]string) ([]byte, error) {
+	request, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint, nil)
+	if err != nil {
+		return nil, err
+	}
+	populateHeaders(request, headers)
+	q := request.URL.Query()
+	for k, v := range queryParams {
+		q.Add(k, v)
+	}
+	request.URL.RawQuery = q.Encode()
+	return c.do(request)
+}
+
+func (c *ContextClient) do(request *http.Request) ([]byte, error) {
+	resp, err := c.client.Do(request)
+	if err != nil {
+		return nil, &noonhttp.ServerError{StatusCode: 500, Message: "client return status : " + err.Error()}
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 400 {
+		return ioutil.ReadAll(resp.Body)
+	}
+	if _, err = io.Copy(ioutil.Discard, resp.Body); err != nil {
+		return nil, err
+	}
+	if resp.StatusCode < 500 {
+		return nil, &noonhttp.ClientError{StatusCode: resp.StatusCode, Message: "client return Status : " + resp.Status}
+	}
+	return nil, &noonhttp.ServerError{StatusCode: resp.StatusCode, Message: "client return Status : " + resp.Status}
+}
+
+func populateHeaders(request *http.Request, headers map[string]string) {
+	for key, value := range headers {
+		request.Header.Add(key, value)
+	}
+}
+
+// PostBytes posts body as it is and returns the status of the response, 0 when the request failed before a response
+// was received. The response body is discarded.
+func (c *ContextClient) PostBytes(ctx context.Context, endpoint string, headers map[string]string, body []byte) (int, error) {
+	request, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, bytes.NewBuffer(body))
+	if err != nil {
+		return 0, err
+	}
+	populateHeaders(request, headers)
+	resp, err := c.client.Do(request)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(ioutil.Discard, resp.Body)
+	return resp.StatusCode, nil
+}
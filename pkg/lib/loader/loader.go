@@ -0,0 +1,127 @@
+package loader
+
+import (
+	noonerror "bitbucket.org/noon-micro/curriculum/pkg/lib/error"
+	"bitbucket.org/noon-micro/curriculum/pkg/lib/logger"
+	"context"
+	"sync"
+	"time"
+)
+
+// BatchFunc fetches all keys in one round trip. Keys absent from the returned map resolve to nil.
+type BatchFunc func(ctx context.Context, keys []string) (map[string]interface{}, error)
+
+// Loader deduplicates keys and collects concurrent loads issued within wait into a single BatchFunc call.
+// A Loader is meant to live for one request only, results are never invalidated.
+type Loader struct {
+	ctx      context.Context
+	fetch    BatchFunc
+	wait     time.Duration
+	maxBatch int
+	mu       sync.Mutex
+	cache    map[string]*thunk
+	pending  *batch
+}
+
+type thunk struct {
+	done  chan struct{}
+	value interface{}
+	err   error
+}
+
+type batch struct {
+	keys   []string
+	thunks []*thunk
+}
+
+// New returns a loader which waits up to wait before dispatching and never sends more than maxBatch keys at once.
+// Every batch runs under ctx, which is the context of the request owning the loader.
+func New(ctx context.Context, fetch BatchFunc, wait time.Duration, maxBatch int) *Loader {
+	return &Loader{ctx: ctx, fetch: fetch, wait: wait, maxBatch: maxBatch, cache: make(map[string]*thunk)}
+}
+
+// Load returns the value for key, joining any batch which is already pending
+func (l *Loader) Load(key string) (interface{}, error) {
+	th := l.enqueue(key)
+	<-th.done
+	return th.value, th.err
+}
+
+// LoadMany enqueues all keys before waiting so that they travel in the same batch
+func (l *Loader) LoadMany(keys []string) (values []interface{}, err error) {
+	thunks := make([]*thunk, len(keys))
+	for i, key := range keys {
+		thunks[i] = l.enqueue(key)
+	}
+	values = make([]interface{}, len(keys))
+	for i, th := range thunks {
+		<-th.done
+		if th.err != nil {
+			return nil, th.err
+		}
+		values[i] = th.value
+	}
+	return values, nil
+}
+
+func (l *Loader) enqueue(key string) *thunk {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if th, ok := l.cache[key]; ok {
+		return th
+	}
+	th := &thunk{done: make(chan struct{})}
+	l.cache[key] = th
+	if l.pending == nil {
+		b := &batch{}
+		l.pending = b
+		time.AfterFunc(l.wait, func() {
+			l.mu.Lock()
+			if l.pending != b {
+				l.mu.Unlock()
+				return
+			}
+			l.pending = nil
+			l.mu.Unlock()
+			l.dispatch(b)
+		})
+	}
+	l.pending.keys = append(l.pending.keys, key)
+	l.pending.thunks = append(l.pending.thunks, th)
+	if l.maxBatch > 0 && len(l.pending.keys) >= l.maxBatch {
+		b := l.pending
+		l.pending = nil
+		go l.dispatch(b)
+	}
+	return th
+}
+
+func (l *Loader) dispatch(b *batch) {
+	var values map[string]interface{}
+	var err error
+	func() {
+		defer func() {
+			if r := recover(); r != nil {
+				logger.Client.Error("loaderBatchPanicked", logger.GetErrorStack())
+				err = noonerror.New(noonerror.ErrInternalServer, "loaderBatchPanicked")
+			}
+		}()
+		values, err = l.fetch(l.ctx, b.keys)
+	}()
+	if err != nil {
+		// failed keys are dropped so that a later load in the same request can retry
+		l.mu.Lock()
+		for _, key := range b.keys {
+			delete(l.cache, key)
+		}
+		l.mu.Unlock()
+	}
+	for i, th := range b.thunks {
+		if err != nil {
+			th.err = err
+		} else {
+			th.value = values[b.keys[i]]
+		}
+		close(th.done)
+	}
+}
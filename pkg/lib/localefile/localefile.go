@@ -0,0 +1,168 @@
+package localefile
+
+import (
+	"bitbucket.org/noon-micro/curriculum/pkg/domain"
+	"encoding/csv"
+	"encoding/xml"
+	"errors"
+	"io"
+	"strings"
+)
+
+const (
+	FormatCsv   = "csv"
+	FormatXliff = "xliff"
+
+	xliffVersion   = "1.2"
+	xliffNamespace = "urn:oasis:names:tc:xliff:document:1.2"
+	xliffOriginal  = "curriculum"
+)
+
+var csvHeader = []string{"tag_id", "tag_type", "source", "target"}
+
+var (
+	ErrFormatInvalid = errors.New("localeFileFormatInvalid")
+	ErrHeaderInvalid = errors.New("localeFileHeaderInvalid")
+	ErrXliffInvalid  = errors.New("localeFileXliffInvalid")
+)
+
+type xliffDocument struct {
+	XMLName xml.Name    `xml:"xliff"`
+	Version string      `xml:"version,attr"`
+	Xmlns   string      `xml:"xmlns,attr,omitempty"`
+	Files   []xliffFile `xml:"file"`
+}
+
+type xliffFile struct {
+	Original       string      `xml:"original,attr"`
+	SourceLanguage string      `xml:"source-language,attr"`
+	TargetLanguage string      `xml:"target-language,attr,omitempty"`
+	Datatype       string      `xml:"datatype,attr"`
+	Units          []xliffUnit `xml:"body>trans-unit"`
+}
+
+type xliffUnit struct {
+	ID      string  `xml:"id,attr"`
+	Resname string  `xml:"resname,attr,omitempty"`
+	Source  string  `xml:"source"`
+	Target  *string `xml:"target"`
+}
+
+// ContentType of the file written for format
+func ContentType(format string) string {
+	if format == FormatXliff {
+		return "application/x-xliff+xml; charset=utf-8"
+	}
+	return "text/csv; charset=utf-8"
+}
+
+// Write encodes units as csv or as an xliff 1.2 document translating sourceLocale into targetLocale
+func Write(w io.Writer, format string, sourceLocale string, targetLocale string, units []*domain.TranslationUnit) error {
+	switch format {
+	case FormatCsv:
+		return writeCsv(w, units)
+	case FormatXliff:
+		return writeXliff(w, sourceLocale, targetLocale, units)
+	}
+	return ErrFormatInvalid
+}
+
+// Read decodes a file written by Write, for xliff the target language of the file is returned as locale
+func Read(r io.Reader, format string) (units []*domain.TranslationUnit, locale string, err error) {
+	switch format {
+	case FormatCsv:
+		units, err = readCsv(r)
+		return units, "", err
+	case FormatXliff:
+		return readXliff(r)
+	}
+	return nil, "", ErrFormatInvalid
+}
+
+func writeCsv(w io.Writer, units []*domain.TranslationUnit) error {
+	writer := csv.NewWriter(w)
+	if err := writer.Write(csvHeader); err != nil {
+		return err
+	}
+	for _, v := range units {
+		if err := writer.Write([]string{value(v.TagID), value(v.TagType), value(v.Source), value(v.Target)}); err != nil {
+			return err
+		}
+	}
+	writer.Flush()
+	return writer.Error()
+}
+
+func readCsv(r io.Reader) (units []*domain.TranslationUnit, err error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = len(csvHeader)
+	header, err := reader.Read()
+	if err != nil {
+		return nil, ErrHeaderInvalid
+	}
+	for i, column := range csvHeader {
+		if strings.TrimSpace(strings.TrimPrefix(header[i], "\ufeff")) != column {
+			return nil, ErrHeaderInvalid
+		}
+	}
+	for line := 2; ; line++ {
+		record, err := reader.Read()
+		if err == io.EOF {
+			return units, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		units = append(units, &domain.TranslationUnit{TagID: pointer(record[0]), TagType: pointer(record[1]),
+			Source: pointer(record[2]), Target: pointer(record[3]), Line: line})
+	}
+}
+
+func writeXliff(w io.Writer, sourceLocale string, targetLocale string, units []*domain.TranslationUnit) error {
+	file := xliffFile{Original: xliffOriginal, SourceLanguage: sourceLocale, TargetLanguage: targetLocale, Datatype: "plaintext"}
+	for _, v := range units {
+		file.Units = append(file.Units, xliffUnit{ID: value(v.TagID), Resname: value(v.TagType), Source: value(v.Source), Target: v.Target})
+	}
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return err
+	}
+	encoder := xml.NewEncoder(w)
+	encoder.Indent("", "  ")
+	return encoder.Encode(xliffDocument{Version: xliffVersion, Xmlns: xliffNamespace, Files: []xliffFile{file}})
+}
+
+func readXliff(r io.Reader) (units []*domain.TranslationUnit, locale string, err error) {
+	var document xliffDocument
+	if err = xml.NewDecoder(r).Decode(&document); err != nil || document.Version != xliffVersion {
+		return nil, "", ErrXliffInvalid
+	}
+	line := 0
+	for _, file := range document.Files {
+		if locale == "" {
+			locale = file.TargetLanguage
+		} else if file.TargetLanguage != "" && file.TargetLanguage != locale {
+			return nil, "", ErrXliffInvalid
+		}
+		for _, v := range file.Units {
+			line++
+			unit := &domain.TranslationUnit{TagID: pointer(v.ID), TagType: pointer(v.Resname), Source: pointer(v.Source), Line: line}
+			if v.Target != nil {
+				unit.Target = pointer(*v.Target)
+			}
+			units = append(units, unit)
+		}
+	}
+	return units, locale, nil
+}
+
+func value(s *string) string {
+	if s == nil {
+		return ""
+	}
+	return *s
+}
+
+func pointer(s string) *string {
+	s = strings.TrimSpace(s)
+	return &s
+}
@@ -0,0 +1,120 @@
+package localefile
+
+import (
+	"bitbucket.org/noon-micro/curriculum/pkg/domain"
+	"bytes"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func testUnits() []*domain.TranslationUnit {
+	return []*domain.TranslationUnit{
+		{TagID: pointer("50"), TagType: pointer("chapter"), Source: pointer("Numbers"), Target: pointer("الأعداد")},
+		{TagID: pointer("52"), TagType: pointer("chapter"), Source: pointer("Géométrie & Mesure, \"2D\""), Target: pointer("")},
+	}
+}
+
+func TestRoundTrip(t *testing.T) {
+	for _, format := range []string{FormatCsv, FormatXliff} {
+		var buf bytes.Buffer
+		if err := Write(&buf, format, "en", "ar", testUnits()); err != nil {
+			t.Fatalf("%s: write: %v", format, err)
+		}
+		units, locale, err := Read(&buf, format)
+		if err != nil {
+			t.Fatalf("%s: read: %v", format, err)
+		}
+		if format == FormatXliff && locale != "ar" {
+			t.Errorf("%s: locale %q, want ar", format, locale)
+		}
+		want := testUnits()
+		if len(units) != len(want) {
+			t.Fatalf("%s: read %d units, want %d", format, len(units), len(want))
+		}
+		for i, v := range units {
+			if v.Line == 0 {
+				t.Errorf("%s: unit %d has no line", format, i)
+			}
+			v.Line = 0
+			if !reflect.DeepEqual(v, want[i]) {
+				t.Errorf("%s: unit %d is %+v, want %+v", format, i, *v, *want[i])
+			}
+		}
+	}
+}
+
+func TestReadCsv(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		units int
+		err   error
+	}{
+		{name: "bom prefixed header", input: "\ufefftag_id,tag_type,source,target\n50,chapter,Numbers,الأعداد\n", units: 1},
+		{name: "padded header", input: " tag_id , tag_type,source,target\n50,chapter,Numbers,\n", units: 1},
+		{name: "header only", input: "tag_id,tag_type,source,target\n"},
+		{name: "empty file", input: "", err: ErrHeaderInvalid},
+		{name: "renamed column", input: "id,tag_type,source,target\n", err: ErrHeaderInvalid},
+	}
+	for _, test := range tests {
+		units, _, err := Read(strings.NewReader(test.input), FormatCsv)
+		if err != test.err {
+			t.Errorf("%s: err %v, want %v", test.name, err, test.err)
+		}
+		if len(units) != test.units {
+			t.Errorf("%s: read %d units, want %d", test.name, len(units), test.units)
+		}
+	}
+}
+
+func TestReadCsvLines(t *testing.T) {
+	units, _, err := Read(strings.NewReader("tag_id,tag_type,source,target\n50,chapter,Numbers,a\n51,topic,Counting to ten,b\n"), FormatCsv)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if units[0].Line != 2 || units[1].Line != 3 {
+		t.Errorf("lines %d and %d, want 2 and 3", units[0].Line, units[1].Line)
+	}
+}
+
+func TestReadXliff(t *testing.T) {
+	document := func(files string) string {
+		return `<?xml version="1.0" encoding="UTF-8"?><xliff version="1.2" xmlns="urn:oasis:names:tc:xliff:document:1.2">` + files + `</xliff>`
+	}
+	file := func(target string, id string) string {
+		return `<file original="curriculum" source-language="en" target-language="` + target + `" datatype="plaintext"><body>` +
+			`<trans-unit id="` + id + `" resname="chapter"><source>Numbers</source><target>x</target></trans-unit></body></file>`
+	}
+	tests := []struct {
+		name   string
+		input  string
+		units  int
+		locale string
+		err    error
+	}{
+		{name: "one file", input: document(file("ar", "50")), units: 1, locale: "ar"},
+		{name: "same target languages", input: document(file("ar", "50") + file("ar", "52")), units: 2, locale: "ar"},
+		{name: "mixed target languages", input: document(file("ar", "50") + file("fr", "52")), err: ErrXliffInvalid},
+		{name: "wrong version", input: strings.Replace(document(file("ar", "50")), `version="1.2"`, `version="2.0"`, 1), err: ErrXliffInvalid},
+		{name: "not xml", input: "tag_id,tag_type,source,target", err: ErrXliffInvalid},
+	}
+	for _, test := range tests {
+		units, locale, err := Read(strings.NewReader(test.input), FormatXliff)
+		if err != test.err {
+			t.Errorf("%s: err %v, want %v", test.name, err, test.err)
+		}
+		if len(units) != test.units || locale != test.locale {
+			t.Errorf("%s: read %d units in %q, want %d in %q", test.name, len(units), locale, test.units, test.locale)
+		}
+	}
+}
+
+func TestFormatInvalid(t *testing.T) {
+	if err := Write(&bytes.Buffer{}, "json", "en", "ar", testUnits()); err != ErrFormatInvalid {
+		t.Errorf("write err %v, want %v", err, ErrFormatInvalid)
+	}
+	if _, _, err := Read(strings.NewReader(""), "json"); err != ErrFormatInvalid {
+		t.Errorf("read err %v, want %v", err, ErrFormatInvalid)
+	}
+}
@@ -0,0 +1,105 @@
+package middleware
+
+import (
+	"bitbucket.org/noon-micro/curriculum/pkg/lib/helper"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// CacheGenerations returns the generations of the cached rows the listings are built from, they grow with every
+// committed change of a tag, an order or a locale
+type CacheGenerations func(ctx context.Context) (string, error)
+
+var listingMaxAge time.Duration
+
+var fetchCacheGenerations CacheGenerations
+
+// InitializeConditionalGet sets how long clients may reuse a listing before revalidating it and where the ETags are
+// derived from
+func InitializeConditionalGet(maxAge time.Duration, generations CacheGenerations) {
+	listingMaxAge = maxAge
+	fetchCacheGenerations = generations
+}
+
+// ConditionalGetMiddleware tags listings with an ETag derived from the cache generations and the request, and answers
+// 304 without building the listing when the client already holds it. The listing depends on the negotiated locale
+// and country and on the teacher asking, hence the Vary header. A listing is served without an ETag when the
+// generations cannot be read.
+func ConditionalGetMiddleware(next http.HandlerFunc) http.HandlerFunc {
+	return conditionalGet(next, false)
+}
+
+// ConditionalGetByClientMiddleware is ConditionalGetMiddleware for the listings which also depend on the country the
+// client ip is located in, the ETag changes with the client ip
+func ConditionalGetByClientMiddleware(next http.HandlerFunc) http.HandlerFunc {
+	return conditionalGet(next, true)
+}
+
+func conditionalGet(next http.HandlerFunc, byClient bool) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if fetchCacheGenerations == nil {
+			next.ServeHTTP(w, r)
+			return
+		}
+		generations, err := fetchCacheGenerations(r.Context())
+		if err != nil {
+			next.ServeHTTP(w, r)
+			return
+		}
+		etag := listingETag(generations, r, byClient)
+		w.Header().Add("Vary", "Accept-Language, Locale, Country, userId")
+		w.Header().Set("ETag", etag)
+		w.Header().Set("Cache-Control", "private, max-age="+strconv.Itoa(int(listingMaxAge/time.Second)))
+		if etagMatches(r.Header.Get("If-None-Match"), etag) {
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		next.ServeHTTP(&conditionalResponseWriter{ResponseWriter: w}, r)
+	}
+}
+
+// listingETag hashes the generations with everything the handler reads from the request
+func listingETag(generations string, r *http.Request, byClient bool) string {
+	parts := []string{generations, r.URL.RequestURI(), r.Header.Get("locale"), r.Header.Get("country"), r.Header.Get("userId")}
+	if byClient {
+		parts = append(parts, helper.ClientIp(r))
+	}
+	sum := sha256.Sum256([]byte(strings.Join(parts, "\n")))
+	return `"` + hex.EncodeToString(sum[:16]) + `"`
+}
+
+// etagMatches compares the If-None-Match list weakly, as RFC 7232 asks for GET
+func etagMatches(ifNoneMatch string, etag string) bool {
+	for _, v := range strings.Split(ifNoneMatch, ",") {
+		v = strings.TrimPrefix(strings.TrimSpace(v), "W/")
+		if v == "*" || v == etag {
+			return true
+		}
+	}
+	return false
+}
+
+// conditionalResponseWriter drops the ETag of an error, which must not be revalidated into a 304
+type conditionalResponseWriter struct {
+	http.ResponseWriter
+	wroteHeader bool
+}
+
+func (w *conditionalResponseWriter) WriteHeader(status int) {
+	if !w.wroteHeader && status != http.StatusOK {
+		w.Header().Del("ETag")
+		w.Header().Del("Cache-Control")
+	}
+	w.wroteHeader = true
+	w.ResponseWriter.WriteHeader(status)
+}
+
+func (w *conditionalResponseWriter) Write(data []byte) (int, error) {
+	w.wroteHeader = true
+	return w.ResponseWriter.Write(data)
+}
@@ -0,0 +1,107 @@
+package middleware
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestConditionalGetMiddleware(t *testing.T) {
+	generations := "1:1:1"
+	var generationsErr error
+	InitializeConditionalGet(time.Minute, func(ctx context.Context) (string, error) {
+		return generations, generationsErr
+	})
+	defer InitializeConditionalGet(0, nil)
+	built := 0
+	status := http.StatusOK
+	handler := ConditionalGetMiddleware(func(w http.ResponseWriter, r *http.Request) {
+		built++
+		w.WriteHeader(status)
+		_, _ = w.Write([]byte(`{"data":[]}`))
+	})
+	serve := func(locale string, ifNoneMatch string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, "/student/grades?country_id=9", nil)
+		req.Header.Set("locale", locale)
+		req.Header.Set("If-None-Match", ifNoneMatch)
+		rec := httptest.NewRecorder()
+		handler(rec, req)
+		return rec
+	}
+
+	rec := serve("ar", "")
+	etag := rec.Header().Get("ETag")
+	if rec.Code != http.StatusOK || etag == "" || rec.Body.String() != `{"data":[]}` {
+		t.Fatalf("first request served %d with ETag %q", rec.Code, etag)
+	}
+	if vary := rec.Header().Get("Vary"); !strings.Contains(vary, "Locale") || !strings.Contains(vary, "Country") || !strings.Contains(vary, "userId") {
+		t.Errorf("Vary is %q", vary)
+	}
+	if cacheControl := rec.Header().Get("Cache-Control"); cacheControl != "private, max-age=60" {
+		t.Errorf("Cache-Control is %q", cacheControl)
+	}
+
+	rec = serve("ar", `"other", W/`+etag)
+	if rec.Code != http.StatusNotModified || rec.Body.Len() != 0 || rec.Header().Get("ETag") != etag {
+		t.Errorf("matching If-None-Match served %d with ETag %q", rec.Code, rec.Header().Get("ETag"))
+	}
+	if rec.Header().Get("Vary") == "" {
+		t.Errorf("304 was sent without Vary")
+	}
+	if built != 1 {
+		t.Errorf("listing was built %d times, want once", built)
+	}
+
+	if rec = serve("en", etag); rec.Code != http.StatusOK || rec.Header().Get("ETag") == etag {
+		t.Errorf("another locale served %d with the same ETag", rec.Code)
+	}
+
+	generations = "1:2:1"
+	rec = serve("ar", etag)
+	if rec.Code != http.StatusOK || rec.Header().Get("ETag") == etag {
+		t.Errorf("after a change served %d with ETag %q", rec.Code, rec.Header().Get("ETag"))
+	}
+
+	status = http.StatusBadRequest
+	if rec = serve("ar", ""); rec.Code != http.StatusBadRequest || rec.Header().Get("ETag") != "" {
+		t.Errorf("error served %d with ETag %q", rec.Code, rec.Header().Get("ETag"))
+	}
+
+	status = http.StatusOK
+	generationsErr = errors.New("redis down")
+	if rec = serve("ar", rec.Header().Get("ETag")); rec.Code != http.StatusOK || rec.Header().Get("ETag") != "" {
+		t.Errorf("without generations served %d with ETag %q", rec.Code, rec.Header().Get("ETag"))
+	}
+}
+
+func TestConditionalGetByClientMiddleware(t *testing.T) {
+	InitializeConditionalGet(time.Minute, func(ctx context.Context) (string, error) {
+		return "1:1:1", nil
+	})
+	defer InitializeConditionalGet(0, nil)
+	listing := func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"data":[]}`))
+	}
+	etag := func(handler http.HandlerFunc, remoteAddr string) string {
+		req := httptest.NewRequest(http.MethodGet, "/student/countries", nil)
+		req.RemoteAddr = remoteAddr
+		rec := httptest.NewRecorder()
+		handler(rec, req)
+		return rec.Header().Get("ETag")
+	}
+	byClient := ConditionalGetByClientMiddleware(listing)
+	if etag(byClient, "5.62.61.1:4431") == etag(byClient, "94.200.1.1:4431") {
+		t.Errorf("clients in two countries got the same ETag")
+	}
+	if etag(byClient, "5.62.61.1:4431") != etag(byClient, "5.62.61.1:5120") {
+		t.Errorf("the ETag of a client moved with its port")
+	}
+	plain := ConditionalGetMiddleware(listing)
+	if etag(plain, "5.62.61.1:4431") != etag(plain, "94.200.1.1:4431") {
+		t.Errorf("a listing not depending on the client got an ETag per client")
+	}
+}
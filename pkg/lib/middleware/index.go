@@ -3,8 +3,10 @@ package middleware
 import (
 	"bitbucket.org/noon-go/auth"
 	"bitbucket.org/noon-go/translator"
+	"bitbucket.org/noon-micro/curriculum/pkg/domain"
 	"bitbucket.org/noon-micro/curriculum/pkg/entity"
 	loggers "bitbucket.org/noon-micro/curriculum/pkg/lib/logger"
+	"context"
 	"encoding/json"
 	"github.com/sirupsen/logrus"
 	"log"
@@ -14,17 +16,30 @@ import (
 
 type middleware func(http.HandlerFunc) http.HandlerFunc
 
-var noonAuthEntity *auth.AuthenticateEntity
+// Authenticator validates the caller of a request, *auth.AuthenticateEntity calls bifrost
+type Authenticator interface {
+	Process(roles string, headers http.Header) (*auth.Success, error)
+}
+
+var noonAuthEntity Authenticator
+
+var newTagLoader func(context.Context) domain.TagLoader
 
 // InitializeMiddleware sets http client for middleware
-func InitializeMiddleware(authEntity *auth.AuthenticateEntity) {
+func InitializeMiddleware(authEntity Authenticator) {
 	noonAuthEntity = authEntity
 }
 
+// InitializeLoader sets the factory used to attach a tag loader to every request
+func InitializeLoader(factory func(context.Context) domain.TagLoader) {
+	newTagLoader = factory
+}
+
 // AuthWrapMiddleware wraps and applies multiple middleware and auth middleware
 func AuthWrapMiddleware(next http.HandlerFunc, roles string) http.HandlerFunc {
 	applyMiddleware := []middleware{
 		recoverHandler,
+		loaderMiddleware,
 	}
 	wrapped := authMiddleware(next, roles)
 
@@ -40,6 +55,7 @@ func AuthWrapMiddleware(next http.HandlerFunc, roles string) http.HandlerFunc {
 func UnAuthWrapMiddleware(next http.HandlerFunc) http.HandlerFunc {
 	applyMiddleware := []middleware{
 		recoverHandler,
+		loaderMiddleware,
 	}
 	wrapped := next
 
@@ -71,6 +87,22 @@ func recoverHandler(next http.HandlerFunc) http.HandlerFunc {
 	}
 }
 
+// loaderMiddleware attaches a fresh tag loader to the request context
+func loaderMiddleware(next http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		r = r.WithContext(WithLoader(r.Context()))
+		next.ServeHTTP(w, r)
+	}
+}
+
+// WithLoader attaches a fresh tag loader to ctx, for calls which do not come through the http middleware
+func WithLoader(ctx context.Context) context.Context {
+	if newTagLoader == nil {
+		return ctx
+	}
+	return domain.WithTagLoader(ctx, newTagLoader(ctx))
+}
+
 // authMiddleware calls auth module and process request
 func authMiddleware(next http.HandlerFunc, roles string) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
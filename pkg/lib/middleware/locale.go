@@ -0,0 +1,133 @@
+package middleware
+
+import (
+	"bitbucket.org/noon-micro/curriculum/pkg/lib/helper"
+	"context"
+	"golang.org/x/text/language"
+	"net/http"
+	"strings"
+)
+
+// LocaleResolver returns the allowed locales of a country and the locale served when none of them is asked for,
+// an unknown country has no allowed locales
+type LocaleResolver func(ctx context.Context, countryId string) (allowed []string, defaultLocale string, err error)
+
+var resolveCountryLocales LocaleResolver
+
+var defaultLocale string
+
+type requestedLocaleKey struct{}
+
+type fallbackLocaleKey struct{}
+
+// InitializeLocaleResolver sets the lookup of the allowed locales of a country and the locale served when
+// neither the request nor the country settle it
+func InitializeLocaleResolver(resolver LocaleResolver, fallback string) {
+	resolveCountryLocales = resolver
+	defaultLocale = fallback
+}
+
+// LocaleMiddleware replaces the locale header with the negotiated locale so that handlers keep reading it from
+// there, the country is taken from the country_id parameter or the country header. fallback replaces the default
+// locale of InitializeLocaleResolver for the route when it is not empty. Only routes serving localized names are
+// wrapped, as negotiating reads the country.
+func LocaleMiddleware(next http.HandlerFunc, fallback string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		countryId := r.URL.Query().Get("country_id")
+		if countryId == "" {
+			countryId = r.Header.Get("country")
+		}
+		ctx := context.WithValue(r.Context(), requestedLocaleKey{}, r.Header.Get("locale"))
+		if fallback != "" {
+			ctx = context.WithValue(ctx, fallbackLocaleKey{}, fallback)
+		}
+		r = r.WithContext(ctx)
+		r.Header.Set("locale", *NegotiateLocale(w, r, &countryId, nil))
+		next.ServeHTTP(w, r)
+	}
+}
+
+// NegotiateLocale picks the locale served for the country, trying the explicit locale, or else the locale header
+// the request came with, and then the Accept-Language preferences by quality. Only the allowed locales of the
+// country are served, any well formed locale is accepted for requests without a known country. The result is
+// echoed in the Content-Language header.
+func NegotiateLocale(w http.ResponseWriter, r *http.Request, countryId *string, explicit *string) *string {
+	requested, _ := r.Context().Value(requestedLocaleKey{}).(string)
+	locale := ResolveLocale(r.Context(), countryId, explicit, requested, r.Header.Get("Accept-Language"))
+	w.Header().Set("Content-Language", *locale)
+	return locale
+}
+
+// ResolveLocale negotiates the locale like NegotiateLocale for callers which do not come through http, requested
+// and acceptLanguage stand for the locale and Accept-Language headers
+func ResolveLocale(ctx context.Context, countryId *string, explicit *string, requested string, acceptLanguage string) *string {
+	var allowed []string
+	fallback, _ := ctx.Value(fallbackLocaleKey{}).(string)
+	if fallback == "" {
+		fallback = defaultLocale
+	}
+	if resolveCountryLocales != nil && countryId != nil && *countryId != "" {
+		countryLocales, countryDefault, err := resolveCountryLocales(ctx, *countryId)
+		if err == nil {
+			allowed = countryLocales
+			if countryDefault != "" {
+				fallback = countryDefault
+			}
+		}
+	}
+	var candidates []string
+	if explicit != nil && strings.TrimSpace(*explicit) != "" {
+		candidates = append(candidates, strings.TrimSpace(*explicit))
+	} else if strings.TrimSpace(requested) != "" {
+		candidates = append(candidates, strings.TrimSpace(requested))
+	}
+	tags, quality, err := language.ParseAcceptLanguage(acceptLanguage)
+	if err == nil {
+		for i, tag := range tags {
+			if quality[i] > 0 && tag != language.Und {
+				candidates = append(candidates, tag.String())
+			}
+		}
+	}
+	locale := helper.NormalizeLocale(fallback)
+	for _, candidate := range candidates {
+		if matched, ok := matchLocale(candidate, allowed); ok {
+			locale = matched
+			break
+		}
+	}
+	return &locale
+}
+
+// matchLocale returns the allowed locale matching candidate exactly or by language, e.g. ar-SA is served as ar
+// when only ar is allowed. The match is normalized like the stored locales whatever the case of the country
+// settings or the request.
+func matchLocale(candidate string, allowed []string) (string, bool) {
+	tag, err := language.Parse(candidate)
+	if err != nil {
+		return "", false
+	}
+	normalized := helper.NormalizeLocale(tag.String())
+	if len(allowed) == 0 {
+		return normalized, true
+	}
+	for _, v := range allowed {
+		if helper.NormalizeLocale(v) == normalized {
+			return normalized, true
+		}
+	}
+	base := localeLanguage(normalized)
+	for _, v := range allowed {
+		if localeLanguage(helper.NormalizeLocale(v)) == base {
+			return helper.NormalizeLocale(v), true
+		}
+	}
+	return "", false
+}
+
+func localeLanguage(locale string) string {
+	if i := strings.Index(locale, "-"); i > 0 {
+		return locale[:i]
+	}
+	return locale
+}
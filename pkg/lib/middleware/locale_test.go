@@ -0,0 +1,51 @@
+package middleware
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestLocaleMiddlewareFallback(t *testing.T) {
+	InitializeLocaleResolver(func(ctx context.Context, countryId string) ([]string, string, error) {
+		if countryId == "9" {
+			return []string{"en", "ar"}, "ar", nil
+		}
+		if countryId == "10" {
+			return []string{"en-US", "AR"}, "AR", nil
+		}
+		return nil, "", nil
+	}, "en")
+	defer InitializeLocaleResolver(nil, "")
+	tests := []struct {
+		name     string
+		fallback string
+		country  string
+		locale   string
+		want     string
+	}{
+		{name: "route fallback", fallback: "ar", want: "ar"},
+		{name: "default fallback", want: "en"},
+		{name: "country default", fallback: "en", country: "9", want: "ar"},
+		{name: "requested locale", fallback: "ar", country: "9", locale: "en-US", want: "en"},
+		{name: "locale not allowed", fallback: "en", country: "9", locale: "fr", want: "ar"},
+		{name: "mixed case request", fallback: "ar", locale: "pt_BR", want: "pt-br"},
+		{name: "mixed case allowed locale", fallback: "ar", country: "10", locale: "EN-us", want: "en-us"},
+		{name: "allowed locale by language", fallback: "en", country: "10", locale: "ar-SA", want: "ar"},
+		{name: "mixed case country default", fallback: "en", country: "10", locale: "fr", want: "ar"},
+	}
+	for _, test := range tests {
+		var served string
+		handler := LocaleMiddleware(func(w http.ResponseWriter, r *http.Request) {
+			served = r.Header.Get("locale")
+		}, test.fallback)
+		req := httptest.NewRequest(http.MethodGet, "/student/grades?country_id="+test.country, nil)
+		req.Header.Set("locale", test.locale)
+		rec := httptest.NewRecorder()
+		handler(rec, req)
+		if served != test.want || rec.Header().Get("Content-Language") != test.want {
+			t.Errorf("%s: served %q with Content-Language %q, want %q", test.name, served, rec.Header().Get("Content-Language"), test.want)
+		}
+	}
+}
@@ -0,0 +1,23 @@
+package middleware
+
+import (
+	"bitbucket.org/noon-go/auth"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+// MemoryAuthenticator stands in for bifrost in the memory environment, the bearer token is taken as the user id
+type MemoryAuthenticator struct{}
+
+func (MemoryAuthenticator) Process(roles string, headers http.Header) (*auth.Success, error) {
+	tokens := strings.Split(headers.Get("Authorization"), " ")
+	if len(tokens) <= 1 {
+		return nil, &auth.Error{StatusCode: http.StatusUnauthorized, Message: "noPermission"}
+	}
+	userId, err := strconv.Atoi(tokens[1])
+	if err != nil {
+		return nil, &auth.Error{StatusCode: http.StatusUnauthorized, Message: "noPermission"}
+	}
+	return &auth.Success{UserID: userId, Valid: "true"}, nil
+}
@@ -0,0 +1,75 @@
+package searchkey
+
+import (
+	"bitbucket.org/noon-micro/curriculum/pkg/domain"
+	"golang.org/x/text/unicode/norm"
+	"strings"
+	"unicode"
+)
+
+const tatweel = 'ـ'
+
+// LocaleSuffix is appended to the locale of a name to index its search key as a name of its own, the private use
+// subtag keeps it apart from the names served in the locale
+const LocaleSuffix = "-x-search"
+
+// letters folds the arabic letters which are written interchangeably, the hamza carriers are already folded by
+// the decomposition as the hamza is a combining mark
+var letters = map[rune]rune{
+	'ٱ': 'ا', // alef wasla to alef
+	'ى': 'ي', // alef maksura to yeh
+	'ة': 'ه', // teh marbuta to heh
+	'ی': 'ي', // farsi yeh to yeh
+	'ک': 'ك', // keheh to kaf
+}
+
+// Normalize returns the search key of a name. Accents, arabic diacritics, tatweel and the hamza carried by alef,
+// waw and yeh are dropped, alef, yeh and teh marbuta variants are folded, eastern digits become ascii digits and
+// the text is lower cased with punctuation and runs of spaces collapsed to a single space.
+func Normalize(text string) string {
+	var b strings.Builder
+	space := false
+	for _, r := range norm.NFKD.String(text) {
+		switch {
+		case unicode.Is(unicode.Mn, r) || r == tatweel:
+			continue
+		case unicode.IsSpace(r) || unicode.IsPunct(r) || unicode.IsSymbol(r):
+			space = b.Len() > 0
+			continue
+		case r >= '٠' && r <= '٩':
+			r = '0' + r - '٠'
+		case r >= '۰' && r <= '۹':
+			r = '0' + r - '۰'
+		}
+		if folded, ok := letters[r]; ok {
+			r = folded
+		}
+		if space {
+			b.WriteRune(' ')
+			space = false
+		}
+		b.WriteRune(unicode.ToLower(r))
+	}
+	return b.String()
+}
+
+// Names returns a copy of names followed by the search key of every name, so that the search backend matches the
+// normalized text of a query without knowing about search keys. A key is sent even when it equals the name, an
+// update then always replaces the key of the previous name.
+func Names(names []*domain.TagName) []*domain.TagName {
+	var result, keys []*domain.TagName
+	for _, v := range names {
+		if v == nil {
+			continue
+		}
+		name := *v
+		result = append(result, &name)
+		if name.Value == nil || name.Locale == nil || strings.HasSuffix(*name.Locale, LocaleSuffix) {
+			continue
+		}
+		key := Normalize(*name.Value)
+		locale := *name.Locale + LocaleSuffix
+		keys = append(keys, &domain.TagName{Value: &key, Locale: &locale})
+	}
+	return append(result, keys...)
+}
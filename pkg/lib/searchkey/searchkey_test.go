@@ -0,0 +1,60 @@
+package searchkey
+
+import (
+	"bitbucket.org/noon-micro/curriculum/pkg/domain"
+	"testing"
+)
+
+func TestNormalize(t *testing.T) {
+	tests := []struct {
+		name string
+		text string
+		want string
+	}{
+		{name: "alef with hamza above", text: "أحمد", want: "احمد"},
+		{name: "alef with hamza below", text: "إسلام", want: "اسلام"},
+		{name: "alef with madda", text: "آية", want: "ايه"},
+		{name: "alef wasla", text: "ٱلكتاب", want: "الكتاب"},
+		{name: "waw with hamza", text: "مؤمن", want: "مومن"},
+		{name: "yeh with hamza", text: "رئيس", want: "رييس"},
+		{name: "teh marbuta", text: "مدرسة", want: "مدرسه"},
+		{name: "alef maksura", text: "مستوى", want: "مستوي"},
+		{name: "farsi yeh and keheh", text: "کتابی", want: "كتابي"},
+		{name: "tatweel", text: "قـــراءة", want: "قراءه"},
+		{name: "harakat", text: "الهَنْدَسَة", want: "الهندسه"},
+		{name: "shadda and tanween", text: "مُعَلِّمٌ", want: "معلم"},
+		{name: "eastern arabic digits", text: "الصف ٣", want: "الصف 3"},
+		{name: "persian digits", text: "۱۲", want: "12"},
+		{name: "latin accents", text: "Géométrie", want: "geometrie"},
+		{name: "ligature", text: "ﬁnal", want: "final"},
+		{name: "punctuation", text: "Géométrie & Mesure", want: "geometrie mesure"},
+		{name: "runs of spaces", text: "  Grade \t 1\n", want: "grade 1"},
+		{name: "arabic punctuation", text: "علوم، رياضيات؟", want: "علوم رياضيات"},
+		{name: "only punctuation", text: " - ", want: ""},
+		{name: "already normalized", text: "numbers", want: "numbers"},
+	}
+	for _, test := range tests {
+		if got := Normalize(test.text); got != test.want {
+			t.Errorf("%s: Normalize(%q) = %q, want %q", test.name, test.text, got, test.want)
+		}
+	}
+}
+
+func TestNames(t *testing.T) {
+	value, locale := "الأعداد", "ar"
+	key, keyLocale := "x", "ar"+LocaleSuffix
+	names := []*domain.TagName{{Value: &value, Locale: &locale}, nil, {Value: &key, Locale: &keyLocale}}
+	result := Names(names)
+	if len(result) != 3 {
+		t.Fatalf("got %d names, want 3", len(result))
+	}
+	if result[0] == names[0] || *result[0].Value != value {
+		t.Errorf("name was not copied: %+v", result[0])
+	}
+	if *result[1].Value != key || *result[1].Locale != keyLocale {
+		t.Errorf("search key name was not kept as is: %q in %q", *result[1].Value, *result[1].Locale)
+	}
+	if *result[2].Value != "الاعداد" || *result[2].Locale != "ar"+LocaleSuffix {
+		t.Errorf("search key is %q in %q", *result[2].Value, *result[2].Locale)
+	}
+}
@@ -0,0 +1,90 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+)
+
+// driverName backs repository.Db in the memory environment, services keep opening transactions through it
+const driverName = "curriculum-memory"
+
+// journalQuery is the only statement understood by the driver, it records an undo step on the open transaction
+const journalQuery = "journal"
+
+func init() {
+	sql.Register(driverName, memoryDriver{})
+}
+
+type memoryDriver struct{}
+
+func (memoryDriver) Open(string) (driver.Conn, error) {
+	return &memoryConn{}, nil
+}
+
+type memoryConn struct {
+	tx *memoryTx
+}
+
+type memoryTx struct {
+	conn *memoryConn
+	undo []func()
+}
+
+func (c *memoryConn) Prepare(string) (driver.Stmt, error) {
+	return nil, errors.New("memory driver does not prepare statements")
+}
+
+func (c *memoryConn) Close() error {
+	return nil
+}
+
+func (c *memoryConn) Begin() (driver.Tx, error) {
+	c.tx = &memoryTx{conn: c}
+	return c.tx, nil
+}
+
+func (c *memoryConn) Ping(context.Context) error {
+	return nil
+}
+
+// CheckNamedValue lets undo closures through as statement arguments
+func (c *memoryConn) CheckNamedValue(*driver.NamedValue) error {
+	return nil
+}
+
+func (c *memoryConn) ExecContext(_ context.Context, query string, args []driver.NamedValue) (driver.Result, error) {
+	if query != journalQuery || c.tx == nil || len(args) != 1 {
+		return nil, errors.New("memory driver only journals inside a transaction")
+	}
+	undo, ok := args[0].Value.(func())
+	if !ok {
+		return nil, errors.New("memory driver journals undo functions only")
+	}
+	c.tx.undo = append(c.tx.undo, undo)
+	return driver.RowsAffected(0), nil
+}
+
+func (t *memoryTx) Commit() error {
+	t.conn.tx = nil
+	return nil
+}
+
+// Rollback reverts the writes made through the transaction, latest first
+func (t *memoryTx) Rollback() error {
+	for i := len(t.undo) - 1; i >= 0; i-- {
+		t.undo[i]()
+	}
+	t.conn.tx = nil
+	return nil
+}
+
+// journal registers undo on tx so that a rollback reverts the write, writes without a transaction are final
+func journal(ctx context.Context, tx *sql.Tx, undo func()) error {
+	if tx == nil {
+		return nil
+	}
+	_, err := tx.ExecContext(ctx, journalQuery, undo)
+	return err
+}
@@ -0,0 +1,26 @@
+package repository
+
+import (
+	"bitbucket.org/noon-micro/curriculum/pkg/domain"
+	"context"
+)
+
+type GradeProductRepo struct {
+	store *Store
+}
+
+func NewGradeProductRepository(store *Store) *GradeProductRepo {
+	return &GradeProductRepo{store}
+}
+
+func (t *GradeProductRepo) FetchGradesFromProductId(ctx context.Context, productId *string) (gradeProducts []*domain.GradeProduct, err error) {
+	t.store.mu.RLock()
+	defer t.store.mu.RUnlock()
+	for _, v := range t.store.gradeProducts {
+		if equal(v.ProductId, productId) {
+			row := *v
+			gradeProducts = append(gradeProducts, &row)
+		}
+	}
+	return gradeProducts, nil
+}
@@ -0,0 +1,49 @@
+package repository
+
+import (
+	"bitbucket.org/noon-micro/curriculum/pkg/domain"
+	"context"
+)
+
+type LegacyTagMappingRepo struct {
+	store *Store
+}
+
+func NewLegacyTagMappingRepository(store *Store) *LegacyTagMappingRepo {
+	return &LegacyTagMappingRepo{store}
+}
+
+func (t *LegacyTagMappingRepo) FetchTagIdFromLegacyId(ctx context.Context, legacyType *string, id *string) (legacyTagMappings []*domain.LegacyTagMapping, err error) {
+	return t.filter(func(v *domain.LegacyTagMapping) bool {
+		return equal(v.LegacyIdType, legacyType) && equal(v.LegacyId, id)
+	}), nil
+}
+
+func (t *LegacyTagMappingRepo) FetchLegacyIdFromTagId(ctx context.Context, tagId *string) (legacyTagMappings []*domain.LegacyTagMapping, err error) {
+	return t.filter(func(v *domain.LegacyTagMapping) bool {
+		return equal(v.TagID, tagId)
+	}), nil
+}
+
+func (t *LegacyTagMappingRepo) FetchLegacyIdFromTagIds(ctx context.Context, tagIds []*string) (legacyTagMappings []*domain.LegacyTagMapping, err error) {
+	if len(tagIds) == 0 {
+		return
+	}
+	set := idSet(tagIds)
+	return t.filter(func(v *domain.LegacyTagMapping) bool {
+		_, ok := set[*v.TagID]
+		return ok
+	}), nil
+}
+
+func (t *LegacyTagMappingRepo) filter(match func(*domain.LegacyTagMapping) bool) (legacyTagMappings []*domain.LegacyTagMapping) {
+	t.store.mu.RLock()
+	defer t.store.mu.RUnlock()
+	for _, v := range t.store.legacyTagMappings {
+		if match(v) {
+			row := *v
+			legacyTagMappings = append(legacyTagMappings, &row)
+		}
+	}
+	return legacyTagMappings
+}
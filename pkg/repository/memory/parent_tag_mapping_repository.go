@@ -0,0 +1,204 @@
+package repository
+
+import (
+	"bitbucket.org/noon-micro/curriculum/pkg/domain"
+	noonerror "bitbucket.org/noon-micro/curriculum/pkg/lib/error"
+	"context"
+	"database/sql"
+	"strings"
+	"time"
+)
+
+type ParentTagMappingRepo struct {
+	store *Store
+}
+
+func NewParentTagMappingRepository(store *Store) *ParentTagMappingRepo {
+	return &ParentTagMappingRepo{store}
+}
+
+func (t *ParentTagMappingRepo) CreateParentTagMapping(ctx context.Context, tx *sql.Tx, parentTagMapping *domain.ParentTagMapping) (err error) {
+	t.store.mu.Lock()
+	row := *parentTagMapping
+	row.ID = t.store.nextId()
+	t.store.parentTagMappings = append(t.store.parentTagMappings, &row)
+	t.store.parentTagIndex[*row.ID] = &row
+	t.store.mu.Unlock()
+	return journal(ctx, tx, func() {
+		t.store.mu.Lock()
+		defer t.store.mu.Unlock()
+		delete(t.store.parentTagIndex, *row.ID)
+		for i, v := range t.store.parentTagMappings {
+			if v == &row {
+				t.store.parentTagMappings = append(t.store.parentTagMappings[:i], t.store.parentTagMappings[i+1:]...)
+				break
+			}
+		}
+	})
+}
+
+func (t *ParentTagMappingRepo) FetchFilteredParentTagMappings(ctx context.Context, tagType *string, id *string) (parentTagMappings []*domain.ParentTagMapping, err error) {
+	t.store.mu.RLock()
+	defer t.store.mu.RUnlock()
+	tagIds := make(map[string]struct{})
+	for _, v := range t.store.parentTagMappings {
+		if v.Publish && equal(v.TagType, tagType) && equal(v.ParentTagID, id) {
+			tagIds[*v.TagID] = struct{}{}
+		}
+	}
+	for _, v := range t.store.parentTagMappings {
+		if _, ok := tagIds[*v.TagID]; ok {
+			parentTagMappings = append(parentTagMappings, &domain.ParentTagMapping{TagID: v.TagID, ParentTagID: v.ParentTagID, ParentTagType: v.ParentTagType, Hidden: v.Hidden, Publish: v.Publish})
+		}
+	}
+	return parentTagMappings, nil
+}
+
+func (t *ParentTagMappingRepo) FetchByInParentTagMappings(ctx context.Context, ids []*string) (parentTagMappings []*domain.ParentTagMapping, err error) {
+	if len(ids) == 0 {
+		return
+	}
+	set := idSet(ids)
+	return t.filter(func(v *domain.ParentTagMapping) bool {
+		_, ok := set[*v.TagID]
+		return ok && v.Publish
+	}), nil
+}
+
+func (t *ParentTagMappingRepo) FetchParentTagMappings(ctx context.Context, id *string) (parentTagMappings []*domain.ParentTagMapping, err error) {
+	return t.filter(func(v *domain.ParentTagMapping) bool {
+		return equal(v.TagID, id) && v.Publish
+	}), nil
+}
+
+func (t *ParentTagMappingRepo) FetchParentTagMappingByParentTagIdTagId(ctx context.Context, tagId *string, parentTagId *string) (parentTagMappings *domain.ParentTagMapping, err error) {
+	tagsList := t.filter(func(v *domain.ParentTagMapping) bool {
+		return equal(v.TagID, tagId) && equal(v.ParentTagID, parentTagId) && v.Publish
+	})
+	if len(tagsList) == 0 {
+		return
+	}
+	if len(tagsList) > 1 {
+		return nil, noonerror.New(noonerror.ErrInternalServer, "parentTagMappingCountError")
+	}
+	return tagsList[0], nil
+}
+
+func (t *ParentTagMappingRepo) FetchByInParentTagMappingsByParentTagIdTagIds(ctx context.Context, ids []*string, parentTagId *string) (parentTagMappings []*domain.ParentTagMapping, err error) {
+	if len(ids) == 0 {
+		return
+	}
+	set := idSet(ids)
+	return t.filter(func(v *domain.ParentTagMapping) bool {
+		_, ok := set[*v.TagID]
+		return ok && equal(v.ParentTagID, parentTagId) && v.Publish
+	}), nil
+}
+
+func (t *ParentTagMappingRepo) FetchParentTagMappingsByParentTagIds(ctx context.Context, parentTagId *string, tagType *string) (parentTagMappings []*domain.ParentTagMapping, err error) {
+	return t.filter(func(v *domain.ParentTagMapping) bool {
+		return equal(v.ParentTagID, parentTagId) && equal(v.TagType, tagType) && v.Publish
+	}), nil
+}
+
+func (t *ParentTagMappingRepo) FetchTagIdsByParentTagPrefix(ctx context.Context, parentTagId *string) (tagIds []*string, err error) {
+	visited := make(map[string]struct{})
+	for _, v := range t.filter(func(v *domain.ParentTagMapping) bool {
+		return v.Publish && v.ParentTagID != nil && (*v.ParentTagID == *parentTagId || strings.HasPrefix(*v.ParentTagID, *parentTagId+"."))
+	}) {
+		if _, ok := visited[*v.TagID]; ok {
+			continue
+		}
+		visited[*v.TagID] = struct{}{}
+		tagIds = append(tagIds, v.TagID)
+	}
+	return tagIds, nil
+}
+
+func (t *ParentTagMappingRepo) ToggleHideParentTagMapping(ctx context.Context, tx *sql.Tx, hidden bool, id *string) (err error) {
+	return t.update(ctx, tx, id, func(v *domain.ParentTagMapping) {
+		v.Hidden = hidden
+	})
+}
+
+func (t *ParentTagMappingRepo) DeleteParentTagMapping(ctx context.Context, tx *sql.Tx, id *string) (err error) {
+	return t.update(ctx, tx, id, func(v *domain.ParentTagMapping) {
+		v.Publish = false
+	})
+}
+
+func (t *ParentTagMappingRepo) UpdateTagOrder(ctx context.Context, tx *sql.Tx, order *int, id *string) (err error) {
+	return t.update(ctx, tx, id, func(v *domain.ParentTagMapping) {
+		if order == nil {
+			v.Order = nil
+			return
+		}
+		value := *order
+		v.Order = &value
+	})
+}
+
+func (t *ParentTagMappingRepo) FetchTagOrderVersion(ctx context.Context, parentTagIds *string, tagType *string) (version int, err error) {
+	t.store.mu.RLock()
+	defer t.store.mu.RUnlock()
+	return t.store.tagOrderVersions[*parentTagIds+":"+*tagType], nil
+}
+
+func (t *ParentTagMappingRepo) UpdateTagOrderVersion(ctx context.Context, tx *sql.Tx, parentTagIds *string, tagType *string, version *int) (err error) {
+	key := *parentTagIds + ":" + *tagType
+	t.store.mu.Lock()
+	previous := t.store.tagOrderVersions[key]
+	if version != nil && *version != previous {
+		t.store.mu.Unlock()
+		return noonerror.New(noonerror.ErrConflict, "tagOrderVersionConflict")
+	}
+	t.store.tagOrderVersions[key] = previous + 1
+	t.store.mu.Unlock()
+	return journal(ctx, tx, func() {
+		t.store.mu.Lock()
+		defer t.store.mu.Unlock()
+		t.store.tagOrderVersions[key] = previous
+	})
+}
+
+func (t *ParentTagMappingRepo) IsCollegePresent(ctx context.Context, tagType *string, tagId *string) (hasCollege bool, err error) {
+	return len(t.filter(func(v *domain.ParentTagMapping) bool {
+		return equal(v.TagType, tagType) && equal(v.ParentTagID, tagId) && !v.Hidden && v.Publish
+	})) > 0, nil
+}
+
+func (t *ParentTagMappingRepo) filter(match func(*domain.ParentTagMapping) bool) (parentTagMappings []*domain.ParentTagMapping) {
+	t.store.mu.RLock()
+	defer t.store.mu.RUnlock()
+	for _, v := range t.store.parentTagMappings {
+		if match(v) {
+			row := *v
+			parentTagMappings = append(parentTagMappings, &row)
+		}
+	}
+	return parentTagMappings
+}
+
+// update applies change to the row and journals the previous row on tx
+func (t *ParentTagMappingRepo) update(ctx context.Context, tx *sql.Tx, id *string, change func(*domain.ParentTagMapping)) error {
+	t.store.mu.Lock()
+	row, ok := t.store.parentTagIndex[*id]
+	if !ok {
+		t.store.mu.Unlock()
+		return nil
+	}
+	previous := *row
+	change(row)
+	row.UpdatedAt = time.Now()
+	t.store.mu.Unlock()
+	return journal(ctx, tx, func() {
+		t.store.mu.Lock()
+		defer t.store.mu.Unlock()
+		*row = previous
+	})
+}
+
+// equal compares nullable columns the way a sql equality does, null never matches
+func equal(column *string, value *string) bool {
+	return column != nil && value != nil && *column == *value
+}
@@ -0,0 +1,86 @@
+package repository
+
+import (
+	"bitbucket.org/noon-micro/curriculum/pkg/domain"
+	"context"
+	"database/sql"
+	"sort"
+	"time"
+)
+
+type RegionCountryMappingRepo struct {
+	store *Store
+}
+
+func NewRegionCountryMappingRepository(store *Store) *RegionCountryMappingRepo {
+	return &RegionCountryMappingRepo{store}
+}
+
+func (t *RegionCountryMappingRepo) CreateRegionCountryMapping(ctx context.Context, tx *sql.Tx, regionCountryMapping *domain.RegionCountryMapping) (err error) {
+	t.store.mu.Lock()
+	row := *regionCountryMapping
+	row.ID = t.store.nextId()
+	t.store.regionCountries = append(t.store.regionCountries, &row)
+	t.store.mu.Unlock()
+	return journal(ctx, tx, func() {
+		t.store.mu.Lock()
+		defer t.store.mu.Unlock()
+		for i, v := range t.store.regionCountries {
+			if v == &row {
+				t.store.regionCountries = append(t.store.regionCountries[:i], t.store.regionCountries[i+1:]...)
+				break
+			}
+		}
+	})
+}
+
+func (t *RegionCountryMappingRepo) FetchByInRegionCountryMappings(ctx context.Context, ids []*string) (regionCountryMappings []*domain.RegionCountryMapping, err error) {
+	if len(ids) == 0 {
+		return
+	}
+	set := idSet(ids)
+	t.store.mu.RLock()
+	defer t.store.mu.RUnlock()
+	for _, v := range t.store.regionCountries {
+		if _, ok := set[*v.RegionID]; ok && v.Publish {
+			row := *v
+			regionCountryMappings = append(regionCountryMappings, &row)
+		}
+	}
+	sort.SliceStable(regionCountryMappings, func(i, j int) bool {
+		return regionCountryOrder(regionCountryMappings[i]) < regionCountryOrder(regionCountryMappings[j])
+	})
+	return regionCountryMappings, nil
+}
+
+func (t *RegionCountryMappingRepo) DeleteRegionCountryMapping(ctx context.Context, tx *sql.Tx, id *string) (err error) {
+	t.store.mu.Lock()
+	var row *domain.RegionCountryMapping
+	for _, v := range t.store.regionCountries {
+		if equal(v.ID, id) {
+			row = v
+			break
+		}
+	}
+	if row == nil {
+		t.store.mu.Unlock()
+		return
+	}
+	previous := *row
+	row.Publish = false
+	row.UpdatedAt = time.Now()
+	t.store.mu.Unlock()
+	return journal(ctx, tx, func() {
+		t.store.mu.Lock()
+		defer t.store.mu.Unlock()
+		*row = previous
+	})
+}
+
+// regionCountryOrder sorts mappings without an order first like a null order column does
+func regionCountryOrder(regionCountryMapping *domain.RegionCountryMapping) int {
+	if regionCountryMapping.Order == nil {
+		return 0
+	}
+	return *regionCountryMapping.Order
+}
@@ -0,0 +1,250 @@
+package repository
+
+import (
+	"bitbucket.org/noon-micro/curriculum/pkg/domain"
+	"bitbucket.org/noon-micro/curriculum/pkg/lib/logger"
+	mysqlrepo "bitbucket.org/noon-micro/curriculum/pkg/repository/mysql"
+	"database/sql"
+	"encoding/json"
+	"github.com/sirupsen/logrus"
+	"io/ioutil"
+	"strconv"
+	"sync"
+)
+
+// Store keeps every table in memory. Rows are kept in insertion order, which is the order
+// mysql returns them in as none of the queries sort.
+type Store struct {
+	mu                sync.RWMutex
+	lastId            int64
+	tags              []*domain.Tags
+	tagIndex          map[string]*domain.Tags
+	parentTagMappings []*domain.ParentTagMapping
+	parentTagIndex    map[string]*domain.ParentTagMapping
+	tagLocaleMappings []*domain.TagLocaleMapping
+	tagLocaleIndex    map[string]*domain.TagLocaleMapping
+	attributeLocales  []*domain.TagAttributeLocale
+	countryRules      []*domain.TagCountryRule
+	regionCountries   []*domain.RegionCountryMapping
+	legacyTagMappings []*domain.LegacyTagMapping
+	gradeProducts     []*domain.GradeProduct
+	webhooks          []*domain.WebhookSubscription
+	webhookDeliveries []*domain.WebhookDelivery
+	// tagChanges are numbered apart from the other rows, like the auto increment of their own table
+	tagChanges    []*domain.TagChange
+	lastTagChange int64
+	// tagEvents are numbered apart from the other rows as well, their number is the sequence of the event
+	tagEvents    []*tagEventRow
+	lastTagEvent int64
+	// tagOrderVersions is keyed by the parent tag ids and the tag type of the sibling set
+	tagOrderVersions map[string]int
+}
+
+// LoadFixture reads the json fixture seeding the memory environment
+func LoadFixture(path string) (*domain.Fixture, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	fixture := new(domain.Fixture)
+	if err = json.Unmarshal(data, fixture); err != nil {
+		return nil, err
+	}
+	return fixture, nil
+}
+
+// InitializeMemory builds the repositories on top of a store seeded from fixture. repository.Db of the
+// mysql package is pointed at the memory driver so that service transactions roll back memory writes.
+func InitializeMemory(fixture *domain.Fixture) *mysqlrepo.Repositories {
+	db, err := sql.Open(driverName, "")
+	if err != nil {
+		panic(err)
+	}
+	store := NewStore(fixture)
+	logger.Client.WithFields(logrus.Fields{
+		"tags":              len(store.tags),
+		"parentTagMappings": len(store.parentTagMappings),
+		"tagLocaleMappings": len(store.tagLocaleMappings),
+	}).Info("Memory store seeded successfully")
+	mysqlrepo.Db = db
+	return &mysqlrepo.Repositories{
+		Tags:                NewTagsRepository(store),
+		TagLocaleMapping:    NewTagLocaleMappingRepository(store),
+		TagAttributeLocale:  NewTagAttributeLocaleRepository(store),
+		TagCountryRule:      NewTagCountryRuleRepository(store),
+		RegionCountry:       NewRegionCountryMappingRepository(store),
+		ParentTagMapping:    NewParentTagMappingRepository(store),
+		LegacyTagMapping:    NewLegacyTagMappingRepository(store),
+		GradeProduct:        NewGradeProductRepository(store),
+		WebhookSubscription: NewWebhookSubscriptionRepository(store),
+		WebhookDelivery:     NewWebhookDeliveryRepository(store),
+		TagChange:           NewTagChangeRepository(store),
+		TagEventOutbox:      NewTagEventOutboxRepository(store),
+		Db:                  db,
+	}
+}
+
+// NewStore copies the fixture rows, rows without an id are numbered after the highest fixture id
+func NewStore(fixture *domain.Fixture) *Store {
+	s := &Store{
+		tagIndex:         make(map[string]*domain.Tags),
+		parentTagIndex:   make(map[string]*domain.ParentTagMapping),
+		tagLocaleIndex:   make(map[string]*domain.TagLocaleMapping),
+		tagOrderVersions: make(map[string]int),
+	}
+	if fixture == nil {
+		return s
+	}
+	for _, ids := range [][]*string{tagIds(fixture.Tags), parentTagMappingIds(fixture.ParentTagMappings), tagLocaleMappingIds(fixture.TagLocaleMappings), tagAttributeLocaleIds(fixture.TagAttributeLocales), tagCountryRuleIds(fixture.TagCountryRules),
+		regionCountryMappingIds(fixture.RegionCountryMappings)} {
+		for _, id := range ids {
+			if id == nil {
+				continue
+			}
+			if n, err := strconv.ParseInt(*id, 10, 64); err == nil && n > s.lastId {
+				s.lastId = n
+			}
+		}
+	}
+	for _, v := range fixture.Tags {
+		tag := cloneTag(v)
+		if tag.ID == nil {
+			tag.ID = s.nextId()
+		}
+		s.tags = append(s.tags, tag)
+		s.tagIndex[*tag.ID] = tag
+	}
+	for _, v := range fixture.ParentTagMappings {
+		parentTagMapping := *v
+		if parentTagMapping.ID == nil {
+			parentTagMapping.ID = s.nextId()
+		}
+		s.parentTagMappings = append(s.parentTagMappings, &parentTagMapping)
+		s.parentTagIndex[*parentTagMapping.ID] = &parentTagMapping
+	}
+	for _, v := range fixture.TagLocaleMappings {
+		tagLocaleMapping := *v
+		if tagLocaleMapping.ID == nil {
+			tagLocaleMapping.ID = s.nextId()
+		}
+		s.tagLocaleMappings = append(s.tagLocaleMappings, &tagLocaleMapping)
+		s.tagLocaleIndex[*tagLocaleMapping.ID] = &tagLocaleMapping
+	}
+	for _, v := range fixture.TagAttributeLocales {
+		tagAttributeLocale := *v
+		if tagAttributeLocale.ID == nil {
+			tagAttributeLocale.ID = s.nextId()
+		}
+		s.attributeLocales = append(s.attributeLocales, &tagAttributeLocale)
+	}
+	for _, v := range fixture.TagCountryRules {
+		tagCountryRule := *v
+		if tagCountryRule.ID == nil {
+			tagCountryRule.ID = s.nextId()
+		}
+		s.countryRules = append(s.countryRules, &tagCountryRule)
+	}
+	for _, v := range fixture.RegionCountryMappings {
+		regionCountryMapping := *v
+		if regionCountryMapping.ID == nil {
+			regionCountryMapping.ID = s.nextId()
+		}
+		s.regionCountries = append(s.regionCountries, &regionCountryMapping)
+	}
+	for _, v := range fixture.LegacyTagMappings {
+		legacyTagMapping := *v
+		s.legacyTagMappings = append(s.legacyTagMappings, &legacyTagMapping)
+	}
+	for _, v := range fixture.GradeProducts {
+		gradeProduct := *v
+		s.gradeProducts = append(s.gradeProducts, &gradeProduct)
+	}
+	return s
+}
+
+// nextId mimics an auto increment column, it is shared by all tables which only need unique ids
+func (s *Store) nextId() *string {
+	s.lastId++
+	id := strconv.FormatInt(s.lastId, 10)
+	return &id
+}
+
+func tagIds(tags []*domain.Tags) (ids []*string) {
+	for _, v := range tags {
+		ids = append(ids, v.ID)
+	}
+	return ids
+}
+
+func parentTagMappingIds(parentTagMappings []*domain.ParentTagMapping) (ids []*string) {
+	for _, v := range parentTagMappings {
+		ids = append(ids, v.ID)
+	}
+	return ids
+}
+
+func tagLocaleMappingIds(tagLocaleMappings []*domain.TagLocaleMapping) (ids []*string) {
+	for _, v := range tagLocaleMappings {
+		ids = append(ids, v.ID)
+	}
+	return ids
+}
+
+func tagAttributeLocaleIds(tagAttributeLocales []*domain.TagAttributeLocale) (ids []*string) {
+	for _, v := range tagAttributeLocales {
+		ids = append(ids, v.ID)
+	}
+	return ids
+}
+
+func tagCountryRuleIds(tagCountryRules []*domain.TagCountryRule) (ids []*string) {
+	for _, v := range tagCountryRules {
+		ids = append(ids, v.ID)
+	}
+	return ids
+}
+
+func regionCountryMappingIds(regionCountryMappings []*domain.RegionCountryMapping) (ids []*string) {
+	for _, v := range regionCountryMappings {
+		ids = append(ids, v.ID)
+	}
+	return ids
+}
+
+func idSet(ids []*string) map[string]struct{} {
+	set := make(map[string]struct{}, len(ids))
+	for _, id := range ids {
+		if id != nil {
+			set[*id] = struct{}{}
+		}
+	}
+	return set
+}
+
+// cloneTag copies the row so that callers never share memory with the store
+func cloneTag(tag *domain.Tags) *domain.Tags {
+	clone := *tag
+	clone.ID = cloneString(tag.ID)
+	clone.Type = cloneString(tag.Type)
+	clone.Name = cloneString(tag.Name)
+	clone.LocaleName = cloneString(tag.LocaleName)
+	if tag.CreatorId != nil {
+		creatorId := *tag.CreatorId
+		clone.CreatorId = &creatorId
+	}
+	if tag.Attributes != nil {
+		clone.Attributes = make(map[string]interface{}, len(tag.Attributes))
+		for k, v := range tag.Attributes {
+			clone.Attributes[k] = v
+		}
+	}
+	return &clone
+}
+
+func cloneString(value *string) *string {
+	if value == nil {
+		return nil
+	}
+	clone := *value
+	return &clone
+}
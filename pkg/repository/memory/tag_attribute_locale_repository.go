@@ -0,0 +1,74 @@
+package repository
+
+import (
+	"bitbucket.org/noon-micro/curriculum/pkg/domain"
+	"context"
+	"database/sql"
+	"time"
+)
+
+type TagAttributeLocaleRepo struct {
+	store *Store
+}
+
+func NewTagAttributeLocaleRepository(store *Store) *TagAttributeLocaleRepo {
+	return &TagAttributeLocaleRepo{store}
+}
+
+func (t *TagAttributeLocaleRepo) CreateTagAttributeLocale(ctx context.Context, tx *sql.Tx, tagAttributeLocale *domain.TagAttributeLocale) (err error) {
+	t.store.mu.Lock()
+	row := *tagAttributeLocale
+	row.ID = t.store.nextId()
+	t.store.attributeLocales = append(t.store.attributeLocales, &row)
+	t.store.mu.Unlock()
+	return journal(ctx, tx, func() {
+		t.store.mu.Lock()
+		defer t.store.mu.Unlock()
+		for i, v := range t.store.attributeLocales {
+			if v == &row {
+				t.store.attributeLocales = append(t.store.attributeLocales[:i], t.store.attributeLocales[i+1:]...)
+				break
+			}
+		}
+	})
+}
+
+func (t *TagAttributeLocaleRepo) FetchByInTagAttributeLocales(ctx context.Context, ids []*string) (tagAttributeLocales []*domain.TagAttributeLocale, err error) {
+	if len(ids) == 0 {
+		return
+	}
+	set := idSet(ids)
+	t.store.mu.RLock()
+	defer t.store.mu.RUnlock()
+	for _, v := range t.store.attributeLocales {
+		if _, ok := set[*v.TagID]; ok && v.Publish {
+			row := *v
+			tagAttributeLocales = append(tagAttributeLocales, &row)
+		}
+	}
+	return tagAttributeLocales, nil
+}
+
+func (t *TagAttributeLocaleRepo) DeleteTagAttributeLocale(ctx context.Context, tx *sql.Tx, id *string) (err error) {
+	t.store.mu.Lock()
+	var row *domain.TagAttributeLocale
+	for _, v := range t.store.attributeLocales {
+		if equal(v.ID, id) {
+			row = v
+			break
+		}
+	}
+	if row == nil {
+		t.store.mu.Unlock()
+		return
+	}
+	previous := *row
+	row.Publish = false
+	row.UpdatedAt = time.Now()
+	t.store.mu.Unlock()
+	return journal(ctx, tx, func() {
+		t.store.mu.Lock()
+		defer t.store.mu.Unlock()
+		*row = previous
+	})
+}
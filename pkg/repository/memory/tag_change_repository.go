@@ -0,0 +1,70 @@
+package repository
+
+import (
+	"bitbucket.org/noon-micro/curriculum/pkg/domain"
+	"context"
+	"database/sql"
+	"time"
+)
+
+type TagChangeRepo struct {
+	store *Store
+}
+
+func NewTagChangeRepository(store *Store) *TagChangeRepo {
+	return &TagChangeRepo{store}
+}
+
+func (t *TagChangeRepo) CreateTagChange(ctx context.Context, tx *sql.Tx, change *domain.TagChange) (err error) {
+	t.store.mu.Lock()
+	for _, v := range t.store.tagChanges {
+		if equal(v.EventID, change.EventID) {
+			t.store.mu.Unlock()
+			return
+		}
+	}
+	t.store.lastTagChange++
+	row := *change
+	row.Sequence = t.store.lastTagChange
+	row.ParentTags = append([]string(nil), change.ParentTags...)
+	t.store.tagChanges = append(t.store.tagChanges, &row)
+	t.store.mu.Unlock()
+	return journal(ctx, tx, func() {
+		t.store.mu.Lock()
+		defer t.store.mu.Unlock()
+		for i, v := range t.store.tagChanges {
+			if v == &row {
+				t.store.tagChanges = append(t.store.tagChanges[:i], t.store.tagChanges[i+1:]...)
+				break
+			}
+		}
+	})
+}
+
+func (t *TagChangeRepo) FetchTagChanges(ctx context.Context, after int64, limit int) (changes []*domain.TagChange, err error) {
+	t.store.mu.RLock()
+	defer t.store.mu.RUnlock()
+	for _, v := range t.store.tagChanges {
+		if len(changes) == limit {
+			break
+		}
+		if v.Sequence > after {
+			row := *v
+			changes = append(changes, &row)
+		}
+	}
+	return changes, nil
+}
+
+func (t *TagChangeRepo) DeleteTagChanges(ctx context.Context, recordedBefore time.Time) (err error) {
+	t.store.mu.Lock()
+	defer t.store.mu.Unlock()
+	var kept []*domain.TagChange
+	for _, v := range t.store.tagChanges {
+		if !v.RecordedAt.Before(recordedBefore) {
+			kept = append(kept, v)
+		}
+	}
+	t.store.tagChanges = kept
+	return
+}
@@ -0,0 +1,74 @@
+package repository
+
+import (
+	"bitbucket.org/noon-micro/curriculum/pkg/domain"
+	"context"
+	"database/sql"
+	"time"
+)
+
+type TagCountryRuleRepo struct {
+	store *Store
+}
+
+func NewTagCountryRuleRepository(store *Store) *TagCountryRuleRepo {
+	return &TagCountryRuleRepo{store}
+}
+
+func (t *TagCountryRuleRepo) CreateTagCountryRule(ctx context.Context, tx *sql.Tx, tagCountryRule *domain.TagCountryRule) (err error) {
+	t.store.mu.Lock()
+	row := *tagCountryRule
+	row.ID = t.store.nextId()
+	t.store.countryRules = append(t.store.countryRules, &row)
+	t.store.mu.Unlock()
+	return journal(ctx, tx, func() {
+		t.store.mu.Lock()
+		defer t.store.mu.Unlock()
+		for i, v := range t.store.countryRules {
+			if v == &row {
+				t.store.countryRules = append(t.store.countryRules[:i], t.store.countryRules[i+1:]...)
+				break
+			}
+		}
+	})
+}
+
+func (t *TagCountryRuleRepo) FetchByInTagCountryRules(ctx context.Context, ids []*string) (tagCountryRules []*domain.TagCountryRule, err error) {
+	if len(ids) == 0 {
+		return
+	}
+	set := idSet(ids)
+	t.store.mu.RLock()
+	defer t.store.mu.RUnlock()
+	for _, v := range t.store.countryRules {
+		if _, ok := set[*v.TagID]; ok && v.Publish {
+			row := *v
+			tagCountryRules = append(tagCountryRules, &row)
+		}
+	}
+	return tagCountryRules, nil
+}
+
+func (t *TagCountryRuleRepo) DeleteTagCountryRule(ctx context.Context, tx *sql.Tx, id *string) (err error) {
+	t.store.mu.Lock()
+	var row *domain.TagCountryRule
+	for _, v := range t.store.countryRules {
+		if equal(v.ID, id) {
+			row = v
+			break
+		}
+	}
+	if row == nil {
+		t.store.mu.Unlock()
+		return
+	}
+	previous := *row
+	row.Publish = false
+	row.UpdatedAt = time.Now()
+	t.store.mu.Unlock()
+	return journal(ctx, tx, func() {
+		t.store.mu.Lock()
+		defer t.store.mu.Unlock()
+		*row = previous
+	})
+}
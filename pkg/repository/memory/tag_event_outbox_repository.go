@@ -0,0 +1,139 @@
+package repository
+
+import (
+	"bitbucket.org/noon-micro/curriculum/pkg/domain"
+	"context"
+	"database/sql"
+	"encoding/json"
+	"time"
+)
+
+type TagEventOutboxRepo struct {
+	store *Store
+}
+
+// tagEventRow is an event of the outbox, relayedAt stays zero until the event is relayed and claimedBy is empty
+// while no relay holds a claim on it
+type tagEventRow struct {
+	event        domain.TagEvent
+	relayedAt    time.Time
+	claimedBy    string
+	claimedUntil time.Time
+}
+
+// tagEventClaim is the claim of a row as it was before a change, for the journal
+type tagEventClaim struct {
+	row          *tagEventRow
+	claimedBy    string
+	claimedUntil time.Time
+}
+
+func NewTagEventOutboxRepository(store *Store) *TagEventOutboxRepo {
+	return &TagEventOutboxRepo{store}
+}
+
+func (t *TagEventOutboxRepo) CreateTagEvent(ctx context.Context, tx *sql.Tx, event *domain.TagEvent) (err error) {
+	data, err := json.Marshal(event.Data)
+	if err != nil {
+		return
+	}
+	t.store.mu.Lock()
+	t.store.lastTagEvent++
+	event.Sequence = t.store.lastTagEvent
+	row := &tagEventRow{event: *event}
+	row.event.Data = json.RawMessage(data)
+	t.store.tagEvents = append(t.store.tagEvents, row)
+	t.store.mu.Unlock()
+	return journal(ctx, tx, func() {
+		t.store.mu.Lock()
+		defer t.store.mu.Unlock()
+		for i, v := range t.store.tagEvents {
+			if v == row {
+				t.store.tagEvents = append(t.store.tagEvents[:i], t.store.tagEvents[i+1:]...)
+				break
+			}
+		}
+	})
+}
+
+func (t *TagEventOutboxRepo) ClaimPendingTagEvents(ctx context.Context, tx *sql.Tx, relayId string, claimedAt time.Time, claimedUntil time.Time, limit int) (events []*domain.TagEvent, err error) {
+	t.store.mu.Lock()
+	var pending []*tagEventRow
+	for _, v := range t.store.tagEvents {
+		if !v.relayedAt.IsZero() {
+			continue
+		}
+		if v.claimedBy != "" && v.claimedBy != relayId && v.claimedUntil.After(claimedAt) {
+			t.store.mu.Unlock()
+			return nil, nil
+		}
+		if len(pending) < limit {
+			pending = append(pending, v)
+		}
+	}
+	var claims []tagEventClaim
+	for _, v := range pending {
+		claims = append(claims, tagEventClaim{row: v, claimedBy: v.claimedBy, claimedUntil: v.claimedUntil})
+		v.claimedBy, v.claimedUntil = relayId, claimedUntil
+		event := v.event
+		events = append(events, &event)
+	}
+	t.store.mu.Unlock()
+	return events, t.restoreClaims(ctx, tx, claims)
+}
+
+func (t *TagEventOutboxRepo) MarkTagEventsRelayed(ctx context.Context, tx *sql.Tx, relayId string, sequences []int64, relayedAt time.Time) (err error) {
+	set := make(map[int64]struct{}, len(sequences))
+	for _, v := range sequences {
+		set[v] = struct{}{}
+	}
+	t.store.mu.Lock()
+	var marked []*tagEventRow
+	var claims []tagEventClaim
+	for _, v := range t.store.tagEvents {
+		if !v.relayedAt.IsZero() || v.claimedBy != relayId {
+			continue
+		}
+		claims = append(claims, tagEventClaim{row: v, claimedBy: v.claimedBy, claimedUntil: v.claimedUntil})
+		v.claimedBy, v.claimedUntil = "", time.Time{}
+		if _, ok := set[v.event.Sequence]; ok {
+			v.relayedAt = relayedAt
+			marked = append(marked, v)
+		}
+	}
+	t.store.mu.Unlock()
+	if err = t.restoreClaims(ctx, tx, claims); err != nil {
+		return
+	}
+	return journal(ctx, tx, func() {
+		t.store.mu.Lock()
+		defer t.store.mu.Unlock()
+		for _, v := range marked {
+			v.relayedAt = time.Time{}
+		}
+	})
+}
+
+// restoreClaims journals the claims as they were before a change, so that a rollback puts them back
+func (t *TagEventOutboxRepo) restoreClaims(ctx context.Context, tx *sql.Tx, claims []tagEventClaim) error {
+	return journal(ctx, tx, func() {
+		t.store.mu.Lock()
+		defer t.store.mu.Unlock()
+		for _, v := range claims {
+			v.row.claimedBy, v.row.claimedUntil = v.claimedBy, v.claimedUntil
+		}
+	})
+}
+
+func (t *TagEventOutboxRepo) DeleteRelayedTagEvents(ctx context.Context, relayedBefore time.Time) (err error) {
+	t.store.mu.Lock()
+	defer t.store.mu.Unlock()
+	var kept []*tagEventRow
+	for _, v := range t.store.tagEvents {
+		if v.relayedAt.IsZero() || !v.relayedAt.Before(relayedBefore) {
+			kept = append(kept, v)
+		}
+	}
+	t.store.tagEvents = kept
+	return
+}
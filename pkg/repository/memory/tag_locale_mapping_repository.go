@@ -0,0 +1,167 @@
+package repository
+
+import (
+	"bitbucket.org/noon-micro/curriculum/pkg/domain"
+	noonerror "bitbucket.org/noon-micro/curriculum/pkg/lib/error"
+	"context"
+	"database/sql"
+	"time"
+)
+
+type TagLocaleMappingRepo struct {
+	store *Store
+}
+
+func NewTagLocaleMappingRepository(store *Store) *TagLocaleMappingRepo {
+	return &TagLocaleMappingRepo{store}
+}
+
+func (t *TagLocaleMappingRepo) CreateTagLocaleMapping(ctx context.Context, tx *sql.Tx, tagLocaleMapping *domain.TagLocaleMapping) (err error) {
+	t.store.mu.Lock()
+	row := *tagLocaleMapping
+	row.ID = t.store.nextId()
+	t.store.tagLocaleMappings = append(t.store.tagLocaleMappings, &row)
+	t.store.tagLocaleIndex[*row.ID] = &row
+	t.store.mu.Unlock()
+	return journal(ctx, tx, func() {
+		t.store.mu.Lock()
+		defer t.store.mu.Unlock()
+		delete(t.store.tagLocaleIndex, *row.ID)
+		for i, v := range t.store.tagLocaleMappings {
+			if v == &row {
+				t.store.tagLocaleMappings = append(t.store.tagLocaleMappings[:i], t.store.tagLocaleMappings[i+1:]...)
+				break
+			}
+		}
+	})
+}
+
+func (t *TagLocaleMappingRepo) FetchTagLocaleMappings(ctx context.Context, id *string) (tagLocaleMappings []*domain.TagLocaleMapping, err error) {
+	return t.filter(func(v *domain.TagLocaleMapping) bool {
+		return equal(v.TagID, id) && v.Publish
+	}), nil
+}
+
+func (t *TagLocaleMappingRepo) FetchByInTagLocaleMappings(ctx context.Context, ids []*string) (tagLocaleMappings []*domain.TagLocaleMapping, err error) {
+	if len(ids) == 0 {
+		return
+	}
+	set := idSet(ids)
+	return t.filter(func(v *domain.TagLocaleMapping) bool {
+		_, ok := set[*v.TagID]
+		return ok && v.Publish
+	}), nil
+}
+
+func (t *TagLocaleMappingRepo) FetchTagLocaleMappingByLocale(ctx context.Context, tagId *string, countryId *string, locale *string) (tagLocaleMappings *domain.TagLocaleMapping, err error) {
+	tagsList := t.filter(func(v *domain.TagLocaleMapping) bool {
+		return equal(v.TagID, tagId) && equal(v.CountryId, countryId) && equal(v.Locale, locale) && v.Publish
+	})
+	if len(tagsList) == 1 {
+		return tagsList[0], nil
+	} else if len(tagsList) > 1 {
+		return nil, noonerror.New(noonerror.ErrInternalServer, "tagLocaleMappingDBReadError")
+	}
+	return
+}
+
+func (t *TagLocaleMappingRepo) DeleteTagLocaleMapping(ctx context.Context, tx *sql.Tx, id *string) (err error) {
+	t.store.mu.Lock()
+	row, ok := t.store.tagLocaleIndex[*id]
+	if !ok {
+		t.store.mu.Unlock()
+		return
+	}
+	previous := *row
+	row.Publish = false
+	row.UpdatedAt = time.Now()
+	t.store.mu.Unlock()
+	return journal(ctx, tx, func() {
+		t.store.mu.Lock()
+		defer t.store.mu.Unlock()
+		*row = previous
+	})
+}
+
+func (t *TagLocaleMappingRepo) FetchTagLocalesByTagIds(ctx context.Context, ids []*string, locale *string, countryId *string) (tagLocaleMappings []*domain.TagLocaleMapping, err error) {
+	if len(ids) == 0 {
+		return
+	}
+	set := idSet(ids)
+	return t.filter(func(v *domain.TagLocaleMapping) bool {
+		_, ok := set[*v.TagID]
+		return ok && equal(v.Locale, locale) && equal(v.CountryId, countryId) && v.Publish
+	}), nil
+}
+
+func (t *TagLocaleMappingRepo) CreateTagLocaleDraft(ctx context.Context, tx *sql.Tx, tagLocaleMapping *domain.TagLocaleMapping) (err error) {
+	row := *tagLocaleMapping
+	row.Publish = false
+	row.Draft = true
+	return t.CreateTagLocaleMapping(ctx, tx, &row)
+}
+
+func (t *TagLocaleMappingRepo) FetchTagLocaleDrafts(ctx context.Context, ids []*string, locale *string, countryId *string) (tagLocaleMappings []*domain.TagLocaleMapping, err error) {
+	if len(ids) == 0 {
+		return
+	}
+	set := idSet(ids)
+	return t.filter(func(v *domain.TagLocaleMapping) bool {
+		_, ok := set[*v.TagID]
+		return ok && equal(v.Locale, locale) && equal(v.CountryId, countryId) && v.Draft
+	}), nil
+}
+
+func (t *TagLocaleMappingRepo) FetchTagLocaleDraftsByIds(ctx context.Context, ids []*string) (tagLocaleMappings []*domain.TagLocaleMapping, err error) {
+	if len(ids) == 0 {
+		return
+	}
+	set := idSet(ids)
+	return t.filter(func(v *domain.TagLocaleMapping) bool {
+		_, ok := set[*v.ID]
+		return ok && v.Draft
+	}), nil
+}
+
+func (t *TagLocaleMappingRepo) PublishTagLocaleDraft(ctx context.Context, tx *sql.Tx, id *string, name *string) (err error) {
+	return t.updateDraft(ctx, tx, id, func(row *domain.TagLocaleMapping) {
+		row.Publish = true
+		row.Name = name
+	})
+}
+
+func (t *TagLocaleMappingRepo) DiscardTagLocaleDraft(ctx context.Context, tx *sql.Tx, id *string) (err error) {
+	return t.updateDraft(ctx, tx, id, func(row *domain.TagLocaleMapping) {})
+}
+
+// updateDraft clears the draft flag of the row after applying update, rows which are not drafts are left as is
+func (t *TagLocaleMappingRepo) updateDraft(ctx context.Context, tx *sql.Tx, id *string, update func(*domain.TagLocaleMapping)) (err error) {
+	t.store.mu.Lock()
+	row, ok := t.store.tagLocaleIndex[*id]
+	if !ok || !row.Draft {
+		t.store.mu.Unlock()
+		return
+	}
+	previous := *row
+	update(row)
+	row.Draft = false
+	row.UpdatedAt = time.Now()
+	t.store.mu.Unlock()
+	return journal(ctx, tx, func() {
+		t.store.mu.Lock()
+		defer t.store.mu.Unlock()
+		*row = previous
+	})
+}
+
+func (t *TagLocaleMappingRepo) filter(match func(*domain.TagLocaleMapping) bool) (tagLocaleMappings []*domain.TagLocaleMapping) {
+	t.store.mu.RLock()
+	defer t.store.mu.RUnlock()
+	for _, v := range t.store.tagLocaleMappings {
+		if match(v) {
+			row := *v
+			tagLocaleMappings = append(tagLocaleMappings, &row)
+		}
+	}
+	return tagLocaleMappings
+}
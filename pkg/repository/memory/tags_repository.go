@@ -0,0 +1,209 @@
+package repository
+
+import (
+	"bitbucket.org/noon-micro/curriculum/pkg/domain"
+	noonerror "bitbucket.org/noon-micro/curriculum/pkg/lib/error"
+	"context"
+	"database/sql"
+	"time"
+)
+
+type TagsRepo struct {
+	store *Store
+}
+
+func NewTagsRepository(store *Store) *TagsRepo {
+	return &TagsRepo{store}
+}
+
+func (t *TagsRepo) FetchTags(ctx context.Context, id *string) (tags *domain.Tags, err error) {
+	t.store.mu.RLock()
+	defer t.store.mu.RUnlock()
+	tag, ok := t.store.tagIndex[*id]
+	if !ok {
+		return
+	}
+	return cloneTag(tag), nil
+}
+
+func (t *TagsRepo) FetchByInTags(ctx context.Context, ids []*string) (tags []*domain.Tags, err error) {
+	if len(ids) == 0 {
+		return
+	}
+	set := idSet(ids)
+	t.store.mu.RLock()
+	defer t.store.mu.RUnlock()
+	for _, v := range t.store.tags {
+		if _, ok := set[*v.ID]; ok {
+			tags = append(tags, cloneTag(v))
+		}
+	}
+	return tags, nil
+}
+
+func (t *TagsRepo) FetchFilteredTags(ctx context.Context, curriculumType *string, tagType *string) (tags []*domain.Tags, err error) {
+	t.store.mu.RLock()
+	defer t.store.mu.RUnlock()
+	for _, v := range t.store.tags {
+		if v.Publish && v.CurriculumType == *curriculumType && equal(v.Type, tagType) {
+			tags = append(tags, projectTag(v, false))
+		}
+	}
+	return tags, nil
+}
+
+func (t *TagsRepo) FetchFilteredTagsPaginated(ctx context.Context, curriculumType *string, tagType *string, start *int, limit *int) (tags []*domain.Tags, err error) {
+	return t.fetchPaginated(curriculumType, tagType, start, limit, true), nil
+}
+
+func (t *TagsRepo) FetchFilteredTagsPaginatedForAdmin(ctx context.Context, curriculumType *string, tagType *string, start *int, limit *int) (tags []*domain.Tags, err error) {
+	return t.fetchPaginated(curriculumType, tagType, start, limit, false), nil
+}
+
+func (t *TagsRepo) fetchPaginated(curriculumType *string, tagType *string, start *int, limit *int, publishedOnly bool) (tags []*domain.Tags) {
+	t.store.mu.RLock()
+	defer t.store.mu.RUnlock()
+	skipped := 0
+	for _, v := range t.store.tags {
+		if len(tags) >= *limit {
+			break
+		}
+		if (publishedOnly && !v.Publish) || v.CurriculumType != *curriculumType || !equal(v.Type, tagType) {
+			continue
+		}
+		if skipped < *start {
+			skipped++
+			continue
+		}
+		tags = append(tags, projectTag(v, true))
+	}
+	return tags
+}
+
+func (t *TagsRepo) FetchByTagGroup(ctx context.Context, tagGroup *string, tagType *string) (tags []*domain.Tags, err error) {
+	t.store.mu.RLock()
+	defer t.store.mu.RUnlock()
+	for _, v := range t.store.tags {
+		if !v.Publish || v.TagGroup != *tagGroup {
+			continue
+		}
+		if tagType != nil && !equal(v.Type, tagType) {
+			continue
+		}
+		tags = append(tags, projectTag(v, false))
+	}
+	return tags, nil
+}
+
+func (t *TagsRepo) CreateTags(ctx context.Context, tx *sql.Tx, tags *domain.Tags) (id *string, err error) {
+	t.store.mu.Lock()
+	tag := cloneTag(tags)
+	tag.ID = t.store.nextId()
+	tag.LocaleName = nil
+	t.store.tags = append(t.store.tags, tag)
+	t.store.tagIndex[*tag.ID] = tag
+	t.store.mu.Unlock()
+	err = journal(ctx, tx, func() {
+		t.store.mu.Lock()
+		defer t.store.mu.Unlock()
+		delete(t.store.tagIndex, *tag.ID)
+		for i, v := range t.store.tags {
+			if v == tag {
+				t.store.tags = append(t.store.tags[:i], t.store.tags[i+1:]...)
+				break
+			}
+		}
+	})
+	if err != nil {
+		return nil, err
+	}
+	insertId := *tag.ID
+	return &insertId, nil
+}
+
+func (t *TagsRepo) UpdateTag(ctx context.Context, tx *sql.Tx, updateTag *domain.UpdateTag) (err error) {
+	t.store.mu.Lock()
+	tag, ok := t.store.tagIndex[*updateTag.ID]
+	if !ok || (updateTag.Name == nil && updateTag.Hidden == nil && updateTag.Attributes == nil) {
+		t.store.mu.Unlock()
+		return
+	}
+	if updateTag.Version != nil && *updateTag.Version != tag.Version {
+		t.store.mu.Unlock()
+		return noonerror.New(noonerror.ErrConflict, "tagVersionConflict")
+	}
+	previous := *tag
+	if updateTag.Name != nil {
+		name := *updateTag.Name
+		tag.Name = &name
+	}
+	if updateTag.Hidden != nil {
+		tag.Publish = !*updateTag.Hidden
+	}
+	if updateTag.Attributes != nil {
+		tag.Attributes = cloneTag(&domain.Tags{Attributes: updateTag.Attributes}).Attributes
+	}
+	tag.Version++
+	tag.UpdatedAt = time.Now()
+	t.store.mu.Unlock()
+	return journal(ctx, tx, func() {
+		t.store.mu.Lock()
+		defer t.store.mu.Unlock()
+		*tag = previous
+	})
+}
+
+func (t *TagsRepo) DeleteTags(ctx context.Context, tx *sql.Tx, id *string) (err error) {
+	return t.update(ctx, tx, id, func(tag *domain.Tags) {
+		tag.Publish = false
+	})
+}
+
+func (t *TagsRepo) UpdateLocale(ctx context.Context, tx *sql.Tx, localeAvailable bool, id *string) (err error) {
+	return t.update(ctx, tx, id, func(tag *domain.Tags) {
+		tag.LocaleAvailable = localeAvailable
+	})
+}
+
+func (t *TagsRepo) ToggleTags(ctx context.Context, publish bool, ids []*string) (err error) {
+	set := idSet(ids)
+	t.store.mu.Lock()
+	defer t.store.mu.Unlock()
+	for id := range set {
+		if tag, ok := t.store.tagIndex[id]; ok {
+			tag.Publish = publish
+			tag.Version++
+			tag.UpdatedAt = time.Now()
+		}
+	}
+	return
+}
+
+// update applies change to the row and journals the previous row on tx
+func (t *TagsRepo) update(ctx context.Context, tx *sql.Tx, id *string, change func(*domain.Tags)) error {
+	t.store.mu.Lock()
+	tag, ok := t.store.tagIndex[*id]
+	if !ok {
+		t.store.mu.Unlock()
+		return nil
+	}
+	previous := *tag
+	change(tag)
+	tag.Version++
+	tag.UpdatedAt = time.Now()
+	t.store.mu.Unlock()
+	return journal(ctx, tx, func() {
+		t.store.mu.Lock()
+		defer t.store.mu.Unlock()
+		*tag = previous
+	})
+}
+
+// projectTag keeps the columns selected by the filter queries, withPublish adds the publish column of the paginated ones
+func projectTag(tag *domain.Tags, withPublish bool) *domain.Tags {
+	projected := cloneTag(&domain.Tags{ID: tag.ID, Type: tag.Type, Name: tag.Name, Attributes: tag.Attributes})
+	if withPublish {
+		projected.Publish = tag.Publish
+	}
+	return projected
+}
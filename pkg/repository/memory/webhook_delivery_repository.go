@@ -0,0 +1,111 @@
+package repository
+
+import (
+	"bitbucket.org/noon-micro/curriculum/pkg/domain"
+	"context"
+	"sort"
+	"time"
+)
+
+type WebhookDeliveryRepo struct {
+	store *Store
+}
+
+func NewWebhookDeliveryRepository(store *Store) *WebhookDeliveryRepo {
+	return &WebhookDeliveryRepo{store}
+}
+
+func (t *WebhookDeliveryRepo) CreateWebhookDelivery(ctx context.Context, delivery *domain.WebhookDelivery) (id *string, err error) {
+	t.store.mu.Lock()
+	defer t.store.mu.Unlock()
+	for _, v := range t.store.webhookDeliveries {
+		if equal(v.SubscriptionID, delivery.SubscriptionID) && equal(v.EventID, delivery.EventID) && (v.RedeliveryOf == nil && delivery.RedeliveryOf == nil || equal(v.RedeliveryOf, delivery.RedeliveryOf)) {
+			return nil, nil
+		}
+	}
+	row := *delivery
+	row.ID = t.store.nextId()
+	t.store.webhookDeliveries = append(t.store.webhookDeliveries, &row)
+	return cloneString(row.ID), nil
+}
+
+func (t *WebhookDeliveryRepo) FetchWebhookDelivery(ctx context.Context, id *string) (delivery *domain.WebhookDelivery, err error) {
+	t.store.mu.RLock()
+	defer t.store.mu.RUnlock()
+	for _, v := range t.store.webhookDeliveries {
+		if equal(v.ID, id) {
+			row := *v
+			return &row, nil
+		}
+	}
+	return
+}
+
+func (t *WebhookDeliveryRepo) FetchWebhookDeliveries(ctx context.Context, getDeliveries *domain.GetWebhookDeliveries) (deliveries []*domain.WebhookDelivery, err error) {
+	t.store.mu.RLock()
+	defer t.store.mu.RUnlock()
+	skipped := 0
+	for i := len(t.store.webhookDeliveries) - 1; i >= 0 && len(deliveries) < getDeliveries.Limit; i-- {
+		v := t.store.webhookDeliveries[i]
+		if !equal(v.SubscriptionID, getDeliveries.SubscriptionID) || (getDeliveries.Status != nil && !equal(v.Status, getDeliveries.Status)) {
+			continue
+		}
+		if skipped < getDeliveries.Start {
+			skipped++
+			continue
+		}
+		row := *v
+		deliveries = append(deliveries, &row)
+	}
+	return deliveries, nil
+}
+
+func (t *WebhookDeliveryRepo) FetchDueWebhookDeliveries(ctx context.Context, now time.Time, limit int) (deliveries []*domain.WebhookDelivery, err error) {
+	t.store.mu.RLock()
+	defer t.store.mu.RUnlock()
+	for _, v := range t.store.webhookDeliveries {
+		if *v.Status == domain.WebhookDeliveryStatusEnum.Pending && !v.NextAttemptAt.After(now) {
+			row := *v
+			deliveries = append(deliveries, &row)
+		}
+	}
+	sort.SliceStable(deliveries, func(i, j int) bool {
+		return deliveries[i].NextAttemptAt.Before(deliveries[j].NextAttemptAt)
+	})
+	if len(deliveries) > limit {
+		deliveries = deliveries[:limit]
+	}
+	return deliveries, nil
+}
+
+func (t *WebhookDeliveryRepo) ClaimWebhookDelivery(ctx context.Context, delivery *domain.WebhookDelivery, until time.Time) (claimed bool, err error) {
+	t.store.mu.Lock()
+	defer t.store.mu.Unlock()
+	for _, v := range t.store.webhookDeliveries {
+		if equal(v.ID, delivery.ID) {
+			if *v.Status != domain.WebhookDeliveryStatusEnum.Pending || !v.NextAttemptAt.Equal(delivery.NextAttemptAt) {
+				return false, nil
+			}
+			v.NextAttemptAt = until
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func (t *WebhookDeliveryRepo) UpdateWebhookDelivery(ctx context.Context, delivery *domain.WebhookDelivery) (err error) {
+	t.store.mu.Lock()
+	defer t.store.mu.Unlock()
+	for _, v := range t.store.webhookDeliveries {
+		if equal(v.ID, delivery.ID) {
+			v.Status = cloneString(delivery.Status)
+			v.Attempts = delivery.Attempts
+			v.ResponseStatus = delivery.ResponseStatus
+			v.Error = cloneString(delivery.Error)
+			v.NextAttemptAt = delivery.NextAttemptAt
+			v.UpdatedAt = delivery.UpdatedAt
+			break
+		}
+	}
+	return
+}
@@ -0,0 +1,81 @@
+package repository
+
+import (
+	"bitbucket.org/noon-micro/curriculum/pkg/domain"
+	"context"
+	"time"
+)
+
+type WebhookSubscriptionRepo struct {
+	store *Store
+}
+
+func NewWebhookSubscriptionRepository(store *Store) *WebhookSubscriptionRepo {
+	return &WebhookSubscriptionRepo{store}
+}
+
+func (t *WebhookSubscriptionRepo) CreateWebhookSubscription(ctx context.Context, subscription *domain.WebhookSubscription) (id *string, err error) {
+	t.store.mu.Lock()
+	defer t.store.mu.Unlock()
+	row := cloneWebhookSubscription(subscription)
+	row.ID = t.store.nextId()
+	t.store.webhooks = append(t.store.webhooks, row)
+	return cloneString(row.ID), nil
+}
+
+func (t *WebhookSubscriptionRepo) UpdateWebhookSubscription(ctx context.Context, subscription *domain.WebhookSubscription) (err error) {
+	t.store.mu.Lock()
+	defer t.store.mu.Unlock()
+	for i, v := range t.store.webhooks {
+		if equal(v.ID, subscription.ID) {
+			row := cloneWebhookSubscription(subscription)
+			row.Publish = v.Publish
+			row.CreatedAt = v.CreatedAt
+			t.store.webhooks[i] = row
+			break
+		}
+	}
+	return
+}
+
+func (t *WebhookSubscriptionRepo) FetchWebhookSubscription(ctx context.Context, id *string) (subscription *domain.WebhookSubscription, err error) {
+	t.store.mu.RLock()
+	defer t.store.mu.RUnlock()
+	for _, v := range t.store.webhooks {
+		if equal(v.ID, id) && v.Publish {
+			return cloneWebhookSubscription(v), nil
+		}
+	}
+	return
+}
+
+func (t *WebhookSubscriptionRepo) FetchWebhookSubscriptions(ctx context.Context) (subscriptions []*domain.WebhookSubscription, err error) {
+	t.store.mu.RLock()
+	defer t.store.mu.RUnlock()
+	for _, v := range t.store.webhooks {
+		if v.Publish {
+			subscriptions = append(subscriptions, cloneWebhookSubscription(v))
+		}
+	}
+	return subscriptions, nil
+}
+
+func (t *WebhookSubscriptionRepo) DeleteWebhookSubscription(ctx context.Context, id *string) (err error) {
+	t.store.mu.Lock()
+	defer t.store.mu.Unlock()
+	for _, v := range t.store.webhooks {
+		if equal(v.ID, id) {
+			v.Publish = false
+			v.UpdatedAt = time.Now()
+			break
+		}
+	}
+	return
+}
+
+func cloneWebhookSubscription(subscription *domain.WebhookSubscription) *domain.WebhookSubscription {
+	clone := *subscription
+	clone.TagTypes = append([]string(nil), subscription.TagTypes...)
+	clone.EventTypes = append([]string(nil), subscription.EventTypes...)
+	return &clone
+}
@@ -4,7 +4,9 @@ package repository
 import (
 	"bitbucket.org/noon-micro/curriculum/config"
 	"bitbucket.org/noon-micro/curriculum/pkg/domain"
+	"bitbucket.org/noon-micro/curriculum/pkg/lib/helper"
 	"bitbucket.org/noon-micro/curriculum/pkg/lib/logger"
+	"context"
 	"database/sql"
 	"time"
 
@@ -17,12 +19,19 @@ import (
 var Db *sql.DB
 
 type Repositories struct {
-	Tags             domain.TagsRepository
-	TagLocaleMapping domain.TagLocaleMappingRepository
-	ParentTagMapping domain.ParentTagMappingRepository
-	LegacyTagMapping domain.LegacyTagMappingRepository
-	GradeProduct     domain.GradeProductRepository
-	Db               *sql.DB
+	Tags                domain.TagsRepository
+	TagLocaleMapping    domain.TagLocaleMappingRepository
+	TagAttributeLocale  domain.TagAttributeLocaleRepository
+	TagCountryRule      domain.TagCountryRuleRepository
+	RegionCountry       domain.RegionCountryMappingRepository
+	ParentTagMapping    domain.ParentTagMappingRepository
+	LegacyTagMapping    domain.LegacyTagMappingRepository
+	GradeProduct        domain.GradeProductRepository
+	WebhookSubscription domain.WebhookSubscriptionRepository
+	WebhookDelivery     domain.WebhookDeliveryRepository
+	TagChange           domain.TagChangeRepository
+	TagEventOutbox      domain.TagEventOutboxRepository
+	Db                  *sql.DB
 }
 
 func InitializeMysql(config *config.Configuration) *Repositories {
@@ -50,11 +59,23 @@ func InitializeMysql(config *config.Configuration) *Repositories {
 	contextLogger.Info("MySql connected successfully")
 	Db = db
 	return &Repositories{
-		Tags:             NewTagsRepository(db),
-		TagLocaleMapping: NewTagLocaleMappingRepository(db),
-		ParentTagMapping: NewParentTagMappingRepository(db),
-		LegacyTagMapping: NewLegacyTagMappingRepository(db),
-		GradeProduct:     NewGradeProductRepository(db),
-		Db:               db,
+		Tags:                NewTagsRepository(db),
+		TagLocaleMapping:    NewTagLocaleMappingRepository(db),
+		TagAttributeLocale:  NewTagAttributeLocaleRepository(db),
+		TagCountryRule:      NewTagCountryRuleRepository(db),
+		RegionCountry:       NewRegionCountryMappingRepository(db),
+		ParentTagMapping:    NewParentTagMappingRepository(db),
+		LegacyTagMapping:    NewLegacyTagMappingRepository(db),
+		GradeProduct:        NewGradeProductRepository(db),
+		WebhookSubscription: NewWebhookSubscriptionRepository(db),
+		WebhookDelivery:     NewWebhookDeliveryRepository(db),
+		TagChange:           NewTagChangeRepository(db),
+		TagEventOutbox:      NewTagEventOutboxRepository(db),
+		Db:                  db,
 	}
 }
+
+// withTimeout bounds a single statement by the configured mysql timeout
+func withTimeout(ctx context.Context) (context.Context, context.CancelFunc) {
+	return helper.WithTimeout(ctx, config.GetConfig().MySqlTimeout, config.DefaultMySqlTimeout)
+}
@@ -5,6 +5,7 @@ import (
 	"bitbucket.org/noon-micro/curriculum/pkg/lib/converter"
 	noonerror "bitbucket.org/noon-micro/curriculum/pkg/lib/error"
 	"bitbucket.org/noon-micro/curriculum/pkg/lib/logger"
+	"context"
 	"database/sql"
 	"time"
 )
@@ -21,8 +22,10 @@ func NewGradeProductRepository(db *sql.DB) *GradeProductRepo {
 	return &GradeProductRepo{db}
 }
 
-func (t *GradeProductRepo) FetchGradesFromProductId(productId *string) (gradeProducts []*domain.GradeProduct, err error) {
-	rows, err := t.db.Query(selectGradeFromProduct, *productId)
+func (t *GradeProductRepo) FetchGradesFromProductId(ctx context.Context, productId *string) (gradeProducts []*domain.GradeProduct, err error) {
+	ctx, cancel := withTimeout(ctx)
+	defer cancel()
+	rows, err := t.db.QueryContext(ctx, selectGradeFromProduct, *productId)
 	if err != nil {
 		logger.Client.Error("fetchGradesFromProductIdError", logger.GetErrorStack())
 		return nil, noonerror.New(noonerror.ErrInternalServer, "fetchGradesFromProductIdError")
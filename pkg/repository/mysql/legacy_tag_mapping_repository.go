@@ -5,6 +5,7 @@ import (
 	"bitbucket.org/noon-micro/curriculum/pkg/lib/converter"
 	noonerror "bitbucket.org/noon-micro/curriculum/pkg/lib/error"
 	"bitbucket.org/noon-micro/curriculum/pkg/lib/logger"
+	"context"
 	"database/sql"
 	"strconv"
 	"strings"
@@ -24,8 +25,10 @@ func NewLegacyTagMappingRepository(db *sql.DB) *LegacyTagMappingRepo {
 	return &LegacyTagMappingRepo{db}
 }
 
-func (t *LegacyTagMappingRepo) FetchTagIdFromLegacyId(legacyType *string, id *string) (legacyTagMappings []*domain.LegacyTagMapping, err error) {
-	rows, err := t.db.Query(selectTagId, *legacyType, *id)
+func (t *LegacyTagMappingRepo) FetchTagIdFromLegacyId(ctx context.Context, legacyType *string, id *string) (legacyTagMappings []*domain.LegacyTagMapping, err error) {
+	ctx, cancel := withTimeout(ctx)
+	defer cancel()
+	rows, err := t.db.QueryContext(ctx, selectTagId, *legacyType, *id)
 	if err != nil {
 		logger.Client.Error("fetchTLegacyTagMappingError", logger.GetErrorStack())
 		return nil, noonerror.New(noonerror.ErrInternalServer, "fetchLegacyTagMappingError")
@@ -40,8 +43,10 @@ func (t *LegacyTagMappingRepo) FetchTagIdFromLegacyId(legacyType *string, id *st
 	return tagsList, nil
 }
 
-func (t *LegacyTagMappingRepo) FetchLegacyIdFromTagId(tagId *string) (legacyTagMappings []*domain.LegacyTagMapping, err error) {
-	rows, err := t.db.Query(selectLegacyId, *tagId)
+func (t *LegacyTagMappingRepo) FetchLegacyIdFromTagId(ctx context.Context, tagId *string) (legacyTagMappings []*domain.LegacyTagMapping, err error) {
+	ctx, cancel := withTimeout(ctx)
+	defer cancel()
+	rows, err := t.db.QueryContext(ctx, selectLegacyId, *tagId)
 	if err != nil {
 		logger.Client.Error("fetchTLegacyTagMappingError", logger.GetErrorStack())
 		return nil, noonerror.New(noonerror.ErrInternalServer, "fetchLegacyTagMappingError")
@@ -56,7 +61,9 @@ func (t *LegacyTagMappingRepo) FetchLegacyIdFromTagId(tagId *string) (legacyTagM
 	return tagsList, nil
 }
 
-func (t *LegacyTagMappingRepo) FetchLegacyIdFromTagIds(tagIds []*string) (legacyTagMappings []*domain.LegacyTagMapping, err error) {
+func (t *LegacyTagMappingRepo) FetchLegacyIdFromTagIds(ctx context.Context, tagIds []*string) (legacyTagMappings []*domain.LegacyTagMapping, err error) {
+	ctx, cancel := withTimeout(ctx)
+	defer cancel()
 	if len(tagIds) == 0 {
 		return
 	}
@@ -65,7 +72,7 @@ func (t *LegacyTagMappingRepo) FetchLegacyIdFromTagIds(tagIds []*string) (legacy
 		args[i] = id
 	}
 	stmt := `SELECT * FROM legacy_tag_mapping WHERE tag_id in (?` + strings.Repeat(",?", len(args)-1) + `)`
-	rows, err := t.db.Query(stmt, args...)
+	rows, err := t.db.QueryContext(ctx, stmt, args...)
 	if err != nil {
 		logger.Client.Error("fetchTLegacyTagMappingError", logger.GetErrorStack())
 		return nil, noonerror.New(noonerror.ErrInternalServer, "fetchLegacyTagMappingError")
@@ -26,28 +26,35 @@ var (
 	toggleHideParentTagMapping               = "UPDATE parent_tag_mapping SET hidden = ?, updated_at = ? where id = ?"
 	updateTagOrderParentTagMapping           = "UPDATE parent_tag_mapping SET `order` = ?, updated_at = ? where id = ?"
 	deleteParentTagMapping                   = "UPDATE parent_tag_mapping SET publish = 0, updated_at = ? where id = ?"
+	selectTagIdsByParentTagPrefix            = "SELECT DISTINCT tag_id FROM parent_tag_mapping WHERE (parent_tag_id = ? or parent_tag_id like ?) and publish = 1"
+	// tag_order_version holds one row per ordered sibling set, keyed by its parent_tag_id and tag_type
+	selectTagOrderVersion = "SELECT version FROM tag_order_version WHERE parent_tag_id = ? and tag_type = ?"
+	bumpTagOrderVersion   = "INSERT INTO tag_order_version(parent_tag_id, tag_type, version, updated_at) values(?,?,1,?) ON DUPLICATE KEY UPDATE version = version + 1, updated_at = VALUES(updated_at)"
+	createTagOrderVersion = "INSERT IGNORE INTO tag_order_version(parent_tag_id, tag_type, version, updated_at) values(?,?,1,?)"
+	updateTagOrderVersion = "UPDATE tag_order_version SET version = version + 1, updated_at = ? WHERE parent_tag_id = ? and tag_type = ? and version = ?"
 )
 
 func NewParentTagMappingRepository(db *sql.DB) *ParentTagMappingRepo {
 	return &ParentTagMappingRepo{db}
 }
 
-func (t *ParentTagMappingRepo) CreateParentTagMapping(tx *sql.Tx, parentTagMapping *domain.ParentTagMapping) (err error) {
+func (t *ParentTagMappingRepo) CreateParentTagMapping(ctx context.Context, tx *sql.Tx, parentTagMapping *domain.ParentTagMapping) (err error) {
+	ctx, cancel := withTimeout(ctx)
+	defer cancel()
 	txPresent := true
 	if tx == nil {
 		txPresent = false
-		ctx := context.Background()
 		tx, err = t.db.BeginTx(ctx, nil)
 		if err != nil {
 			return noonerror.New(noonerror.ErrInternalServer, "createParentTagMappingContextCreationError")
 		}
 	}
-	stmt, err := tx.Prepare(insertParentTagMapping)
+	stmt, err := tx.PrepareContext(ctx, insertParentTagMapping)
 	if err != nil {
 		logger.Client.Error("createParentTagMappingError", logger.GetErrorStack())
 		return noonerror.New(noonerror.ErrInternalServer, "createParentTagMappingError")
 	}
-	_, err = stmt.Exec(parentTagMapping.TagID, parentTagMapping.TagType, parentTagMapping.ParentTagType, *parentTagMapping.ParentTagID, parentTagMapping.Order, parentTagMapping.Hidden, parentTagMapping.Publish, parentTagMapping.CreatedAt.UnixNano()/1000000, parentTagMapping.UpdatedAt.UnixNano()/1000000)
+	_, err = stmt.ExecContext(ctx, parentTagMapping.TagID, parentTagMapping.TagType, parentTagMapping.ParentTagType, *parentTagMapping.ParentTagID, parentTagMapping.Order, parentTagMapping.Hidden, parentTagMapping.Publish, parentTagMapping.CreatedAt.UnixNano()/1000000, parentTagMapping.UpdatedAt.UnixNano()/1000000)
 	if err != nil {
 		logger.Client.Error("createParentTagMappingError", logger.GetErrorStack())
 		return noonerror.New(noonerror.ErrInternalServer, "createParentTagMappingError")
@@ -60,8 +67,10 @@ func (t *ParentTagMappingRepo) CreateParentTagMapping(tx *sql.Tx, parentTagMappi
 	return
 }
 
-func (t *ParentTagMappingRepo) FetchFilteredParentTagMappings(tagType *string, id *string) (parentTagMappings []*domain.ParentTagMapping, err error) {
-	rows, err := t.db.Query(filterParentTagMapping, *tagType, *id)
+func (t *ParentTagMappingRepo) FetchFilteredParentTagMappings(ctx context.Context, tagType *string, id *string) (parentTagMappings []*domain.ParentTagMapping, err error) {
+	ctx, cancel := withTimeout(ctx)
+	defer cancel()
+	rows, err := t.db.QueryContext(ctx, filterParentTagMapping, *tagType, *id)
 	if err != nil {
 		logger.Client.Error("fetchFilteredParentTagMappingsError", logger.GetErrorStack())
 		return nil, noonerror.New(noonerror.ErrInternalServer, "parentTagMappingDBReadError")
@@ -76,7 +85,9 @@ func (t *ParentTagMappingRepo) FetchFilteredParentTagMappings(tagType *string, i
 	return tagsList, nil
 }
 
-func (t *ParentTagMappingRepo) FetchByInParentTagMappings(ids []*string) (parentTagMappings []*domain.ParentTagMapping, err error) {
+func (t *ParentTagMappingRepo) FetchByInParentTagMappings(ctx context.Context, ids []*string) (parentTagMappings []*domain.ParentTagMapping, err error) {
+	ctx, cancel := withTimeout(ctx)
+	defer cancel()
 	if len(ids) == 0 {
 		return
 	}
@@ -85,7 +96,7 @@ func (t *ParentTagMappingRepo) FetchByInParentTagMappings(ids []*string) (parent
 		args[i] = id
 	}
 	stmt := `SELECT * FROM parent_tag_mapping WHERE tag_id in (?` + strings.Repeat(",?", len(args)-1) + `) and publish = 1`
-	rows, err := t.db.Query(stmt, args...)
+	rows, err := t.db.QueryContext(ctx, stmt, args...)
 	if err != nil {
 		logger.Client.Error("fetchParentTagMappingsError", logger.GetErrorStack())
 		return
@@ -100,8 +111,10 @@ func (t *ParentTagMappingRepo) FetchByInParentTagMappings(ids []*string) (parent
 	return tagsList, nil
 }
 
-func (t *ParentTagMappingRepo) FetchParentTagMappings(id *string) (parentTagMappings []*domain.ParentTagMapping, err error) {
-	rows, err := t.db.Query(selectParentTagMapping, *id)
+func (t *ParentTagMappingRepo) FetchParentTagMappings(ctx context.Context, id *string) (parentTagMappings []*domain.ParentTagMapping, err error) {
+	ctx, cancel := withTimeout(ctx)
+	defer cancel()
+	rows, err := t.db.QueryContext(ctx, selectParentTagMapping, *id)
 	if err != nil {
 		logger.Client.Error("fetchParentTagMappingsError", logger.GetErrorStack())
 		return nil, noonerror.New(noonerror.ErrInternalServer, "parentTagMappingDBReadError")
@@ -116,8 +129,10 @@ func (t *ParentTagMappingRepo) FetchParentTagMappings(id *string) (parentTagMapp
 	return tagsList, nil
 }
 
-func (t *ParentTagMappingRepo) FetchParentTagMappingByParentTagIdTagId(tagId *string, parentTagId *string) (parentTagMappings *domain.ParentTagMapping, err error) {
-	rows, err := t.db.Query(selectParentTagMappingByParentTagIdTagId, *tagId, *parentTagId)
+func (t *ParentTagMappingRepo) FetchParentTagMappingByParentTagIdTagId(ctx context.Context, tagId *string, parentTagId *string) (parentTagMappings *domain.ParentTagMapping, err error) {
+	ctx, cancel := withTimeout(ctx)
+	defer cancel()
+	rows, err := t.db.QueryContext(ctx, selectParentTagMappingByParentTagIdTagId, *tagId, *parentTagId)
 	if err != nil {
 		logger.Client.Error("fetchParentTagMappingsError", logger.GetErrorStack())
 		return nil, noonerror.New(noonerror.ErrInternalServer, "parentTagMappingDBReadError")
@@ -138,7 +153,9 @@ func (t *ParentTagMappingRepo) FetchParentTagMappingByParentTagIdTagId(tagId *st
 	return tagsList[0], nil
 }
 
-func (t *ParentTagMappingRepo) FetchByInParentTagMappingsByParentTagIdTagIds(ids []*string, parentTagId *string) (parentTagMappings []*domain.ParentTagMapping, err error) {
+func (t *ParentTagMappingRepo) FetchByInParentTagMappingsByParentTagIdTagIds(ctx context.Context, ids []*string, parentTagId *string) (parentTagMappings []*domain.ParentTagMapping, err error) {
+	ctx, cancel := withTimeout(ctx)
+	defer cancel()
 	if len(ids) == 0 {
 		return
 	}
@@ -148,7 +165,7 @@ func (t *ParentTagMappingRepo) FetchByInParentTagMappingsByParentTagIdTagIds(ids
 	}
 	args[len(ids)] = *parentTagId
 	stmt := `SELECT * FROM parent_tag_mapping WHERE tag_id in (?` + strings.Repeat(",?", len(args)-2) + `) and parent_tag_id = ? and publish = 1`
-	rows, err := t.db.Query(stmt, args...)
+	rows, err := t.db.QueryContext(ctx, stmt, args...)
 	if err != nil {
 		logger.Client.Error("fetchParentTagMappingsError", logger.GetErrorStack())
 		return
@@ -163,8 +180,10 @@ func (t *ParentTagMappingRepo) FetchByInParentTagMappingsByParentTagIdTagIds(ids
 	return tagsList, nil
 }
 
-func (t *ParentTagMappingRepo) FetchParentTagMappingsByParentTagIds(parentTagId *string, tagType *string) (parentTagMappings []*domain.ParentTagMapping, err error) {
-	rows, err := t.db.Query(selectParentTagMappingByParentTagIds, *parentTagId, *tagType)
+func (t *ParentTagMappingRepo) FetchParentTagMappingsByParentTagIds(ctx context.Context, parentTagId *string, tagType *string) (parentTagMappings []*domain.ParentTagMapping, err error) {
+	ctx, cancel := withTimeout(ctx)
+	defer cancel()
+	rows, err := t.db.QueryContext(ctx, selectParentTagMappingByParentTagIds, *parentTagId, *tagType)
 	if err != nil {
 		logger.Client.Error("fetchParentTagMappingsByParentTagIdsError", logger.GetErrorStack())
 		return nil, noonerror.New(noonerror.ErrInternalServer, "parentTagMappingsDBReadError")
@@ -179,17 +198,39 @@ func (t *ParentTagMappingRepo) FetchParentTagMappingsByParentTagIds(parentTagId
 	return tagsList, nil
 }
 
-func (t *ParentTagMappingRepo) ToggleHideParentTagMapping(tx *sql.Tx, hidden bool, id *string) (err error) {
+func (t *ParentTagMappingRepo) FetchTagIdsByParentTagPrefix(ctx context.Context, parentTagId *string) (tagIds []*string, err error) {
+	ctx, cancel := withTimeout(ctx)
+	defer cancel()
+	rows, err := t.db.QueryContext(ctx, selectTagIdsByParentTagPrefix, *parentTagId, *parentTagId+".%")
+	if err != nil {
+		logger.Client.Error("fetchTagIdsByParentTagPrefixError", logger.GetErrorStack())
+		return nil, noonerror.New(noonerror.ErrInternalServer, "parentTagMappingsDBReadError")
+	}
+	defer func() {
+		_ = rows.Close()
+	}()
+	for rows.Next() {
+		var tagId sql.RawBytes
+		if err = rows.Scan(&tagId); err != nil {
+			return nil, noonerror.New(noonerror.ErrInternalServer, "parentTagMappingsMapperError")
+		}
+		tagIds = append(tagIds, converter.ConvertToStringPtr(string(tagId)))
+	}
+	return tagIds, nil
+}
+
+func (t *ParentTagMappingRepo) ToggleHideParentTagMapping(ctx context.Context, tx *sql.Tx, hidden bool, id *string) (err error) {
+	ctx, cancel := withTimeout(ctx)
+	defer cancel()
 	txPresent := true
 	if tx == nil {
 		txPresent = false
-		ctx := context.Background()
 		tx, err = t.db.BeginTx(ctx, nil)
 		if err != nil {
 			return noonerror.New(noonerror.ErrInternalServer, "toggleHideParentTagMappingContextCreationError")
 		}
 	}
-	_, err = tx.Query(toggleHideParentTagMapping, hidden, time.Now().UnixNano()/1000000, *id)
+	_, err = tx.QueryContext(ctx, toggleHideParentTagMapping, hidden, time.Now().UnixNano()/1000000, *id)
 	if err != nil {
 		logger.Client.Error("toggleHideTagsError", logger.GetErrorStack())
 		return
@@ -202,17 +243,18 @@ func (t *ParentTagMappingRepo) ToggleHideParentTagMapping(tx *sql.Tx, hidden boo
 	return
 }
 
-func (t *ParentTagMappingRepo) DeleteParentTagMapping(tx *sql.Tx, id *string) (err error) {
+func (t *ParentTagMappingRepo) DeleteParentTagMapping(ctx context.Context, tx *sql.Tx, id *string) (err error) {
+	ctx, cancel := withTimeout(ctx)
+	defer cancel()
 	txPresent := true
 	if tx == nil {
 		txPresent = false
-		ctx := context.Background()
 		tx, err = t.db.BeginTx(ctx, nil)
 		if err != nil {
 			return noonerror.New(noonerror.ErrInternalServer, "deleteParentTagMappingContextCreationError")
 		}
 	}
-	_, err = tx.Query(deleteParentTagMapping, time.Now().UnixNano()/1000000, *id)
+	_, err = tx.QueryContext(ctx, deleteParentTagMapping, time.Now().UnixNano()/1000000, *id)
 	if err != nil {
 		logger.Client.Error("deleteTagsError", logger.GetErrorStack())
 		return
@@ -225,8 +267,10 @@ func (t *ParentTagMappingRepo) DeleteParentTagMapping(tx *sql.Tx, id *string) (e
 	return
 }
 
-func (t *ParentTagMappingRepo) IsCollegePresent(tagType *string, tagId *string) (hasCollege bool, err error) {
-	rows, err := t.db.Query(fetchParentTagMapping, *tagType, *tagId)
+func (t *ParentTagMappingRepo) IsCollegePresent(ctx context.Context, tagType *string, tagId *string) (hasCollege bool, err error) {
+	ctx, cancel := withTimeout(ctx)
+	defer cancel()
+	rows, err := t.db.QueryContext(ctx, fetchParentTagMapping, *tagType, *tagId)
 	if err != nil {
 		logger.Client.Error("fetchParentTagMapping", logger.GetErrorStack())
 		return false, nil
@@ -241,17 +285,18 @@ func (t *ParentTagMappingRepo) IsCollegePresent(tagType *string, tagId *string)
 	return hasResults, nil
 }
 
-func (t *ParentTagMappingRepo) UpdateTagOrder(tx *sql.Tx, order *int, id *string) (err error) {
+func (t *ParentTagMappingRepo) UpdateTagOrder(ctx context.Context, tx *sql.Tx, order *int, id *string) (err error) {
+	ctx, cancel := withTimeout(ctx)
+	defer cancel()
 	txPresent := true
 	if tx == nil {
 		txPresent = false
-		ctx := context.Background()
 		tx, err = t.db.BeginTx(ctx, nil)
 		if err != nil {
 			return noonerror.New(noonerror.ErrInternalServer, "updateTagOrderContextCreationError")
 		}
 	}
-	_, err = tx.Query(updateTagOrderParentTagMapping, order, time.Now().UnixNano()/1000000, *id)
+	_, err = tx.QueryContext(ctx, updateTagOrderParentTagMapping, order, time.Now().UnixNano()/1000000, *id)
 	if err != nil {
 		logger.Client.Error("updateTagOrderError", logger.GetErrorStack())
 		return
@@ -264,6 +309,67 @@ func (t *ParentTagMappingRepo) UpdateTagOrder(tx *sql.Tx, order *int, id *string
 	return
 }
 
+// FetchTagOrderVersion returns the version of the sibling set, a set which was never reordered is at 0
+func (t *ParentTagMappingRepo) FetchTagOrderVersion(ctx context.Context, parentTagIds *string, tagType *string) (version int, err error) {
+	ctx, cancel := withTimeout(ctx)
+	defer cancel()
+	err = t.db.QueryRowContext(ctx, selectTagOrderVersion, *parentTagIds, *tagType).Scan(&version)
+	if err == sql.ErrNoRows {
+		return 0, nil
+	}
+	if err != nil {
+		logger.Client.Error("fetchTagOrderVersionError", logger.GetErrorStack())
+		return 0, noonerror.New(noonerror.ErrInternalServer, "fetchTagOrderVersionError")
+	}
+	return version, nil
+}
+
+// UpdateTagOrderVersion bumps the version of the sibling set. With a version given the bump only applies while the set
+// is still at it, the row stays locked until tx ends so that a concurrent reorder waits and then fails the check.
+func (t *ParentTagMappingRepo) UpdateTagOrderVersion(ctx context.Context, tx *sql.Tx, parentTagIds *string, tagType *string, version *int) (err error) {
+	ctx, cancel := withTimeout(ctx)
+	defer cancel()
+	txPresent := true
+	if tx == nil {
+		txPresent = false
+		tx, err = t.db.BeginTx(ctx, nil)
+		if err != nil {
+			return noonerror.New(noonerror.ErrInternalServer, "updateTagOrderVersionContextCreationError")
+		}
+	}
+	now := time.Now().UnixNano() / 1000000
+	var result sql.Result
+	switch {
+	case version == nil:
+		result, err = tx.ExecContext(ctx, bumpTagOrderVersion, *parentTagIds, *tagType, now)
+	case *version == 0:
+		result, err = tx.ExecContext(ctx, createTagOrderVersion, *parentTagIds, *tagType, now)
+	default:
+		result, err = tx.ExecContext(ctx, updateTagOrderVersion, now, *parentTagIds, *tagType, *version)
+	}
+	if err != nil {
+		logger.Client.Error("updateTagOrderVersionError", logger.GetErrorStack())
+		if !txPresent {
+			_ = tx.Rollback()
+		}
+		return noonerror.New(noonerror.ErrInternalServer, "updateTagOrderVersionError")
+	}
+	if version != nil {
+		if affected, err := result.RowsAffected(); err != nil || affected == 0 {
+			if !txPresent {
+				_ = tx.Rollback()
+			}
+			return noonerror.New(noonerror.ErrConflict, "tagOrderVersionConflict")
+		}
+	}
+	if !txPresent {
+		if err = tx.Commit(); err != nil {
+			return noonerror.New(noonerror.ErrInternalServer, "updateTagOrderVersionCommitError")
+		}
+	}
+	return
+}
+
 func parentTagMappingRowMapper(rows *sql.Rows) (parentTagMappings []*domain.ParentTagMapping, err error) {
 	columns, err := rows.Columns()
 	if err != nil {
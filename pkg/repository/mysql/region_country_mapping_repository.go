@@ -0,0 +1,123 @@
+package repository
+
+import (
+	"bitbucket.org/noon-micro/curriculum/pkg/domain"
+	"bitbucket.org/noon-micro/curriculum/pkg/lib/converter"
+	noonerror "bitbucket.org/noon-micro/curriculum/pkg/lib/error"
+	"bitbucket.org/noon-micro/curriculum/pkg/lib/logger"
+	"context"
+	"database/sql"
+	"strconv"
+	"strings"
+	"time"
+)
+
+type RegionCountryMappingRepo struct {
+	db *sql.DB
+}
+
+var (
+	insertRegionCountryMapping = "INSERT INTO region_country_mapping(region_id, country_id, `order`, publish, created_at, updated_at) values(?,?,?,?,?,?)"
+	deleteRegionCountryMapping = "UPDATE region_country_mapping SET publish = 0, updated_at = ? where id = ?"
+)
+
+func NewRegionCountryMappingRepository(db *sql.DB) *RegionCountryMappingRepo {
+	return &RegionCountryMappingRepo{db}
+}
+
+func (t *RegionCountryMappingRepo) CreateRegionCountryMapping(ctx context.Context, tx *sql.Tx, regionCountryMapping *domain.RegionCountryMapping) (err error) {
+	ctx, cancel := withTimeout(ctx)
+	defer cancel()
+	_, err = tx.ExecContext(ctx, insertRegionCountryMapping, regionCountryMapping.RegionID, regionCountryMapping.CountryId, regionCountryMapping.Order, regionCountryMapping.Publish, regionCountryMapping.CreatedAt.UnixNano()/1000000, regionCountryMapping.UpdatedAt.UnixNano()/1000000)
+	if err != nil {
+		logger.Client.Error("createRegionCountryMappingError", logger.GetErrorStack())
+		return noonerror.New(noonerror.ErrInternalServer, "createRegionCountryMappingError")
+	}
+	return
+}
+
+func (t *RegionCountryMappingRepo) FetchByInRegionCountryMappings(ctx context.Context, ids []*string) (regionCountryMappings []*domain.RegionCountryMapping, err error) {
+	ctx, cancel := withTimeout(ctx)
+	defer cancel()
+	if len(ids) == 0 {
+		return
+	}
+	args := make([]interface{}, len(ids))
+	for i, id := range ids {
+		args[i] = id
+	}
+	stmt := `SELECT * FROM region_country_mapping WHERE region_id in (?` + strings.Repeat(",?", len(args)-1) + `) and publish = 1 ORDER BY ` + "`order`"
+	rows, err := t.db.QueryContext(ctx, stmt, args...)
+	if err != nil {
+		logger.Client.Error("fetchRegionCountryMappingError", logger.GetErrorStack())
+		return nil, noonerror.New(noonerror.ErrInternalServer, "fetchRegionCountryMappingError")
+	}
+	defer func() {
+		_ = rows.Close()
+	}()
+	regionCountryMappings, err = regionCountryMappingRowMapper(rows)
+	if err != nil {
+		return nil, noonerror.New(noonerror.ErrInternalServer, "fetchRegionCountryMappingError")
+	}
+	return regionCountryMappings, nil
+}
+
+func (t *RegionCountryMappingRepo) DeleteRegionCountryMapping(ctx context.Context, tx *sql.Tx, id *string) (err error) {
+	ctx, cancel := withTimeout(ctx)
+	defer cancel()
+	_, err = tx.ExecContext(ctx, deleteRegionCountryMapping, time.Now().UnixNano()/1000000, *id)
+	if err != nil {
+		logger.Client.Error("deleteRegionCountryMappingError", logger.GetErrorStack())
+		return noonerror.New(noonerror.ErrInternalServer, "deleteRegionCountryMappingError")
+	}
+	return
+}
+
+func regionCountryMappingRowMapper(rows *sql.Rows) (regionCountryMappings []*domain.RegionCountryMapping, err error) {
+	columns, err := rows.Columns()
+	if err != nil {
+		return
+	}
+	values := make([]sql.RawBytes, len(columns))
+	scanArgs := make([]interface{}, len(values))
+	for i := range values {
+		scanArgs[i] = &values[i]
+	}
+	for rows.Next() {
+		mapping := &domain.RegionCountryMapping{}
+		err = rows.Scan(scanArgs...)
+		if err != nil {
+			return
+		}
+		for i, col := range values {
+			switch columns[i] {
+			case "id":
+				mapping.ID = converter.ConvertToStringPtr(string(col))
+			case "region_id":
+				mapping.RegionID = converter.ConvertToStringPtr(string(col))
+			case "country_id":
+				mapping.CountryId = converter.ConvertToStringPtr(string(col))
+			case "order":
+				order, _ := strconv.Atoi(string(col))
+				mapping.Order = &order
+			case "publish":
+				mapping.Publish, err = strconv.ParseBool(string(col))
+			case "created_at":
+				var timeMilli int64
+				timeMilli, err = strconv.ParseInt(string(col), 10, 64)
+				mapping.CreatedAt = time.Unix(0, timeMilli*int64(time.Millisecond)).UTC()
+			case "updated_at":
+				var timeMilli int64
+				timeMilli, err = strconv.ParseInt(string(col), 10, 64)
+				mapping.UpdatedAt = time.Unix(0, timeMilli*int64(time.Millisecond)).UTC()
+			default:
+				return nil, noonerror.New(noonerror.ErrInternalServer, "invalid column in region_country_mapping table")
+			}
+			if err != nil {
+				return nil, err
+			}
+		}
+		regionCountryMappings = append(regionCountryMappings, mapping)
+	}
+	return regionCountryMappings, nil
+}
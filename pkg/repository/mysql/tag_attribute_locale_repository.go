@@ -0,0 +1,128 @@
+package repository
+
+import (
+	"bitbucket.org/noon-micro/curriculum/pkg/domain"
+	"bitbucket.org/noon-micro/curriculum/pkg/lib/converter"
+	noonerror "bitbucket.org/noon-micro/curriculum/pkg/lib/error"
+	"bitbucket.org/noon-micro/curriculum/pkg/lib/logger"
+	"context"
+	"database/sql"
+	"strconv"
+	"strings"
+	"time"
+)
+
+type TagAttributeLocaleRepo struct {
+	db *sql.DB
+}
+
+var (
+	insertTagAttributeLocale = "INSERT INTO tag_attribute_locale_mapping(tag_id, tag_type, attribute, locale, country_id, `value`, publish, created_at, updated_at) values(?,?,?,?,?,?,?,?,?)"
+	deleteTagAttributeLocale = "UPDATE tag_attribute_locale_mapping SET publish = 0, updated_at = ? where id = ?"
+)
+
+func NewTagAttributeLocaleRepository(db *sql.DB) *TagAttributeLocaleRepo {
+	return &TagAttributeLocaleRepo{db}
+}
+
+func (t *TagAttributeLocaleRepo) CreateTagAttributeLocale(ctx context.Context, tx *sql.Tx, tagAttributeLocale *domain.TagAttributeLocale) (err error) {
+	ctx, cancel := withTimeout(ctx)
+	defer cancel()
+	_, err = tx.ExecContext(ctx, insertTagAttributeLocale, tagAttributeLocale.TagID, tagAttributeLocale.TagType, tagAttributeLocale.Attribute, tagAttributeLocale.Locale, *tagAttributeLocale.CountryId, tagAttributeLocale.Value, tagAttributeLocale.Publish, tagAttributeLocale.CreatedAt.UnixNano()/1000000, tagAttributeLocale.UpdatedAt.UnixNano()/1000000)
+	if err != nil {
+		logger.Client.Error("createTagAttributeLocaleError", logger.GetErrorStack())
+		return noonerror.New(noonerror.ErrInternalServer, "createTagAttributeLocaleError")
+	}
+	return
+}
+
+func (t *TagAttributeLocaleRepo) FetchByInTagAttributeLocales(ctx context.Context, ids []*string) (tagAttributeLocales []*domain.TagAttributeLocale, err error) {
+	ctx, cancel := withTimeout(ctx)
+	defer cancel()
+	if len(ids) == 0 {
+		return
+	}
+	args := make([]interface{}, len(ids))
+	for i, id := range ids {
+		args[i] = id
+	}
+	stmt := `SELECT * FROM tag_attribute_locale_mapping WHERE tag_id in (?` + strings.Repeat(",?", len(args)-1) + `) and publish = 1`
+	rows, err := t.db.QueryContext(ctx, stmt, args...)
+	if err != nil {
+		logger.Client.Error("fetchTagAttributeLocaleError", logger.GetErrorStack())
+		return nil, noonerror.New(noonerror.ErrInternalServer, "fetchTagAttributeLocaleError")
+	}
+	defer func() {
+		_ = rows.Close()
+	}()
+	tagAttributeLocales, err = tagAttributeLocaleRowMapper(rows)
+	if err != nil {
+		return nil, noonerror.New(noonerror.ErrInternalServer, "fetchTagAttributeLocaleError")
+	}
+	return tagAttributeLocales, nil
+}
+
+func (t *TagAttributeLocaleRepo) DeleteTagAttributeLocale(ctx context.Context, tx *sql.Tx, id *string) (err error) {
+	ctx, cancel := withTimeout(ctx)
+	defer cancel()
+	_, err = tx.ExecContext(ctx, deleteTagAttributeLocale, time.Now().UnixNano()/1000000, *id)
+	if err != nil {
+		logger.Client.Error("deleteTagAttributeLocaleError", logger.GetErrorStack())
+		return noonerror.New(noonerror.ErrInternalServer, "deleteTagAttributeLocaleError")
+	}
+	return
+}
+
+func tagAttributeLocaleRowMapper(rows *sql.Rows) (tagAttributeLocales []*domain.TagAttributeLocale, err error) {
+	columns, err := rows.Columns()
+	if err != nil {
+		return
+	}
+	values := make([]sql.RawBytes, len(columns))
+	scanArgs := make([]interface{}, len(values))
+	for i := range values {
+		scanArgs[i] = &values[i]
+	}
+	for rows.Next() {
+		tag := &domain.TagAttributeLocale{}
+		err = rows.Scan(scanArgs...)
+		if err != nil {
+			return
+		}
+		for i, col := range values {
+			switch columns[i] {
+			case "id":
+				tag.ID = converter.ConvertToStringPtr(string(col))
+			case "tag_id":
+				tag.TagID = converter.ConvertToStringPtr(string(col))
+			case "tag_type":
+				tag.TagType = converter.ConvertToStringPtr(string(col))
+			case "attribute":
+				tag.Attribute = converter.ConvertToStringPtr(string(col))
+			case "locale":
+				tag.Locale = converter.ConvertToStringPtr(string(col))
+			case "country_id":
+				tag.CountryId = converter.ConvertToStringPtr(string(col))
+			case "value":
+				tag.Value = converter.ConvertToStringPtr(string(col))
+			case "publish":
+				tag.Publish, err = strconv.ParseBool(string(col))
+			case "created_at":
+				var timeMilli int64
+				timeMilli, err = strconv.ParseInt(string(col), 10, 64)
+				tag.CreatedAt = time.Unix(0, timeMilli*int64(time.Millisecond)).UTC()
+			case "updated_at":
+				var timeMilli int64
+				timeMilli, err = strconv.ParseInt(string(col), 10, 64)
+				tag.UpdatedAt = time.Unix(0, timeMilli*int64(time.Millisecond)).UTC()
+			default:
+				return nil, noonerror.New(noonerror.ErrInternalServer, "invalid column in tag_attribute_locale_mapping table")
+			}
+			if err != nil {
+				return nil, err
+			}
+		}
+		tagAttributeLocales = append(tagAttributeLocales, tag)
+	}
+	return tagAttributeLocales, nil
+}
@@ -0,0 +1,121 @@
+package repository
+
+import (
+	"bitbucket.org/noon-micro/curriculum/pkg/domain"
+	"bitbucket.org/noon-micro/curriculum/pkg/lib/converter"
+	noonerror "bitbucket.org/noon-micro/curriculum/pkg/lib/error"
+	"bitbucket.org/noon-micro/curriculum/pkg/lib/logger"
+	"context"
+	"database/sql"
+	"strconv"
+	"strings"
+	"time"
+)
+
+type TagChangeRepo struct {
+	db *sql.DB
+}
+
+var (
+	// insertTagChange relies on the unique key of event_id to record a retried event once
+	insertTagChange  = "INSERT IGNORE INTO tag_change(event_id, event_type, entity, tag_id, tag_type, parent_tags, changed_at, recorded_at) values(?,?,?,?,?,?,?,?)"
+	selectTagChanges = "SELECT * FROM tag_change WHERE id > ? ORDER BY id LIMIT ?"
+	deleteTagChanges = "DELETE FROM tag_change WHERE recorded_at < ?"
+)
+
+func NewTagChangeRepository(db *sql.DB) *TagChangeRepo {
+	return &TagChangeRepo{db}
+}
+
+func (t *TagChangeRepo) CreateTagChange(ctx context.Context, tx *sql.Tx, change *domain.TagChange) (err error) {
+	ctx, cancel := withTimeout(ctx)
+	defer cancel()
+	_, err = tx.ExecContext(ctx, insertTagChange, change.EventID, change.EventType, change.Entity, change.TagId, change.TagType, strings.Join(change.ParentTags, ","),
+		change.ChangedAt.UnixNano()/1000000, change.RecordedAt.UnixNano()/1000000)
+	if err != nil {
+		logger.Client.Error("createTagChangeError", logger.GetErrorStack())
+		return noonerror.New(noonerror.ErrInternalServer, "createTagChangeError")
+	}
+	return
+}
+
+func (t *TagChangeRepo) FetchTagChanges(ctx context.Context, after int64, limit int) (changes []*domain.TagChange, err error) {
+	ctx, cancel := withTimeout(ctx)
+	defer cancel()
+	rows, err := t.db.QueryContext(ctx, selectTagChanges, after, limit)
+	if err != nil {
+		logger.Client.Error("fetchTagChangesError", logger.GetErrorStack())
+		return nil, noonerror.New(noonerror.ErrInternalServer, "fetchTagChangesError")
+	}
+	defer func() {
+		_ = rows.Close()
+	}()
+	changes, err = tagChangeRowMapper(rows)
+	if err != nil {
+		return nil, noonerror.New(noonerror.ErrInternalServer, "fetchTagChangesError")
+	}
+	return changes, nil
+}
+
+func (t *TagChangeRepo) DeleteTagChanges(ctx context.Context, recordedBefore time.Time) (err error) {
+	ctx, cancel := withTimeout(ctx)
+	defer cancel()
+	_, err = t.db.ExecContext(ctx, deleteTagChanges, recordedBefore.UnixNano()/1000000)
+	if err != nil {
+		logger.Client.Error("deleteTagChangesError", logger.GetErrorStack())
+		return noonerror.New(noonerror.ErrInternalServer, "deleteTagChangesError")
+	}
+	return
+}
+
+func tagChangeRowMapper(rows *sql.Rows) (changes []*domain.TagChange, err error) {
+	columns, err := rows.Columns()
+	if err != nil {
+		return
+	}
+	values := make([]sql.RawBytes, len(columns))
+	scanArgs := make([]interface{}, len(values))
+	for i := range values {
+		scanArgs[i] = &values[i]
+	}
+	for rows.Next() {
+		change := &domain.TagChange{}
+		err = rows.Scan(scanArgs...)
+		if err != nil {
+			return
+		}
+		for i, col := range values {
+			switch columns[i] {
+			case "id":
+				change.Sequence, err = strconv.ParseInt(string(col), 10, 64)
+			case "event_id":
+				change.EventID = converter.ConvertToStringPtr(string(col))
+			case "event_type":
+				change.EventType = converter.ConvertToStringPtr(string(col))
+			case "entity":
+				change.Entity = converter.ConvertToStringPtr(string(col))
+			case "tag_id":
+				change.TagId = converter.ConvertToStringPtr(string(col))
+			case "tag_type":
+				change.TagType = converter.ConvertToStringPtr(string(col))
+			case "parent_tags":
+				change.ParentTags = splitList(string(col))
+			case "changed_at":
+				var timeMilli int64
+				timeMilli, err = strconv.ParseInt(string(col), 10, 64)
+				change.ChangedAt = time.Unix(0, timeMilli*int64(time.Millisecond)).UTC()
+			case "recorded_at":
+				var timeMilli int64
+				timeMilli, err = strconv.ParseInt(string(col), 10, 64)
+				change.RecordedAt = time.Unix(0, timeMilli*int64(time.Millisecond)).UTC()
+			default:
+				// a column added by a migration ahead of the release reading it is skipped
+			}
+			if err != nil {
+				return nil, err
+			}
+		}
+		changes = append(changes, change)
+	}
+	return changes, nil
+}
@@ -0,0 +1,124 @@
+package repository
+
+import (
+	"bitbucket.org/noon-micro/curriculum/pkg/domain"
+	"bitbucket.org/noon-micro/curriculum/pkg/lib/converter"
+	noonerror "bitbucket.org/noon-micro/curriculum/pkg/lib/error"
+	"bitbucket.org/noon-micro/curriculum/pkg/lib/logger"
+	"context"
+	"database/sql"
+	"strconv"
+	"strings"
+	"time"
+)
+
+type TagCountryRuleRepo struct {
+	db *sql.DB
+}
+
+var (
+	insertTagCountryRule = "INSERT INTO tag_country_rule(tag_id, tag_type, country_id, rule, publish, created_at, updated_at) values(?,?,?,?,?,?,?)"
+	deleteTagCountryRule = "UPDATE tag_country_rule SET publish = 0, updated_at = ? where id = ?"
+)
+
+func NewTagCountryRuleRepository(db *sql.DB) *TagCountryRuleRepo {
+	return &TagCountryRuleRepo{db}
+}
+
+func (t *TagCountryRuleRepo) CreateTagCountryRule(ctx context.Context, tx *sql.Tx, tagCountryRule *domain.TagCountryRule) (err error) {
+	ctx, cancel := withTimeout(ctx)
+	defer cancel()
+	_, err = tx.ExecContext(ctx, insertTagCountryRule, tagCountryRule.TagID, tagCountryRule.TagType, *tagCountryRule.CountryId, tagCountryRule.Rule, tagCountryRule.Publish, tagCountryRule.CreatedAt.UnixNano()/1000000, tagCountryRule.UpdatedAt.UnixNano()/1000000)
+	if err != nil {
+		logger.Client.Error("createTagCountryRuleError", logger.GetErrorStack())
+		return noonerror.New(noonerror.ErrInternalServer, "createTagCountryRuleError")
+	}
+	return
+}
+
+func (t *TagCountryRuleRepo) FetchByInTagCountryRules(ctx context.Context, ids []*string) (tagCountryRules []*domain.TagCountryRule, err error) {
+	ctx, cancel := withTimeout(ctx)
+	defer cancel()
+	if len(ids) == 0 {
+		return
+	}
+	args := make([]interface{}, len(ids))
+	for i, id := range ids {
+		args[i] = id
+	}
+	stmt := `SELECT * FROM tag_country_rule WHERE tag_id in (?` + strings.Repeat(",?", len(args)-1) + `) and publish = 1`
+	rows, err := t.db.QueryContext(ctx, stmt, args...)
+	if err != nil {
+		logger.Client.Error("fetchTagCountryRuleError", logger.GetErrorStack())
+		return nil, noonerror.New(noonerror.ErrInternalServer, "fetchTagCountryRuleError")
+	}
+	defer func() {
+		_ = rows.Close()
+	}()
+	tagCountryRules, err = tagCountryRuleRowMapper(rows)
+	if err != nil {
+		return nil, noonerror.New(noonerror.ErrInternalServer, "fetchTagCountryRuleError")
+	}
+	return tagCountryRules, nil
+}
+
+func (t *TagCountryRuleRepo) DeleteTagCountryRule(ctx context.Context, tx *sql.Tx, id *string) (err error) {
+	ctx, cancel := withTimeout(ctx)
+	defer cancel()
+	_, err = tx.ExecContext(ctx, deleteTagCountryRule, time.Now().UnixNano()/1000000, *id)
+	if err != nil {
+		logger.Client.Error("deleteTagCountryRuleError", logger.GetErrorStack())
+		return noonerror.New(noonerror.ErrInternalServer, "deleteTagCountryRuleError")
+	}
+	return
+}
+
+func tagCountryRuleRowMapper(rows *sql.Rows) (tagCountryRules []*domain.TagCountryRule, err error) {
+	columns, err := rows.Columns()
+	if err != nil {
+		return
+	}
+	values := make([]sql.RawBytes, len(columns))
+	scanArgs := make([]interface{}, len(values))
+	for i := range values {
+		scanArgs[i] = &values[i]
+	}
+	for rows.Next() {
+		tag := &domain.TagCountryRule{}
+		err = rows.Scan(scanArgs...)
+		if err != nil {
+			return
+		}
+		for i, col := range values {
+			switch columns[i] {
+			case "id":
+				tag.ID = converter.ConvertToStringPtr(string(col))
+			case "tag_id":
+				tag.TagID = converter.ConvertToStringPtr(string(col))
+			case "tag_type":
+				tag.TagType = converter.ConvertToStringPtr(string(col))
+			case "country_id":
+				tag.CountryId = converter.ConvertToStringPtr(string(col))
+			case "rule":
+				tag.Rule = converter.ConvertToStringPtr(string(col))
+			case "publish":
+				tag.Publish, err = strconv.ParseBool(string(col))
+			case "created_at":
+				var timeMilli int64
+				timeMilli, err = strconv.ParseInt(string(col), 10, 64)
+				tag.CreatedAt = time.Unix(0, timeMilli*int64(time.Millisecond)).UTC()
+			case "updated_at":
+				var timeMilli int64
+				timeMilli, err = strconv.ParseInt(string(col), 10, 64)
+				tag.UpdatedAt = time.Unix(0, timeMilli*int64(time.Millisecond)).UTC()
+			default:
+				return nil, noonerror.New(noonerror.ErrInternalServer, "invalid column in tag_country_rule table")
+			}
+			if err != nil {
+				return nil, err
+			}
+		}
+		tagCountryRules = append(tagCountryRules, tag)
+	}
+	return tagCountryRules, nil
+}
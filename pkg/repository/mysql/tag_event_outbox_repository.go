@@ -0,0 +1,171 @@
+package repository
+
+import (
+	"bitbucket.org/noon-micro/curriculum/pkg/domain"
+	noonerror "bitbucket.org/noon-micro/curriculum/pkg/lib/error"
+	"bitbucket.org/noon-micro/curriculum/pkg/lib/logger"
+	"context"
+	"database/sql"
+	"encoding/json"
+	"strconv"
+	"strings"
+	"time"
+)
+
+type TagEventOutboxRepo struct {
+	db *sql.DB
+}
+
+var (
+	insertTagEvent         = "INSERT INTO tag_event_outbox(event_id, event_type, schema_version, tag_id, tag_type, data, occurred_at) values(?,?,?,?,?,?,?)"
+	selectPendingTagEvents = "SELECT * FROM tag_event_outbox WHERE relayed_at IS NULL ORDER BY id LIMIT ? FOR UPDATE"
+	// countLiveTagEventClaims counts the pending events another relay holds a claim on which has not run out
+	countLiveTagEventClaims = "SELECT COUNT(*) FROM tag_event_outbox WHERE relayed_at IS NULL AND claimed_by <> ? AND claimed_until > ?"
+	releaseTagEventClaims   = "UPDATE tag_event_outbox SET claimed_by = NULL, claimed_until = NULL WHERE claimed_by = ? AND relayed_at IS NULL"
+	deleteRelayedTagEvents  = "DELETE FROM tag_event_outbox WHERE relayed_at < ?"
+)
+
+func NewTagEventOutboxRepository(db *sql.DB) *TagEventOutboxRepo {
+	return &TagEventOutboxRepo{db}
+}
+
+func (t *TagEventOutboxRepo) CreateTagEvent(ctx context.Context, tx *sql.Tx, event *domain.TagEvent) (err error) {
+	ctx, cancel := withTimeout(ctx)
+	defer cancel()
+	data, err := json.Marshal(event.Data)
+	if err != nil {
+		return noonerror.New(noonerror.ErrInternalServer, "createTagEventError")
+	}
+	res, err := tx.ExecContext(ctx, insertTagEvent, event.ID, event.Type, event.SchemaVersion, event.TagId, event.TagType, string(data),
+		event.OccurredAt.UnixNano()/1000000)
+	if err != nil {
+		logger.Client.Error("createTagEventError", logger.GetErrorStack())
+		return noonerror.New(noonerror.ErrInternalServer, "createTagEventError")
+	}
+	if event.Sequence, err = res.LastInsertId(); err != nil {
+		logger.Client.Error("createTagEventError", logger.GetErrorStack())
+		return noonerror.New(noonerror.ErrInternalServer, "createTagEventError")
+	}
+	return
+}
+
+// ClaimPendingTagEvents locks the oldest pending events until tx ends, the relays claim from the oldest event on so
+// the claim of another relay sits among the locked ones
+func (t *TagEventOutboxRepo) ClaimPendingTagEvents(ctx context.Context, tx *sql.Tx, relayId string, claimedAt time.Time, claimedUntil time.Time, limit int) (events []*domain.TagEvent, err error) {
+	ctx, cancel := withTimeout(ctx)
+	defer cancel()
+	rows, err := tx.QueryContext(ctx, selectPendingTagEvents, limit)
+	if err != nil {
+		logger.Client.Error("claimPendingTagEventsError", logger.GetErrorStack())
+		return nil, noonerror.New(noonerror.ErrInternalServer, "claimPendingTagEventsError")
+	}
+	events, err = tagEventRowMapper(rows)
+	_ = rows.Close()
+	if err != nil {
+		return nil, noonerror.New(noonerror.ErrInternalServer, "claimPendingTagEventsError")
+	}
+	if len(events) == 0 {
+		return nil, nil
+	}
+	var liveClaims int
+	if err = tx.QueryRowContext(ctx, countLiveTagEventClaims, relayId, claimedAt.UnixNano()/1000000).Scan(&liveClaims); err != nil {
+		logger.Client.Error("claimPendingTagEventsError", logger.GetErrorStack())
+		return nil, noonerror.New(noonerror.ErrInternalServer, "claimPendingTagEventsError")
+	}
+	if liveClaims > 0 {
+		return nil, nil
+	}
+	args := []interface{}{relayId, claimedUntil.UnixNano() / 1000000}
+	for _, v := range events {
+		args = append(args, v.Sequence)
+	}
+	stmt := `UPDATE tag_event_outbox SET claimed_by = ?, claimed_until = ? WHERE id in (?` + strings.Repeat(",?", len(events)-1) + `)`
+	if _, err = tx.ExecContext(ctx, stmt, args...); err != nil {
+		logger.Client.Error("claimPendingTagEventsError", logger.GetErrorStack())
+		return nil, noonerror.New(noonerror.ErrInternalServer, "claimPendingTagEventsError")
+	}
+	return events, nil
+}
+
+func (t *TagEventOutboxRepo) MarkTagEventsRelayed(ctx context.Context, tx *sql.Tx, relayId string, sequences []int64, relayedAt time.Time) (err error) {
+	ctx, cancel := withTimeout(ctx)
+	defer cancel()
+	if len(sequences) > 0 {
+		args := []interface{}{relayedAt.UnixNano() / 1000000, relayId}
+		for _, v := range sequences {
+			args = append(args, v)
+		}
+		stmt := `UPDATE tag_event_outbox SET relayed_at = ?, claimed_by = NULL, claimed_until = NULL WHERE claimed_by = ? AND id in (?` +
+			strings.Repeat(",?", len(sequences)-1) + `)`
+		if _, err = tx.ExecContext(ctx, stmt, args...); err != nil {
+			logger.Client.Error("markTagEventsRelayedError", logger.GetErrorStack())
+			return noonerror.New(noonerror.ErrInternalServer, "markTagEventsRelayedError")
+		}
+	}
+	if _, err = tx.ExecContext(ctx, releaseTagEventClaims, relayId); err != nil {
+		logger.Client.Error("markTagEventsRelayedError", logger.GetErrorStack())
+		return noonerror.New(noonerror.ErrInternalServer, "markTagEventsRelayedError")
+	}
+	return
+}
+
+func (t *TagEventOutboxRepo) DeleteRelayedTagEvents(ctx context.Context, relayedBefore time.Time) (err error) {
+	ctx, cancel := withTimeout(ctx)
+	defer cancel()
+	_, err = t.db.ExecContext(ctx, deleteRelayedTagEvents, relayedBefore.UnixNano()/1000000)
+	if err != nil {
+		logger.Client.Error("deleteRelayedTagEventsError", logger.GetErrorStack())
+		return noonerror.New(noonerror.ErrInternalServer, "deleteRelayedTagEventsError")
+	}
+	return
+}
+
+func tagEventRowMapper(rows *sql.Rows) (events []*domain.TagEvent, err error) {
+	columns, err := rows.Columns()
+	if err != nil {
+		return
+	}
+	values := make([]sql.RawBytes, len(columns))
+	scanArgs := make([]interface{}, len(values))
+	for i := range values {
+		scanArgs[i] = &values[i]
+	}
+	for rows.Next() {
+		event := &domain.TagEvent{}
+		err = rows.Scan(scanArgs...)
+		if err != nil {
+			return
+		}
+		for i, col := range values {
+			switch columns[i] {
+			case "id":
+				event.Sequence, err = strconv.ParseInt(string(col), 10, 64)
+			case "event_id":
+				event.ID = string(col)
+			case "event_type":
+				event.Type = string(col)
+			case "schema_version":
+				event.SchemaVersion, err = strconv.Atoi(string(col))
+			case "tag_id":
+				event.TagId = string(col)
+			case "tag_type":
+				event.TagType = string(col)
+			case "data":
+				if col != nil {
+					event.Data = json.RawMessage(append([]byte(nil), col...))
+				}
+			case "occurred_at":
+				var timeMilli int64
+				timeMilli, err = strconv.ParseInt(string(col), 10, 64)
+				event.OccurredAt = time.Unix(0, timeMilli*int64(time.Millisecond)).UTC()
+			default:
+				// relayed_at, the claim and the columns added by a migration ahead of the release reading them are skipped
+			}
+			if err != nil {
+				return nil, err
+			}
+		}
+		events = append(events, event)
+	}
+	return events, nil
+}
@@ -5,6 +5,7 @@ import (
 	"bitbucket.org/noon-micro/curriculum/pkg/lib/converter"
 	noonerror "bitbucket.org/noon-micro/curriculum/pkg/lib/error"
 	"bitbucket.org/noon-micro/curriculum/pkg/lib/logger"
+	"context"
 	"database/sql"
 	"strconv"
 	"strings"
@@ -20,19 +21,25 @@ var (
 	fetchTagLocaleMappingByLocale = "SELECT * FROM tag_locale_mapping WHERE tag_id = ? and country_id = ? and locale = ? and publish = 1"
 	insertTagLocaleMapping        = "INSERT INTO tag_locale_mapping(tag_id, locale, country_id, `name`, publish, tag_type, created_at, updated_at) values(?,?,?,?,?,?,?,?)"
 	deleteTagLocaleMapping        = "UPDATE tag_locale_mapping SET publish = 0, updated_at = ? where id = ?"
+	// drafts need the columns added by migrations/0001_tag_locale_mapping_draft.sql
+	insertTagLocaleDraft  = "INSERT INTO tag_locale_mapping(tag_id, locale, country_id, `name`, publish, tag_type, draft, suggested_by, created_at, updated_at) values(?,?,?,?,0,?,1,?,?,?)"
+	publishTagLocaleDraft = "UPDATE tag_locale_mapping SET publish = 1, draft = 0, `name` = ?, updated_at = ? where id = ? and draft = 1"
+	discardTagLocaleDraft = "UPDATE tag_locale_mapping SET draft = 0, updated_at = ? where id = ? and draft = 1"
 )
 
 func NewTagLocaleMappingRepository(db *sql.DB) *TagLocaleMappingRepo {
 	return &TagLocaleMappingRepo{db}
 }
 
-func (t *TagLocaleMappingRepo) CreateTagLocaleMapping(tx *sql.Tx, tagLocaleMapping *domain.TagLocaleMapping) (err error) {
-	stmt, err := tx.Prepare(insertTagLocaleMapping)
+func (t *TagLocaleMappingRepo) CreateTagLocaleMapping(ctx context.Context, tx *sql.Tx, tagLocaleMapping *domain.TagLocaleMapping) (err error) {
+	ctx, cancel := withTimeout(ctx)
+	defer cancel()
+	stmt, err := tx.PrepareContext(ctx, insertTagLocaleMapping)
 	if err != nil {
 		logger.Client.Error("createTagLocaleMappingError", logger.GetErrorStack())
 		return noonerror.New(noonerror.ErrInternalServer, "createTagLocaleMappingError")
 	}
-	_, err = stmt.Exec(tagLocaleMapping.TagID, tagLocaleMapping.Locale, *tagLocaleMapping.CountryId, tagLocaleMapping.Name, tagLocaleMapping.Publish, tagLocaleMapping.TagType, tagLocaleMapping.CreatedAt.UnixNano()/1000000, tagLocaleMapping.UpdatedAt.UnixNano()/1000000)
+	_, err = stmt.ExecContext(ctx, tagLocaleMapping.TagID, tagLocaleMapping.Locale, *tagLocaleMapping.CountryId, tagLocaleMapping.Name, tagLocaleMapping.Publish, tagLocaleMapping.TagType, tagLocaleMapping.CreatedAt.UnixNano()/1000000, tagLocaleMapping.UpdatedAt.UnixNano()/1000000)
 	if err != nil {
 		logger.Client.Error("createTagLocaleMappingError", logger.GetErrorStack())
 		return noonerror.New(noonerror.ErrInternalServer, "createTagLocaleMappingError")
@@ -40,8 +47,10 @@ func (t *TagLocaleMappingRepo) CreateTagLocaleMapping(tx *sql.Tx, tagLocaleMappi
 	return
 }
 
-func (t *TagLocaleMappingRepo) FetchTagLocaleMappings(id *string) (tagLocaleMappings []*domain.TagLocaleMapping, err error) {
-	rows, err := t.db.Query(selectTagLocaleMapping, *id)
+func (t *TagLocaleMappingRepo) FetchTagLocaleMappings(ctx context.Context, id *string) (tagLocaleMappings []*domain.TagLocaleMapping, err error) {
+	ctx, cancel := withTimeout(ctx)
+	defer cancel()
+	rows, err := t.db.QueryContext(ctx, selectTagLocaleMapping, *id)
 	if err != nil {
 		logger.Client.Error("fetchTagLocaleMappingError", logger.GetErrorStack())
 		return nil, noonerror.New(noonerror.ErrInternalServer, "fetchTagLocaleMappingError")
@@ -56,8 +65,36 @@ func (t *TagLocaleMappingRepo) FetchTagLocaleMappings(id *string) (tagLocaleMapp
 	return tagsList, nil
 }
 
-func (t *TagLocaleMappingRepo) FetchTagLocaleMappingByLocale(tagId *string, countryId *string, locale *string) (tagLocaleMappings *domain.TagLocaleMapping, err error) {
-	rows, err := t.db.Query(fetchTagLocaleMappingByLocale, *tagId, *countryId, *locale)
+func (t *TagLocaleMappingRepo) FetchByInTagLocaleMappings(ctx context.Context, ids []*string) (tagLocaleMappings []*domain.TagLocaleMapping, err error) {
+	ctx, cancel := withTimeout(ctx)
+	defer cancel()
+	if len(ids) == 0 {
+		return
+	}
+	args := make([]interface{}, len(ids))
+	for i, id := range ids {
+		args[i] = id
+	}
+	stmt := `SELECT * FROM tag_locale_mapping WHERE tag_id in (?` + strings.Repeat(",?", len(args)-1) + `) and publish = 1`
+	rows, err := t.db.QueryContext(ctx, stmt, args...)
+	if err != nil {
+		logger.Client.Error("fetchTagLocaleMappingError", logger.GetErrorStack())
+		return nil, noonerror.New(noonerror.ErrInternalServer, "fetchTagLocaleMappingError")
+	}
+	defer func() {
+		_ = rows.Close()
+	}()
+	tagsList, err := tagLocaleMappingRowMapper(rows)
+	if err != nil {
+		return nil, noonerror.New(noonerror.ErrInternalServer, "fetchTagLocaleMappingError")
+	}
+	return tagsList, nil
+}
+
+func (t *TagLocaleMappingRepo) FetchTagLocaleMappingByLocale(ctx context.Context, tagId *string, countryId *string, locale *string) (tagLocaleMappings *domain.TagLocaleMapping, err error) {
+	ctx, cancel := withTimeout(ctx)
+	defer cancel()
+	rows, err := t.db.QueryContext(ctx, fetchTagLocaleMappingByLocale, *tagId, *countryId, *locale)
 	if err != nil {
 		logger.Client.Error("fetchTagLocaleMappingByLocaleError", logger.GetErrorStack())
 		return nil, noonerror.New(noonerror.ErrInternalServer, "fetchTagLocaleMappingByLocaleError")
@@ -77,8 +114,10 @@ func (t *TagLocaleMappingRepo) FetchTagLocaleMappingByLocale(tagId *string, coun
 	return
 }
 
-func (t *TagLocaleMappingRepo) DeleteTagLocaleMapping(tx *sql.Tx, id *string) (err error) {
-	_, err = tx.Query(deleteTagLocaleMapping, time.Now().UnixNano()/1000000, *id)
+func (t *TagLocaleMappingRepo) DeleteTagLocaleMapping(ctx context.Context, tx *sql.Tx, id *string) (err error) {
+	ctx, cancel := withTimeout(ctx)
+	defer cancel()
+	_, err = tx.QueryContext(ctx, deleteTagLocaleMapping, time.Now().UnixNano()/1000000, *id)
 	if err != nil {
 		logger.Client.Error("deleteTagsError", logger.GetErrorStack())
 		return
@@ -86,7 +125,9 @@ func (t *TagLocaleMappingRepo) DeleteTagLocaleMapping(tx *sql.Tx, id *string) (e
 	return
 }
 
-func (t *TagLocaleMappingRepo) FetchTagLocalesByTagIds(ids []*string, locale *string, countryId *string) (tagLocaleMappings []*domain.TagLocaleMapping, err error) {
+func (t *TagLocaleMappingRepo) FetchTagLocalesByTagIds(ctx context.Context, ids []*string, locale *string, countryId *string) (tagLocaleMappings []*domain.TagLocaleMapping, err error) {
+	ctx, cancel := withTimeout(ctx)
+	defer cancel()
 	if len(ids) == 0 {
 		return
 	}
@@ -97,7 +138,7 @@ func (t *TagLocaleMappingRepo) FetchTagLocalesByTagIds(ids []*string, locale *st
 		args[i+2] = id
 	}
 	stmt := `SELECT * FROM tag_locale_mapping WHERE locale = ? and country_id = ? and tag_id in (?` + strings.Repeat(",?", len(args)-3) + `) and publish = 1`
-	rows, err := t.db.Query(stmt, args...)
+	rows, err := t.db.QueryContext(ctx, stmt, args...)
 	if err != nil {
 		logger.Client.Error("fetchParentTagMappingsError", logger.GetErrorStack())
 		return
@@ -112,6 +153,85 @@ func (t *TagLocaleMappingRepo) FetchTagLocalesByTagIds(ids []*string, locale *st
 	return tagsList, nil
 }
 
+func (t *TagLocaleMappingRepo) CreateTagLocaleDraft(ctx context.Context, tx *sql.Tx, tagLocaleMapping *domain.TagLocaleMapping) (err error) {
+	ctx, cancel := withTimeout(ctx)
+	defer cancel()
+	_, err = tx.ExecContext(ctx, insertTagLocaleDraft, tagLocaleMapping.TagID, tagLocaleMapping.Locale, *tagLocaleMapping.CountryId, tagLocaleMapping.Name, tagLocaleMapping.TagType, tagLocaleMapping.SuggestedBy, tagLocaleMapping.CreatedAt.UnixNano()/1000000, tagLocaleMapping.UpdatedAt.UnixNano()/1000000)
+	if err != nil {
+		logger.Client.Error("createTagLocaleDraftError", logger.GetErrorStack())
+		return noonerror.New(noonerror.ErrInternalServer, "createTagLocaleDraftError")
+	}
+	return
+}
+
+func (t *TagLocaleMappingRepo) FetchTagLocaleDrafts(ctx context.Context, ids []*string, locale *string, countryId *string) (tagLocaleMappings []*domain.TagLocaleMapping, err error) {
+	ctx, cancel := withTimeout(ctx)
+	defer cancel()
+	if len(ids) == 0 {
+		return
+	}
+	args := make([]interface{}, len(ids)+2)
+	args[0] = locale
+	args[1] = countryId
+	for i, id := range ids {
+		args[i+2] = id
+	}
+	stmt := `SELECT * FROM tag_locale_mapping WHERE locale = ? and country_id = ? and tag_id in (?` + strings.Repeat(",?", len(args)-3) + `) and draft = 1`
+	return t.fetchTagLocaleDrafts(ctx, stmt, args)
+}
+
+func (t *TagLocaleMappingRepo) FetchTagLocaleDraftsByIds(ctx context.Context, ids []*string) (tagLocaleMappings []*domain.TagLocaleMapping, err error) {
+	ctx, cancel := withTimeout(ctx)
+	defer cancel()
+	if len(ids) == 0 {
+		return
+	}
+	args := make([]interface{}, len(ids))
+	for i, id := range ids {
+		args[i] = id
+	}
+	stmt := `SELECT * FROM tag_locale_mapping WHERE id in (?` + strings.Repeat(",?", len(args)-1) + `) and draft = 1`
+	return t.fetchTagLocaleDrafts(ctx, stmt, args)
+}
+
+func (t *TagLocaleMappingRepo) fetchTagLocaleDrafts(ctx context.Context, stmt string, args []interface{}) (tagLocaleMappings []*domain.TagLocaleMapping, err error) {
+	rows, err := t.db.QueryContext(ctx, stmt, args...)
+	if err != nil {
+		logger.Client.Error("fetchTagLocaleDraftsError", logger.GetErrorStack())
+		return nil, noonerror.New(noonerror.ErrInternalServer, "fetchTagLocaleDraftsError")
+	}
+	defer func() {
+		_ = rows.Close()
+	}()
+	tagsList, err := tagLocaleMappingRowMapper(rows)
+	if err != nil {
+		return nil, noonerror.New(noonerror.ErrInternalServer, "fetchTagLocaleDraftsError")
+	}
+	return tagsList, nil
+}
+
+func (t *TagLocaleMappingRepo) PublishTagLocaleDraft(ctx context.Context, tx *sql.Tx, id *string, name *string) (err error) {
+	ctx, cancel := withTimeout(ctx)
+	defer cancel()
+	_, err = tx.ExecContext(ctx, publishTagLocaleDraft, *name, time.Now().UnixNano()/1000000, *id)
+	if err != nil {
+		logger.Client.Error("publishTagLocaleDraftError", logger.GetErrorStack())
+		return noonerror.New(noonerror.ErrInternalServer, "publishTagLocaleDraftError")
+	}
+	return
+}
+
+func (t *TagLocaleMappingRepo) DiscardTagLocaleDraft(ctx context.Context, tx *sql.Tx, id *string) (err error) {
+	ctx, cancel := withTimeout(ctx)
+	defer cancel()
+	_, err = tx.ExecContext(ctx, discardTagLocaleDraft, time.Now().UnixNano()/1000000, *id)
+	if err != nil {
+		logger.Client.Error("discardTagLocaleDraftError", logger.GetErrorStack())
+		return noonerror.New(noonerror.ErrInternalServer, "discardTagLocaleDraftError")
+	}
+	return
+}
+
 func tagLocaleMappingRowMapper(rows *sql.Rows) (tagLocaleMappings []*domain.TagLocaleMapping, err error) {
 	columns, err := rows.Columns()
 	if err != nil {
@@ -144,6 +264,10 @@ func tagLocaleMappingRowMapper(rows *sql.Rows) (tagLocaleMappings []*domain.TagL
 				tag.Name = converter.ConvertToStringPtr(string(col))
 			case "publish":
 				tag.Publish, err = strconv.ParseBool(string(col))
+			case "draft":
+				tag.Draft, err = strconv.ParseBool(string(col))
+			case "suggested_by":
+				tag.SuggestedBy = converter.ConvertToStringPtr(string(col))
 			case "created_at":
 				var timeMilli int64
 				timeMilli, err = strconv.ParseInt(string(col), 10, 64)
@@ -153,7 +277,7 @@ func tagLocaleMappingRowMapper(rows *sql.Rows) (tagLocaleMappings []*domain.TagL
 				timeMilli, err = strconv.ParseInt(string(col), 10, 64)
 				tag.UpdatedAt = time.Unix(0, timeMilli*int64(time.Millisecond)).UTC()
 			default:
-				return nil, noonerror.New(noonerror.ErrInternalServer, "invalid column in tags table")
+				// a column added by a migration ahead of the release reading it is skipped
 			}
 			if err != nil {
 				return nil, err
@@ -23,8 +23,8 @@ var (
 	filterTags                  = "select id, type, name, attributes from tags where curriculum_type = ? and type = ? and publish = 1"
 	filterTagsByTagGroup        = "select id, type, name, attributes from tags where tag_group = ? and publish = 1"
 	filterTagsByTagGroupAndType = "select id, type, name, attributes from tags where tag_group = ? and type = ? and publish = 1"
-	deleteTags                  = "UPDATE tags SET publish = 0, updated_at = ? where id = ?"
-	updateLocale                = "UPDATE tags SET locale_available = ?, updated_at = ? where id = ?"
+	deleteTags                  = "UPDATE tags SET publish = 0, version = version + 1, updated_at = ? where id = ?"
+	updateLocale                = "UPDATE tags SET locale_available = ?, version = version + 1, updated_at = ? where id = ?"
 	filterTagsPaginated         = "select id, type, name, attributes, publish from tags where curriculum_type = ? and type = ? and publish = 1 limit ? offset ?"
 	filterTagsPaginatedForAdmin = "select id, type, name, attributes, publish from tags where curriculum_type = ? and type = ? limit ? offset ?"
 )
@@ -33,8 +33,10 @@ func NewTagsRepository(db *sql.DB) *TagsRepo {
 	return &TagsRepo{db}
 }
 
-func (t *TagsRepo) FetchTags(id *string) (tags *domain.Tags, err error) {
-	rows, err := t.db.Query(selectTags, *id)
+func (t *TagsRepo) FetchTags(ctx context.Context, id *string) (tags *domain.Tags, err error) {
+	ctx, cancel := withTimeout(ctx)
+	defer cancel()
+	rows, err := t.db.QueryContext(ctx, selectTags, *id)
 	if err != nil {
 		logger.Client.Error("fetchTagsError", logger.GetErrorStack())
 		return
@@ -54,7 +56,9 @@ func (t *TagsRepo) FetchTags(id *string) (tags *domain.Tags, err error) {
 	return
 }
 
-func (t *TagsRepo) FetchByInTags(ids []*string) (tags []*domain.Tags, err error) {
+func (t *TagsRepo) FetchByInTags(ctx context.Context, ids []*string) (tags []*domain.Tags, err error) {
+	ctx, cancel := withTimeout(ctx)
+	defer cancel()
 	if len(ids) == 0 {
 		return
 	}
@@ -63,7 +67,7 @@ func (t *TagsRepo) FetchByInTags(ids []*string) (tags []*domain.Tags, err error)
 		args[i] = id
 	}
 	stmt := `SELECT * FROM tags WHERE id in (?` + strings.Repeat(",?", len(args)-1) + `)`
-	rows, err := t.db.Query(stmt, args...)
+	rows, err := t.db.QueryContext(ctx, stmt, args...)
 	if err != nil {
 		logger.Client.Error("fetchTagsError", logger.GetErrorStack())
 		return
@@ -78,8 +82,10 @@ func (t *TagsRepo) FetchByInTags(ids []*string) (tags []*domain.Tags, err error)
 	return tagsList, nil
 }
 
-func (t *TagsRepo) FetchFilteredTags(curriculumType *string, tagType *string) (tags []*domain.Tags, err error) {
-	rows, err := t.db.Query(filterTags, *curriculumType, *tagType)
+func (t *TagsRepo) FetchFilteredTags(ctx context.Context, curriculumType *string, tagType *string) (tags []*domain.Tags, err error) {
+	ctx, cancel := withTimeout(ctx)
+	defer cancel()
+	rows, err := t.db.QueryContext(ctx, filterTags, *curriculumType, *tagType)
 	if err != nil {
 		logger.Client.Error("fetchFilteredTagsError", logger.GetErrorStack())
 		return
@@ -94,8 +100,10 @@ func (t *TagsRepo) FetchFilteredTags(curriculumType *string, tagType *string) (t
 	return tagsList, nil
 }
 
-func (t *TagsRepo) FetchFilteredTagsPaginated(curriculumType *string, tagType *string, start *int, limit *int) (tags []*domain.Tags, err error) {
-	rows, err := t.db.Query(filterTagsPaginated, *curriculumType, *tagType, *limit, *start)
+func (t *TagsRepo) FetchFilteredTagsPaginated(ctx context.Context, curriculumType *string, tagType *string, start *int, limit *int) (tags []*domain.Tags, err error) {
+	ctx, cancel := withTimeout(ctx)
+	defer cancel()
+	rows, err := t.db.QueryContext(ctx, filterTagsPaginated, *curriculumType, *tagType, *limit, *start)
 	if err != nil {
 		logger.Client.Error("fetchFilteredTagsError", logger.GetErrorStack())
 		return
@@ -110,8 +118,10 @@ func (t *TagsRepo) FetchFilteredTagsPaginated(curriculumType *string, tagType *s
 	return tagsList, nil
 }
 
-func (t *TagsRepo) FetchFilteredTagsPaginatedForAdmin(curriculumType *string, tagType *string, start *int, limit *int) (tags []*domain.Tags, err error) {
-	rows, err := t.db.Query(filterTagsPaginatedForAdmin, *curriculumType, *tagType, *limit, *start)
+func (t *TagsRepo) FetchFilteredTagsPaginatedForAdmin(ctx context.Context, curriculumType *string, tagType *string, start *int, limit *int) (tags []*domain.Tags, err error) {
+	ctx, cancel := withTimeout(ctx)
+	defer cancel()
+	rows, err := t.db.QueryContext(ctx, filterTagsPaginatedForAdmin, *curriculumType, *tagType, *limit, *start)
 	if err != nil {
 		logger.Client.Error("fetchFilteredTagsError", logger.GetErrorStack())
 		return
@@ -126,12 +136,14 @@ func (t *TagsRepo) FetchFilteredTagsPaginatedForAdmin(curriculumType *string, ta
 	return tagsList, nil
 }
 
-func (t *TagsRepo) FetchByTagGroup(tagGroup *string, tagType *string) (tags []*domain.Tags, err error) {
+func (t *TagsRepo) FetchByTagGroup(ctx context.Context, tagGroup *string, tagType *string) (tags []*domain.Tags, err error) {
+	ctx, cancel := withTimeout(ctx)
+	defer cancel()
 	var rows *sql.Rows
 	if tagType != nil {
-		rows, err = t.db.Query(filterTagsByTagGroupAndType, *tagGroup, *tagType)
+		rows, err = t.db.QueryContext(ctx, filterTagsByTagGroupAndType, *tagGroup, *tagType)
 	} else {
-		rows, err = t.db.Query(filterTagsByTagGroup, *tagGroup)
+		rows, err = t.db.QueryContext(ctx, filterTagsByTagGroup, *tagGroup)
 	}
 	if err != nil {
 		logger.Client.Error("fetchByTagGroupError", logger.GetErrorStack())
@@ -147,19 +159,21 @@ func (t *TagsRepo) FetchByTagGroup(tagGroup *string, tagType *string) (tags []*d
 	return tagsList, nil
 }
 
-func (t *TagsRepo) CreateTags(tx *sql.Tx, tags *domain.Tags) (id *string, err error) {
+func (t *TagsRepo) CreateTags(ctx context.Context, tx *sql.Tx, tags *domain.Tags) (id *string, err error) {
+	ctx, cancel := withTimeout(ctx)
+	defer cancel()
 	var attributesStringPtr *string
 	if tags.Attributes != nil {
 		attributes, _ := json.Marshal(tags.Attributes)
 		attributesString := string(attributes)
 		attributesStringPtr = &attributesString
 	}
-	stmt, err := tx.Prepare(insertTags)
+	stmt, err := tx.PrepareContext(ctx, insertTags)
 	if err != nil {
 		logger.Client.Error("createTagError", logger.GetErrorStack())
 		return nil, noonerror.New(noonerror.ErrInternalServer, "createTagError")
 	}
-	res, err := stmt.Exec(tags.Type, tags.Name, tags.CurriculumType, tags.CreatorId, tags.CreatorType, tags.Access, tags.TagGroup, tags.LocaleAvailable, tags.CountryId, tags.Publish, attributesStringPtr, tags.CreatedAt.UnixNano()/1000000, tags.UpdatedAt.UnixNano()/1000000)
+	res, err := stmt.ExecContext(ctx, tags.Type, tags.Name, tags.CurriculumType, tags.CreatorId, tags.CreatorType, tags.Access, tags.TagGroup, tags.LocaleAvailable, tags.CountryId, tags.Publish, attributesStringPtr, tags.CreatedAt.UnixNano()/1000000, tags.UpdatedAt.UnixNano()/1000000)
 	if err != nil {
 		logger.Client.Error("createTagError", logger.GetErrorStack())
 		return nil, noonerror.New(noonerror.ErrInternalServer, "createTagError")
@@ -173,7 +187,10 @@ func (t *TagsRepo) CreateTags(tx *sql.Tx, tags *domain.Tags) (id *string, err er
 	return &insertStringId, nil
 }
 
-func (t *TagsRepo) UpdateTag(updateTag *domain.UpdateTag) (err error) {
+// UpdateTag bumps the version of the tag, an update carrying a version only applies while the tag is still at it
+func (t *TagsRepo) UpdateTag(ctx context.Context, tx *sql.Tx, updateTag *domain.UpdateTag) (err error) {
+	ctx, cancel := withTimeout(ctx)
+	defer cancel()
 	queryString := "UPDATE tags SET "
 	var updateFields []interface{}
 	updated := false
@@ -194,30 +211,45 @@ func (t *TagsRepo) UpdateTag(updateTag *domain.UpdateTag) (err error) {
 		updateFields = append(updateFields, attributesString)
 		updated = true
 	}
-	queryString += "updated_at = ? where id = ?"
+	queryString += "version = version + 1, updated_at = ? where id = ?"
 	updateFields = append(updateFields, time.Now().UnixNano()/1000000, *updateTag.ID)
-	if updated {
-		rows, err := t.db.Query(queryString, updateFields...)
-		if err != nil {
-			logger.Client.Error("updateTagError", logger.GetErrorStack())
-			return noonerror.New(noonerror.ErrInternalServer, "updateTagError")
+	if updateTag.Version != nil {
+		queryString += " and version = ?"
+		updateFields = append(updateFields, *updateTag.Version)
+	}
+	if !updated {
+		return
+	}
+	var result sql.Result
+	if tx != nil {
+		result, err = tx.ExecContext(ctx, queryString, updateFields...)
+	} else {
+		result, err = t.db.ExecContext(ctx, queryString, updateFields...)
+	}
+	if err != nil {
+		logger.Client.Error("updateTagError", logger.GetErrorStack())
+		return noonerror.New(noonerror.ErrInternalServer, "updateTagError")
+	}
+	if updateTag.Version != nil {
+		if affected, err := result.RowsAffected(); err != nil || affected == 0 {
+			return noonerror.New(noonerror.ErrConflict, "tagVersionConflict")
 		}
-		_ = rows.Close()
 	}
 	return
 }
 
-func (t *TagsRepo) DeleteTags(tx *sql.Tx, id *string) (err error) {
+func (t *TagsRepo) DeleteTags(ctx context.Context, tx *sql.Tx, id *string) (err error) {
+	ctx, cancel := withTimeout(ctx)
+	defer cancel()
 	txPresent := true
 	if tx == nil {
 		txPresent = false
-		ctx := context.Background()
 		tx, err = t.db.BeginTx(ctx, nil)
 		if err != nil {
 			return noonerror.New(noonerror.ErrInternalServer, "deleteTagsContextCreationError")
 		}
 	}
-	_, err = tx.Query(deleteTags, time.Now().UnixNano()/1000000, *id)
+	_, err = tx.QueryContext(ctx, deleteTags, time.Now().UnixNano()/1000000, *id)
 	if err != nil {
 		logger.Client.Error("deleteTagsError", logger.GetErrorStack())
 		return
@@ -230,8 +262,10 @@ func (t *TagsRepo) DeleteTags(tx *sql.Tx, id *string) (err error) {
 	return
 }
 
-func (t *TagsRepo) UpdateLocale(tx *sql.Tx, localeAvailable bool, id *string) (err error) {
-	_, err = tx.Query(updateLocale, localeAvailable, time.Now().UnixNano()/1000000, *id)
+func (t *TagsRepo) UpdateLocale(ctx context.Context, tx *sql.Tx, localeAvailable bool, id *string) (err error) {
+	ctx, cancel := withTimeout(ctx)
+	defer cancel()
+	_, err = tx.QueryContext(ctx, updateLocale, localeAvailable, time.Now().UnixNano()/1000000, *id)
 	if err != nil {
 		logger.Client.Error("updateLocaleError", logger.GetErrorStack())
 		return
@@ -239,7 +273,9 @@ func (t *TagsRepo) UpdateLocale(tx *sql.Tx, localeAvailable bool, id *string) (e
 	return
 }
 
-func (t *TagsRepo) ToggleTags(publish bool, ids []*string) (err error) {
+func (t *TagsRepo) ToggleTags(ctx context.Context, publish bool, ids []*string) (err error) {
+	ctx, cancel := withTimeout(ctx)
+	defer cancel()
 	if len(ids) == 0 {
 		return
 	}
@@ -249,8 +285,8 @@ func (t *TagsRepo) ToggleTags(publish bool, ids []*string) (err error) {
 	for i, id := range ids {
 		args[i+2] = id
 	}
-	stmt := `UPDATE tags SET publish = ?, updated_at = ? WHERE id in (?` + strings.Repeat(",?", len(args)-1) + `)`
-	rows, err := t.db.Query(stmt, args...)
+	stmt := `UPDATE tags SET publish = ?, version = version + 1, updated_at = ? WHERE id in (?` + strings.Repeat(",?", len(args)-1) + `)`
+	rows, err := t.db.QueryContext(ctx, stmt, args...)
 	if err != nil {
 		logger.Client.Error("toggleTagsError", logger.GetErrorStack())
 		return
@@ -317,8 +353,10 @@ func tagsRowMapper(rows *sql.Rows) (tags []*domain.Tags, err error) {
 				var timeMilli int64
 				timeMilli, err = strconv.ParseInt(string(col), 10, 64)
 				tag.UpdatedAt = time.Unix(0, timeMilli*int64(time.Millisecond)).UTC()
+			case "version":
+				tag.Version, err = strconv.Atoi(string(col))
 			default:
-				return nil, noonerror.New(noonerror.ErrInternalServer, "invalid column in tags table")
+				// a column added by a migration ahead of the release reading it is skipped
 			}
 		}
 		if err != nil {
@@ -0,0 +1,222 @@
+package repository
+
+import (
+	"bitbucket.org/noon-micro/curriculum/pkg/domain"
+	"bitbucket.org/noon-micro/curriculum/pkg/lib/converter"
+	noonerror "bitbucket.org/noon-micro/curriculum/pkg/lib/error"
+	"bitbucket.org/noon-micro/curriculum/pkg/lib/logger"
+	"context"
+	"database/sql"
+	"strconv"
+	"time"
+)
+
+type WebhookDeliveryRepo struct {
+	db *sql.DB
+}
+
+// selectDueWebhookDelivery and claimWebhookDelivery are served by idx_webhook_delivery_due of migrations/0006_webhook.sql,
+// insertWebhookDelivery skips a delivery already queued through uk_webhook_delivery_event of
+// migrations/0010_webhook_delivery_event.sql
+var (
+	insertWebhookDelivery    = "INSERT IGNORE INTO webhook_delivery(subscription_id, event_id, event_type, tag_id, payload, redelivery_of, status, attempts, response_status, error, next_attempt_at, created_at, updated_at) values(?,?,?,?,?,?,?,?,?,?,?,?,?)"
+	selectWebhookDelivery    = "SELECT * FROM webhook_delivery WHERE id = ?"
+	selectDueWebhookDelivery = "SELECT * FROM webhook_delivery WHERE status = ? and next_attempt_at <= ? ORDER BY next_attempt_at, id LIMIT ?"
+	claimWebhookDelivery     = "UPDATE webhook_delivery SET next_attempt_at = ? where id = ? and status = ? and next_attempt_at = ?"
+	updateWebhookDelivery    = "UPDATE webhook_delivery SET status = ?, attempts = ?, response_status = ?, error = ?, next_attempt_at = ?, updated_at = ? where id = ?"
+)
+
+func NewWebhookDeliveryRepository(db *sql.DB) *WebhookDeliveryRepo {
+	return &WebhookDeliveryRepo{db}
+}
+
+func (t *WebhookDeliveryRepo) CreateWebhookDelivery(ctx context.Context, delivery *domain.WebhookDelivery) (id *string, err error) {
+	ctx, cancel := withTimeout(ctx)
+	defer cancel()
+	redeliveryOf := "0"
+	if delivery.RedeliveryOf != nil {
+		redeliveryOf = *delivery.RedeliveryOf
+	}
+	res, err := t.db.ExecContext(ctx, insertWebhookDelivery, delivery.SubscriptionID, delivery.EventID, delivery.EventType, delivery.TagId, delivery.Payload, redeliveryOf,
+		delivery.Status, delivery.Attempts, delivery.ResponseStatus, delivery.Error, delivery.NextAttemptAt.UnixNano()/1000000, delivery.CreatedAt.UnixNano()/1000000,
+		delivery.UpdatedAt.UnixNano()/1000000)
+	if err != nil {
+		logger.Client.Error("createWebhookDeliveryError", logger.GetErrorStack())
+		return nil, noonerror.New(noonerror.ErrInternalServer, "createWebhookDeliveryError")
+	}
+	if affected, err := res.RowsAffected(); err != nil || affected == 0 {
+		return nil, nil
+	}
+	insertId, err := res.LastInsertId()
+	if err != nil {
+		logger.Client.Error("createWebhookDeliveryError", logger.GetErrorStack())
+		return nil, noonerror.New(noonerror.ErrInternalServer, "createWebhookDeliveryError")
+	}
+	insertStringId := strconv.FormatInt(insertId, 10)
+	return &insertStringId, nil
+}
+
+func (t *WebhookDeliveryRepo) FetchWebhookDelivery(ctx context.Context, id *string) (delivery *domain.WebhookDelivery, err error) {
+	ctx, cancel := withTimeout(ctx)
+	defer cancel()
+	rows, err := t.db.QueryContext(ctx, selectWebhookDelivery, *id)
+	if err != nil {
+		logger.Client.Error("fetchWebhookDeliveryError", logger.GetErrorStack())
+		return nil, noonerror.New(noonerror.ErrInternalServer, "fetchWebhookDeliveryError")
+	}
+	defer func() {
+		_ = rows.Close()
+	}()
+	deliveries, err := webhookDeliveryRowMapper(rows)
+	if err != nil {
+		return nil, noonerror.New(noonerror.ErrInternalServer, "fetchWebhookDeliveryError")
+	}
+	if len(deliveries) == 1 {
+		return deliveries[0], nil
+	}
+	return
+}
+
+func (t *WebhookDeliveryRepo) FetchWebhookDeliveries(ctx context.Context, getDeliveries *domain.GetWebhookDeliveries) (deliveries []*domain.WebhookDelivery, err error) {
+	ctx, cancel := withTimeout(ctx)
+	defer cancel()
+	stmt := "SELECT * FROM webhook_delivery WHERE subscription_id = ?"
+	args := []interface{}{*getDeliveries.SubscriptionID}
+	if getDeliveries.Status != nil {
+		stmt += " and status = ?"
+		args = append(args, *getDeliveries.Status)
+	}
+	stmt += " ORDER BY id DESC LIMIT ?, ?"
+	args = append(args, getDeliveries.Start, getDeliveries.Limit)
+	rows, err := t.db.QueryContext(ctx, stmt, args...)
+	if err != nil {
+		logger.Client.Error("fetchWebhookDeliveryError", logger.GetErrorStack())
+		return nil, noonerror.New(noonerror.ErrInternalServer, "fetchWebhookDeliveryError")
+	}
+	defer func() {
+		_ = rows.Close()
+	}()
+	deliveries, err = webhookDeliveryRowMapper(rows)
+	if err != nil {
+		return nil, noonerror.New(noonerror.ErrInternalServer, "fetchWebhookDeliveryError")
+	}
+	return deliveries, nil
+}
+
+func (t *WebhookDeliveryRepo) FetchDueWebhookDeliveries(ctx context.Context, now time.Time, limit int) (deliveries []*domain.WebhookDelivery, err error) {
+	ctx, cancel := withTimeout(ctx)
+	defer cancel()
+	rows, err := t.db.QueryContext(ctx, selectDueWebhookDelivery, domain.WebhookDeliveryStatusEnum.Pending, now.UnixNano()/1000000, limit)
+	if err != nil {
+		logger.Client.Error("fetchWebhookDeliveryError", logger.GetErrorStack())
+		return nil, noonerror.New(noonerror.ErrInternalServer, "fetchWebhookDeliveryError")
+	}
+	defer func() {
+		_ = rows.Close()
+	}()
+	deliveries, err = webhookDeliveryRowMapper(rows)
+	if err != nil {
+		return nil, noonerror.New(noonerror.ErrInternalServer, "fetchWebhookDeliveryError")
+	}
+	return deliveries, nil
+}
+
+// ClaimWebhookDelivery only moves the next attempt when nobody moved it since the delivery was read, so that a
+// delivery is sent by one instance at a time
+func (t *WebhookDeliveryRepo) ClaimWebhookDelivery(ctx context.Context, delivery *domain.WebhookDelivery, until time.Time) (claimed bool, err error) {
+	ctx, cancel := withTimeout(ctx)
+	defer cancel()
+	res, err := t.db.ExecContext(ctx, claimWebhookDelivery, until.UnixNano()/1000000, *delivery.ID, domain.WebhookDeliveryStatusEnum.Pending, delivery.NextAttemptAt.UnixNano()/1000000)
+	if err != nil {
+		logger.Client.Error("claimWebhookDeliveryError", logger.GetErrorStack())
+		return false, noonerror.New(noonerror.ErrInternalServer, "claimWebhookDeliveryError")
+	}
+	affected, err := res.RowsAffected()
+	if err != nil {
+		logger.Client.Error("claimWebhookDeliveryError", logger.GetErrorStack())
+		return false, noonerror.New(noonerror.ErrInternalServer, "claimWebhookDeliveryError")
+	}
+	return affected == 1, nil
+}
+
+func (t *WebhookDeliveryRepo) UpdateWebhookDelivery(ctx context.Context, delivery *domain.WebhookDelivery) (err error) {
+	ctx, cancel := withTimeout(ctx)
+	defer cancel()
+	_, err = t.db.ExecContext(ctx, updateWebhookDelivery, delivery.Status, delivery.Attempts, delivery.ResponseStatus, delivery.Error, delivery.NextAttemptAt.UnixNano()/1000000,
+		delivery.UpdatedAt.UnixNano()/1000000, *delivery.ID)
+	if err != nil {
+		logger.Client.Error("updateWebhookDeliveryError", logger.GetErrorStack())
+		return noonerror.New(noonerror.ErrInternalServer, "updateWebhookDeliveryError")
+	}
+	return
+}
+
+func webhookDeliveryRowMapper(rows *sql.Rows) (deliveries []*domain.WebhookDelivery, err error) {
+	columns, err := rows.Columns()
+	if err != nil {
+		return
+	}
+	values := make([]sql.RawBytes, len(columns))
+	scanArgs := make([]interface{}, len(values))
+	for i := range values {
+		scanArgs[i] = &values[i]
+	}
+	for rows.Next() {
+		delivery := &domain.WebhookDelivery{}
+		err = rows.Scan(scanArgs...)
+		if err != nil {
+			return
+		}
+		for i, col := range values {
+			switch columns[i] {
+			case "id":
+				delivery.ID = converter.ConvertToStringPtr(string(col))
+			case "subscription_id":
+				delivery.SubscriptionID = converter.ConvertToStringPtr(string(col))
+			case "event_id":
+				delivery.EventID = converter.ConvertToStringPtr(string(col))
+			case "event_type":
+				delivery.EventType = converter.ConvertToStringPtr(string(col))
+			case "tag_id":
+				delivery.TagId = converter.ConvertToStringPtr(string(col))
+			case "payload":
+				delivery.Payload = converter.ConvertToStringPtr(string(col))
+			case "redelivery_of":
+				if string(col) != "0" {
+					delivery.RedeliveryOf = converter.ConvertToStringPtr(string(col))
+				}
+			case "status":
+				delivery.Status = converter.ConvertToStringPtr(string(col))
+			case "attempts":
+				delivery.Attempts, err = strconv.Atoi(string(col))
+			case "response_status":
+				if col != nil {
+					var responseStatus int
+					responseStatus, err = strconv.Atoi(string(col))
+					delivery.ResponseStatus = &responseStatus
+				}
+			case "error":
+				delivery.Error = converter.ConvertToStringPtr(string(col))
+			case "next_attempt_at":
+				var timeMilli int64
+				timeMilli, err = strconv.ParseInt(string(col), 10, 64)
+				delivery.NextAttemptAt = time.Unix(0, timeMilli*int64(time.Millisecond)).UTC()
+			case "created_at":
+				var timeMilli int64
+				timeMilli, err = strconv.ParseInt(string(col), 10, 64)
+				delivery.CreatedAt = time.Unix(0, timeMilli*int64(time.Millisecond)).UTC()
+			case "updated_at":
+				var timeMilli int64
+				timeMilli, err = strconv.ParseInt(string(col), 10, 64)
+				delivery.UpdatedAt = time.Unix(0, timeMilli*int64(time.Millisecond)).UTC()
+			default:
+				return nil, noonerror.New(noonerror.ErrInternalServer, "invalid column in webhook_delivery table")
+			}
+			if err != nil {
+				return nil, err
+			}
+		}
+		deliveries = append(deliveries, delivery)
+	}
+	return deliveries, nil
+}
@@ -0,0 +1,171 @@
+package repository
+
+import (
+	"bitbucket.org/noon-micro/curriculum/pkg/domain"
+	"bitbucket.org/noon-micro/curriculum/pkg/lib/converter"
+	noonerror "bitbucket.org/noon-micro/curriculum/pkg/lib/error"
+	"bitbucket.org/noon-micro/curriculum/pkg/lib/logger"
+	"context"
+	"database/sql"
+	"strconv"
+	"strings"
+	"time"
+)
+
+type WebhookSubscriptionRepo struct {
+	db *sql.DB
+}
+
+var (
+	insertWebhookSubscription  = "INSERT INTO webhook_subscription(url, secret, subtree_prefix, tag_types, event_types, active, publish, created_at, updated_at) values(?,?,?,?,?,?,?,?,?)"
+	updateWebhookSubscription  = "UPDATE webhook_subscription SET url = ?, secret = ?, subtree_prefix = ?, tag_types = ?, event_types = ?, active = ?, updated_at = ? where id = ?"
+	selectWebhookSubscription  = "SELECT * FROM webhook_subscription WHERE id = ? and publish = 1"
+	selectWebhookSubscriptions = "SELECT * FROM webhook_subscription WHERE publish = 1 ORDER BY id"
+	deleteWebhookSubscription  = "UPDATE webhook_subscription SET publish = 0, updated_at = ? where id = ?"
+)
+
+func NewWebhookSubscriptionRepository(db *sql.DB) *WebhookSubscriptionRepo {
+	return &WebhookSubscriptionRepo{db}
+}
+
+func (t *WebhookSubscriptionRepo) CreateWebhookSubscription(ctx context.Context, subscription *domain.WebhookSubscription) (id *string, err error) {
+	ctx, cancel := withTimeout(ctx)
+	defer cancel()
+	res, err := t.db.ExecContext(ctx, insertWebhookSubscription, subscription.Url, subscription.Secret, subscription.SubtreePrefix, strings.Join(subscription.TagTypes, ","),
+		strings.Join(subscription.EventTypes, ","), subscription.Active, subscription.Publish, subscription.CreatedAt.UnixNano()/1000000, subscription.UpdatedAt.UnixNano()/1000000)
+	if err != nil {
+		logger.Client.Error("createWebhookSubscriptionError", logger.GetErrorStack())
+		return nil, noonerror.New(noonerror.ErrInternalServer, "createWebhookSubscriptionError")
+	}
+	insertId, err := res.LastInsertId()
+	if err != nil {
+		logger.Client.Error("createWebhookSubscriptionError", logger.GetErrorStack())
+		return nil, noonerror.New(noonerror.ErrInternalServer, "createWebhookSubscriptionError")
+	}
+	insertStringId := strconv.FormatInt(insertId, 10)
+	return &insertStringId, nil
+}
+
+func (t *WebhookSubscriptionRepo) UpdateWebhookSubscription(ctx context.Context, subscription *domain.WebhookSubscription) (err error) {
+	ctx, cancel := withTimeout(ctx)
+	defer cancel()
+	_, err = t.db.ExecContext(ctx, updateWebhookSubscription, subscription.Url, subscription.Secret, subscription.SubtreePrefix, strings.Join(subscription.TagTypes, ","),
+		strings.Join(subscription.EventTypes, ","), subscription.Active, subscription.UpdatedAt.UnixNano()/1000000, *subscription.ID)
+	if err != nil {
+		logger.Client.Error("updateWebhookSubscriptionError", logger.GetErrorStack())
+		return noonerror.New(noonerror.ErrInternalServer, "updateWebhookSubscriptionError")
+	}
+	return
+}
+
+func (t *WebhookSubscriptionRepo) FetchWebhookSubscription(ctx context.Context, id *string) (subscription *domain.WebhookSubscription, err error) {
+	ctx, cancel := withTimeout(ctx)
+	defer cancel()
+	rows, err := t.db.QueryContext(ctx, selectWebhookSubscription, *id)
+	if err != nil {
+		logger.Client.Error("fetchWebhookSubscriptionError", logger.GetErrorStack())
+		return nil, noonerror.New(noonerror.ErrInternalServer, "fetchWebhookSubscriptionError")
+	}
+	defer func() {
+		_ = rows.Close()
+	}()
+	subscriptions, err := webhookSubscriptionRowMapper(rows)
+	if err != nil {
+		return nil, noonerror.New(noonerror.ErrInternalServer, "fetchWebhookSubscriptionError")
+	}
+	if len(subscriptions) == 1 {
+		return subscriptions[0], nil
+	}
+	return
+}
+
+func (t *WebhookSubscriptionRepo) FetchWebhookSubscriptions(ctx context.Context) (subscriptions []*domain.WebhookSubscription, err error) {
+	ctx, cancel := withTimeout(ctx)
+	defer cancel()
+	rows, err := t.db.QueryContext(ctx, selectWebhookSubscriptions)
+	if err != nil {
+		logger.Client.Error("fetchWebhookSubscriptionError", logger.GetErrorStack())
+		return nil, noonerror.New(noonerror.ErrInternalServer, "fetchWebhookSubscriptionError")
+	}
+	defer func() {
+		_ = rows.Close()
+	}()
+	subscriptions, err = webhookSubscriptionRowMapper(rows)
+	if err != nil {
+		return nil, noonerror.New(noonerror.ErrInternalServer, "fetchWebhookSubscriptionError")
+	}
+	return subscriptions, nil
+}
+
+func (t *WebhookSubscriptionRepo) DeleteWebhookSubscription(ctx context.Context, id *string) (err error) {
+	ctx, cancel := withTimeout(ctx)
+	defer cancel()
+	_, err = t.db.ExecContext(ctx, deleteWebhookSubscription, time.Now().UnixNano()/1000000, *id)
+	if err != nil {
+		logger.Client.Error("deleteWebhookSubscriptionError", logger.GetErrorStack())
+		return noonerror.New(noonerror.ErrInternalServer, "deleteWebhookSubscriptionError")
+	}
+	return
+}
+
+func webhookSubscriptionRowMapper(rows *sql.Rows) (subscriptions []*domain.WebhookSubscription, err error) {
+	columns, err := rows.Columns()
+	if err != nil {
+		return
+	}
+	values := make([]sql.RawBytes, len(columns))
+	scanArgs := make([]interface{}, len(values))
+	for i := range values {
+		scanArgs[i] = &values[i]
+	}
+	for rows.Next() {
+		subscription := &domain.WebhookSubscription{}
+		err = rows.Scan(scanArgs...)
+		if err != nil {
+			return
+		}
+		for i, col := range values {
+			switch columns[i] {
+			case "id":
+				subscription.ID = converter.ConvertToStringPtr(string(col))
+			case "url":
+				subscription.Url = converter.ConvertToStringPtr(string(col))
+			case "secret":
+				subscription.Secret = converter.ConvertToStringPtr(string(col))
+			case "subtree_prefix":
+				subscription.SubtreePrefix = converter.ConvertToStringPtr(string(col))
+			case "tag_types":
+				subscription.TagTypes = splitList(string(col))
+			case "event_types":
+				subscription.EventTypes = splitList(string(col))
+			case "active":
+				subscription.Active, err = strconv.ParseBool(string(col))
+			case "publish":
+				subscription.Publish, err = strconv.ParseBool(string(col))
+			case "created_at":
+				var timeMilli int64
+				timeMilli, err = strconv.ParseInt(string(col), 10, 64)
+				subscription.CreatedAt = time.Unix(0, timeMilli*int64(time.Millisecond)).UTC()
+			case "updated_at":
+				var timeMilli int64
+				timeMilli, err = strconv.ParseInt(string(col), 10, 64)
+				subscription.UpdatedAt = time.Unix(0, timeMilli*int64(time.Millisecond)).UTC()
+			default:
+				return nil, noonerror.New(noonerror.ErrInternalServer, "invalid column in webhook_subscription table")
+			}
+			if err != nil {
+				return nil, err
+			}
+		}
+		subscriptions = append(subscriptions, subscription)
+	}
+	return subscriptions, nil
+}
+
+// splitList reads a comma separated column, an empty column is an empty list
+func splitList(value string) []string {
+	if value == "" {
+		return nil
+	}
+	return strings.Split(value, ",")
+}
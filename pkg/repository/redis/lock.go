@@ -0,0 +1,28 @@
+package repository
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"github.com/go-redis/redis"
+)
+
+// releaseLock deletes the lock only while it still holds the token of the caller, a lock which expired and was taken
+// by another process is left alone
+var releaseLock = redis.NewScript(`if redis.call("get", KEYS[1]) == ARGV[1] then return redis.call("del", KEYS[1]) else return 0 end`)
+
+// AcquireLock takes the lock at key for LockTtl, the token it returns is needed to release it
+func AcquireLock(ctx context.Context, key string) (token string, locked bool, err error) {
+	buf := make([]byte, 16)
+	if _, err = rand.Read(buf); err != nil {
+		return "", false, err
+	}
+	token = hex.EncodeToString(buf)
+	locked, err = Client(ctx).SetNX(key, token, LockTtl).Result()
+	return token, locked, err
+}
+
+// ReleaseLock releases the lock at key if it is still held with token
+func ReleaseLock(ctx context.Context, key string, token string) error {
+	return releaseLock.Run(Client(ctx), []string{key}, token).Err()
+}
@@ -0,0 +1,57 @@
+package repository
+
+import (
+	"context"
+	"github.com/alicebob/miniredis/v2"
+	"github.com/go-redis/redis"
+	redistrace "gopkg.in/DataDog/dd-trace-go.v1/contrib/go-redis/redis"
+	"testing"
+)
+
+func newTestRedis(t *testing.T) *miniredis.Miniredis {
+	server, err := miniredis.Run()
+	if err != nil {
+		t.Fatal(err)
+	}
+	RedisClient = redistrace.NewClient(&redis.Options{Addr: server.Addr()})
+	return server
+}
+
+func TestReleaseLockKeepsLockOfNextHolder(t *testing.T) {
+	server := newTestRedis(t)
+	defer server.Close()
+	ctx := context.Background()
+	staleToken, locked, err := AcquireLock(ctx, "key:lock")
+	if err != nil || !locked {
+		t.Fatalf("first acquire: locked %v, err %v", locked, err)
+	}
+	server.FastForward(LockTtl)
+	token, locked, err := AcquireLock(ctx, "key:lock")
+	if err != nil || !locked {
+		t.Fatalf("acquire after expiry: locked %v, err %v", locked, err)
+	}
+	if err = ReleaseLock(ctx, "key:lock", staleToken); err != nil {
+		t.Fatal(err)
+	}
+	if value, _ := server.Get("key:lock"); value != token {
+		t.Fatalf("stale release removed the lock of the next holder, lock is %q", value)
+	}
+	if err = ReleaseLock(ctx, "key:lock", token); err != nil {
+		t.Fatal(err)
+	}
+	if server.Exists("key:lock") {
+		t.Fatal("holder could not release its own lock")
+	}
+}
+
+func TestAcquireLockIsExclusive(t *testing.T) {
+	server := newTestRedis(t)
+	defer server.Close()
+	ctx := context.Background()
+	if _, locked, err := AcquireLock(ctx, "key:lock"); err != nil || !locked {
+		t.Fatalf("first acquire: locked %v, err %v", locked, err)
+	}
+	if _, locked, err := AcquireLock(ctx, "key:lock"); err != nil || locked {
+		t.Fatalf("second acquire: locked %v, err %v", locked, err)
+	}
+}
@@ -0,0 +1,25 @@
+package repository
+
+import (
+	"bitbucket.org/noon-micro/curriculum/pkg/lib/logger"
+	"github.com/alicebob/miniredis/v2"
+	"github.com/go-redis/redis"
+	redistrace "gopkg.in/DataDog/dd-trace-go.v1/contrib/go-redis/redis"
+	"time"
+)
+
+// InitializeMemoryRedisClient serves the cache from an in process redis, used by the memory environment
+func InitializeMemoryRedisClient() {
+	server, err := miniredis.Run()
+	if err != nil {
+		panic(err)
+	}
+	// miniredis only expires keys when told to, let ttls run on the wall clock
+	go func() {
+		for range time.Tick(time.Second) {
+			server.FastForward(time.Second)
+		}
+	}()
+	RedisClient = redistrace.NewClient(&redis.Options{Addr: server.Addr()}, redistrace.WithServiceName("curriculum-redis"))
+	logger.Client.Info("In Memory Redis Client Created Successfully For Url " + server.Addr())
+}
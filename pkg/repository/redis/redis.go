@@ -1,7 +1,10 @@
 package repository
 
 import (
+	"bitbucket.org/noon-micro/curriculum/config"
+	"bitbucket.org/noon-micro/curriculum/pkg/lib/helper"
 	"bitbucket.org/noon-micro/curriculum/pkg/lib/logger"
+	"context"
 	"github.com/go-redis/redis"
 	"github.com/sirupsen/logrus"
 	redistrace "gopkg.in/DataDog/dd-trace-go.v1/contrib/go-redis/redis"
@@ -19,9 +22,22 @@ const (
 	CurriculumGradeProductPrefix     string = "curriculum:grade_product:"
 	CurriculumParentTagMappingPrefix string = "curriculum:parent_tag_mapping:"
 	CurriculumTagLocaleMappingPrefix string = "curriculum:tag_locale_mapping:"
+	CurriculumAttributeLocalePrefix  string = "curriculum:tag_attribute_locale:"
+	CurriculumCountryRulePrefix      string = "curriculum:tag_country_rule:"
+	CurriculumRegionCountryPrefix    string = "curriculum:region_country_mapping:"
 	CurriculumMultiGradePrefix       string = "curriculum:multi_grade:"
+	CurriculumGenerationPrefix       string = "curriculum:generation:"
+	LockSuffix                       string = ":lock"
+	RefreshSuffix                    string = ":refresh"
 	MultiGradeTtl                           = 30 * time.Minute
 	RedisTtl                                = 24 * time.Hour
+	LockTtl                                 = 5 * time.Second
+	LockWait                                = 50 * time.Millisecond
+	LockAttempts                            = 10
+	// NegativeTtl bounds how long a missing row is remembered, a write racing the read may have been cached as missing
+	NegativeTtl = 5 * time.Minute
+	// a key is refreshed ahead of time once less than 1/EarlyRefreshRatio of its ttl remains
+	EarlyRefreshRatio = 10
 )
 
 // InitializeRedisClient Initialize redis client
@@ -35,7 +51,8 @@ func InitializeRedisClient(host string, port string) {
 		url = url + ":" + port
 	}
 
-	options := &redis.Options{Addr: url}
+	timeout := helper.Timeout(config.GetConfig().RedisTimeout, config.DefaultRedisTimeout)
+	options := &redis.Options{Addr: url, ReadTimeout: timeout, WriteTimeout: timeout}
 	RedisClient = redistrace.NewClient(options, redistrace.WithServiceName("curriculum-redis"))
 
 	_, err := RedisClient.Ping().Result()
@@ -47,3 +64,9 @@ func InitializeRedisClient(host string, port string) {
 
 	contextLogger.Info("Redis Client Created Successfully For Url " + url)
 }
+
+// Client returns the redis client bound to ctx so that commands are traced under the request.
+// go-redis v6 does not abort in flight commands on cancellation, the read and write timeouts bound them instead.
+func Client(ctx context.Context) *redistrace.Client {
+	return RedisClient.WithContext(ctx)
+}
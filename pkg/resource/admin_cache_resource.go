@@ -0,0 +1,109 @@
+package resource
+
+import (
+	"bitbucket.org/noon-micro/curriculum/pkg/domain"
+	"bitbucket.org/noon-micro/curriculum/pkg/entity"
+	"bitbucket.org/noon-micro/curriculum/pkg/lib/error"
+	"bitbucket.org/noon-micro/curriculum/pkg/lib/helper"
+	"bitbucket.org/noon-micro/curriculum/pkg/resource/entity/request"
+	entityresponse "bitbucket.org/noon-micro/curriculum/pkg/resource/entity/response"
+	"encoding/json"
+	"github.com/gorilla/mux"
+	"github.com/jinzhu/copier"
+	"net/http"
+)
+
+func (t *AdminTagsResource) getTagCache(rw http.ResponseWriter, req *http.Request) {
+	params := mux.Vars(req)
+	tagIdString := params["id"]
+	res, err := t.ats.GetTagCache(req.Context(), &tagIdString)
+	if err != nil {
+		entity.HandleError(rw, "", err, req.Header.Get("locale"), true)
+		return
+	}
+	sendCacheEntries(rw, req, res)
+}
+
+func (t *AdminTagsResource) getHierarchyCache(rw http.ResponseWriter, req *http.Request) {
+	params, err := getQueryParams(req)
+	if err != nil {
+		entity.HandleError(rw, "", err, req.Header.Get("locale"), true)
+		return
+	}
+	hierarchy, _ := params["hierarchy"]
+	if len(hierarchy) == 0 {
+		entity.HandleError(rw, "badRequest", noonerror.New(noonerror.ErrParamMissing, "hierarchyMissing"), req.Header.Get("locale"), true)
+		return
+	}
+	res, err := t.ats.GetHierarchyCache(req.Context(), &hierarchy)
+	if err != nil {
+		entity.HandleError(rw, "", err, req.Header.Get("locale"), true)
+		return
+	}
+	sendCacheEntries(rw, req, res)
+}
+
+func (t *AdminTagsResource) evictCache(rw http.ResponseWriter, req *http.Request) {
+	var evictCache request.EvictCacheDTO
+	err := json.NewDecoder(req.Body).Decode(&evictCache)
+	if err != nil {
+		entity.HandleError(rw, "badRequest", noonerror.ErrInvalidRequest, req.Header.Get("locale"), true)
+		return
+	}
+	err = helper.Validate(evictCache)
+	if err != nil {
+		entity.HandleError(rw, "badRequest", noonerror.New(noonerror.ErrInvalidRequest, err.Error()), req.Header.Get("locale"), true)
+		return
+	}
+	res, err := t.ats.EvictCache(req.Context(), evictCache.Keys)
+	if err != nil {
+		entity.HandleError(rw, "", err, req.Header.Get("locale"), true)
+		return
+	}
+	err = new(entity.Response).SendResponse(rw, entityresponse.EvictCacheResponseDTO{Keys: res}, nil, http.StatusOK)
+	if err != nil {
+		entity.HandleError(rw, "internalServerError", noonerror.ErrInternalServer, req.Header.Get("locale"), true)
+		return
+	}
+}
+
+func (t *AdminTagsResource) flushHierarchyCache(rw http.ResponseWriter, req *http.Request) {
+	var flushCache request.FlushHierarchyCacheDTO
+	err := json.NewDecoder(req.Body).Decode(&flushCache)
+	if err != nil {
+		entity.HandleError(rw, "badRequest", noonerror.ErrInvalidRequest, req.Header.Get("locale"), true)
+		return
+	}
+	err = helper.Validate(flushCache)
+	if err != nil {
+		entity.HandleError(rw, "badRequest", noonerror.New(noonerror.ErrInvalidRequest, err.Error()), req.Header.Get("locale"), true)
+		return
+	}
+	res, err := t.ats.FlushHierarchyCache(req.Context(), flushCache.Hierarchy)
+	if err != nil {
+		entity.HandleError(rw, "", err, req.Header.Get("locale"), true)
+		return
+	}
+	err = new(entity.Response).SendResponse(rw, entityresponse.EvictCacheResponseDTO{Keys: res}, nil, http.StatusOK)
+	if err != nil {
+		entity.HandleError(rw, "internalServerError", noonerror.ErrInternalServer, req.Header.Get("locale"), true)
+		return
+	}
+}
+
+func sendCacheEntries(rw http.ResponseWriter, req *http.Request, cacheEntries []*domain.CacheEntry) {
+	responses := []*entityresponse.CacheEntryResponseDTO{}
+	for _, v := range cacheEntries {
+		response := new(entityresponse.CacheEntryResponseDTO)
+		if err := copier.Copy(response, v); err != nil {
+			entity.HandleError(rw, "", noonerror.New(noonerror.ErrInternalServer, "mapperError"), req.Header.Get("locale"), true)
+			return
+		}
+		responses = append(responses, response)
+	}
+	err := new(entity.Response).SendResponse(rw, responses, nil, http.StatusOK)
+	if err != nil {
+		entity.HandleError(rw, "internalServerError", noonerror.ErrInternalServer, req.Header.Get("locale"), true)
+		return
+	}
+}
@@ -0,0 +1,325 @@
+package resource
+
+import (
+	"bitbucket.org/noon-micro/curriculum/pkg/domain"
+	"bitbucket.org/noon-micro/curriculum/pkg/entity"
+	"bitbucket.org/noon-micro/curriculum/pkg/lib/error"
+	"bitbucket.org/noon-micro/curriculum/pkg/lib/helper"
+	"bitbucket.org/noon-micro/curriculum/pkg/lib/localefile"
+	"bitbucket.org/noon-micro/curriculum/pkg/resource/entity/request"
+	entityresponse "bitbucket.org/noon-micro/curriculum/pkg/resource/entity/response"
+	"bitbucket.org/noon-micro/curriculum/pkg/service/constant"
+	"bytes"
+	"encoding/json"
+	"github.com/gorilla/mux"
+	"github.com/jinzhu/copier"
+	"net/http"
+	"strings"
+)
+
+// maxTranslationFileSize bounds the body of a translation import
+const maxTranslationFileSize = 10 << 20
+
+func (t *AdminTagsResource) exportTagLocales(rw http.ResponseWriter, req *http.Request) {
+	params, err := getQueryParams(req)
+	if err != nil {
+		entity.HandleError(rw, "", err, req.Header.Get("locale"), true)
+		return
+	}
+	export := request.TranslationExportDTO{Format: localefile.FormatCsv}
+	if hierarchy, ok := params["hierarchy"]; ok {
+		export.Hierarchy = &hierarchy
+	}
+	if countryId, ok := params["country_id"]; ok {
+		export.CountryId = &countryId
+	}
+	if locale, ok := params["locale"]; ok {
+		export.Locale = &locale
+	}
+	if format, ok := params["format"]; ok {
+		export.Format = format
+	}
+	err = helper.Validate(export)
+	if err != nil {
+		entity.HandleError(rw, "badRequest", noonerror.New(noonerror.ErrInvalidRequest, err.Error()), req.Header.Get("locale"), true)
+		return
+	}
+	var translationExport domain.TranslationExport
+	if err = copier.Copy(&translationExport, &export); err != nil {
+		entity.HandleError(rw, "", noonerror.New(noonerror.ErrInternalServer, "mapperError"), req.Header.Get("locale"), true)
+		return
+	}
+	units, err := t.ats.ExportTagLocales(req.Context(), &translationExport)
+	if err != nil {
+		entity.HandleError(rw, "", err, req.Header.Get("locale"), true)
+		return
+	}
+	var body bytes.Buffer
+	if err = localefile.Write(&body, export.Format, constant.DefaultLocale, *export.Locale, units); err != nil {
+		entity.HandleError(rw, "internalServerError", noonerror.New(noonerror.ErrInternalServer, "translationExportError"), req.Header.Get("locale"), true)
+		return
+	}
+	fileName := strings.Replace(*export.Hierarchy, ".", "-", -1) + "_" + *export.CountryId + "_" + *export.Locale + "." + export.Format
+	rw.Header().Set("Content-Type", localefile.ContentType(export.Format))
+	rw.Header().Set("Content-Disposition", "attachment; filename=\""+fileName+"\"")
+	rw.WriteHeader(http.StatusOK)
+	_, _ = rw.Write(body.Bytes())
+}
+
+func (t *AdminTagsResource) importTagLocales(rw http.ResponseWriter, req *http.Request) {
+	params, err := getQueryParams(req)
+	if err != nil {
+		entity.HandleError(rw, "", err, req.Header.Get("locale"), true)
+		return
+	}
+	translationImport := request.TranslationImportDTO{Format: localefile.FormatCsv, DryRun: params["dry_run"] == "true"}
+	if countryId, ok := params["country_id"]; ok {
+		translationImport.CountryId = &countryId
+	}
+	if locale, ok := params["locale"]; ok {
+		translationImport.Locale = &locale
+	}
+	if format, ok := params["format"]; ok {
+		translationImport.Format = format
+	}
+	units, fileLocale, err := localefile.Read(http.MaxBytesReader(rw, req.Body, maxTranslationFileSize), translationImport.Format)
+	if err != nil {
+		entity.HandleError(rw, "badRequest", noonerror.New(noonerror.ErrInvalidRequest, "translationFileInvalid"), req.Header.Get("locale"), true)
+		return
+	}
+	if fileLocale != "" {
+		if translationImport.Locale != nil && !strings.EqualFold(*translationImport.Locale, fileLocale) {
+			entity.HandleError(rw, "badRequest", noonerror.New(noonerror.ErrInvalidRequest, "translationLocaleMismatch"), req.Header.Get("locale"), true)
+			return
+		}
+		translationImport.Locale = &fileLocale
+	}
+	err = helper.Validate(translationImport)
+	if err != nil {
+		entity.HandleError(rw, "badRequest", noonerror.New(noonerror.ErrInvalidRequest, err.Error()), req.Header.Get("locale"), true)
+		return
+	}
+	res, err := t.ats.ImportTagLocales(req.Context(), &domain.TranslationImport{CountryId: translationImport.CountryId,
+		Locale: translationImport.Locale, DryRun: translationImport.DryRun, Units: units})
+	if err != nil {
+		entity.HandleError(rw, "", err, req.Header.Get("locale"), true)
+		return
+	}
+	var response entityresponse.TranslationImportResponseDTO
+	if err = copier.Copy(&response, res); err != nil {
+		entity.HandleError(rw, "", noonerror.New(noonerror.ErrInternalServer, "mapperError"), req.Header.Get("locale"), true)
+		return
+	}
+	err = new(entity.Response).SendResponse(rw, response, nil, http.StatusOK)
+	if err != nil {
+		entity.HandleError(rw, "internalServerError", noonerror.ErrInternalServer, req.Header.Get("locale"), true)
+		return
+	}
+}
+
+func (t *AdminTagsResource) getTranslationCoverage(rw http.ResponseWriter, req *http.Request) {
+	params, err := getQueryParams(req)
+	if err != nil {
+		entity.HandleError(rw, "", err, req.Header.Get("locale"), true)
+		return
+	}
+	coverage := request.TranslationCoverageDTO{Missing: params["missing"] == "true"}
+	if hierarchy, ok := params["hierarchy"]; ok {
+		coverage.Hierarchy = &hierarchy
+	}
+	if countryId, ok := params["country_id"]; ok {
+		coverage.CountryId = &countryId
+	}
+	if locale, ok := params["locale"]; ok {
+		coverage.Locale = &locale
+	}
+	if tagType, ok := params["type"]; ok {
+		coverage.TagType = &tagType
+	}
+	err = helper.Validate(coverage)
+	if err != nil {
+		entity.HandleError(rw, "badRequest", noonerror.New(noonerror.ErrInvalidRequest, err.Error()), req.Header.Get("locale"), true)
+		return
+	}
+	var coverageRequest domain.TranslationCoverageRequest
+	if err = copier.Copy(&coverageRequest, &coverage); err != nil {
+		entity.HandleError(rw, "", noonerror.New(noonerror.ErrInternalServer, "mapperError"), req.Header.Get("locale"), true)
+		return
+	}
+	res, err := t.ats.GetTranslationCoverage(req.Context(), &coverageRequest)
+	if err != nil {
+		entity.HandleError(rw, "", err, req.Header.Get("locale"), true)
+		return
+	}
+	var response entityresponse.TranslationCoverageResponseDTO
+	if err = copier.Copy(&response, res); err != nil {
+		entity.HandleError(rw, "", noonerror.New(noonerror.ErrInternalServer, "mapperError"), req.Header.Get("locale"), true)
+		return
+	}
+	err = new(entity.Response).SendResponse(rw, response, nil, http.StatusOK)
+	if err != nil {
+		entity.HandleError(rw, "internalServerError", noonerror.ErrInternalServer, req.Header.Get("locale"), true)
+		return
+	}
+}
+
+func (t *AdminTagsResource) suggestTagLocales(rw http.ResponseWriter, req *http.Request) {
+	var suggest request.TranslationSuggestDTO
+	err := json.NewDecoder(req.Body).Decode(&suggest)
+	if err != nil {
+		entity.HandleError(rw, "badRequest", noonerror.ErrInvalidRequest, req.Header.Get("locale"), true)
+		return
+	}
+	err = helper.Validate(suggest)
+	if err != nil {
+		entity.HandleError(rw, "badRequest", noonerror.New(noonerror.ErrInvalidRequest, err.Error()), req.Header.Get("locale"), true)
+		return
+	}
+	var translationSuggest domain.TranslationSuggest
+	if err = copier.Copy(&translationSuggest, &suggest); err != nil {
+		entity.HandleError(rw, "", noonerror.New(noonerror.ErrInternalServer, "mapperError"), req.Header.Get("locale"), true)
+		return
+	}
+	res, err := t.ats.SuggestTagLocales(req.Context(), &translationSuggest)
+	if err != nil {
+		entity.HandleError(rw, "", err, req.Header.Get("locale"), true)
+		return
+	}
+	var response entityresponse.TranslationSuggestResponseDTO
+	if err = copier.Copy(&response, res); err != nil {
+		entity.HandleError(rw, "", noonerror.New(noonerror.ErrInternalServer, "mapperError"), req.Header.Get("locale"), true)
+		return
+	}
+	err = new(entity.Response).SendResponse(rw, response, nil, http.StatusOK)
+	if err != nil {
+		entity.HandleError(rw, "internalServerError", noonerror.ErrInternalServer, req.Header.Get("locale"), true)
+		return
+	}
+}
+
+func (t *AdminTagsResource) getTagLocaleDrafts(rw http.ResponseWriter, req *http.Request) {
+	params, err := getQueryParams(req)
+	if err != nil {
+		entity.HandleError(rw, "", err, req.Header.Get("locale"), true)
+		return
+	}
+	var drafts request.TranslationDraftDTO
+	if hierarchy, ok := params["hierarchy"]; ok {
+		drafts.Hierarchy = &hierarchy
+	}
+	if countryId, ok := params["country_id"]; ok {
+		drafts.CountryId = &countryId
+	}
+	if locale, ok := params["locale"]; ok {
+		drafts.Locale = &locale
+	}
+	err = helper.Validate(drafts)
+	if err != nil {
+		entity.HandleError(rw, "badRequest", noonerror.New(noonerror.ErrInvalidRequest, err.Error()), req.Header.Get("locale"), true)
+		return
+	}
+	var draftRequest domain.TranslationDraftRequest
+	if err = copier.Copy(&draftRequest, &drafts); err != nil {
+		entity.HandleError(rw, "", noonerror.New(noonerror.ErrInternalServer, "mapperError"), req.Header.Get("locale"), true)
+		return
+	}
+	res, err := t.ats.GetTagLocaleDrafts(req.Context(), &draftRequest)
+	if err != nil {
+		entity.HandleError(rw, "", err, req.Header.Get("locale"), true)
+		return
+	}
+	err = new(entity.Response).SendResponse(rw, res, nil, http.StatusOK)
+	if err != nil {
+		entity.HandleError(rw, "internalServerError", noonerror.ErrInternalServer, req.Header.Get("locale"), true)
+		return
+	}
+}
+
+func (t *AdminTagsResource) reviewTagLocaleDrafts(rw http.ResponseWriter, req *http.Request) {
+	var review request.TranslationReviewDTO
+	err := json.NewDecoder(req.Body).Decode(&review)
+	if err != nil {
+		entity.HandleError(rw, "badRequest", noonerror.ErrInvalidRequest, req.Header.Get("locale"), true)
+		return
+	}
+	err = helper.Validate(review)
+	if err != nil {
+		entity.HandleError(rw, "badRequest", noonerror.New(noonerror.ErrInvalidRequest, err.Error()), req.Header.Get("locale"), true)
+		return
+	}
+	translationReview := domain.TranslationReview{CountryId: review.CountryId, Locale: review.Locale, Reject: review.Reject}
+	for _, v := range review.Approve {
+		translationReview.Approve = append(translationReview.Approve, &domain.TranslationApproval{ID: v.ID, Name: v.Name})
+	}
+	res, err := t.ats.ReviewTagLocaleDrafts(req.Context(), &translationReview)
+	if err != nil {
+		entity.HandleError(rw, "", err, req.Header.Get("locale"), true)
+		return
+	}
+	var response entityresponse.TranslationReviewResponseDTO
+	if err = copier.Copy(&response, res); err != nil {
+		entity.HandleError(rw, "", noonerror.New(noonerror.ErrInternalServer, "mapperError"), req.Header.Get("locale"), true)
+		return
+	}
+	err = new(entity.Response).SendResponse(rw, response, nil, http.StatusOK)
+	if err != nil {
+		entity.HandleError(rw, "internalServerError", noonerror.ErrInternalServer, req.Header.Get("locale"), true)
+		return
+	}
+}
+
+func (t *AdminTagsResource) getTagAttributeLocales(rw http.ResponseWriter, req *http.Request) {
+	params := mux.Vars(req)
+	tagIdString := params["id"]
+	res, err := t.ats.GetTagAttributeLocales(req.Context(), &tagIdString)
+	if err != nil {
+		entity.HandleError(rw, "", err, req.Header.Get("locale"), true)
+		return
+	}
+	response := []*entityresponse.TagAttributeLocaleResponseDTO{}
+	if err = copier.Copy(&response, res); err != nil {
+		entity.HandleError(rw, "", noonerror.New(noonerror.ErrInternalServer, "mapperError"), req.Header.Get("locale"), true)
+		return
+	}
+	err = new(entity.Response).SendResponse(rw, response, nil, http.StatusOK)
+	if err != nil {
+		entity.HandleError(rw, "internalServerError", noonerror.ErrInternalServer, req.Header.Get("locale"), true)
+		return
+	}
+}
+
+func (t *AdminTagsResource) updateTagAttributeLocales(rw http.ResponseWriter, req *http.Request) {
+	params := mux.Vars(req)
+	tagIdString := params["id"]
+	var update request.UpdateTagAttributeLocalesDTO
+	err := json.NewDecoder(req.Body).Decode(&update)
+	if err != nil {
+		entity.HandleError(rw, "badRequest", noonerror.ErrInvalidRequest, req.Header.Get("locale"), true)
+		return
+	}
+	err = helper.Validate(update)
+	if err != nil {
+		entity.HandleError(rw, "badRequest", noonerror.New(noonerror.ErrInvalidRequest, err.Error()), req.Header.Get("locale"), true)
+		return
+	}
+	var updateTagAttributeLocales domain.UpdateTagAttributeLocales
+	if err = copier.Copy(&updateTagAttributeLocales, &update); err != nil {
+		entity.HandleError(rw, "", noonerror.New(noonerror.ErrInternalServer, "mapperError"), req.Header.Get("locale"), true)
+		return
+	}
+	res, err := t.ats.UpdateTagAttributeLocales(req.Context(), &tagIdString, &updateTagAttributeLocales)
+	if err != nil {
+		entity.HandleError(rw, "", err, req.Header.Get("locale"), true)
+		return
+	}
+	response := []*entityresponse.TagAttributeLocaleResponseDTO{}
+	if err = copier.Copy(&response, res); err != nil {
+		entity.HandleError(rw, "", noonerror.New(noonerror.ErrInternalServer, "mapperError"), req.Header.Get("locale"), true)
+		return
+	}
+	err = new(entity.Response).SendResponse(rw, response, nil, http.StatusOK)
+	if err != nil {
+		entity.HandleError(rw, "internalServerError", noonerror.ErrInternalServer, req.Header.Get("locale"), true)
+		return
+	}
+}
@@ -31,12 +31,26 @@ func NewAdminTagsResource(route *mux.Router, ats domain.AdminTagsService) {
 	route.HandleFunc("/admin/tags/update", middleware.AuthWrapMiddleware(resource.updateTag, "admin")).Methods("PUT")
 	route.HandleFunc("/admin/tags/order", middleware.AuthWrapMiddleware(resource.updateTagOrder, "admin")).Methods("PUT")
 	route.HandleFunc("/admin/tags/locale/{action}", middleware.AuthWrapMiddleware(resource.updateLocaleForTags, "admin")).Methods("PUT")
+	route.HandleFunc("/admin/tags/locale/export", middleware.AuthWrapMiddleware(resource.exportTagLocales, "admin")).Methods("GET")
+	route.HandleFunc("/admin/tags/locale/import", middleware.AuthWrapMiddleware(resource.importTagLocales, "admin")).Methods("POST")
+	route.HandleFunc("/admin/tags/locale/coverage", middleware.AuthWrapMiddleware(resource.getTranslationCoverage, "admin")).Methods("GET")
+	route.HandleFunc("/admin/tags/locale/suggest", middleware.AuthWrapMiddleware(resource.suggestTagLocales, "admin")).Methods("POST")
+	route.HandleFunc("/admin/tags/locale/drafts", middleware.AuthWrapMiddleware(resource.getTagLocaleDrafts, "admin")).Methods("GET")
+	route.HandleFunc("/admin/tags/locale/drafts/review", middleware.AuthWrapMiddleware(resource.reviewTagLocaleDrafts, "admin")).Methods("POST")
 	route.HandleFunc("/admin/tags/delete/hierarchy", middleware.AuthWrapMiddleware(resource.removeTagsFromHierarchy, "admin")).Methods("PUT")
 	route.HandleFunc("/admin/tags/delete/identifier", middleware.AuthWrapMiddleware(resource.removeIdentifier, "admin")).Methods("PUT")
 	route.HandleFunc("/admin/tags", middleware.AuthWrapMiddleware(resource.getTags, "admin")).Methods("GET")
 	route.HandleFunc("/admin/tags/{id:[0-9]+}", middleware.AuthWrapMiddleware(resource.getTag, "admin")).Methods("GET")
+	route.HandleFunc("/admin/tags/{id:[0-9]+}/attributes/locale", middleware.AuthWrapMiddleware(resource.getTagAttributeLocales, "admin")).Methods("GET")
+	route.HandleFunc("/admin/tags/{id:[0-9]+}/attributes/locale", middleware.AuthWrapMiddleware(resource.updateTagAttributeLocales, "admin")).Methods("PUT")
+	route.HandleFunc("/admin/tags/{id:[0-9]+}/availability", middleware.AuthWrapMiddleware(resource.getTagAvailability, "admin")).Methods("GET")
+	route.HandleFunc("/admin/tags/{id:[0-9]+}/availability", middleware.AuthWrapMiddleware(resource.updateTagAvailability, "admin")).Methods("PUT")
 	route.HandleFunc("/admin/tags/search", middleware.AuthWrapMiddleware(resource.getTagsSearch, "admin")).Methods("GET")
 	route.HandleFunc("/admin/elastic/migrate", middleware.UnAuthWrapMiddleware(resource.migrateToElastic)).Methods("POST")
+	route.HandleFunc("/admin/cache/tags/{id:[0-9]+}", middleware.AuthWrapMiddleware(resource.getTagCache, "admin")).Methods("GET")
+	route.HandleFunc("/admin/cache/hierarchy", middleware.AuthWrapMiddleware(resource.getHierarchyCache, "admin")).Methods("GET")
+	route.HandleFunc("/admin/cache/evict", middleware.AuthWrapMiddleware(resource.evictCache, "admin")).Methods("POST")
+	route.HandleFunc("/admin/cache/flush", middleware.AuthWrapMiddleware(resource.flushHierarchyCache, "admin")).Methods("POST")
 
 	route.HandleFunc("/admin/boards", middleware.AuthWrapMiddleware(resource.getBoardTags, "admin.supply")).Methods("GET")
 	route.HandleFunc("/admin/grades", middleware.AuthWrapMiddleware(resource.getGradeTags, "admin.supply")).Methods("GET")
@@ -52,6 +66,10 @@ func NewAdminTagsResource(route *mux.Router, ats domain.AdminTagsService) {
 	route.HandleFunc("/admin/topics", middleware.AuthWrapMiddleware(resource.getTopicTags, "admin.supply")).Methods("GET")
 
 	route.HandleFunc("/admin/countries", middleware.AuthWrapMiddleware(resource.getCountriesNew, "admin.supply")).Methods("GET")
+	route.HandleFunc("/admin/regions", middleware.AuthWrapMiddleware(resource.getRegions, "admin.supply")).Methods("GET")
+	route.HandleFunc("/admin/regions/{id:[0-9]+}/countries", middleware.AuthWrapMiddleware(resource.updateRegionCountries, "admin")).Methods("PUT")
+	route.HandleFunc("/admin/countries/{id:[0-9]+}/calendar", middleware.AuthWrapMiddleware(resource.getAcademicCalendar, "admin.supply")).Methods("GET")
+	route.HandleFunc("/admin/countries/{id:[0-9]+}/calendar", middleware.AuthWrapMiddleware(resource.updateAcademicCalendar, "admin")).Methods("PUT")
 
 }
 
@@ -79,7 +97,7 @@ func (t *AdminTagsResource) createTagsFromAdmin(rw http.ResponseWriter, req *htt
 	createTag.CreatorId = &userId
 	createTag.CreatorType = new(string)
 	*createTag.CreatorType = "admin"
-	res, err := t.ats.CreateAdminTags(createTag.TagGroup, &createTag)
+	res, err := t.ats.CreateAdminTags(req.Context(), createTag.TagGroup, &createTag)
 	if err != nil {
 		entity.HandleError(rw, "", err, req.Header.Get("locale"), true)
 		return
@@ -107,7 +125,7 @@ func (t *AdminTagsResource) addTagsToHierarchy(rw http.ResponseWriter, req *http
 	if err = copier.Copy(&updateTag, &tag); err != nil {
 		entity.HandleError(rw, "", noonerror.New(noonerror.ErrInternalServer, "mapperError"), req.Header.Get("locale"), true)
 	}
-	res, err := t.ats.UpdateAdminTags(updateTag.TagGroup, &updateTag)
+	res, err := t.ats.UpdateAdminTags(req.Context(), updateTag.TagGroup, &updateTag)
 	if err != nil {
 		entity.HandleError(rw, "", err, req.Header.Get("locale"), true)
 		return
@@ -135,7 +153,7 @@ func (t *AdminTagsResource) addMultipleTagsToHierarchy(rw http.ResponseWriter, r
 	if err = copier.Copy(&updateTag, &tag); err != nil {
 		entity.HandleError(rw, "", noonerror.New(noonerror.ErrInternalServer, "mapperError"), req.Header.Get("locale"), true)
 	}
-	res, err := t.ats.UpdateMultipleAdminTags(&updateTag)
+	res, err := t.ats.UpdateMultipleAdminTags(req.Context(), &updateTag)
 	if err != nil {
 		entity.HandleError(rw, "", err, req.Header.Get("locale"), true)
 		return
@@ -163,12 +181,26 @@ func (t *AdminTagsResource) updateTag(rw http.ResponseWriter, req *http.Request)
 	if err = copier.Copy(&updateTag, &tag); err != nil {
 		entity.HandleError(rw, "", noonerror.New(noonerror.ErrInternalServer, "mapperError"), req.Header.Get("locale"), true)
 	}
-	err = t.ats.UpdateTag(&updateTag)
-	if err != nil {
+	if updateTag.Version, err = writeVersion(req, tag.Version); err != nil {
 		entity.HandleError(rw, "", err, req.Header.Get("locale"), true)
 		return
 	}
-	err = new(entity.Response).SendResponse(rw, nil, nil, http.StatusCreated)
+	res, err := t.ats.UpdateTag(req.Context(), &updateTag)
+	if err != nil && res == nil {
+		entity.HandleError(rw, "", err, req.Header.Get("locale"), true)
+		return
+	}
+	response := new(entityresponse.TagLocaleInfoResponseDTO)
+	if err := copier.Copy(response, res); err != nil {
+		entity.HandleError(rw, "", noonerror.New(noonerror.ErrInternalServer, "mapperError"), req.Header.Get("locale"), true)
+		return
+	}
+	setVersion(rw, response.Version)
+	status := http.StatusCreated
+	if err != nil {
+		status = http.StatusConflict
+	}
+	err = new(entity.Response).SendResponse(rw, response, nil, status)
 	if err != nil {
 		entity.HandleError(rw, "internalServerError", noonerror.ErrInternalServer, req.Header.Get("locale"), true)
 		return
@@ -191,18 +223,56 @@ func (t *AdminTagsResource) updateTagOrder(rw http.ResponseWriter, req *http.Req
 	if err = copier.Copy(&updateTag, &tag); err != nil {
 		entity.HandleError(rw, "", noonerror.New(noonerror.ErrInternalServer, "mapperError"), req.Header.Get("locale"), true)
 	}
-	err = t.ats.UpdateTagOrder(updateTag.TagGroup, &updateTag)
-	if err != nil {
+	if updateTag.Version, err = writeVersion(req, tag.Version); err != nil {
 		entity.HandleError(rw, "", err, req.Header.Get("locale"), true)
 		return
 	}
-	err = new(entity.Response).SendResponse(rw, nil, nil, http.StatusCreated)
+	res, err := t.ats.UpdateTagOrder(req.Context(), updateTag.TagGroup, &updateTag)
+	if err != nil && res == nil {
+		entity.HandleError(rw, "", err, req.Header.Get("locale"), true)
+		return
+	}
+	response := new(entityresponse.TagOrdersResponseDTO)
+	if err := copier.Copy(response, res); err != nil {
+		entity.HandleError(rw, "", noonerror.New(noonerror.ErrInternalServer, "mapperError"), req.Header.Get("locale"), true)
+		return
+	}
+	setVersion(rw, &response.Version)
+	status := http.StatusCreated
+	if err != nil {
+		status = http.StatusConflict
+	}
+	err = new(entity.Response).SendResponse(rw, response, nil, status)
 	if err != nil {
 		entity.HandleError(rw, "internalServerError", noonerror.ErrInternalServer, req.Header.Get("locale"), true)
 		return
 	}
 }
 
+// writeVersion reads the version an update was made against, from the payload or else from the If-Match header. A
+// write which was rejected for being stale is answered with 409 and the current state.
+func writeVersion(req *http.Request, version *int) (*int, error) {
+	if version != nil {
+		return version, nil
+	}
+	ifMatch := strings.TrimPrefix(strings.TrimSpace(req.Header.Get("If-Match")), "W/")
+	if len(ifMatch) == 0 {
+		return nil, noonerror.New(noonerror.ErrBadRequest, "versionRequired")
+	}
+	value, err := strconv.Atoi(strings.Trim(ifMatch, `"`))
+	if err != nil || value < 0 {
+		return nil, noonerror.New(noonerror.ErrBadRequest, "versionInvalid")
+	}
+	return &value, nil
+}
+
+// setVersion sends the version as the ETag, the value to send back in If-Match
+func setVersion(rw http.ResponseWriter, version *int) {
+	if version != nil {
+		rw.Header().Set("ETag", `"`+strconv.Itoa(*version)+`"`)
+	}
+}
+
 func (t *AdminTagsResource) removeTagsFromHierarchy(rw http.ResponseWriter, req *http.Request) {
 	var tag request.RemoveHierarchyDTO
 	err := json.NewDecoder(req.Body).Decode(&tag)
@@ -219,7 +289,7 @@ func (t *AdminTagsResource) removeTagsFromHierarchy(rw http.ResponseWriter, req
 	if err = copier.Copy(&removeHierarchy, &tag); err != nil {
 		entity.HandleError(rw, "", noonerror.New(noonerror.ErrInternalServer, "mapperError"), req.Header.Get("locale"), true)
 	}
-	res, err := t.ats.RemoveAdminTagFromHierarchy(removeHierarchy.TagGroup, &removeHierarchy)
+	res, err := t.ats.RemoveAdminTagFromHierarchy(req.Context(), removeHierarchy.TagGroup, &removeHierarchy)
 	if err != nil {
 		entity.HandleError(rw, "", err, req.Header.Get("locale"), true)
 		return
@@ -243,7 +313,7 @@ func (t *AdminTagsResource) removeIdentifier(rw http.ResponseWriter, req *http.R
 		entity.HandleError(rw, "badRequest", noonerror.New(noonerror.ErrInvalidRequest, err.Error()), req.Header.Get("locale"), true)
 		return
 	}
-	err = t.ats.RemoveIdentifierTag(tag.ID)
+	err = t.ats.RemoveIdentifierTag(req.Context(), tag.ID)
 	if err != nil {
 		entity.HandleError(rw, "", err, req.Header.Get("locale"), true)
 		return
@@ -267,7 +337,7 @@ func (t *AdminTagsResource) migrateToElastic(rw http.ResponseWriter, req *http.R
 		entity.HandleError(rw, "badRequest", noonerror.New(noonerror.ErrInvalidRequest, err.Error()), req.Header.Get("locale"), true)
 		return
 	}
-	err = t.ats.MigrateToElastic(tag.Start, tag.End)
+	err = t.ats.MigrateToElastic(req.Context(), tag.Start, tag.End)
 	if err != nil {
 		entity.HandleError(rw, "", err, req.Header.Get("locale"), true)
 		return
@@ -323,7 +393,7 @@ func (t *AdminTagsResource) getTags(rw http.ResponseWriter, req *http.Request) {
 	if err = copier.Copy(&getTags, &tag); err != nil {
 		entity.HandleError(rw, "", noonerror.New(noonerror.ErrInternalServer, "mapperError"), req.Header.Get("locale"), true)
 	}
-	res, err := t.ats.GetTags(&getTags)
+	res, err := t.ats.GetTags(req.Context(), &getTags)
 	if err != nil {
 		entity.HandleError(rw, "", err, req.Header.Get("locale"), true)
 		return
@@ -338,7 +408,7 @@ func (t *AdminTagsResource) getTags(rw http.ResponseWriter, req *http.Request) {
 func (t *AdminTagsResource) getTag(rw http.ResponseWriter, req *http.Request) {
 	params := mux.Vars(req)
 	tagIdString := params["id"]
-	res, err := t.ats.GetTag(&tagIdString)
+	res, err := t.ats.GetTag(req.Context(), &tagIdString)
 	if err != nil {
 		entity.HandleError(rw, "", err, req.Header.Get("locale"), true)
 		return
@@ -347,6 +417,7 @@ func (t *AdminTagsResource) getTag(rw http.ResponseWriter, req *http.Request) {
 	if err = copier.Copy(response, res); err != nil {
 		entity.HandleError(rw, "", noonerror.New(noonerror.ErrInternalServer, "mapperError"), req.Header.Get("locale"), true)
 	}
+	setVersion(rw, response.Version)
 	err = new(entity.Response).SendResponse(rw, response, nil, http.StatusOK)
 	if err != nil {
 		entity.HandleError(rw, "internalServerError", noonerror.ErrInternalServer, req.Header.Get("locale"), true)
@@ -413,7 +484,7 @@ func (t *AdminTagsResource) getTagsSearch(rw http.ResponseWriter, req *http.Requ
 	if err = copier.Copy(&getTags, &tag); err != nil {
 		entity.HandleError(rw, "", noonerror.New(noonerror.ErrInternalServer, "mapperError"), req.Header.Get("locale"), true)
 	}
-	res, err := t.ats.GetTagsSearch(&getTags)
+	res, err := t.ats.GetTagsSearch(req.Context(), &getTags)
 	if err != nil {
 		entity.HandleError(rw, "", err, req.Header.Get("locale"), true)
 		return
@@ -475,7 +546,7 @@ func (t *AdminTagsResource) updateLocaleForTags(rw http.ResponseWriter, req *htt
 	if err = copier.Copy(&tagLocale, &tag); err != nil {
 		entity.HandleError(rw, "", noonerror.New(noonerror.ErrInternalServer, "mapperError"), req.Header.Get("locale"), true)
 	}
-	err = t.ats.UpdateTagLocale(&action, &tagLocale)
+	err = t.ats.UpdateTagLocale(req.Context(), &action, &tagLocale)
 	if err != nil {
 		entity.HandleError(rw, "", err, req.Header.Get("locale"), true)
 		return
@@ -684,7 +755,7 @@ func (t *AdminTagsResource) getTagsForLibrary(tagType string, tagGroup string, c
 	if err = copier.Copy(&getAdminTags, &tag); err != nil {
 		entity.HandleError(rw, "", noonerror.New(noonerror.ErrInternalServer, "mapperError"), req.Header.Get("locale"), true)
 	}
-	res, err := t.ats.GetAdminTags(&getAdminTags)
+	res, err := t.ats.GetAdminTags(req.Context(), &getAdminTags)
 	if err != nil {
 		entity.HandleError(rw, "", err, req.Header.Get("locale"), true)
 		return
@@ -785,7 +856,7 @@ func (t *AdminTagsResource) getTestSkillTags(tagType string, tagGroup string, pa
 	if err = copier.Copy(&getAdminTags, &tag); err != nil {
 		entity.HandleError(rw, "", noonerror.New(noonerror.ErrInternalServer, "mapperError"), req.Header.Get("locale"), true)
 	}
-	res, err := t.ats.GetTestsSkillsForLibrary(&getAdminTags)
+	res, err := t.ats.GetTestsSkillsForLibrary(req.Context(), &getAdminTags)
 	if err != nil {
 		entity.HandleError(rw, "", err, req.Header.Get("locale"), true)
 		return
@@ -820,7 +891,7 @@ func (t *AdminTagsResource) getCountriesNew(rw http.ResponseWriter, req *http.Re
 	countryId := req.Header.Get("country")
 	locale := req.Header.Get("locale")
 
-	ipAddress := req.Header.Get("X-FORWARDED-FOR")
+	ipAddress := helper.ClientIp(req)
 	start, _ := params["start"]
 	startInt := 0
 	if len(start) > 0 {
@@ -831,10 +902,12 @@ func (t *AdminTagsResource) getCountriesNew(rw http.ResponseWriter, req *http.Re
 	if len(limit) > 0 {
 		limitInt, _ = strconv.Atoi(limit)
 	}
+	groupBy, _ := params["group_by"]
 	queryParams := request.GetCountriesNewDTO{
 		IpAddress: ipAddress,
 		CountryId: &countryId,
 		Locale:    &locale,
+		GroupBy:   &groupBy,
 		Start:     startInt,
 		Limit:     limitInt,
 	}
@@ -842,7 +915,7 @@ func (t *AdminTagsResource) getCountriesNew(rw http.ResponseWriter, req *http.Re
 	if err = copier.Copy(&getQueryParams, &queryParams); err != nil {
 		entity.HandleError(rw, "", noonerror.New(noonerror.ErrInternalServer, "mapperError"), req.Header.Get("locale"), true)
 	}
-	res, err := t.ats.GetCountriesTagsNew(&getQueryParams)
+	res, err := t.ats.GetCountriesTagsNew(req.Context(), &getQueryParams)
 	if err != nil {
 		entity.HandleError(rw, "", err, req.Header.Get("locale"), true)
 		return
@@ -853,3 +926,174 @@ func (t *AdminTagsResource) getCountriesNew(rw http.ResponseWriter, req *http.Re
 		return
 	}
 }
+
+func (t *AdminTagsResource) getTagAvailability(rw http.ResponseWriter, req *http.Request) {
+	params := mux.Vars(req)
+	tagIdString := params["id"]
+	res, err := t.ats.GetTagAvailability(req.Context(), &tagIdString)
+	if err != nil {
+		entity.HandleError(rw, "", err, req.Header.Get("locale"), true)
+		return
+	}
+	response := new(entityresponse.TagAvailabilityResponseDTO)
+	if err = copier.Copy(response, res); err != nil {
+		entity.HandleError(rw, "", noonerror.New(noonerror.ErrInternalServer, "mapperError"), req.Header.Get("locale"), true)
+		return
+	}
+	err = new(entity.Response).SendResponse(rw, response, nil, http.StatusOK)
+	if err != nil {
+		entity.HandleError(rw, "internalServerError", noonerror.ErrInternalServer, req.Header.Get("locale"), true)
+		return
+	}
+}
+
+func (t *AdminTagsResource) updateTagAvailability(rw http.ResponseWriter, req *http.Request) {
+	params := mux.Vars(req)
+	tagIdString := params["id"]
+	var update request.UpdateTagAvailabilityDTO
+	err := json.NewDecoder(req.Body).Decode(&update)
+	if err != nil {
+		entity.HandleError(rw, "badRequest", noonerror.ErrInvalidRequest, req.Header.Get("locale"), true)
+		return
+	}
+	err = helper.Validate(update)
+	if err != nil {
+		entity.HandleError(rw, "badRequest", noonerror.New(noonerror.ErrInvalidRequest, err.Error()), req.Header.Get("locale"), true)
+		return
+	}
+	var tagAvailability domain.TagAvailability
+	if err = copier.Copy(&tagAvailability, &update); err != nil {
+		entity.HandleError(rw, "", noonerror.New(noonerror.ErrInternalServer, "mapperError"), req.Header.Get("locale"), true)
+		return
+	}
+	res, err := t.ats.UpdateTagAvailability(req.Context(), &tagIdString, &tagAvailability)
+	if err != nil {
+		entity.HandleError(rw, "", err, req.Header.Get("locale"), true)
+		return
+	}
+	response := new(entityresponse.TagAvailabilityResponseDTO)
+	if err = copier.Copy(response, res); err != nil {
+		entity.HandleError(rw, "", noonerror.New(noonerror.ErrInternalServer, "mapperError"), req.Header.Get("locale"), true)
+		return
+	}
+	err = new(entity.Response).SendResponse(rw, response, nil, http.StatusOK)
+	if err != nil {
+		entity.HandleError(rw, "internalServerError", noonerror.ErrInternalServer, req.Header.Get("locale"), true)
+		return
+	}
+}
+
+func (t *AdminTagsResource) getRegions(rw http.ResponseWriter, req *http.Request) {
+	res, err := t.ats.GetRegions(req.Context())
+	if err != nil {
+		entity.HandleError(rw, "", err, req.Header.Get("locale"), true)
+		return
+	}
+	response := []*entityresponse.RegionResponseDTO{}
+	if err = copier.Copy(&response, res); err != nil {
+		entity.HandleError(rw, "", noonerror.New(noonerror.ErrInternalServer, "mapperError"), req.Header.Get("locale"), true)
+		return
+	}
+	err = new(entity.Response).SendResponse(rw, response, nil, http.StatusOK)
+	if err != nil {
+		entity.HandleError(rw, "internalServerError", noonerror.ErrInternalServer, req.Header.Get("locale"), true)
+		return
+	}
+}
+
+func (t *AdminTagsResource) updateRegionCountries(rw http.ResponseWriter, req *http.Request) {
+	params := mux.Vars(req)
+	regionIdString := params["id"]
+	var update request.UpdateRegionCountriesDTO
+	err := json.NewDecoder(req.Body).Decode(&update)
+	if err != nil {
+		entity.HandleError(rw, "badRequest", noonerror.ErrInvalidRequest, req.Header.Get("locale"), true)
+		return
+	}
+	err = helper.Validate(update)
+	if err != nil {
+		entity.HandleError(rw, "badRequest", noonerror.New(noonerror.ErrInvalidRequest, err.Error()), req.Header.Get("locale"), true)
+		return
+	}
+	var updateRegionCountries domain.UpdateRegionCountries
+	if err = copier.Copy(&updateRegionCountries, &update); err != nil {
+		entity.HandleError(rw, "", noonerror.New(noonerror.ErrInternalServer, "mapperError"), req.Header.Get("locale"), true)
+		return
+	}
+	res, err := t.ats.UpdateRegionCountries(req.Context(), &regionIdString, &updateRegionCountries)
+	if err != nil {
+		entity.HandleError(rw, "", err, req.Header.Get("locale"), true)
+		return
+	}
+	response := new(entityresponse.RegionResponseDTO)
+	if err = copier.Copy(response, res); err != nil {
+		entity.HandleError(rw, "", noonerror.New(noonerror.ErrInternalServer, "mapperError"), req.Header.Get("locale"), true)
+		return
+	}
+	err = new(entity.Response).SendResponse(rw, response, nil, http.StatusOK)
+	if err != nil {
+		entity.HandleError(rw, "internalServerError", noonerror.ErrInternalServer, req.Header.Get("locale"), true)
+		return
+	}
+}
+
+func (t *AdminTagsResource) getAcademicCalendar(rw http.ResponseWriter, req *http.Request) {
+	params := mux.Vars(req)
+	countryIdString := params["id"]
+	res, err := t.ats.GetAcademicCalendar(req.Context(), &countryIdString)
+	if err != nil {
+		entity.HandleError(rw, "", err, req.Header.Get("locale"), true)
+		return
+	}
+	response := new(entityresponse.AcademicCalendarResponseDTO)
+	if err = copier.Copy(response, res); err != nil {
+		entity.HandleError(rw, "", noonerror.New(noonerror.ErrInternalServer, "mapperError"), req.Header.Get("locale"), true)
+		return
+	}
+	err = new(entity.Response).SendResponse(rw, response, nil, http.StatusOK)
+	if err != nil {
+		entity.HandleError(rw, "internalServerError", noonerror.ErrInternalServer, req.Header.Get("locale"), true)
+		return
+	}
+}
+
+func (t *AdminTagsResource) updateAcademicCalendar(rw http.ResponseWriter, req *http.Request) {
+	params := mux.Vars(req)
+	countryIdString := params["id"]
+	var update request.UpdateAcademicCalendarDTO
+	err := json.NewDecoder(req.Body).Decode(&update)
+	if err != nil {
+		entity.HandleError(rw, "badRequest", noonerror.ErrInvalidRequest, req.Header.Get("locale"), true)
+		return
+	}
+	err = helper.Validate(update)
+	if err != nil {
+		entity.HandleError(rw, "badRequest", noonerror.New(noonerror.ErrInvalidRequest, err.Error()), req.Header.Get("locale"), true)
+		return
+	}
+	var academicCalendar domain.AcademicCalendar
+	if err = copier.Copy(&academicCalendar, &update); err != nil {
+		entity.HandleError(rw, "", noonerror.New(noonerror.ErrInternalServer, "mapperError"), req.Header.Get("locale"), true)
+		return
+	}
+	res, err := t.ats.UpdateAcademicCalendar(req.Context(), &countryIdString, &academicCalendar)
+	if err != nil && res == nil {
+		entity.HandleError(rw, "", err, req.Header.Get("locale"), true)
+		return
+	}
+	response := new(entityresponse.AcademicCalendarResponseDTO)
+	if err := copier.Copy(response, res); err != nil {
+		entity.HandleError(rw, "", noonerror.New(noonerror.ErrInternalServer, "mapperError"), req.Header.Get("locale"), true)
+		return
+	}
+	// the country changed while the calendar was merged into it, the current calendar is sent back with 409
+	status := http.StatusOK
+	if err != nil {
+		status = http.StatusConflict
+	}
+	err = new(entity.Response).SendResponse(rw, response, nil, status)
+	if err != nil {
+		entity.HandleError(rw, "internalServerError", noonerror.ErrInternalServer, req.Header.Get("locale"), true)
+		return
+	}
+}
@@ -0,0 +1,196 @@
+package resource
+
+import (
+	"bitbucket.org/noon-micro/curriculum/pkg/domain"
+	"bitbucket.org/noon-micro/curriculum/pkg/entity"
+	"bitbucket.org/noon-micro/curriculum/pkg/lib/error"
+	"bitbucket.org/noon-micro/curriculum/pkg/lib/helper"
+	"bitbucket.org/noon-micro/curriculum/pkg/lib/middleware"
+	"bitbucket.org/noon-micro/curriculum/pkg/resource/entity/request"
+	entityresponse "bitbucket.org/noon-micro/curriculum/pkg/resource/entity/response"
+	"encoding/json"
+	"github.com/gorilla/mux"
+	"github.com/jinzhu/copier"
+	"net/http"
+	"strconv"
+)
+
+type AdminWebhookResource struct {
+	ws domain.WebhookService
+}
+
+func NewAdminWebhookResource(route *mux.Router, ws domain.WebhookService) {
+	resource := &AdminWebhookResource{
+		ws: ws,
+	}
+	route.HandleFunc("/admin/webhooks", middleware.AuthWrapMiddleware(resource.createWebhookSubscription, "admin")).Methods("POST")
+	route.HandleFunc("/admin/webhooks", middleware.AuthWrapMiddleware(resource.getWebhookSubscriptions, "admin")).Methods("GET")
+	route.HandleFunc("/admin/webhooks/{id:[0-9]+}", middleware.AuthWrapMiddleware(resource.getWebhookSubscription, "admin")).Methods("GET")
+	route.HandleFunc("/admin/webhooks/{id:[0-9]+}", middleware.AuthWrapMiddleware(resource.updateWebhookSubscription, "admin")).Methods("PUT")
+	route.HandleFunc("/admin/webhooks/{id:[0-9]+}", middleware.AuthWrapMiddleware(resource.deleteWebhookSubscription, "admin")).Methods("DELETE")
+	route.HandleFunc("/admin/webhooks/{id:[0-9]+}/deliveries", middleware.AuthWrapMiddleware(resource.getWebhookDeliveries, "admin")).Methods("GET")
+	route.HandleFunc("/admin/webhooks/deliveries/{id:[0-9]+}/redeliver", middleware.AuthWrapMiddleware(resource.redeliverWebhook, "admin")).Methods("POST")
+}
+
+func (t *AdminWebhookResource) createWebhookSubscription(rw http.ResponseWriter, req *http.Request) {
+	var create request.CreateWebhookSubscriptionDTO
+	err := json.NewDecoder(req.Body).Decode(&create)
+	if err != nil {
+		entity.HandleError(rw, "badRequest", noonerror.ErrInvalidRequest, req.Header.Get("locale"), true)
+		return
+	}
+	err = helper.Validate(create)
+	if err != nil {
+		entity.HandleError(rw, "badRequest", noonerror.New(noonerror.ErrInvalidRequest, err.Error()), req.Header.Get("locale"), true)
+		return
+	}
+	var save domain.SaveWebhookSubscription
+	if err = copier.Copy(&save, &create); err != nil {
+		entity.HandleError(rw, "", noonerror.New(noonerror.ErrInternalServer, "mapperError"), req.Header.Get("locale"), true)
+		return
+	}
+	res, err := t.ws.CreateWebhookSubscription(req.Context(), &save)
+	if err != nil {
+		entity.HandleError(rw, "", err, req.Header.Get("locale"), true)
+		return
+	}
+	sendWebhookSubscription(rw, req, res, http.StatusCreated)
+}
+
+func (t *AdminWebhookResource) getWebhookSubscriptions(rw http.ResponseWriter, req *http.Request) {
+	res, err := t.ws.GetWebhookSubscriptions(req.Context())
+	if err != nil {
+		entity.HandleError(rw, "", err, req.Header.Get("locale"), true)
+		return
+	}
+	response := []*entityresponse.WebhookSubscriptionResponseDTO{}
+	if err = copier.Copy(&response, res); err != nil {
+		entity.HandleError(rw, "", noonerror.New(noonerror.ErrInternalServer, "mapperError"), req.Header.Get("locale"), true)
+		return
+	}
+	err = new(entity.Response).SendResponse(rw, response, nil, http.StatusOK)
+	if err != nil {
+		entity.HandleError(rw, "internalServerError", noonerror.ErrInternalServer, req.Header.Get("locale"), true)
+		return
+	}
+}
+
+func (t *AdminWebhookResource) getWebhookSubscription(rw http.ResponseWriter, req *http.Request) {
+	params := mux.Vars(req)
+	subscriptionIdString := params["id"]
+	res, err := t.ws.GetWebhookSubscription(req.Context(), &subscriptionIdString)
+	if err != nil {
+		entity.HandleError(rw, "", err, req.Header.Get("locale"), true)
+		return
+	}
+	sendWebhookSubscription(rw, req, res, http.StatusOK)
+}
+
+func (t *AdminWebhookResource) updateWebhookSubscription(rw http.ResponseWriter, req *http.Request) {
+	params := mux.Vars(req)
+	subscriptionIdString := params["id"]
+	var update request.UpdateWebhookSubscriptionDTO
+	err := json.NewDecoder(req.Body).Decode(&update)
+	if err != nil {
+		entity.HandleError(rw, "badRequest", noonerror.ErrInvalidRequest, req.Header.Get("locale"), true)
+		return
+	}
+	err = helper.Validate(update)
+	if err != nil {
+		entity.HandleError(rw, "badRequest", noonerror.New(noonerror.ErrInvalidRequest, err.Error()), req.Header.Get("locale"), true)
+		return
+	}
+	var save domain.SaveWebhookSubscription
+	if err = copier.Copy(&save, &update); err != nil {
+		entity.HandleError(rw, "", noonerror.New(noonerror.ErrInternalServer, "mapperError"), req.Header.Get("locale"), true)
+		return
+	}
+	res, err := t.ws.UpdateWebhookSubscription(req.Context(), &subscriptionIdString, &save)
+	if err != nil {
+		entity.HandleError(rw, "", err, req.Header.Get("locale"), true)
+		return
+	}
+	sendWebhookSubscription(rw, req, res, http.StatusOK)
+}
+
+func (t *AdminWebhookResource) deleteWebhookSubscription(rw http.ResponseWriter, req *http.Request) {
+	params := mux.Vars(req)
+	subscriptionIdString := params["id"]
+	err := t.ws.DeleteWebhookSubscription(req.Context(), &subscriptionIdString)
+	if err != nil {
+		entity.HandleError(rw, "", err, req.Header.Get("locale"), true)
+		return
+	}
+	err = new(entity.Response).SendResponse(rw, nil, nil, http.StatusOK)
+	if err != nil {
+		entity.HandleError(rw, "internalServerError", noonerror.ErrInternalServer, req.Header.Get("locale"), true)
+		return
+	}
+}
+
+func (t *AdminWebhookResource) getWebhookDeliveries(rw http.ResponseWriter, req *http.Request) {
+	subscriptionIdString := mux.Vars(req)["id"]
+	params, err := getQueryParams(req)
+	if err != nil {
+		entity.HandleError(rw, "", err, req.Header.Get("locale"), true)
+		return
+	}
+	getDeliveries := &domain.GetWebhookDeliveries{SubscriptionID: &subscriptionIdString}
+	if status, _ := params["status"]; len(status) > 0 {
+		getDeliveries.Status = &status
+	}
+	if start, _ := params["start"]; len(start) > 0 {
+		getDeliveries.Start, _ = strconv.Atoi(start)
+	}
+	if limit, _ := params["limit"]; len(limit) > 0 {
+		getDeliveries.Limit, _ = strconv.Atoi(limit)
+	}
+	res, err := t.ws.GetWebhookDeliveries(req.Context(), getDeliveries)
+	if err != nil {
+		entity.HandleError(rw, "", err, req.Header.Get("locale"), true)
+		return
+	}
+	response := []*entityresponse.WebhookDeliveryResponseDTO{}
+	if err = copier.Copy(&response, res); err != nil {
+		entity.HandleError(rw, "", noonerror.New(noonerror.ErrInternalServer, "mapperError"), req.Header.Get("locale"), true)
+		return
+	}
+	err = new(entity.Response).SendResponse(rw, response, nil, http.StatusOK)
+	if err != nil {
+		entity.HandleError(rw, "internalServerError", noonerror.ErrInternalServer, req.Header.Get("locale"), true)
+		return
+	}
+}
+
+func (t *AdminWebhookResource) redeliverWebhook(rw http.ResponseWriter, req *http.Request) {
+	params := mux.Vars(req)
+	deliveryIdString := params["id"]
+	res, err := t.ws.RedeliverWebhook(req.Context(), &deliveryIdString)
+	if err != nil {
+		entity.HandleError(rw, "", err, req.Header.Get("locale"), true)
+		return
+	}
+	response := new(entityresponse.WebhookDeliveryResponseDTO)
+	if err = copier.Copy(response, res); err != nil {
+		entity.HandleError(rw, "", noonerror.New(noonerror.ErrInternalServer, "mapperError"), req.Header.Get("locale"), true)
+		return
+	}
+	err = new(entity.Response).SendResponse(rw, response, nil, http.StatusCreated)
+	if err != nil {
+		entity.HandleError(rw, "internalServerError", noonerror.ErrInternalServer, req.Header.Get("locale"), true)
+		return
+	}
+}
+
+func sendWebhookSubscription(rw http.ResponseWriter, req *http.Request, subscription *domain.WebhookSubscription, statusCode int) {
+	response := new(entityresponse.WebhookSubscriptionResponseDTO)
+	if err := copier.Copy(response, subscription); err != nil {
+		entity.HandleError(rw, "", noonerror.New(noonerror.ErrInternalServer, "mapperError"), req.Header.Get("locale"), true)
+		return
+	}
+	err := new(entity.Response).SendResponse(rw, response, nil, statusCode)
+	if err != nil {
+		entity.HandleError(rw, "internalServerError", noonerror.ErrInternalServer, req.Header.Get("locale"), true)
+		return
+	}
+}
@@ -34,6 +34,7 @@ type UpdateTagOrderDTO struct {
 	TagGroup       *string     `json:"tag_group" validate:"required,oneof=curriculum content"`
 	Hierarchy      []*string   `json:"hierarchy" validate:"required,contains-nil"`
 	Orders         []*OrderDTO `json:"orders" validate:"contains-nil"`
+	Version        *int        `json:"version" validate:"omitempty,min=0"`
 }
 
 type OrderDTO struct {
@@ -46,6 +47,7 @@ type UpdateTagDTO struct {
 	Attributes map[string]interface{} `json:"attributes"`
 	Name       *string                `json:"name"`
 	Hidden     *bool                  `json:"hidden"`
+	Version    *int                   `json:"version" validate:"omitempty,min=0"`
 }
 
 type RemoveHierarchyDTO struct {
@@ -72,6 +74,7 @@ type GetCountriesNewDTO struct {
 	ISOCode	  string  `json:iso_code`
 	CountryId      *string   `json:"country_id"`
 	Locale         *string   `json:"locale"`
+	GroupBy        *string   `json:"group_by"`
 	Start     int    `json:"start"`
 	Limit     int    `json:"limit"`
 }
@@ -141,3 +144,121 @@ type LocaleDTO struct {
 	CountryId *string `json:"country_id" validate:"required,min=1"`
 	Locale    *string `json:"locale" validate:"required,min=1"`
 }
+
+type EvictCacheDTO struct {
+	Keys []*string `json:"keys" validate:"required,min=1,contains-nil"`
+}
+
+type FlushHierarchyCacheDTO struct {
+	Hierarchy *string `json:"hierarchy" validate:"required,min=1"`
+}
+
+type TranslationExportDTO struct {
+	Hierarchy *string `json:"hierarchy" validate:"required,min=1"`
+	CountryId *string `json:"country_id" validate:"required,min=1"`
+	Locale    *string `json:"locale" validate:"required,min=1"`
+	Format    string  `json:"format" validate:"oneof=csv xliff"`
+}
+
+type TranslationImportDTO struct {
+	CountryId *string `json:"country_id" validate:"required,min=1"`
+	Locale    *string `json:"locale" validate:"required,min=1"`
+	Format    string  `json:"format" validate:"oneof=csv xliff"`
+	DryRun    bool    `json:"dry_run"`
+}
+
+type TranslationCoverageDTO struct {
+	Hierarchy *string `json:"hierarchy" validate:"required,min=1"`
+	CountryId *string `json:"country_id" validate:"omitempty,min=1"`
+	Locale    *string `json:"locale" validate:"omitempty,min=1"`
+	TagType   *string `json:"type" validate:"omitempty,min=1"`
+	Missing   bool    `json:"missing"`
+}
+
+type TranslationSuggestDTO struct {
+	Hierarchy *string `json:"hierarchy" validate:"required,min=1"`
+	CountryId *string `json:"country_id" validate:"required,min=1"`
+	Locale    *string `json:"locale" validate:"required,min=1"`
+	TagType   *string `json:"type" validate:"omitempty,min=1"`
+}
+
+type TranslationDraftDTO struct {
+	Hierarchy *string `json:"hierarchy" validate:"required,min=1"`
+	CountryId *string `json:"country_id" validate:"required,min=1"`
+	Locale    *string `json:"locale" validate:"required,min=1"`
+}
+
+type TranslationApprovalDTO struct {
+	ID   *string `json:"id" validate:"required,min=1"`
+	Name *string `json:"name" validate:"omitempty,min=1"`
+}
+
+type TranslationReviewDTO struct {
+	CountryId *string                   `json:"country_id" validate:"required,min=1"`
+	Locale    *string                   `json:"locale" validate:"required,min=1"`
+	Approve   []*TranslationApprovalDTO `json:"approve" validate:"dive,required"`
+	Reject    []*string                 `json:"reject" validate:"dive,required,min=1"`
+}
+
+type AttributeLocaleDTO struct {
+	Attribute *string `json:"attribute" validate:"required,min=1"`
+	CountryId *string `json:"country_id" validate:"required,min=1"`
+	Locale    *string `json:"locale" validate:"required,min=1"`
+	Value     *string `json:"value"`
+}
+
+type UpdateTagAttributeLocalesDTO struct {
+	Attributes []*AttributeLocaleDTO `json:"attributes" validate:"required,min=1,dive,required"`
+}
+
+type UpdateRegionCountriesDTO struct {
+	Countries []*string `json:"countries" validate:"required,dive,required,numeric"`
+}
+
+type UpdateTagAvailabilityDTO struct {
+	Include []*string `json:"include" validate:"dive,required,numeric"`
+	Exclude []*string `json:"exclude" validate:"dive,required,numeric"`
+}
+
+type AcademicTermDTO struct {
+	Name      *string `json:"name" validate:"required,min=1"`
+	StartDate *string `json:"start_date" validate:"required"`
+	EndDate   *string `json:"end_date" validate:"required"`
+}
+
+type AcademicHolidayDTO struct {
+	Name      *string `json:"name" validate:"required,min=1"`
+	StartDate *string `json:"start_date" validate:"required"`
+	EndDate   *string `json:"end_date" validate:"required"`
+}
+
+type AcademicYearDTO struct {
+	Name      *string               `json:"name" validate:"required,min=1"`
+	StartDate *string               `json:"start_date" validate:"required"`
+	EndDate   *string               `json:"end_date" validate:"required"`
+	Terms     []*AcademicTermDTO    `json:"terms" validate:"required,min=1,dive,required"`
+	Holidays  []*AcademicHolidayDTO `json:"holidays" validate:"dive,required"`
+}
+
+type UpdateAcademicCalendarDTO struct {
+	PromotionDate *string            `json:"promotion_date" validate:"required"`
+	Years         []*AcademicYearDTO `json:"years" validate:"required,min=1,dive,required"`
+}
+
+type CreateWebhookSubscriptionDTO struct {
+	Url           *string  `json:"url" validate:"required,url"`
+	Secret        *string  `json:"secret" validate:"required,min=16"`
+	SubtreePrefix *string  `json:"subtree_prefix"`
+	TagTypes      []string `json:"tag_types" validate:"dive,required"`
+	EventTypes    []string `json:"event_types" validate:"dive,required"`
+	Active        *bool    `json:"active"`
+}
+
+type UpdateWebhookSubscriptionDTO struct {
+	Url           *string  `json:"url" validate:"omitempty,url"`
+	Secret        *string  `json:"secret" validate:"omitempty,min=16"`
+	SubtreePrefix *string  `json:"subtree_prefix"`
+	TagTypes      []string `json:"tag_types" validate:"dive,required"`
+	EventTypes    []string `json:"event_types" validate:"dive,required"`
+	Active        *bool    `json:"active"`
+}
@@ -106,3 +106,16 @@ type GetTestAndSkillsDTO struct {
 	Test      *bool   `json:"test"`
 	Skill     *bool   `json:"skill"`
 }
+
+type GetCurrentTermDTO struct {
+	CountryId *string `json:"country_id" validate:"required,numeric"`
+	Date      *string `json:"date"`
+}
+
+type GetExpectedGradeDTO struct {
+	CountryId  *string `json:"country_id" validate:"required,numeric"`
+	BoardId    *string `json:"board_id" validate:"required,numeric"`
+	GradeId    *string `json:"grade_id" validate:"required,numeric"`
+	EnrolledAt *string `json:"enrolled_at" validate:"required"`
+	Date       *string `json:"date"`
+}
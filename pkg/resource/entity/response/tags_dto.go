@@ -1,13 +1,17 @@
 package response
 
-import "bitbucket.org/noon-micro/curriculum/pkg/domain"
+import (
+	"bitbucket.org/noon-micro/curriculum/pkg/domain"
+	"time"
+)
 
 type AdminTagResponseSearchDTO struct {
-	ID         *string                `json:"id"`
-	Type       *string                `json:"type"`
-	Name       *string                `json:"name"`
-	LocaleName *string                `json:"locale_name"`
-	Attributes map[string]interface{} `json:"attributes,omitempty"`
+	ID           *string                `json:"id"`
+	Type         *string                `json:"type"`
+	Name         *string                `json:"name"`
+	LocaleName   *string                `json:"locale_name"`
+	ServedLocale *string                `json:"served_locale,omitempty"`
+	Attributes   map[string]interface{} `json:"attributes,omitempty"`
 }
 
 type TagLocaleInfoResponseDTO struct {
@@ -16,8 +20,11 @@ type TagLocaleInfoResponseDTO struct {
 	CurriculumType *string                  `json:"curriculum_type,omitempty"`
 	Grade          *int                     `json:"grade,omitempty"`
 	Name           *string                  `json:"name"`
+	ServedLocale   *string                  `json:"served_locale,omitempty"`
+	Available      *bool                    `json:"available,omitempty"`
 	Attributes     map[string]interface{}   `json:"attributes"`
 	Locale         []*domain.LocaleResponse `json:"locales"`
+	Version        *int                     `json:"version,omitempty"`
 }
 
 type GetTeacherTagsResponseDTO struct {
@@ -40,20 +47,24 @@ type MetaResponseDTO struct {
 }
 
 type TeacherTagResponseDTO struct {
-	ID         *string                `json:"id"`
-	Type       *string                `json:"type"`
-	Name       *string                `json:"name"`
-	LocaleName *string                `json:"locale_name,omitempty"`
-	Attributes map[string]interface{} `json:"attributes"`
+	ID               *string                `json:"id"`
+	Type             *string                `json:"type"`
+	Name             *string                `json:"name"`
+	LocaleName       *string                `json:"locale_name,omitempty"`
+	ServedLocale     *string                `json:"served_locale,omitempty"`
+	Attributes       map[string]interface{} `json:"attributes"`
+	LocaleAttributes map[string]interface{} `json:"locale_attributes,omitempty"`
 }
 
 type AdminTagResponseDTO struct {
-	ID         *string                `json:"id"`
-	Type       *string                `json:"type"`
-	Name       *string                `json:"name"`
-	LocaleName *string                `json:"locale_name,omitempty"`
-	Hidden     bool                   `json:"hidden"`
-	Attributes map[string]interface{} `json:"attributes"`
+	ID               *string                `json:"id"`
+	Type             *string                `json:"type"`
+	Name             *string                `json:"name"`
+	LocaleName       *string                `json:"locale_name,omitempty"`
+	ServedLocale     *string                `json:"served_locale,omitempty"`
+	Hidden           bool                   `json:"hidden"`
+	Attributes       map[string]interface{} `json:"attributes"`
+	LocaleAttributes map[string]interface{} `json:"locale_attributes,omitempty"`
 }
 
 type RpcTagResponseDTO struct {
@@ -61,8 +72,151 @@ type RpcTagResponseDTO struct {
 	Type          *string `json:"type"`
 	Name          *string `json:"name"`
 	LocaleName    *string `json:"locale_name,omitempty"`
+	ServedLocale  *string `json:"served_locale,omitempty"`
 	BackgroundPic *string `json:"background_pic"`
 	Color         *string `json:"color"`
 	Pic           *string `json:"pic"`
 	NegativePic   *string `json:"negative_pic"`
 }
+
+type CacheEntryResponseDTO struct {
+	Key    string      `json:"key"`
+	Ttl    int64       `json:"ttl"`
+	Cached interface{} `json:"cached"`
+	Source interface{} `json:"source,omitempty"`
+	Stale  bool        `json:"stale"`
+}
+
+type EvictCacheResponseDTO struct {
+	Keys []string `json:"keys"`
+}
+
+type TranslationImportResponseDTO struct {
+	Created      []*domain.TranslationChange `json:"created"`
+	Updated      []*domain.TranslationChange `json:"updated"`
+	Unchanged    int                         `json:"unchanged"`
+	Skipped      int                         `json:"skipped"`
+	Invalid      []*domain.TranslationError  `json:"invalid"`
+	Applied      bool                        `json:"applied"`
+	SearchFailed []*string                   `json:"search_failed,omitempty"`
+}
+
+type TranslationSuggestResponseDTO struct {
+	Created     []*domain.TranslationDraft `json:"created"`
+	Pending     int                        `json:"pending"`
+	Unsuggested int                        `json:"unsuggested"`
+}
+
+type TranslationReviewResponseDTO struct {
+	Approved     []*domain.TranslationChange `json:"approved"`
+	Rejected     int                         `json:"rejected"`
+	Invalid      []*domain.TranslationError  `json:"invalid"`
+	Applied      bool                        `json:"applied"`
+	SearchFailed []*string                   `json:"search_failed,omitempty"`
+}
+
+type TranslationCoverageResponseDTO struct {
+	Hierarchy *string                             `json:"hierarchy"`
+	Total     int                                 `json:"total"`
+	Locales   []*domain.TranslationCoverageLocale `json:"locales"`
+}
+
+type RegionResponseDTO struct {
+	ID        *string   `json:"id"`
+	Name      *string   `json:"name"`
+	Countries []*string `json:"countries"`
+}
+
+type TagAvailabilityResponseDTO struct {
+	Include []*string `json:"include"`
+	Exclude []*string `json:"exclude"`
+}
+
+type TagAttributeLocaleResponseDTO struct {
+	ID        *string `json:"id"`
+	Attribute *string `json:"attribute"`
+	CountryId *string `json:"country_id"`
+	Locale    *string `json:"locale"`
+	Value     *string `json:"value"`
+}
+
+type AcademicTermResponseDTO struct {
+	Name      *string `json:"name"`
+	StartDate *string `json:"start_date"`
+	EndDate   *string `json:"end_date"`
+}
+
+type AcademicHolidayResponseDTO struct {
+	Name      *string `json:"name"`
+	StartDate *string `json:"start_date"`
+	EndDate   *string `json:"end_date"`
+}
+
+type AcademicYearResponseDTO struct {
+	Name      *string                       `json:"name"`
+	StartDate *string                       `json:"start_date"`
+	EndDate   *string                       `json:"end_date"`
+	Terms     []*AcademicTermResponseDTO    `json:"terms"`
+	Holidays  []*AcademicHolidayResponseDTO `json:"holidays"`
+}
+
+type AcademicCalendarResponseDTO struct {
+	PromotionDate *string                    `json:"promotion_date"`
+	Years         []*AcademicYearResponseDTO `json:"years"`
+}
+
+// WebhookSubscriptionResponseDTO leaves the secret out
+type WebhookSubscriptionResponseDTO struct {
+	ID            *string   `json:"id"`
+	Url           *string   `json:"url"`
+	SubtreePrefix *string   `json:"subtree_prefix"`
+	TagTypes      []string  `json:"tag_types"`
+	EventTypes    []string  `json:"event_types"`
+	Active        bool      `json:"active"`
+	CreatedAt     time.Time `json:"created_at"`
+	UpdatedAt     time.Time `json:"updated_at"`
+}
+
+type WebhookDeliveryResponseDTO struct {
+	ID             *string   `json:"id"`
+	SubscriptionID *string   `json:"subscription_id"`
+	EventID        *string   `json:"event_id"`
+	EventType      *string   `json:"event_type"`
+	TagId          *string   `json:"tag_id"`
+	Payload        *string   `json:"payload"`
+	RedeliveryOf   *string   `json:"redelivery_of"`
+	Status         *string   `json:"status"`
+	Attempts       int       `json:"attempts"`
+	ResponseStatus *int      `json:"response_status"`
+	Error          *string   `json:"error"`
+	NextAttemptAt  time.Time `json:"next_attempt_at"`
+	CreatedAt      time.Time `json:"created_at"`
+	UpdatedAt      time.Time `json:"updated_at"`
+}
+
+type TagChangeResponseDTO struct {
+	Sequence   int64     `json:"sequence"`
+	EventID    *string   `json:"event_id"`
+	EventType  *string   `json:"event_type"`
+	Entity     *string   `json:"entity"`
+	TagId      *string   `json:"tag_id"`
+	TagType    *string   `json:"tag_type"`
+	ParentTags []string  `json:"parent_tags"`
+	ChangedAt  time.Time `json:"changed_at"`
+}
+
+// TagChangesMetaDTO is sent as the meta of a changes page, Cursor resumes after the page
+type TagChangesMetaDTO struct {
+	Cursor  string `json:"cursor"`
+	HasMore bool   `json:"has_more"`
+}
+
+type TagOrdersResponseDTO struct {
+	Version int                 `json:"version"`
+	Orders  []*OrderResponseDTO `json:"orders"`
+}
+
+type OrderResponseDTO struct {
+	ID    *string `json:"id"`
+	Order *int    `json:"order"`
+}
@@ -0,0 +1,68 @@
+package resource
+
+import (
+	"bitbucket.org/noon-micro/curriculum/pkg/domain"
+	"bitbucket.org/noon-micro/curriculum/pkg/entity"
+	"bitbucket.org/noon-micro/curriculum/pkg/lib/error"
+	"bitbucket.org/noon-micro/curriculum/pkg/lib/middleware"
+	entityresponse "bitbucket.org/noon-micro/curriculum/pkg/resource/entity/response"
+	"github.com/gorilla/mux"
+	"github.com/jinzhu/copier"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+type RpcChangesResource struct {
+	tcs domain.TagChangeService
+}
+
+func NewRpcChangesResource(route *mux.Router, tcs domain.TagChangeService) {
+	resource := &RpcChangesResource{
+		tcs: tcs,
+	}
+	route.HandleFunc("/rpc/changes", middleware.UnAuthWrapMiddleware(resource.getChanges)).Methods("GET")
+}
+
+// getChanges serves the changes feed, wait is the number of seconds an empty page may be held open for
+func (t *RpcChangesResource) getChanges(rw http.ResponseWriter, req *http.Request) {
+	params, err := getQueryParams(req)
+	if err != nil {
+		entity.HandleError(rw, "", err, req.Header.Get("locale"), true)
+		return
+	}
+	getChanges := new(domain.GetTagChanges)
+	if cursor, _ := params["cursor"]; len(cursor) > 0 {
+		getChanges.Cursor = &cursor
+	}
+	if limit, _ := params["limit"]; len(limit) > 0 {
+		if getChanges.Limit, err = strconv.Atoi(limit); err != nil {
+			entity.HandleError(rw, "", noonerror.New(noonerror.ErrInvalidRequest, "limitInvalid"), req.Header.Get("locale"), true)
+			return
+		}
+	}
+	if wait, _ := params["wait"]; len(wait) > 0 {
+		seconds, err := strconv.Atoi(wait)
+		if err != nil || seconds < 0 {
+			entity.HandleError(rw, "", noonerror.New(noonerror.ErrInvalidRequest, "waitInvalid"), req.Header.Get("locale"), true)
+			return
+		}
+		getChanges.Wait = time.Duration(seconds) * time.Second
+	}
+	res, err := t.tcs.GetTagChanges(req.Context(), getChanges)
+	if err != nil {
+		entity.HandleError(rw, "", err, req.Header.Get("locale"), true)
+		return
+	}
+	response := []*entityresponse.TagChangeResponseDTO{}
+	if err = copier.Copy(&response, res.Changes); err != nil {
+		entity.HandleError(rw, "", noonerror.New(noonerror.ErrInternalServer, "mapperError"), req.Header.Get("locale"), true)
+		return
+	}
+	meta := &entityresponse.TagChangesMetaDTO{Cursor: res.Cursor, HasMore: res.HasMore}
+	err = new(entity.Response).SendResponse(rw, response, meta, http.StatusOK)
+	if err != nil {
+		entity.HandleError(rw, "internalServerError", noonerror.ErrInternalServer, req.Header.Get("locale"), true)
+		return
+	}
+}
@@ -8,6 +8,8 @@ import (
 	"bitbucket.org/noon-micro/curriculum/pkg/lib/middleware"
 	"bitbucket.org/noon-micro/curriculum/pkg/resource/entity/request"
 	"bitbucket.org/noon-micro/curriculum/pkg/resource/entity/response"
+	"bitbucket.org/noon-micro/curriculum/pkg/service/constant"
+	"context"
 	"encoding/json"
 	"github.com/gorilla/mux"
 	"github.com/jinzhu/copier"
@@ -22,19 +24,21 @@ func NewRpcTagsResource(route *mux.Router, rts domain.RpcTagsService) {
 	resource := &RpcTagsResource{
 		rts: rts,
 	}
-	route.HandleFunc("/rpc/getTags", middleware.UnAuthWrapMiddleware(resource.getTagsList)).Methods("POST")
-	route.HandleFunc("/rpc/getTagsByHierarchy", middleware.UnAuthWrapMiddleware(resource.getTagsByHierarchy)).Methods("POST")
-	route.HandleFunc("/rpc/validateHierarchy", middleware.UnAuthWrapMiddleware(resource.validateHierarchy)).Methods("POST")
-	route.HandleFunc("/rpc/createTags", middleware.UnAuthWrapMiddleware(resource.createTags)).Methods("POST")
-	route.HandleFunc("/rpc/getDefaultTags", middleware.UnAuthWrapMiddleware(resource.getDefaultTags)).Methods("POST")
-	route.HandleFunc("/rpc/getSuggestedTags", middleware.UnAuthWrapMiddleware(resource.getSuggestedTags)).Methods("POST")
-	route.HandleFunc("/rpc/getLegacyDataFromTagId", middleware.UnAuthWrapMiddleware(resource.getLegacyDataFromTagId)).Methods("POST")
-	route.HandleFunc("/rpc/getLegacyDataFromTagIds", middleware.UnAuthWrapMiddleware(resource.getLegacyDataFromTagIds)).Methods("POST")
-	route.HandleFunc("/rpc/getTagDataFromLegacyId", middleware.UnAuthWrapMiddleware(resource.getTagDataFromLegacyId)).Methods("POST")
-	route.HandleFunc("/rpc/getGradeTags", middleware.UnAuthWrapMiddleware(resource.getGradeTags)).Methods("POST")
-
-	route.HandleFunc("/rpc/getK12Products", middleware.UnAuthWrapMiddleware(resource.getK12Products)).Methods("POST")
-	route.HandleFunc("/rpc/getUniversityProducts", middleware.UnAuthWrapMiddleware(resource.getUniversityProducts)).Methods("POST")
+	route.HandleFunc("/rpc/getTags", middleware.UnAuthWrapMiddleware(middleware.LocaleMiddleware(resource.getTagsList, constant.DefaultLocale))).Methods("POST")
+	route.HandleFunc("/rpc/getTagsByHierarchy", middleware.UnAuthWrapMiddleware(middleware.LocaleMiddleware(resource.getTagsByHierarchy, constant.DefaultLocale))).Methods("POST")
+	route.HandleFunc("/rpc/validateHierarchy", middleware.UnAuthWrapMiddleware(middleware.LocaleMiddleware(resource.validateHierarchy, constant.DefaultLocale))).Methods("POST")
+	route.HandleFunc("/rpc/createTags", middleware.UnAuthWrapMiddleware(middleware.LocaleMiddleware(resource.createTags, constant.DefaultLocale))).Methods("POST")
+	route.HandleFunc("/rpc/getDefaultTags", middleware.UnAuthWrapMiddleware(middleware.LocaleMiddleware(resource.getDefaultTags, constant.DefaultLocale))).Methods("POST")
+	route.HandleFunc("/rpc/getSuggestedTags", middleware.UnAuthWrapMiddleware(middleware.LocaleMiddleware(resource.getSuggestedTags, constant.DefaultLocale))).Methods("POST")
+	route.HandleFunc("/rpc/getLegacyDataFromTagId", middleware.UnAuthWrapMiddleware(middleware.LocaleMiddleware(resource.getLegacyDataFromTagId, constant.DefaultLocale))).Methods("POST")
+	route.HandleFunc("/rpc/getLegacyDataFromTagIds", middleware.UnAuthWrapMiddleware(middleware.LocaleMiddleware(resource.getLegacyDataFromTagIds, constant.DefaultLocale))).Methods("POST")
+	route.HandleFunc("/rpc/getTagDataFromLegacyId", middleware.UnAuthWrapMiddleware(middleware.LocaleMiddleware(resource.getTagDataFromLegacyId, constant.DefaultLocale))).Methods("POST")
+	route.HandleFunc("/rpc/getGradeTags", middleware.UnAuthWrapMiddleware(middleware.LocaleMiddleware(resource.getGradeTags, constant.DefaultLocale))).Methods("POST")
+	route.HandleFunc("/rpc/getCurrentTerm", middleware.UnAuthWrapMiddleware(middleware.LocaleMiddleware(resource.getCurrentTerm, constant.DefaultLocale))).Methods("POST")
+	route.HandleFunc("/rpc/getExpectedGrade", middleware.UnAuthWrapMiddleware(middleware.LocaleMiddleware(resource.getExpectedGrade, constant.DefaultLocale))).Methods("POST")
+
+	route.HandleFunc("/rpc/getK12Products", middleware.UnAuthWrapMiddleware(middleware.LocaleMiddleware(resource.getK12Products, constant.DefaultLocale))).Methods("POST")
+	route.HandleFunc("/rpc/getUniversityProducts", middleware.UnAuthWrapMiddleware(middleware.LocaleMiddleware(resource.getUniversityProducts, constant.DefaultLocale))).Methods("POST")
 }
 
 func (t *RpcTagsResource) createTags(rw http.ResponseWriter, req *http.Request) {
@@ -57,7 +61,7 @@ func (t *RpcTagsResource) createTags(rw http.ResponseWriter, req *http.Request)
 	}
 	createTags.CreatorType = new(string)
 	*createTags.CreatorType = "teacher"
-	res, err := t.rts.CreateTags(&createTags)
+	res, err := t.rts.CreateTags(req.Context(), &createTags)
 	if err != nil {
 		entity.HandleError(rw, "", err, req.Header.Get("locale"), false)
 		return
@@ -86,6 +90,7 @@ func (t *RpcTagsResource) getTagsList(rw http.ResponseWriter, req *http.Request)
 		entity.HandleError(rw, "badRequest", noonerror.ErrInvalidRequest, req.Header.Get("locale"), false)
 		return
 	}
+	tag.Locale = middleware.NegotiateLocale(rw, req, tag.CountryId, tag.Locale)
 	err = helper.Validate(tag)
 	if err != nil {
 		entity.HandleError(rw, "badRequest", noonerror.New(noonerror.ErrInvalidRequest, err.Error()), req.Header.Get("locale"), false)
@@ -95,7 +100,7 @@ func (t *RpcTagsResource) getTagsList(rw http.ResponseWriter, req *http.Request)
 	if err = copier.Copy(&getTags, &tag); err != nil {
 		entity.HandleError(rw, "", noonerror.New(noonerror.ErrInternalServer, "mapperError"), req.Header.Get("locale"), false)
 	}
-	res, err := t.rts.GetTagsByIds(&getTags, locale)
+	res, err := t.rts.GetTagsByIds(req.Context(), &getTags, locale)
 	if err != nil {
 		entity.HandleError(rw, "", err, req.Header.Get("locale"), false)
 		return
@@ -132,7 +137,7 @@ func (t *RpcTagsResource) getTagsByHierarchy(rw http.ResponseWriter, req *http.R
 	if err = copier.Copy(&getTags, &tag); err != nil {
 		entity.HandleError(rw, "", noonerror.New(noonerror.ErrInternalServer, "mapperError"), req.Header.Get("locale"), false)
 	}
-	res, err := t.rts.GetTags(&getTags)
+	res, err := t.rts.GetTags(req.Context(), &getTags)
 	if err != nil {
 		entity.HandleError(rw, "", err, req.Header.Get("locale"), false)
 		return
@@ -162,7 +167,7 @@ func (t *RpcTagsResource) validateHierarchy(rw http.ResponseWriter, req *http.Re
 	if err = copier.Copy(&validateHierarchy, &tag); err != nil {
 		entity.HandleError(rw, "", noonerror.New(noonerror.ErrInternalServer, "mapperError"), req.Header.Get("locale"), false)
 	}
-	err = t.rts.ValidateHierarchy(&validateHierarchy)
+	err = t.rts.ValidateHierarchy(req.Context(), &validateHierarchy)
 	if err != nil {
 		entity.HandleError(rw, "", err, req.Header.Get("locale"), false)
 		return
@@ -174,9 +179,9 @@ func (t *RpcTagsResource) validateHierarchy(rw http.ResponseWriter, req *http.Re
 	}
 }
 
-func (t *RpcTagsResource) getDefaultTags(rw http.ResponseWriter, _ *http.Request) {
+func (t *RpcTagsResource) getDefaultTags(rw http.ResponseWriter, req *http.Request) {
 
-	res, err := t.rts.GetDefaultTags()
+	res, err := t.rts.GetDefaultTags(req.Context())
 	if err != nil {
 		entity.HandleError(rw, "", err, "", false)
 		return
@@ -196,6 +201,7 @@ func (t *RpcTagsResource) getSuggestedTags(rw http.ResponseWriter, req *http.Req
 		entity.HandleError(rw, "badRequest", noonerror.ErrInvalidRequest, req.Header.Get("locale"), false)
 		return
 	}
+	tag.Locale = middleware.NegotiateLocale(rw, req, tag.CountryId, tag.Locale)
 	curriculumType := domain.CurriculumTypeEnum.Default
 	tag.CurriculumType = &curriculumType
 	err = helper.Validate(tag)
@@ -207,7 +213,7 @@ func (t *RpcTagsResource) getSuggestedTags(rw http.ResponseWriter, req *http.Req
 	if err = copier.Copy(&gst, &tag); err != nil {
 		entity.HandleError(rw, "", noonerror.New(noonerror.ErrInternalServer, "mapperError"), req.Header.Get("locale"), false)
 	}
-	res, err := t.rts.GetSuggestedCurriculum(&gst)
+	res, err := t.rts.GetSuggestedCurriculum(req.Context(), &gst)
 	if err != nil {
 		entity.HandleError(rw, "", err, req.Header.Get("locale"), false)
 		return
@@ -232,7 +238,7 @@ func (t *RpcTagsResource) getLegacyDataFromTagId(rw http.ResponseWriter, req *ht
 		entity.HandleError(rw, "badRequest", noonerror.New(noonerror.ErrInvalidRequest, err.Error()), req.Header.Get("locale"), false)
 		return
 	}
-	res, err := t.rts.GetLegacyDataFromTagId(tag.ID)
+	res, err := t.rts.GetLegacyDataFromTagId(req.Context(), tag.ID)
 	if err != nil {
 		entity.HandleError(rw, "", err, req.Header.Get("locale"), false)
 		return
@@ -257,7 +263,7 @@ func (t *RpcTagsResource) getLegacyDataFromTagIds(rw http.ResponseWriter, req *h
 		entity.HandleError(rw, "badRequest", noonerror.New(noonerror.ErrInvalidRequest, err.Error()), req.Header.Get("locale"), false)
 		return
 	}
-	resp, err := t.rts.GetLegacyDataFromTagIds(tag.IDs)
+	resp, err := t.rts.GetLegacyDataFromTagIds(req.Context(), tag.IDs)
 	if err != nil {
 		entity.HandleError(rw, "", err, req.Header.Get("locale"), false)
 		return
@@ -282,7 +288,7 @@ func (t *RpcTagsResource) getTagDataFromLegacyId(rw http.ResponseWriter, req *ht
 		entity.HandleError(rw, "badRequest", noonerror.New(noonerror.ErrInvalidRequest, err.Error()), req.Header.Get("locale"), false)
 		return
 	}
-	res, err := t.rts.GetTagDataFromLegacyId(tag.Type, tag.ID)
+	res, err := t.rts.GetTagDataFromLegacyId(req.Context(), tag.Type, tag.ID)
 	if err != nil {
 		entity.HandleError(rw, "", err, req.Header.Get("locale"), false)
 		return
@@ -311,7 +317,7 @@ func (t *RpcTagsResource) getGradeTags(rw http.ResponseWriter, req *http.Request
 	if tag.ProductId == nil {
 		tag.ProductId = &zero
 	}
-	res, err := t.rts.GetGradeTags(tag.Grade, tag.ProductId)
+	res, err := t.rts.GetGradeTags(req.Context(), tag.Grade, tag.ProductId)
 	if err != nil {
 		entity.HandleError(rw, "", err, req.Header.Get("locale"), false)
 		return
@@ -331,6 +337,7 @@ func (t *RpcTagsResource) getK12Products(rw http.ResponseWriter, req *http.Reque
 		entity.HandleError(rw, "badRequest", noonerror.ErrInvalidRequest, req.Header.Get("locale"), false)
 		return
 	}
+	tag.Locale = middleware.NegotiateLocale(rw, req, tag.CountryId, tag.Locale)
 	err = helper.Validate(tag)
 	if err != nil {
 		entity.HandleError(rw, "badRequest", noonerror.New(noonerror.ErrInvalidRequest, err.Error()), req.Header.Get("locale"), false)
@@ -341,7 +348,7 @@ func (t *RpcTagsResource) getK12Products(rw http.ResponseWriter, req *http.Reque
 		entity.HandleError(rw, "", noonerror.New(noonerror.ErrInternalServer, "mapperError"), req.Header.Get("locale"), false)
 	}
 	var responses []*response.RpcTagResponseDTO
-	res, err := t.getTagsByRpc(domain.TagTypeEnum.Subject, domain.TagGroupEnum.Curriculum, domain.CurriculumTypeEnum.K12, &genericProductsDTO)
+	res, err := t.getTagsByRpc(req.Context(), domain.TagTypeEnum.Subject, domain.TagGroupEnum.Curriculum, domain.CurriculumTypeEnum.K12, &genericProductsDTO)
 	if err != nil {
 		entity.HandleError(rw, "", err, req.Header.Get("locale"), false)
 		return
@@ -351,7 +358,7 @@ func (t *RpcTagsResource) getK12Products(rw http.ResponseWriter, req *http.Reque
 	}
 	if genericProductsDTO.Test != nil && *genericProductsDTO.Test == true {
 		testProductDTO := request.GenericProductsDTO{CountryId: genericProductsDTO.CountryId, Locale: genericProductsDTO.Locale}
-		resTest, err := t.getTagsByRpc(domain.TagTypeEnum.Test, domain.TagGroupEnum.Curriculum, domain.CurriculumTypeEnum.K12TestPrep, &testProductDTO)
+		resTest, err := t.getTagsByRpc(req.Context(), domain.TagTypeEnum.Test, domain.TagGroupEnum.Curriculum, domain.CurriculumTypeEnum.K12TestPrep, &testProductDTO)
 		if err != nil {
 			entity.HandleError(rw, "", err, req.Header.Get("locale"), false)
 			return
@@ -362,7 +369,7 @@ func (t *RpcTagsResource) getK12Products(rw http.ResponseWriter, req *http.Reque
 	}
 	if genericProductsDTO.Skill != nil && *genericProductsDTO.Skill == true {
 		testProductDTO := request.GenericProductsDTO{CountryId: genericProductsDTO.CountryId, Locale: genericProductsDTO.Locale}
-		resSkill, err := t.getTagsByRpc(domain.TagTypeEnum.Skill, domain.TagGroupEnum.Curriculum, domain.CurriculumTypeEnum.K12Skill, &testProductDTO)
+		resSkill, err := t.getTagsByRpc(req.Context(), domain.TagTypeEnum.Skill, domain.TagGroupEnum.Curriculum, domain.CurriculumTypeEnum.K12Skill, &testProductDTO)
 		if err != nil {
 			entity.HandleError(rw, "", err, req.Header.Get("locale"), false)
 			return
@@ -386,6 +393,7 @@ func (t *RpcTagsResource) getUniversityProducts(rw http.ResponseWriter, req *htt
 		entity.HandleError(rw, "badRequest", noonerror.ErrInvalidRequest, req.Header.Get("locale"), false)
 		return
 	}
+	tag.Locale = middleware.NegotiateLocale(rw, req, tag.CountryId, tag.Locale)
 	err = helper.Validate(tag)
 	if err != nil {
 		entity.HandleError(rw, "badRequest", noonerror.New(noonerror.ErrInvalidRequest, err.Error()), req.Header.Get("locale"), false)
@@ -396,7 +404,7 @@ func (t *RpcTagsResource) getUniversityProducts(rw http.ResponseWriter, req *htt
 		entity.HandleError(rw, "", noonerror.New(noonerror.ErrInternalServer, "mapperError"), req.Header.Get("locale"), false)
 	}
 	var responses []*response.RpcTagResponseDTO
-	res, err := t.getTagsByRpc(domain.TagTypeEnum.Course, domain.TagGroupEnum.Curriculum, domain.CurriculumTypeEnum.University, &genericProductsDTO)
+	res, err := t.getTagsByRpc(req.Context(), domain.TagTypeEnum.Course, domain.TagGroupEnum.Curriculum, domain.CurriculumTypeEnum.University, &genericProductsDTO)
 	if err != nil {
 		entity.HandleError(rw, "", err, req.Header.Get("locale"), false)
 		return
@@ -406,7 +414,7 @@ func (t *RpcTagsResource) getUniversityProducts(rw http.ResponseWriter, req *htt
 	}
 	if genericProductsDTO.Test != nil && *genericProductsDTO.Test == true {
 		testProductDTO := request.GenericProductsDTO{CountryId: genericProductsDTO.CountryId, Locale: genericProductsDTO.Locale}
-		resTest, err := t.getTagsByRpc(domain.TagTypeEnum.Test, domain.TagGroupEnum.Curriculum, domain.CurriculumTypeEnum.UniversityTestPrep, &testProductDTO)
+		resTest, err := t.getTagsByRpc(req.Context(), domain.TagTypeEnum.Test, domain.TagGroupEnum.Curriculum, domain.CurriculumTypeEnum.UniversityTestPrep, &testProductDTO)
 		if err != nil {
 			entity.HandleError(rw, "", err, req.Header.Get("locale"), false)
 			return
@@ -417,7 +425,7 @@ func (t *RpcTagsResource) getUniversityProducts(rw http.ResponseWriter, req *htt
 	}
 	if genericProductsDTO.Skill != nil && *genericProductsDTO.Skill == true {
 		testProductDTO := request.GenericProductsDTO{CountryId: genericProductsDTO.CountryId, Locale: genericProductsDTO.Locale}
-		resSkill, err := t.getTagsByRpc(domain.TagTypeEnum.Skill, domain.TagGroupEnum.Curriculum, domain.CurriculumTypeEnum.UniversitySkill, &testProductDTO)
+		resSkill, err := t.getTagsByRpc(req.Context(), domain.TagTypeEnum.Skill, domain.TagGroupEnum.Curriculum, domain.CurriculumTypeEnum.UniversitySkill, &testProductDTO)
 		if err != nil {
 			entity.HandleError(rw, "", err, req.Header.Get("locale"), false)
 			return
@@ -433,7 +441,7 @@ func (t *RpcTagsResource) getUniversityProducts(rw http.ResponseWriter, req *htt
 	}
 }
 
-func (t *RpcTagsResource) getTagsByRpc(tagType string, tagGroup string, curriculumType string, genericDTO *request.GenericProductsDTO) (*response.GetRpcTagsResponseDTO, error) {
+func (t *RpcTagsResource) getTagsByRpc(ctx context.Context, tagType string, tagGroup string, curriculumType string, genericDTO *request.GenericProductsDTO) (*response.GetRpcTagsResponseDTO, error) {
 
 	startInt := 0
 	limitInt := 100
@@ -466,7 +474,7 @@ func (t *RpcTagsResource) getTagsByRpc(tagType string, tagGroup string, curricul
 	if err = copier.Copy(&getRpcTags, &tag); err != nil {
 		return nil, noonerror.New(noonerror.ErrInternalServer, "mapperError")
 	}
-	res, err := t.rts.GetRpcTags(&getRpcTags)
+	res, err := t.rts.GetRpcTags(ctx, &getRpcTags)
 	if err != nil {
 		return nil, err
 	}
@@ -485,3 +493,63 @@ func (t *RpcTagsResource) getTagsByRpc(tagType string, tagGroup string, curricul
 	}
 	return &getRpcTagsResponse, nil
 }
+
+func (t *RpcTagsResource) getCurrentTerm(rw http.ResponseWriter, req *http.Request) {
+	var term request.GetCurrentTermDTO
+
+	err := json.NewDecoder(req.Body).Decode(&term)
+	if err != nil {
+		entity.HandleError(rw, "badRequest", noonerror.ErrInvalidRequest, req.Header.Get("locale"), false)
+		return
+	}
+	err = helper.Validate(term)
+	if err != nil {
+		entity.HandleError(rw, "badRequest", noonerror.New(noonerror.ErrInvalidRequest, err.Error()), req.Header.Get("locale"), false)
+		return
+	}
+	var getCurrentTerm domain.GetCurrentTerm
+	if err = copier.Copy(&getCurrentTerm, &term); err != nil {
+		entity.HandleError(rw, "", noonerror.New(noonerror.ErrInternalServer, "mapperError"), req.Header.Get("locale"), false)
+		return
+	}
+	res, err := t.rts.GetCurrentTerm(req.Context(), &getCurrentTerm)
+	if err != nil {
+		entity.HandleError(rw, "", err, req.Header.Get("locale"), false)
+		return
+	}
+	err = new(entity.Response).SendResponse(rw, res, nil, http.StatusOK)
+	if err != nil {
+		entity.HandleError(rw, "internalServerError", noonerror.ErrInternalServer, req.Header.Get("locale"), false)
+		return
+	}
+}
+
+func (t *RpcTagsResource) getExpectedGrade(rw http.ResponseWriter, req *http.Request) {
+	var grade request.GetExpectedGradeDTO
+
+	err := json.NewDecoder(req.Body).Decode(&grade)
+	if err != nil {
+		entity.HandleError(rw, "badRequest", noonerror.ErrInvalidRequest, req.Header.Get("locale"), false)
+		return
+	}
+	err = helper.Validate(grade)
+	if err != nil {
+		entity.HandleError(rw, "badRequest", noonerror.New(noonerror.ErrInvalidRequest, err.Error()), req.Header.Get("locale"), false)
+		return
+	}
+	var getExpectedGrade domain.GetExpectedGrade
+	if err = copier.Copy(&getExpectedGrade, &grade); err != nil {
+		entity.HandleError(rw, "", noonerror.New(noonerror.ErrInternalServer, "mapperError"), req.Header.Get("locale"), false)
+		return
+	}
+	res, err := t.rts.GetExpectedGrade(req.Context(), &getExpectedGrade)
+	if err != nil {
+		entity.HandleError(rw, "", err, req.Header.Get("locale"), false)
+		return
+	}
+	err = new(entity.Response).SendResponse(rw, res, nil, http.StatusOK)
+	if err != nil {
+		entity.HandleError(rw, "internalServerError", noonerror.ErrInternalServer, req.Header.Get("locale"), false)
+		return
+	}
+}
@@ -7,12 +7,9 @@ import (
 	"bitbucket.org/noon-micro/curriculum/pkg/lib/helper"
 	"bitbucket.org/noon-micro/curriculum/pkg/lib/middleware"
 	"bitbucket.org/noon-micro/curriculum/pkg/resource/entity/request"
-	"fmt"
+	"bitbucket.org/noon-micro/curriculum/pkg/service/constant"
 	"github.com/gorilla/mux"
-	"github.com/oschwald/maxminddb-golang"
 	mapper "gopkg.in/jeevatkm/go-model.v1"
-	"log"
-	"net"
 	"net/http"
 	"strconv"
 	"strings"
@@ -26,12 +23,12 @@ func NewStudentTagsResource(route *mux.Router, sts domain.StudentTagsService) {
 	resource := &StudentTagsResource{
 		sts: sts,
 	}
-	route.HandleFunc("/student/countries", middleware.UnAuthWrapMiddleware(resource.getCountriesNew)).Methods("GET")
+	route.HandleFunc("/student/countries", middleware.UnAuthWrapMiddleware(middleware.LocaleMiddleware(middleware.ConditionalGetByClientMiddleware(resource.getCountriesNew), constant.StudentDefaultLocale))).Methods("GET")
 	//route.HandleFunc("/student/countries_new", middleware.RecoverHandler(auth.Authenticate("admin", resource.getCountriesNew))).Methods("GET")
-	route.HandleFunc("/student/grades", middleware.UnAuthWrapMiddleware(resource.getGrades)).Methods("GET")
-	route.HandleFunc("/student/boards", middleware.UnAuthWrapMiddleware(resource.getBoards)).Methods("GET")
-	route.HandleFunc("/student/degrees", middleware.UnAuthWrapMiddleware(resource.getDegrees)).Methods("GET")
-	route.HandleFunc("/student/majors", middleware.UnAuthWrapMiddleware(resource.getMajors)).Methods("GET")
+	route.HandleFunc("/student/grades", middleware.UnAuthWrapMiddleware(middleware.LocaleMiddleware(middleware.ConditionalGetMiddleware(resource.getGrades), constant.StudentDefaultLocale))).Methods("GET")
+	route.HandleFunc("/student/boards", middleware.UnAuthWrapMiddleware(middleware.LocaleMiddleware(middleware.ConditionalGetMiddleware(resource.getBoards), constant.StudentDefaultLocale))).Methods("GET")
+	route.HandleFunc("/student/degrees", middleware.UnAuthWrapMiddleware(middleware.LocaleMiddleware(middleware.ConditionalGetMiddleware(resource.getDegrees), constant.StudentDefaultLocale))).Methods("GET")
+	route.HandleFunc("/student/majors", middleware.UnAuthWrapMiddleware(middleware.LocaleMiddleware(middleware.ConditionalGetMiddleware(resource.getMajors), constant.StudentDefaultLocale))).Methods("GET")
 }
 
 func (t *StudentTagsResource) getCountries(rw http.ResponseWriter, req *http.Request) {
@@ -70,7 +67,7 @@ func (t *StudentTagsResource) getCountries(rw http.ResponseWriter, req *http.Req
 	}
 	var getTags domain.GetCountries
 	mapper.Copy(&getTags, tag)
-	res, err := t.sts.GetCountries(&getTags)
+	res, err := t.sts.GetCountries(req.Context(), &getTags)
 	if err != nil {
 		entity.HandleError(rw, "", err, req.Header.Get("locale"), true)
 		return
@@ -92,30 +89,7 @@ func (t *StudentTagsResource) getCountriesNew(rw http.ResponseWriter, req *http.
 	countryId := req.Header.Get("country")
 	locale := req.Header.Get("locale")
 
-	ipAddress := req.Header.Get("X-FORWARDED-FOR")
-	db, err := maxminddb.Open("GeoIP2-Country.mmdb")
-	if err != nil {
-		log.Fatal(err)
-	}
-	defer db.Close()
-
-	ip := net.ParseIP(ipAddress)
-
-	var record struct {
-		Country struct {
-			ISOCode string `maxminddb:"iso_code"`
-		} `maxminddb:"country"`
-	}
-
-	err = db.Lookup(ip, &record)
-	if err != nil {
-		log.Fatal(err)
-	}
-	var isoCode = "SA"
-	if record.Country.ISOCode != "" {
-		isoCode = record.Country.ISOCode
-	}
-	fmt.Print(record.Country.ISOCode)
+	ipAddress := helper.ClientIp(req)
 	start, _ := params["start"]
 	startInt := 0
 	if len(start) > 0 {
@@ -126,18 +100,19 @@ func (t *StudentTagsResource) getCountriesNew(rw http.ResponseWriter, req *http.
 	if len(limit) > 0 {
 		limitInt, _ = strconv.Atoi(limit)
 	}
+	groupBy, _ := params["group_by"]
 	queryParams := request.GetCountriesNewDTO{
 		IpAddress: ipAddress,
-		ISOCode: isoCode,
 		CountryId: &countryId,
 		Locale:    &locale,
+		GroupBy:   &groupBy,
 		Start:     startInt,
 		Limit:     limitInt,
 	}
 	err = helper.Validate(queryParams)
 	var getQueryParams domain.GetCountriesNew
 	mapper.Copy(&getQueryParams, queryParams)
-	res, err := t.sts.GetCountriesNew(&getQueryParams)
+	res, err := t.sts.GetCountriesNew(req.Context(), &getQueryParams)
 	if err != nil {
 		entity.HandleError(rw, "", err, req.Header.Get("locale"), true)
 		return
@@ -162,9 +137,6 @@ func (t *StudentTagsResource) getBoards(rw http.ResponseWriter, req *http.Reques
 	tagGroup := "curriculum"
 
 	locale := req.Header.Get("locale")
-	if locale == "" {
-		locale = "ar"
-	}
 
 	tag := request.GetTagsDTO{
 		Type:           &tagType,
@@ -183,7 +155,7 @@ func (t *StudentTagsResource) getBoards(rw http.ResponseWriter, req *http.Reques
 	var getTags domain.GetTags
 	mapper.Copy(&getTags, tag)
 
-	res, err := t.sts.GetBoards(&getTags)
+	res, err := t.sts.GetBoards(req.Context(), &getTags)
 	if err != nil {
 		entity.HandleError(rw, "", err, req.Header.Get("locale"), true)
 		return
@@ -209,9 +181,6 @@ func (t *StudentTagsResource) getGrades(rw http.ResponseWriter, req *http.Reques
 	tagGroup := "curriculum"
 
 	locale := req.Header.Get("locale")
-	if locale == "" {
-		locale = "ar"
-	}
 	hierarchy := countryIdString
 	if len(boardId)>0{
 		hierarchy = countryIdString+"."+boardId
@@ -233,7 +202,7 @@ func (t *StudentTagsResource) getGrades(rw http.ResponseWriter, req *http.Reques
 	var getTags domain.GetTags
 	mapper.Copy(&getTags, tag)
 
-	res, err := t.sts.GetGrades(&getTags)
+	res, err := t.sts.GetGrades(req.Context(), &getTags)
 	if err != nil {
 		entity.HandleError(rw, "", err, req.Header.Get("locale"), true)
 		return
@@ -270,9 +239,6 @@ func (t *StudentTagsResource) getDegrees(rw http.ResponseWriter, req *http.Reque
 	}
 
 	locale := req.Header.Get("locale")
-	if locale == "" {
-		locale = "ar"
-	}
 
 	tag := request.GetTagsSearchDTO{
 		Text:           &text,
@@ -298,7 +264,7 @@ func (t *StudentTagsResource) getDegrees(rw http.ResponseWriter, req *http.Reque
 		getTags.Text = nil
 	}
 
-	res, err := t.sts.GetDegrees(&getTags)
+	res, err := t.sts.GetDegrees(req.Context(), &getTags)
 	if err != nil {
 		entity.HandleError(rw, "", err, req.Header.Get("locale"), true)
 		return
@@ -341,9 +307,6 @@ func (t *StudentTagsResource) getMajors(rw http.ResponseWriter, req *http.Reques
 	}
 
 	locale := req.Header.Get("locale")
-	if locale == "" {
-		locale = "ar"
-	}
 
 	tag := request.GetTagsSearchDTO{
 		Text:           &text,
@@ -369,7 +332,7 @@ func (t *StudentTagsResource) getMajors(rw http.ResponseWriter, req *http.Reques
 		getTags.Text = nil
 	}
 
-	res, err := t.sts.GetMajors(&getTags)
+	res, err := t.sts.GetMajors(req.Context(), &getTags)
 	if err != nil {
 		entity.HandleError(rw, "", err, req.Header.Get("locale"), true)
 		return
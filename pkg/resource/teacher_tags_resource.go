@@ -8,11 +8,9 @@ import (
 	"bitbucket.org/noon-micro/curriculum/pkg/lib/middleware"
 	"bitbucket.org/noon-micro/curriculum/pkg/resource/entity/request"
 	"bitbucket.org/noon-micro/curriculum/pkg/resource/entity/response"
+	"bitbucket.org/noon-micro/curriculum/pkg/service/constant"
 	"github.com/gorilla/mux"
 	"github.com/jinzhu/copier"
-	"github.com/oschwald/maxminddb-golang"
-	"log"
-	"net"
 	"net/http"
 	"strconv"
 )
@@ -25,19 +23,19 @@ func NewTeacherTagsResource(route *mux.Router, tts domain.TeacherTagsService) {
 	resource := &TeacherTagsResource{
 		tts: tts,
 	}
-	route.HandleFunc("/teacher/grades", middleware.AuthWrapMiddleware(resource.getGradeTags, "teacher")).Methods("GET")
-	route.HandleFunc("/teacher/boards", middleware.AuthWrapMiddleware(resource.getBoardTags, "teacher")).Methods("GET")
-	route.HandleFunc("/teacher/degrees", middleware.AuthWrapMiddleware(resource.getDegreeTags, "teacher")).Methods("GET")
-	route.HandleFunc("/teacher/majors", middleware.AuthWrapMiddleware(resource.getMajorTags, "teacher")).Methods("GET")
-	route.HandleFunc("/teacher/courses", middleware.AuthWrapMiddleware(resource.getCourseTags, "teacher")).Methods("GET")
-	route.HandleFunc("/teacher/sections", middleware.AuthWrapMiddleware(resource.getSectionTags, "teacher")).Methods("GET")
-	route.HandleFunc("/teacher/subjects", middleware.AuthWrapMiddleware(resource.getSubjectTags, "teacher")).Methods("GET")
-	route.HandleFunc("/teacher/curriculum", middleware.AuthWrapMiddleware(resource.getCurriculumTags, "teacher")).Methods("GET")
-	route.HandleFunc("/teacher/tests", middleware.AuthWrapMiddleware(resource.getTestTags, "teacher")).Methods("GET")
-	route.HandleFunc("/teacher/skills", middleware.AuthWrapMiddleware(resource.getSkillTags, "teacher")).Methods("GET")
-	route.HandleFunc("/teacher/chapters", middleware.AuthWrapMiddleware(resource.getChapterTags, "teacher")).Methods("GET")
-	route.HandleFunc("/teacher/topics", middleware.AuthWrapMiddleware(resource.getTopicTags, "teacher")).Methods("GET")
-	route.HandleFunc("/teacher/countries", middleware.UnAuthWrapMiddleware(resource.getCountriesNew)).Methods("GET")
+	route.HandleFunc("/teacher/grades", middleware.AuthWrapMiddleware(middleware.LocaleMiddleware(middleware.ConditionalGetMiddleware(resource.getGradeTags), constant.DefaultLocale), "teacher")).Methods("GET")
+	route.HandleFunc("/teacher/boards", middleware.AuthWrapMiddleware(middleware.LocaleMiddleware(middleware.ConditionalGetMiddleware(resource.getBoardTags), constant.DefaultLocale), "teacher")).Methods("GET")
+	route.HandleFunc("/teacher/degrees", middleware.AuthWrapMiddleware(middleware.LocaleMiddleware(middleware.ConditionalGetMiddleware(resource.getDegreeTags), constant.DefaultLocale), "teacher")).Methods("GET")
+	route.HandleFunc("/teacher/majors", middleware.AuthWrapMiddleware(middleware.LocaleMiddleware(middleware.ConditionalGetMiddleware(resource.getMajorTags), constant.DefaultLocale), "teacher")).Methods("GET")
+	route.HandleFunc("/teacher/courses", middleware.AuthWrapMiddleware(middleware.LocaleMiddleware(middleware.ConditionalGetMiddleware(resource.getCourseTags), constant.DefaultLocale), "teacher")).Methods("GET")
+	route.HandleFunc("/teacher/sections", middleware.AuthWrapMiddleware(middleware.LocaleMiddleware(middleware.ConditionalGetMiddleware(resource.getSectionTags), constant.DefaultLocale), "teacher")).Methods("GET")
+	route.HandleFunc("/teacher/subjects", middleware.AuthWrapMiddleware(middleware.LocaleMiddleware(middleware.ConditionalGetMiddleware(resource.getSubjectTags), constant.DefaultLocale), "teacher")).Methods("GET")
+	route.HandleFunc("/teacher/curriculum", middleware.AuthWrapMiddleware(middleware.LocaleMiddleware(middleware.ConditionalGetMiddleware(resource.getCurriculumTags), constant.DefaultLocale), "teacher")).Methods("GET")
+	route.HandleFunc("/teacher/tests", middleware.AuthWrapMiddleware(middleware.LocaleMiddleware(middleware.ConditionalGetMiddleware(resource.getTestTags), constant.DefaultLocale), "teacher")).Methods("GET")
+	route.HandleFunc("/teacher/skills", middleware.AuthWrapMiddleware(middleware.LocaleMiddleware(middleware.ConditionalGetMiddleware(resource.getSkillTags), constant.DefaultLocale), "teacher")).Methods("GET")
+	route.HandleFunc("/teacher/chapters", middleware.AuthWrapMiddleware(middleware.LocaleMiddleware(middleware.ConditionalGetMiddleware(resource.getChapterTags), constant.DefaultLocale), "teacher")).Methods("GET")
+	route.HandleFunc("/teacher/topics", middleware.AuthWrapMiddleware(middleware.LocaleMiddleware(middleware.ConditionalGetMiddleware(resource.getTopicTags), constant.DefaultLocale), "teacher")).Methods("GET")
+	route.HandleFunc("/teacher/countries", middleware.UnAuthWrapMiddleware(middleware.LocaleMiddleware(middleware.ConditionalGetByClientMiddleware(resource.getCountriesNew), constant.DefaultLocale))).Methods("GET")
 }
 
 func (t *TeacherTagsResource) getBoardTags(rw http.ResponseWriter, req *http.Request) {
@@ -235,7 +233,7 @@ func (t *TeacherTagsResource) getTags(tagType string, tagGroup string, curriculu
 	if err = copier.Copy(&getTeacherTags, &tag); err != nil {
 		entity.HandleError(rw, "", noonerror.New(noonerror.ErrInternalServer, "mapperError"), req.Header.Get("locale"), true)
 	}
-	res, err := t.tts.GetTeacherTags(&getTeacherTags)
+	res, err := t.tts.GetTeacherTags(req.Context(), &getTeacherTags)
 	if err != nil {
 		entity.HandleError(rw, "", err, req.Header.Get("locale"), true)
 		return
@@ -337,7 +335,7 @@ func (t *TeacherTagsResource) getTestSkillTags(tagType string, tagGroup string,
 	if err = copier.Copy(&getTeacherTags, &tag); err != nil {
 		entity.HandleError(rw, "", noonerror.New(noonerror.ErrInternalServer, "mapperError"), req.Header.Get("locale"), true)
 	}
-	res, err := t.tts.GetTestsSkillsForLibrary(&getTeacherTags)
+	res, err := t.tts.GetTestsSkillsForLibrary(req.Context(), &getTeacherTags)
 	if err != nil {
 		entity.HandleError(rw, "", err, req.Header.Get("locale"), true)
 		return
@@ -372,48 +370,7 @@ func (t *TeacherTagsResource) getCountriesNew(rw http.ResponseWriter, req *http.
 	countryId := req.Header.Get("country")
 	locale := req.Header.Get("locale")
 
-	ipAddress := req.Header.Get("X-FORWARDED-FOR")
-
-	db, err := maxminddb.Open("GeoIP2-Country.mmdb")
-
-	if err != nil {
-
-		log.Fatal(err)
-
-	}
-
-	defer db.Close()
-
-
-	ip := net.ParseIP(ipAddress)
-
-
-	var record struct {
-
-		Country struct {
-
-			ISOCode string `maxminddb:"iso_code"`
-
-		} `maxminddb:"country"`
-
-	}
-
-
-	err = db.Lookup(ip, &record)
-
-	if err != nil {
-
-		log.Fatal(err)
-
-	}
-
-	var isoCode = "SA"
-
-	if record.Country.ISOCode != "" {
-
-		isoCode = record.Country.ISOCode
-
-	}
+	ipAddress := helper.ClientIp(req)
 
 	start, _ := params["start"]
 	startInt := 0
@@ -425,11 +382,12 @@ func (t *TeacherTagsResource) getCountriesNew(rw http.ResponseWriter, req *http.
 	if len(limit) > 0 {
 		limitInt, _ = strconv.Atoi(limit)
 	}
+	groupBy, _ := params["group_by"]
 	queryParams := request.GetCountriesNewDTO{
 		IpAddress: ipAddress,
-		ISOCode:   isoCode,
 		CountryId: &countryId,
 		Locale:    &locale,
+		GroupBy:   &groupBy,
 		Start:     startInt,
 		Limit:     limitInt,
 	}
@@ -437,7 +395,7 @@ func (t *TeacherTagsResource) getCountriesNew(rw http.ResponseWriter, req *http.
 	if err = copier.Copy(&getQueryParams, &queryParams); err != nil {
 		entity.HandleError(rw, "", noonerror.New(noonerror.ErrInternalServer, "mapperError"), req.Header.Get("locale"), true)
 	}
-	res, err := t.tts.GetCountriesTagsNew(&getQueryParams)
+	res, err := t.tts.GetCountriesTagsNew(req.Context(), &getQueryParams)
 	if err != nil {
 		entity.HandleError(rw, "", err, req.Header.Get("locale"), true)
 		return
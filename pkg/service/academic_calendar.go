@@ -0,0 +1,326 @@
+package service
+
+import (
+	"bitbucket.org/noon-micro/curriculum/pkg/domain"
+	noonerror "bitbucket.org/noon-micro/curriculum/pkg/lib/error"
+	repository "bitbucket.org/noon-micro/curriculum/pkg/repository/mysql"
+	"bitbucket.org/noon-micro/curriculum/pkg/service/constant"
+	"context"
+	"encoding/json"
+	"sort"
+	"time"
+)
+
+// FetchAcademicCalendar returns the calendar of the country, nil when none is configured
+func (t *TagsServiceStruct) FetchAcademicCalendar(ctx context.Context, countryId *string) (*domain.AcademicCalendar, error) {
+	tagData, err := t.fetchCountryTag(ctx, countryId)
+	if err != nil {
+		return nil, err
+	}
+	value, ok := tagData.Attributes[constant.AcademicCalendarAttribute]
+	if !ok || value == nil {
+		return nil, nil
+	}
+	calendarByte, err := json.Marshal(value)
+	if err != nil {
+		return nil, noonerror.New(noonerror.ErrInternalServer, "academicCalendarMappingError")
+	}
+	var calendar domain.AcademicCalendar
+	if err = json.Unmarshal(calendarByte, &calendar); err != nil {
+		return nil, noonerror.New(noonerror.ErrInternalServer, "academicCalendarMappingError")
+	}
+	return &calendar, nil
+}
+
+func (t *TagsServiceStruct) fetchCountryTag(ctx context.Context, countryId *string) (*domain.Tags, error) {
+	tagData, err := t.FetchTags(ctx, countryId)
+	if err != nil {
+		return nil, err
+	}
+	if tagData == nil || tagData.Type == nil || *tagData.Type != domain.TagTypeEnum.Country {
+		return nil, noonerror.New(noonerror.ErrBadRequest, "countryNotFound")
+	}
+	return tagData, nil
+}
+
+// GetCurrentTerm finds the term the date falls in and the one after it, the date defaults to today
+func (t *RpcTagsServiceStruct) GetCurrentTerm(ctx context.Context, getCurrentTerm *domain.GetCurrentTerm) (*domain.CurrentTerm, error) {
+	date, err := calendarDateOrToday(getCurrentTerm.Date)
+	if err != nil {
+		return nil, err
+	}
+	calendar, err := t.ts.FetchAcademicCalendar(ctx, getCurrentTerm.CountryId)
+	if err != nil {
+		return nil, err
+	}
+	if calendar == nil {
+		return nil, noonerror.New(noonerror.ErrBadRequest, "academicCalendarNotFound")
+	}
+	dateString := date.Format(constant.CalendarDateLayout)
+	currentTerm := &domain.CurrentTerm{CountryId: getCurrentTerm.CountryId, Date: &dateString}
+	for _, year := range calendar.Years {
+		if within(date, year.StartDate, year.EndDate) {
+			currentTerm.Year = year.Name
+			for _, v := range year.Holidays {
+				if within(date, v.StartDate, v.EndDate) {
+					currentTerm.Holiday = v.Name
+					break
+				}
+			}
+		}
+		for _, v := range year.Terms {
+			if within(date, v.StartDate, v.EndDate) {
+				currentTerm.Current = v
+			} else if currentTerm.Next == nil && parseCalendarDate(v.StartDate).After(date) {
+				currentTerm.Next = v
+			}
+		}
+	}
+	return currentTerm, nil
+}
+
+// GetExpectedGrade moves the enrollment grade up once for every promotion date after the enrollment, following the
+// order of the grade tags of the board
+func (t *RpcTagsServiceStruct) GetExpectedGrade(ctx context.Context, getExpectedGrade *domain.GetExpectedGrade) (*domain.ExpectedGrade, error) {
+	enrolledAt, err := parseRequestDate(getExpectedGrade.EnrolledAt)
+	if err != nil {
+		return nil, err
+	}
+	date, err := calendarDateOrToday(getExpectedGrade.Date)
+	if err != nil {
+		return nil, err
+	}
+	if date.Before(enrolledAt) {
+		return nil, noonerror.New(noonerror.ErrBadRequest, "invalidCalendarRange")
+	}
+	calendar, err := t.ts.FetchAcademicCalendar(ctx, getExpectedGrade.CountryId)
+	if err != nil {
+		return nil, err
+	}
+	if calendar == nil || calendar.PromotionDate == nil {
+		return nil, noonerror.New(noonerror.ErrBadRequest, "academicCalendarNotFound")
+	}
+	promotionDate, err := time.Parse(constant.PromotionDateLayout, *calendar.PromotionDate)
+	if err != nil {
+		return nil, noonerror.New(noonerror.ErrInternalServer, "invalidPromotionDate")
+	}
+	promotions := countPromotions(promotionDate, enrolledAt, date)
+	parentTagIds := *getExpectedGrade.CountryId + "." + *getExpectedGrade.BoardId
+	gradeType := domain.TagTypeEnum.Grade
+	tagOrders, err := t.ts.FetchTagOrders(ctx, &parentTagIds, &gradeType)
+	if err != nil {
+		return nil, err
+	}
+	var grades []*domain.ParentTagMapping
+	for _, v := range tagOrders {
+		if v.Publish && !v.Hidden {
+			grades = append(grades, v)
+		}
+	}
+	sort.SliceStable(grades, func(i, j int) bool {
+		return orderValue(grades[i].Order) < orderValue(grades[j].Order)
+	})
+	index := -1
+	for i, v := range grades {
+		if *v.TagID == *getExpectedGrade.GradeId {
+			index = i
+			break
+		}
+	}
+	if index == -1 {
+		return nil, noonerror.New(noonerror.ErrBadRequest, "gradeNotFound")
+	}
+	expectedGrade := &domain.ExpectedGrade{Promotions: promotions}
+	if index += promotions; index >= len(grades) {
+		expectedGrade.Graduated = true
+		index = len(grades) - 1
+	}
+	expectedGrade.GradeId = grades[index].TagID
+	tagData, err := t.ts.FetchTags(ctx, expectedGrade.GradeId)
+	if err != nil {
+		return nil, err
+	}
+	if tagData != nil {
+		expectedGrade.Name = tagData.Name
+	}
+	return expectedGrade, nil
+}
+
+// GetAcademicCalendar returns the calendar of the country, an empty one when none is configured
+func (t *AdminTagsServiceStruct) GetAcademicCalendar(ctx context.Context, countryId *string) (*domain.AcademicCalendar, error) {
+	calendar, err := t.ts.FetchAcademicCalendar(ctx, countryId)
+	if err != nil {
+		return nil, err
+	}
+	if calendar == nil {
+		calendar = &domain.AcademicCalendar{Years: []*domain.AcademicYear{}}
+	}
+	return calendar, nil
+}
+
+// UpdateAcademicCalendar replaces the calendar of the country, the rest of its attributes are kept. It is rejected
+// with the current calendar when the country changed since it was read.
+func (t *AdminTagsServiceStruct) UpdateAcademicCalendar(ctx context.Context, countryId *string, calendar *domain.AcademicCalendar) (*domain.AcademicCalendar, error) {
+	tagData, err := t.ts.FetchTags(ctx, countryId)
+	if err != nil {
+		return nil, err
+	}
+	if tagData == nil || tagData.Type == nil || *tagData.Type != domain.TagTypeEnum.Country {
+		return nil, noonerror.New(noonerror.ErrBadRequest, "countryNotFound")
+	}
+	if err = validateAcademicCalendar(calendar); err != nil {
+		return nil, err
+	}
+	calendarByte, err := json.Marshal(calendar)
+	if err != nil {
+		return nil, noonerror.New(noonerror.ErrInternalServer, "academicCalendarMappingError")
+	}
+	var value map[string]interface{}
+	if err = json.Unmarshal(calendarByte, &value); err != nil {
+		return nil, noonerror.New(noonerror.ErrInternalServer, "academicCalendarMappingError")
+	}
+	attributes := make(map[string]interface{}, len(tagData.Attributes)+1)
+	for k, v := range tagData.Attributes {
+		attributes[k] = v
+	}
+	attributes[constant.AcademicCalendarAttribute] = value
+	tx, err := repository.Db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, noonerror.New(noonerror.ErrInternalServer, "ContextCreationError")
+	}
+	// the calendar is merged into the attributes as they were read, a change of the country since then is a conflict
+	if err = t.ts.UpdateTag(ctx, tx, &domain.UpdateTag{ID: countryId, Type: tagData.Type, Attributes: attributes, Version: &tagData.Version}); err != nil {
+		_ = tx.Rollback()
+		if isConflict(err) {
+			calendar, _ := t.GetAcademicCalendar(ctx, countryId)
+			return calendar, err
+		}
+		return nil, err
+	}
+	if err = t.ep.Publish(ctx, tx, domain.TagEventTypeEnum.AttributesChanged, countryId, tagData.Type, &domain.TagAttributesChangedEventData{
+		Keys: []string{constant.AcademicCalendarAttribute}, Attributes: attributes}); err != nil {
+		_ = tx.Rollback()
+		return nil, err
+	}
+	if err = tx.Commit(); err != nil {
+		return nil, noonerror.New(noonerror.ErrInternalServer, "dbCommitError")
+	}
+	t.evictTag(ctx, countryId)
+	return t.GetAcademicCalendar(ctx, countryId)
+}
+
+// validateAcademicCalendar expects the years and their terms in chronological order without overlaps, terms and
+// holidays have to fall within their year
+func validateAcademicCalendar(calendar *domain.AcademicCalendar) error {
+	if calendar.PromotionDate == nil {
+		return noonerror.New(noonerror.ErrBadRequest, "invalidPromotionDate")
+	}
+	if _, err := time.Parse(constant.PromotionDateLayout, *calendar.PromotionDate); err != nil {
+		return noonerror.New(noonerror.ErrBadRequest, "invalidPromotionDate")
+	}
+	var previousYear time.Time
+	for _, year := range calendar.Years {
+		yearStart, yearEnd, err := parseCalendarRange(year.StartDate, year.EndDate)
+		if err != nil {
+			return err
+		}
+		if !previousYear.IsZero() && !yearStart.After(previousYear) {
+			return noonerror.New(noonerror.ErrBadRequest, "academicYearsOverlap")
+		}
+		previousYear = yearEnd
+		var previousTerm time.Time
+		for _, v := range year.Terms {
+			start, end, err := parseCalendarRange(v.StartDate, v.EndDate)
+			if err != nil {
+				return err
+			}
+			if start.Before(yearStart) || end.After(yearEnd) {
+				return noonerror.New(noonerror.ErrBadRequest, "academicTermOutsideYear")
+			}
+			if !previousTerm.IsZero() && !start.After(previousTerm) {
+				return noonerror.New(noonerror.ErrBadRequest, "academicTermsOverlap")
+			}
+			previousTerm = end
+		}
+		for _, v := range year.Holidays {
+			start, end, err := parseCalendarRange(v.StartDate, v.EndDate)
+			if err != nil {
+				return err
+			}
+			if start.Before(yearStart) || end.After(yearEnd) {
+				return noonerror.New(noonerror.ErrBadRequest, "academicHolidayOutsideYear")
+			}
+		}
+	}
+	return nil
+}
+
+// countPromotions counts the promotion dates after the enrollment up to the date included, a promotion on 02-29 falls
+// on 02-28 in the years without one
+func countPromotions(promotionDate time.Time, enrolledAt time.Time, date time.Time) int {
+	promotions := 0
+	for year := enrolledAt.Year(); year <= date.Year(); year++ {
+		day := promotionDate.Day()
+		if lastDay := time.Date(year, promotionDate.Month()+1, 0, 0, 0, 0, 0, time.UTC).Day(); day > lastDay {
+			day = lastDay
+		}
+		promotion := time.Date(year, promotionDate.Month(), day, 0, 0, 0, 0, time.UTC)
+		if promotion.After(enrolledAt) && !promotion.After(date) {
+			promotions++
+		}
+	}
+	return promotions
+}
+
+func parseCalendarRange(startDate *string, endDate *string) (start time.Time, end time.Time, err error) {
+	if start, err = parseRequestDate(startDate); err != nil {
+		return
+	}
+	if end, err = parseRequestDate(endDate); err != nil {
+		return
+	}
+	if end.Before(start) {
+		err = noonerror.New(noonerror.ErrBadRequest, "invalidCalendarRange")
+	}
+	return
+}
+
+func parseRequestDate(date *string) (time.Time, error) {
+	if date == nil {
+		return time.Time{}, noonerror.New(noonerror.ErrBadRequest, "invalidCalendarDate")
+	}
+	parsed, err := time.Parse(constant.CalendarDateLayout, *date)
+	if err != nil {
+		return time.Time{}, noonerror.New(noonerror.ErrBadRequest, "invalidCalendarDate")
+	}
+	return parsed, nil
+}
+
+func calendarDateOrToday(date *string) (time.Time, error) {
+	if date == nil || *date == "" {
+		now := time.Now().UTC()
+		return time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC), nil
+	}
+	return parseRequestDate(date)
+}
+
+// parseCalendarDate reads dates which were validated when the calendar was saved
+func parseCalendarDate(date *string) time.Time {
+	if date == nil {
+		return time.Time{}
+	}
+	parsed, _ := time.Parse(constant.CalendarDateLayout, *date)
+	return parsed
+}
+
+// within tells whether the date falls in the range, both ends included
+func within(date time.Time, startDate *string, endDate *string) bool {
+	return !date.Before(parseCalendarDate(startDate)) && !date.After(parseCalendarDate(endDate))
+}
+
+func orderValue(order *int) int {
+	if order == nil {
+		return constant.OrderMax
+	}
+	return *order
+}
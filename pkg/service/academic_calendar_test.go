@@ -0,0 +1,196 @@
+package service
+
+import (
+	"bitbucket.org/noon-micro/curriculum/pkg/domain"
+	noonerror "bitbucket.org/noon-micro/curriculum/pkg/lib/error"
+	"bitbucket.org/noon-micro/curriculum/pkg/service/constant"
+	"context"
+	"testing"
+	"time"
+)
+
+func calendarYear(name, startDate, endDate string, terms [][3]string, holidays [][3]string) *domain.AcademicYear {
+	year := &domain.AcademicYear{Name: str(name), StartDate: str(startDate), EndDate: str(endDate)}
+	for _, v := range terms {
+		year.Terms = append(year.Terms, &domain.AcademicTerm{Name: str(v[0]), StartDate: str(v[1]), EndDate: str(v[2])})
+	}
+	for _, v := range holidays {
+		year.Holidays = append(year.Holidays, &domain.AcademicHoliday{Name: str(v[0]), StartDate: str(v[1]), EndDate: str(v[2])})
+	}
+	return year
+}
+
+func errorMessage(err error) string {
+	if noonError, ok := err.(*noonerror.NoonError); ok {
+		return noonError.Message
+	}
+	if err != nil {
+		return err.Error()
+	}
+	return ""
+}
+
+func TestValidateAcademicCalendar(t *testing.T) {
+	terms := [][3]string{{"Term 1", "2026-08-23", "2026-11-19"}, {"Term 2", "2026-11-29", "2027-03-04"}}
+	holidays := [][3]string{{"Winter break", "2026-12-27", "2027-01-02"}}
+	cases := []struct {
+		name          string
+		promotionDate *string
+		years         []*domain.AcademicYear
+		want          string
+	}{
+		{"valid", str("08-20"), []*domain.AcademicYear{
+			calendarYear("2026-2027", "2026-08-23", "2027-06-24", terms, holidays),
+			calendarYear("2027-2028", "2027-08-22", "2028-06-22", nil, nil)}, ""},
+		{"promotion on a leap day", str("02-29"), nil, ""},
+		{"missing promotion date", nil, nil, "invalidPromotionDate"},
+		{"malformed promotion date", str("13-01"), nil, "invalidPromotionDate"},
+		{"year ending before it starts", str("08-20"), []*domain.AcademicYear{
+			calendarYear("2026-2027", "2027-06-24", "2026-08-23", nil, nil)}, "invalidCalendarRange"},
+		{"malformed year date", str("08-20"), []*domain.AcademicYear{
+			calendarYear("2026-2027", "2026-08-23", "2027-02-29", nil, nil)}, "invalidCalendarDate"},
+		{"year starting on the end of the previous one", str("08-20"), []*domain.AcademicYear{
+			calendarYear("2026-2027", "2026-08-23", "2027-06-24", nil, nil),
+			calendarYear("2027-2028", "2027-06-24", "2028-06-22", nil, nil)}, "academicYearsOverlap"},
+		{"years out of order", str("08-20"), []*domain.AcademicYear{
+			calendarYear("2027-2028", "2027-08-22", "2028-06-22", nil, nil),
+			calendarYear("2026-2027", "2026-08-23", "2027-06-24", nil, nil)}, "academicYearsOverlap"},
+		{"overlapping terms", str("08-20"), []*domain.AcademicYear{
+			calendarYear("2026-2027", "2026-08-23", "2027-06-24", [][3]string{
+				{"Term 1", "2026-08-23", "2026-11-19"}, {"Term 2", "2026-11-19", "2027-03-04"}}, nil)}, "academicTermsOverlap"},
+		{"term past the end of the year", str("08-20"), []*domain.AcademicYear{
+			calendarYear("2026-2027", "2026-08-23", "2027-06-24", [][3]string{
+				{"Term 3", "2027-03-14", "2027-06-25"}}, nil)}, "academicTermOutsideYear"},
+		{"holiday before the year", str("08-20"), []*domain.AcademicYear{
+			calendarYear("2026-2027", "2026-08-23", "2027-06-24", terms, [][3]string{
+				{"Summer", "2026-08-01", "2026-08-30"}})}, "academicHolidayOutsideYear"},
+		{"holiday after the year", str("08-20"), []*domain.AcademicYear{
+			calendarYear("2026-2027", "2026-08-23", "2027-06-24", terms, [][3]string{
+				{"Summer", "2027-06-20", "2027-08-20"}})}, "academicHolidayOutsideYear"},
+	}
+	for _, c := range cases {
+		err := validateAcademicCalendar(&domain.AcademicCalendar{PromotionDate: c.promotionDate, Years: c.years})
+		if got := errorMessage(err); got != c.want {
+			t.Errorf("%s: got %q, want %q", c.name, got, c.want)
+		}
+	}
+}
+
+func TestGetCurrentTerm(t *testing.T) {
+	services := newTestServices(t)
+	rpcService := NewRpcTagsService(services.tags, nil, services.publisher)
+	name := func(term *domain.AcademicTerm) string {
+		if term == nil {
+			return ""
+		}
+		return *term.Name
+	}
+	value := func(s *string) string {
+		if s == nil {
+			return ""
+		}
+		return *s
+	}
+	cases := []struct {
+		date    string
+		year    string
+		current string
+		next    string
+		holiday string
+	}{
+		{"2026-08-01", "", "", "Term 1", ""},
+		{"2026-08-23", "2026-2027", "Term 1", "Term 2", ""},
+		{"2026-11-19", "2026-2027", "Term 1", "Term 2", ""},
+		{"2026-11-22", "2026-2027", "", "Term 2", ""},
+		{"2026-12-30", "2026-2027", "Term 2", "Term 3", "Winter break"},
+		{"2027-03-10", "2026-2027", "", "Term 3", ""},
+		{"2027-06-24", "2026-2027", "Term 3", "", ""},
+		{"2027-07-01", "", "", "", ""},
+	}
+	for _, c := range cases {
+		currentTerm, err := rpcService.GetCurrentTerm(context.Background(), &domain.GetCurrentTerm{CountryId: str("9"), Date: str(c.date)})
+		if err != nil {
+			t.Errorf("%s: %v", c.date, err)
+			continue
+		}
+		if got := value(currentTerm.Year); got != c.year {
+			t.Errorf("%s: got year %q, want %q", c.date, got, c.year)
+		}
+		if got := name(currentTerm.Current); got != c.current {
+			t.Errorf("%s: got current %q, want %q", c.date, got, c.current)
+		}
+		if got := name(currentTerm.Next); got != c.next {
+			t.Errorf("%s: got next %q, want %q", c.date, got, c.next)
+		}
+		if got := value(currentTerm.Holiday); got != c.holiday {
+			t.Errorf("%s: got holiday %q, want %q", c.date, got, c.holiday)
+		}
+	}
+	if _, err := rpcService.GetCurrentTerm(context.Background(), &domain.GetCurrentTerm{CountryId: str("10"), Date: str("2026-09-01")}); errorMessage(err) != "academicCalendarNotFound" {
+		t.Errorf("country without a calendar: got %v", err)
+	}
+}
+
+func TestGetExpectedGrade(t *testing.T) {
+	services := newTestServices(t)
+	rpcService := NewRpcTagsService(services.tags, nil, services.publisher)
+	cases := []struct {
+		name       string
+		gradeId    string
+		enrolledAt string
+		date       string
+		want       string
+		promotions int
+		graduated  bool
+		err        string
+	}{
+		{"before the first promotion", "251", "2026-09-01", "2027-08-19", "251", 0, false, ""},
+		{"on the promotion date", "251", "2026-09-01", "2027-08-20", "252", 1, false, ""},
+		{"enrolled on the promotion date", "251", "2026-08-20", "2026-08-20", "251", 0, false, ""},
+		{"past the last grade", "251", "2026-09-01", "2028-08-20", "252", 2, true, ""},
+		{"from the last grade", "252", "2026-01-10", "2026-09-01", "252", 1, true, ""},
+		{"date before the enrollment", "251", "2026-09-01", "2026-08-01", "", 0, false, "invalidCalendarRange"},
+		{"grade outside the board", "20", "2026-09-01", "2027-08-20", "", 0, false, "gradeNotFound"},
+	}
+	for _, c := range cases {
+		expectedGrade, err := rpcService.GetExpectedGrade(context.Background(), &domain.GetExpectedGrade{CountryId: str("9"), BoardId: str("2"),
+			GradeId: str(c.gradeId), EnrolledAt: str(c.enrolledAt), Date: str(c.date)})
+		if got := errorMessage(err); got != c.err {
+			t.Errorf("%s: got error %q, want %q", c.name, got, c.err)
+			continue
+		}
+		if err != nil {
+			continue
+		}
+		if *expectedGrade.GradeId != c.want || expectedGrade.Promotions != c.promotions || expectedGrade.Graduated != c.graduated {
+			t.Errorf("%s: got %s after %d promotions graduated %v, want %s after %d graduated %v", c.name, *expectedGrade.GradeId,
+				expectedGrade.Promotions, expectedGrade.Graduated, c.want, c.promotions, c.graduated)
+		}
+	}
+}
+
+func TestCountPromotionsOnALeapDay(t *testing.T) {
+	promotionDate, err := time.Parse(constant.PromotionDateLayout, "02-29")
+	if err != nil {
+		t.Fatal(err)
+	}
+	cases := []struct {
+		enrolledAt string
+		date       string
+		want       int
+	}{
+		{"2026-01-10", "2026-02-27", 0},
+		{"2026-01-10", "2026-02-28", 1},
+		{"2026-01-10", "2027-03-01", 2},
+		{"2027-03-01", "2028-02-28", 0},
+		{"2027-03-01", "2028-02-29", 1},
+		{"2026-02-28", "2027-02-27", 0},
+	}
+	for _, c := range cases {
+		enrolledAt, _ := time.Parse(constant.CalendarDateLayout, c.enrolledAt)
+		date, _ := time.Parse(constant.CalendarDateLayout, c.date)
+		if got := countPromotions(promotionDate, enrolledAt, date); got != c.want {
+			t.Errorf("%s to %s: got %d promotions, want %d", c.enrolledAt, c.date, got, c.want)
+		}
+	}
+}
@@ -1,14 +1,18 @@
 package service
 
 import (
+	"bitbucket.org/noon-micro/curriculum/config"
 	"bitbucket.org/noon-micro/curriculum/pkg/domain"
 	noonerror "bitbucket.org/noon-micro/curriculum/pkg/lib/error"
 	"bitbucket.org/noon-micro/curriculum/pkg/lib/flow"
+	"bitbucket.org/noon-micro/curriculum/pkg/lib/helper"
 	"bitbucket.org/noon-micro/curriculum/pkg/lib/logger"
 	repository "bitbucket.org/noon-micro/curriculum/pkg/repository/mysql"
+	redisrepo "bitbucket.org/noon-micro/curriculum/pkg/repository/redis"
 	"bitbucket.org/noon-micro/curriculum/pkg/service/constant"
 	dtomapper "bitbucket.org/noon-micro/curriculum/pkg/service/mapper"
 	"context"
+	"database/sql"
 	"github.com/jinzhu/copier"
 	"strconv"
 	"strings"
@@ -19,83 +23,108 @@ import (
 type AdminTagsServiceStruct struct {
 	ts domain.TagsService
 	es domain.Elastic
+	tp domain.TranslationProvider
+	ep domain.TagEventPublisher
 }
 
-func NewAdminTagsService(ts domain.TagsService, es domain.Elastic) *AdminTagsServiceStruct {
-	return &AdminTagsServiceStruct{ts: ts, es: es}
+func NewAdminTagsService(ts domain.TagsService, es domain.Elastic, tp domain.TranslationProvider, ep domain.TagEventPublisher) *AdminTagsServiceStruct {
+	return &AdminTagsServiceStruct{ts: ts, es: es, tp: tp, ep: ep}
 }
 
-func (t *AdminTagsServiceStruct) GetTags(tags *domain.GetTags) (getTagResponse *domain.GetTagsResponse, err error) {
+func (t *AdminTagsServiceStruct) GetTags(ctx context.Context, tags *domain.GetTags) (getTagResponse *domain.GetTagsResponse, err error) {
 	switch *tags.TagGroup {
 	case domain.TagGroupEnum.Curriculum:
-		return t.getCurriculumTags(tags)
+		return t.getCurriculumTags(ctx, tags)
 	case domain.TagGroupEnum.Content:
-		return t.getContentTags(tags)
+		return t.getContentTags(ctx, tags)
 	}
 	return
 }
 
-func (t *AdminTagsServiceStruct) GetAdminTags(tags *domain.GetAdminTags) (getTagResponse *domain.GetTagsResponse, err error) {
+func (t *AdminTagsServiceStruct) GetAdminTags(ctx context.Context, tags *domain.GetAdminTags) (getTagResponse *domain.GetTagsResponse, err error) {
 
 	switch *tags.TagGroup {
 	case domain.TagGroupEnum.Curriculum:
-		return t.getCurriculumTagsForLibrary(tags)
+		return t.getCurriculumTagsForLibrary(ctx, tags)
 	case domain.TagGroupEnum.Content:
-		return t.getContentTagsForLibrary(tags)
+		return t.getContentTagsForLibrary(ctx, tags)
 	}
 	return
 
 }
 
-func (t *AdminTagsServiceStruct) GetTagsSearch(tags *domain.GetTags) (getTagResponse *domain.GetTagsResponse, err error) {
+func (t *AdminTagsServiceStruct) GetTagsSearch(ctx context.Context, tags *domain.GetTags) (getTagResponse *domain.GetTagsResponse, err error) {
 	switch *tags.TagGroup {
 	case domain.TagGroupEnum.Curriculum:
-		return t.getCurriculumTagsSearch(tags)
+		return t.getCurriculumTagsSearch(ctx, tags)
 	case domain.TagGroupEnum.Content:
-		return t.getContentTagsSearch(tags)
+		return t.getContentTagsSearch(ctx, tags)
 	case domain.TagGroupEnum.Identifier:
-		return t.getIdentifierTagsSearch(tags)
+		return t.getIdentifierTagsSearch(ctx, tags)
 	}
 	return
 }
 
-func (t *AdminTagsServiceStruct) CreateAdminTags(tagGroup *string, tags *domain.CreateTags) (tagResponse *domain.TagResponse, err error) {
+func (t *AdminTagsServiceStruct) CreateAdminTags(ctx context.Context, tagGroup *string, tags *domain.CreateTags) (tagResponse *domain.TagResponse, err error) {
 	switch *tagGroup {
 	case domain.TagGroupEnum.Curriculum:
-		return t.createCurriculumTag(tags)
+		tagResponse, err = t.createCurriculumTag(ctx, tags)
 	case domain.TagGroupEnum.Content:
-		return t.createContentTag(tags)
+		tagResponse, err = t.createContentTag(ctx, tags)
 	case domain.TagGroupEnum.Identifier:
-		return t.createIdentifierTag(tags)
+		tagResponse, err = t.createIdentifierTag(ctx, tags)
 	}
 	return
 }
 
-func (t *AdminTagsServiceStruct) UpdateAdminTags(tagGroup *string, tags *domain.UpdateTags) (tagResponse *domain.TagResponse, err error) {
+// publishCreated writes the created event in the transaction creating the tag
+func (t *AdminTagsServiceStruct) publishCreated(ctx context.Context, tx *sql.Tx, tagId *string, tags *domain.CreateTags) error {
+	return t.ep.Publish(ctx, tx, domain.TagEventTypeEnum.Created, tagId, tags.Type, &domain.TagCreatedEventData{Name: tags.Name,
+		TagGroup: tags.TagGroup, CurriculumType: tags.CurriculumType, Hierarchy: tags.Hierarchy})
+}
+
+func (t *AdminTagsServiceStruct) UpdateAdminTags(ctx context.Context, tagGroup *string, tags *domain.UpdateTags) (tagResponse *domain.TagResponse, err error) {
 	switch *tagGroup {
 	case domain.TagGroupEnum.Curriculum:
-		return t.updateCurriculumTag(tags)
+		tagResponse, err = t.updateCurriculumTag(ctx, tags)
 	case domain.TagGroupEnum.Content:
-		return t.updateContentTag(tags)
+		tagResponse, err = t.updateContentTag(ctx, tags)
 	}
 	return
 }
 
-func (t *AdminTagsServiceStruct) UpdateTagOrder(tagGroup *string, tags *domain.UpdateTagOrder) (err error) {
+// UpdateTagOrder returns the sibling set as it is after the reorder, or as it currently is when the reorder was made
+// against a stale version
+func (t *AdminTagsServiceStruct) UpdateTagOrder(ctx context.Context, tagGroup *string, tags *domain.UpdateTagOrder) (tagOrders *domain.TagOrders, err error) {
+	var parentTags *string
 	switch *tagGroup {
 	case domain.TagGroupEnum.Curriculum:
-		return t.updateCurriculumTagOrder(tags)
+		parentTags, err = t.updateCurriculumTagOrder(ctx, tags)
 	case domain.TagGroupEnum.Content:
-		return t.updateContentTagOrder(tags)
+		parentTags, err = t.updateContentTagOrder(ctx, tags)
 	}
-	return
+	if parentTags == nil {
+		return nil, err
+	}
+	if err != nil {
+		if isConflict(err) {
+			tagOrders, _ = t.ts.FetchCurrentTagOrders(ctx, parentTags, tags.Type)
+		}
+		return tagOrders, err
+	}
+	return t.ts.FetchCurrentTagOrders(ctx, parentTags, tags.Type)
 }
 
-func (t *AdminTagsServiceStruct) UpdateTag(updateTag *domain.UpdateTag) (err error) {
-	tagData, err := t.ts.FetchTags(updateTag.ID)
+// UpdateTag returns the tag as it is after the update, or as it currently is when the update was made against a stale
+// version
+func (t *AdminTagsServiceStruct) UpdateTag(ctx context.Context, updateTag *domain.UpdateTag) (tagResponse *domain.TagResponse, err error) {
+	tagData, err := t.ts.FetchTags(ctx, updateTag.ID)
 	if err != nil {
 		return
 	}
+	if tagData == nil {
+		return nil, noonerror.New(noonerror.ErrBadRequest, "tagFetchError")
+	}
 	for k, v := range tagData.Attributes {
 		_, ok := updateTag.Attributes[k]
 		if !ok && updateTag.Attributes != nil {
@@ -103,24 +132,90 @@ func (t *AdminTagsServiceStruct) UpdateTag(updateTag *domain.UpdateTag) (err err
 		}
 	}
 	updateTag.Type = tagData.Type
-	return t.ts.UpdateTag(updateTag)
+	tx, err := repository.Db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, noonerror.New(noonerror.ErrInternalServer, "ContextCreationError")
+	}
+	if err = t.ts.UpdateTag(ctx, tx, updateTag); err != nil {
+		_ = tx.Rollback()
+		if isConflict(err) {
+			tagResponse, _ = t.GetTag(ctx, updateTag.ID)
+		}
+		return
+	}
+	if err = t.publishTagUpdate(ctx, tx, tagData, updateTag); err != nil {
+		_ = tx.Rollback()
+		return
+	}
+	if err = tx.Commit(); err != nil {
+		return nil, noonerror.New(noonerror.ErrInternalServer, "dbCommitError")
+	}
+	t.evictTag(ctx, updateTag.ID)
+	return t.GetTag(ctx, updateTag.ID)
+}
+
+// evictTag drops the cached tag once its transaction is committed, a read racing the transaction may have cached the
+// previous version
+func (t *AdminTagsServiceStruct) evictTag(ctx context.Context, id *string) {
+	key := redisrepo.CurriculumPrefix + *id
+	if _, err := t.ts.EvictCacheKeys(ctx, []*string{&key}); err != nil {
+		logger.Client.Error("evictTagError:id:"+*id, err)
+	}
 }
 
-func (t *AdminTagsServiceStruct) RemoveAdminTagFromHierarchy(tagGroup *string, tags *domain.RemoveHierarchy) (tagResponse *domain.TagResponse, err error) {
+// evictTagOrders drops the cached orders under the parent tags once the reorder is committed, for the same reason as evictTag
+func (t *AdminTagsServiceStruct) evictTagOrders(ctx context.Context, parentTags, tagType *string) {
+	key := redisrepo.CurriculumTagOrderPrefix + *parentTags + ":" + *tagType
+	if _, err := t.ts.EvictCacheKeys(ctx, []*string{&key}); err != nil {
+		logger.Client.Error("evictTagOrdersError:parentTags:"+*parentTags, err)
+	}
+}
+
+// isConflict tells whether the write was rejected for being made against a stale version
+func isConflict(err error) bool {
+	noonError, ok := err.(*noonerror.NoonError)
+	return ok && noonError.Err == noonerror.ErrConflict
+}
+
+// publishTagUpdate writes an event for every kind of change the update made to the tag in the transaction of the update
+func (t *AdminTagsServiceStruct) publishTagUpdate(ctx context.Context, tx *sql.Tx, tagData *domain.Tags, updateTag *domain.UpdateTag) error {
+	if updateTag.Name != nil && (tagData.Name == nil || *tagData.Name != *updateTag.Name) {
+		if err := t.ep.Publish(ctx, tx, domain.TagEventTypeEnum.Renamed, updateTag.ID, tagData.Type, &domain.TagRenamedEventData{PreviousName: tagData.Name, Name: updateTag.Name}); err != nil {
+			return err
+		}
+	}
+	if updateTag.Attributes != nil {
+		if keys := changedAttributes(tagData.Attributes, updateTag.Attributes); len(keys) > 0 {
+			if err := t.ep.Publish(ctx, tx, domain.TagEventTypeEnum.AttributesChanged, updateTag.ID, tagData.Type, &domain.TagAttributesChangedEventData{Keys: keys, Attributes: updateTag.Attributes}); err != nil {
+				return err
+			}
+		}
+	}
+	if updateTag.Hidden != nil && *updateTag.Hidden == tagData.Publish {
+		eventType := domain.TagEventTypeEnum.Unhidden
+		if *updateTag.Hidden {
+			eventType = domain.TagEventTypeEnum.Hidden
+		}
+		return t.ep.Publish(ctx, tx, eventType, updateTag.ID, tagData.Type, nil)
+	}
+	return nil
+}
+
+func (t *AdminTagsServiceStruct) RemoveAdminTagFromHierarchy(ctx context.Context, tagGroup *string, tags *domain.RemoveHierarchy) (tagResponse *domain.TagResponse, err error) {
 	switch *tagGroup {
 	case domain.TagGroupEnum.Curriculum:
-		return t.removeCurriculumTagHierarchy(tags)
+		tagResponse, err = t.removeCurriculumTagHierarchy(ctx, tags)
 	case domain.TagGroupEnum.Content:
-		return t.removeContentTagHierarchy(tags)
+		tagResponse, err = t.removeContentTagHierarchy(ctx, tags)
 	}
 	return
 }
 
-func (t *AdminTagsServiceStruct) UpdateMultipleAdminTags(tags *domain.UpdateMultipleTags) (ids []*string, err error) {
+func (t *AdminTagsServiceStruct) UpdateMultipleAdminTags(ctx context.Context, tags *domain.UpdateMultipleTags) (ids []*string, err error) {
 	if len(tags.Hierarchy) == 0 {
 		return nil, noonerror.New(noonerror.ErrBadRequest, "hierarchyAbsent")
 	}
-	tagHierarchySlice, err := t.ts.GetTagsConcurrent(tags.Hierarchy)
+	tagHierarchySlice, err := t.ts.GetTagsConcurrent(ctx, tags.Hierarchy)
 	if err != nil {
 		return
 	}
@@ -168,7 +263,6 @@ func (t *AdminTagsServiceStruct) UpdateMultipleAdminTags(tags *domain.UpdateMult
 					return
 				}
 			}()
-			ctx := context.Background()
 			tx, err := repository.Db.BeginTx(ctx, nil)
 			if err != nil {
 				failureIds <- id
@@ -176,7 +270,7 @@ func (t *AdminTagsServiceStruct) UpdateMultipleAdminTags(tags *domain.UpdateMult
 				wg.Done()
 				return
 			}
-			parentTagMappings, err := t.ts.FetchParentTagMappings(id)
+			parentTagMappings, err := t.ts.FetchParentTagMappings(ctx, id)
 			if err != nil {
 				failureIds <- id
 				errChan <- err
@@ -209,15 +303,15 @@ func (t *AdminTagsServiceStruct) UpdateMultipleAdminTags(tags *domain.UpdateMult
 				}
 			}
 			rollback := func() {
-				go func() {
-					_ = t.es.RemoveParentTags(id, addTags)
-					_ = t.es.HideParentTags(id, hiddenTags)
-				}()
+				go func(ctx context.Context) {
+					_ = t.es.RemoveParentTags(ctx, id, addTags)
+					_ = t.es.HideParentTags(ctx, id, hiddenTags)
+				}(helper.Detach(ctx))
 				failureIds <- id
 				errChan <- err
 				wg.Done()
 			}
-			err = t.es.AddParentTags(id, allParents)
+			err = t.es.AddParentTags(ctx, id, allParents)
 			if err != nil {
 				rollback()
 				return
@@ -229,7 +323,7 @@ func (t *AdminTagsServiceStruct) UpdateMultipleAdminTags(tags *domain.UpdateMult
 				if val == *parentHideOrderTags {
 					order = constant.OrderMax
 				}
-				if err = t.ts.CreateParentTagMapping(tx, &domain.ParentTagMapping{TagID: id, TagType: tags.Type, ParentTagType: &key, ParentTagID: &val, Order: &order, Hidden: false, Publish: true, CreatedAt: time.Now(), UpdatedAt: time.Now()}); err != nil {
+				if err = t.ts.CreateParentTagMapping(ctx, tx, &domain.ParentTagMapping{TagID: id, TagType: tags.Type, ParentTagType: &key, ParentTagID: &val, Order: &order, Hidden: false, Publish: true, CreatedAt: time.Now(), UpdatedAt: time.Now()}); err != nil {
 					_ = tx.Rollback()
 					rollback()
 					return
@@ -237,12 +331,17 @@ func (t *AdminTagsServiceStruct) UpdateMultipleAdminTags(tags *domain.UpdateMult
 			}
 			for k := range hiddenTagsMap {
 				key := k
-				if err = t.ts.ToggleHideParentTagMapping(tx, false, id, &key); err != nil {
+				if err = t.ts.ToggleHideParentTagMapping(ctx, tx, false, id, &key); err != nil {
 					_ = tx.Rollback()
 					rollback()
 					return
 				}
 			}
+			if err = t.ep.Publish(ctx, tx, domain.TagEventTypeEnum.HierarchyAdded, id, tags.Type, &domain.TagHierarchyEventData{Hierarchy: tags.Hierarchy}); err != nil {
+				_ = tx.Rollback()
+				rollback()
+				return
+			}
 			if err = tx.Commit(); err != nil {
 				rollback()
 				return
@@ -267,7 +366,7 @@ func (t *AdminTagsServiceStruct) UpdateMultipleAdminTags(tags *domain.UpdateMult
 		}
 	}
 	if tagHierarchy.IsOrdered && len(tagHierarchySlice) > 0 {
-		tagOrders, _ := t.ts.FetchTagOrders(parentHideOrderTags, tags.Type)
+		tagOrders, _ := t.ts.FetchTagOrders(ctx, parentHideOrderTags, tags.Type)
 		maxOrder := 0
 		for _, v := range tagOrders {
 			_, ok := successIdMap[*v.TagID]
@@ -287,42 +386,42 @@ func (t *AdminTagsServiceStruct) UpdateMultipleAdminTags(tags *domain.UpdateMult
 				orders = append(orders, &domain.Order{Order: &order, SqlId: &sqlId})
 			}
 		}
-		ctx := context.Background()
 		tx, err := repository.Db.BeginTx(ctx, nil)
 		if err != nil {
 			return successIdsString, nil
 		}
-		if err = t.ts.UpdateTagOrders(tx, orders, parentHideOrderTags, tags.Type); err != nil {
+		if err = t.ts.UpdateTagOrders(ctx, tx, orders, parentHideOrderTags, tags.Type, nil); err != nil {
 			return successIdsString, nil
 		}
 		if err = tx.Commit(); err != nil {
 			return successIdsString, nil
 		}
+		t.evictTagOrders(ctx, parentHideOrderTags, tags.Type)
 	}
 	return successIdsString, nil
 }
 
-func (t *AdminTagsServiceStruct) updateCurriculumTagOrder(tags *domain.UpdateTagOrder) (err error) {
+func (t *AdminTagsServiceStruct) updateCurriculumTagOrder(ctx context.Context, tags *domain.UpdateTagOrder) (parentTags *string, err error) {
 	curriculum, err := flow.GetCurriculum(tags.CurriculumType)
 	if err != nil {
 		return
 	}
 	curriculumObject, ok := curriculum[*tags.Type]
 	if !ok {
-		return noonerror.New(noonerror.ErrBadRequest, "tagTypeInvalid")
+		return nil, noonerror.New(noonerror.ErrBadRequest, "tagTypeInvalid")
 	}
 	if !curriculumObject.IsOrdered {
-		return noonerror.New(noonerror.ErrBadRequest, "orderingNotAllowed")
+		return nil, noonerror.New(noonerror.ErrBadRequest, "orderingNotAllowed")
 	}
-	tagHierarchySlice, err := t.ts.GetTagsConcurrent(tags.Hierarchy)
+	tagHierarchySlice, err := t.ts.GetTagsConcurrent(ctx, tags.Hierarchy)
 	if err != nil {
 		return
 	}
-	parentTags, err := verifyAndFetchParentCurriculumTags(tags.CurriculumType, tagHierarchySlice, curriculumObject.Level)
+	parentTags, err = verifyAndFetchParentCurriculumTags(tags.CurriculumType, tagHierarchySlice, curriculumObject.Level)
 	if err != nil {
 		return
 	}
-	tagOrderData, err := t.ts.FetchTagOrders(parentTags, tags.Type)
+	tagOrderData, err := t.ts.FetchTagOrders(ctx, parentTags, tags.Type)
 	if err != nil {
 		return
 	}
@@ -336,33 +435,33 @@ func (t *AdminTagsServiceStruct) updateCurriculumTagOrder(tags *domain.UpdateTag
 			}
 		}
 		if !orderFound {
-			return noonerror.New(noonerror.ErrBadRequest, "tagIdMissing")
+			return nil, noonerror.New(noonerror.ErrBadRequest, "tagIdMissing")
 		}
 	}
-	return t.ts.UpdateTagOrders(nil, tags.Orders, parentTags, tags.Type)
+	return parentTags, t.applyTagOrders(ctx, tags, parentTags)
 }
 
-func (t *AdminTagsServiceStruct) updateContentTagOrder(tags *domain.UpdateTagOrder) (err error) {
+func (t *AdminTagsServiceStruct) updateContentTagOrder(ctx context.Context, tags *domain.UpdateTagOrder) (parentTags *string, err error) {
 	curriculum, err := flow.GetCurriculum(tags.CurriculumType)
 	if err != nil {
 		return
 	}
 	curriculumObject, ok := curriculum[*tags.Type]
 	if !ok {
-		return noonerror.New(noonerror.ErrBadRequest, "tagTypeInvalid")
+		return nil, noonerror.New(noonerror.ErrBadRequest, "tagTypeInvalid")
 	}
 	if !curriculumObject.IsOrdered {
-		return noonerror.New(noonerror.ErrBadRequest, "orderingNotAllowed")
+		return nil, noonerror.New(noonerror.ErrBadRequest, "orderingNotAllowed")
 	}
-	tagHierarchySlice, err := t.ts.GetTagsConcurrent(tags.Hierarchy)
+	tagHierarchySlice, err := t.ts.GetTagsConcurrent(ctx, tags.Hierarchy)
 	if err != nil {
 		return
 	}
 	_, parentHideOrderTags, _, err := verifyAndFetchParentCurriculumTagsForContent(tags.CurriculumType, tags.Type, tagHierarchySlice, constant.WriteAccessType)
 	if err != nil {
-		return err
+		return nil, err
 	}
-	tagOrderData, err := t.ts.FetchTagOrders(parentHideOrderTags, tags.Type)
+	tagOrderData, err := t.ts.FetchTagOrders(ctx, parentHideOrderTags, tags.Type)
 	if err != nil {
 		return
 	}
@@ -376,24 +475,63 @@ func (t *AdminTagsServiceStruct) updateContentTagOrder(tags *domain.UpdateTagOrd
 			}
 		}
 		if !orderFound {
-			return noonerror.New(noonerror.ErrBadRequest, "tagIdMissing")
+			return nil, noonerror.New(noonerror.ErrBadRequest, "tagIdMissing")
+		}
+	}
+	return parentHideOrderTags, t.applyTagOrders(ctx, tags, parentHideOrderTags)
+}
+
+// applyTagOrders reorders the sibling set in one transaction, so that the orders commit together with the version check
+// and their events
+func (t *AdminTagsServiceStruct) applyTagOrders(ctx context.Context, tags *domain.UpdateTagOrder, parentTags *string) (err error) {
+	tx, err := repository.Db.BeginTx(ctx, nil)
+	if err != nil {
+		return noonerror.New(noonerror.ErrInternalServer, "contextCreationError")
+	}
+	if err = t.ts.UpdateTagOrders(ctx, tx, tags.Orders, parentTags, tags.Type, tags.Version); err != nil {
+		_ = tx.Rollback()
+		return
+	}
+	for _, v := range tags.Orders {
+		if err = t.ep.Publish(ctx, tx, domain.TagEventTypeEnum.Reordered, v.ID, tags.Type, &domain.TagReorderedEventData{ParentTags: parentTags, Order: v.Order}); err != nil {
+			_ = tx.Rollback()
+			return
 		}
 	}
-	return t.ts.UpdateTagOrders(nil, tags.Orders, parentHideOrderTags, tags.Type)
+	if err = tx.Commit(); err != nil {
+		return noonerror.New(noonerror.ErrInternalServer, "dbCommitError")
+	}
+	t.evictTagOrders(ctx, parentTags, tags.Type)
+	return
 }
 
-func (t *AdminTagsServiceStruct) RemoveIdentifierTag(id *string) (err error) {
-	tagData, err := t.ts.FetchTags(id)
+func (t *AdminTagsServiceStruct) RemoveIdentifierTag(ctx context.Context, id *string) (err error) {
+	tagData, err := t.ts.FetchTags(ctx, id)
 	if err != nil || tagData == nil {
 		return noonerror.New(noonerror.ErrBadRequest, "tagFetchError")
 	}
 	if tagData.TagGroup != domain.TagGroupEnum.Identifier {
 		return noonerror.New(noonerror.ErrBadRequest, "notIdentifier")
 	}
-	return t.ts.DeleteTags(nil, id)
+	tx, err := repository.Db.BeginTx(ctx, nil)
+	if err != nil {
+		return noonerror.New(noonerror.ErrInternalServer, "ContextCreationError")
+	}
+	if err = t.ts.DeleteTags(ctx, tx, id); err != nil {
+		_ = tx.Rollback()
+		return
+	}
+	if err = t.ep.Publish(ctx, tx, domain.TagEventTypeEnum.Deleted, id, tagData.Type, &domain.TagDeletedEventData{Name: tagData.Name}); err != nil {
+		_ = tx.Rollback()
+		return
+	}
+	if err = tx.Commit(); err != nil {
+		return noonerror.New(noonerror.ErrInternalServer, "dbCommitError")
+	}
+	return
 }
 
-func (t *AdminTagsServiceStruct) MigrateToElastic(start *string, end *string) (err error) {
+func (t *AdminTagsServiceStruct) MigrateToElastic(ctx context.Context, start *string, end *string) (err error) {
 	startInt, _ := strconv.Atoi(*start)
 	endInt, _ := strconv.Atoi(*end)
 	if startInt > endInt {
@@ -402,11 +540,11 @@ func (t *AdminTagsServiceStruct) MigrateToElastic(start *string, end *string) (e
 	for i := startInt; i <= endInt; i++ {
 		id := new(string)
 		*id = strconv.Itoa(i)
-		tagData, err := t.ts.FetchTags(id)
+		tagData, err := t.ts.FetchTags(ctx, id)
 		if err != nil || tagData == nil {
 			return noonerror.New(noonerror.ErrBadRequest, "tagFetchError")
 		}
-		parentTagMappings, err := t.ts.FetchParentTagMappings(id)
+		parentTagMappings, err := t.ts.FetchParentTagMappings(ctx, id)
 		if err != nil {
 			return noonerror.New(noonerror.ErrBadRequest, "parentTagsFetchError")
 		}
@@ -425,7 +563,7 @@ func (t *AdminTagsServiceStruct) MigrateToElastic(start *string, end *string) (e
 		if err != nil {
 			return err
 		}
-		err = t.es.CreateTag(createElasticEntity)
+		err = t.es.CreateTag(ctx, createElasticEntity)
 		if err != nil {
 			return err
 		}
@@ -434,33 +572,34 @@ func (t *AdminTagsServiceStruct) MigrateToElastic(start *string, end *string) (e
 			if v.Hidden {
 				hiddenParents = append(hiddenParents, v.ParentTagID)
 			}
-			if err := t.es.HideParentTags(id, hiddenParents); err != nil {
+			if err := t.es.HideParentTags(ctx, id, hiddenParents); err != nil {
 				return err
 			}
 		}
+		// the names are pushed again even when the document exists, which backfills their search keys
 		var tagNames []*domain.TagName
 		defaultLocale := constant.DefaultLocale
 		tagNames = append(tagNames, &domain.TagName{Locale: &defaultLocale, Value: tagData.Name})
 		if tagData.LocaleAvailable {
-			tagLocaleMappings, err := t.ts.FetchTagLocaleMappings(id)
+			tagLocaleMappings, err := t.ts.FetchTagLocaleMappings(ctx, id)
 			if err != nil {
 				return err
 			}
 			for _, val := range tagLocaleMappings {
 				tagNames = append(tagNames, &domain.TagName{Locale: val.Locale, Value: val.Name})
 			}
-			if err := t.es.UpdateTag(id, nil, tagNames); err != nil {
-				return err
-			}
+		}
+		if err := t.es.UpdateTag(ctx, id, nil, tagNames); err != nil {
+			return err
 		}
 	}
 	return
 }
 
-func (t *AdminTagsServiceStruct) GetTag(id *string) (tagResponse *domain.TagResponse, err error) {
+func (t *AdminTagsServiceStruct) GetTag(ctx context.Context, id *string) (tagResponse *domain.TagResponse, err error) {
 	tagResponse = new(domain.TagResponse)
 	var tagLocaleData []*domain.TagLocaleMapping
-	tagData, err := t.ts.FetchTags(id)
+	tagData, err := t.ts.FetchTags(ctx, id)
 	if err != nil {
 		return
 	}
@@ -468,8 +607,10 @@ func (t *AdminTagsServiceStruct) GetTag(id *string) (tagResponse *domain.TagResp
 	tagResponse.Type = tagData.Type
 	tagResponse.Name = tagData.Name
 	tagResponse.Attributes = tagData.Attributes
+	version := tagData.Version
+	tagResponse.Version = &version
 	if tagData.LocaleAvailable {
-		tagLocaleData, err = t.ts.FetchTagLocaleMappings(id)
+		tagLocaleData, err = t.ts.FetchTagLocaleMappings(ctx, id)
 		if err != nil {
 			return tagResponse, nil
 		}
@@ -484,19 +625,18 @@ func (t *AdminTagsServiceStruct) GetTag(id *string) (tagResponse *domain.TagResp
 	return tagResponse, nil
 }
 
-func (t *AdminTagsServiceStruct) UpdateTagLocale(action *string, tagLocale *domain.TagLocale) (err error) {
-	ctx := context.Background()
+func (t *AdminTagsServiceStruct) UpdateTagLocale(ctx context.Context, action *string, tagLocale *domain.TagLocale) (err error) {
 	tx, err := repository.Db.BeginTx(ctx, nil)
 	if err != nil {
 		return noonerror.New(noonerror.ErrInternalServer, "ContextCreationError")
 	}
-	tagData, err := t.ts.FetchTags(tagLocale.ID)
+	tagData, err := t.ts.FetchTags(ctx, tagLocale.ID)
 	if err != nil || tagData == nil {
 		return noonerror.New(noonerror.ErrBadRequest, "tagFetchError")
 	}
 	var tagLocaleData []*domain.TagLocaleMapping
 	if tagData.LocaleAvailable {
-		tagLocaleData, _ = t.ts.FetchTagLocaleMappings(tagLocale.ID)
+		tagLocaleData, _ = t.ts.FetchTagLocaleMappings(ctx, tagLocale.ID)
 	}
 	totalLocales := len(tagLocaleData)
 	tagLocaleMap := make(map[string][]*domain.TagLocaleMapping)
@@ -506,19 +646,19 @@ func (t *AdminTagsServiceStruct) UpdateTagLocale(action *string, tagLocale *doma
 	tagLocaleFinalMap[key] = append(tagLocaleFinalMap[key], &domain.TagName{Value: tagData.Name, Locale: &localDefaultLocale})
 	updated := false
 	for _, val := range tagLocaleData {
-		key := strings.ToLower(*val.Locale) + ":" + *val.CountryId
+		key := helper.NormalizeLocale(*val.Locale) + ":" + *val.CountryId
 		tagLocaleMap[key] = append(tagLocaleMap[key], val)
-		locale := strings.ToLower(*val.Locale)
+		locale := helper.NormalizeLocale(*val.Locale)
 		if key != constant.DefaultLocale+":"+"0" {
 			tagLocaleFinalMap[key] = append(tagLocaleFinalMap[key], &domain.TagName{Value: val.Name, Locale: &locale})
 		}
 	}
 	for _, val := range tagLocale.Locale {
-		key := strings.ToLower(*val.Locale) + ":" + *val.CountryId
+		key := helper.NormalizeLocale(*val.Locale) + ":" + *val.CountryId
 		_, ok := tagLocaleMap[key]
 		if ok {
 			for _, locale := range tagLocaleMap[key] {
-				if err = t.ts.DeleteTagLocaleMapping(tx, locale); err != nil {
+				if err = t.ts.DeleteTagLocaleMapping(ctx, tx, locale); err != nil {
 					_ = tx.Rollback()
 					return err
 				}
@@ -529,8 +669,8 @@ func (t *AdminTagsServiceStruct) UpdateTagLocale(action *string, tagLocale *doma
 			tagLocaleFinalMap[key] = nil
 		}
 		if *action == "add" {
-			locale := strings.ToLower(*val.Locale)
-			if err = t.ts.CreateTagLocaleMapping(tx, &domain.TagLocaleMapping{Locale: &locale, CountryId: val.CountryId, TagID: tagLocale.ID,
+			locale := helper.NormalizeLocale(*val.Locale)
+			if err = t.ts.CreateTagLocaleMapping(ctx, tx, &domain.TagLocaleMapping{Locale: &locale, CountryId: val.CountryId, TagID: tagLocale.ID,
 				Name: tagLocale.Name, TagType: tagData.Type, Publish: true, CreatedAt: time.Now(), UpdatedAt: time.Now()}); err != nil {
 				_ = tx.Rollback()
 				return err
@@ -541,13 +681,13 @@ func (t *AdminTagsServiceStruct) UpdateTagLocale(action *string, tagLocale *doma
 		}
 	}
 	if !tagData.LocaleAvailable && totalLocales > 0 {
-		if err = t.ts.UpdateLocale(tx, true, tagLocale.ID); err != nil {
+		if err = t.ts.UpdateLocale(ctx, tx, true, tagLocale.ID); err != nil {
 			_ = tx.Rollback()
 			return err
 		}
 	}
 	if tagData.LocaleAvailable && totalLocales == 0 {
-		if err = t.ts.UpdateLocale(tx, false, tagLocale.ID); err != nil {
+		if err = t.ts.UpdateLocale(ctx, tx, false, tagLocale.ID); err != nil {
 			_ = tx.Rollback()
 			return err
 		}
@@ -559,7 +699,20 @@ func (t *AdminTagsServiceStruct) UpdateTagLocale(action *string, tagLocale *doma
 				tagLocales = append(tagLocales, v...)
 			}
 		}
-		if err = t.es.UpdateTag(tagLocale.ID, nil, tagLocales); err != nil {
+		if err = t.es.UpdateTag(ctx, tagLocale.ID, nil, tagLocales); err != nil {
+			_ = tx.Rollback()
+			return err
+		}
+		localeChanged := &domain.TagLocaleChangedEventData{Action: *action}
+		for _, val := range tagLocale.Locale {
+			locale := helper.NormalizeLocale(*val.Locale)
+			eventLocale := &domain.TagEventLocale{Locale: &locale, CountryId: val.CountryId}
+			if *action == domain.TagLocaleActionEnum.Add {
+				eventLocale.Name = tagLocale.Name
+			}
+			localeChanged.Locales = append(localeChanged.Locales, eventLocale)
+		}
+		if err = t.ep.Publish(ctx, tx, domain.TagEventTypeEnum.LocaleChanged, tagLocale.ID, tagData.Type, localeChanged); err != nil {
 			_ = tx.Rollback()
 			return err
 		}
@@ -570,8 +723,7 @@ func (t *AdminTagsServiceStruct) UpdateTagLocale(action *string, tagLocale *doma
 	return
 }
 
-func (t *AdminTagsServiceStruct) createCurriculumTag(tags *domain.CreateTags) (tagResponse *domain.TagResponse, err error) {
-	ctx := context.Background()
+func (t *AdminTagsServiceStruct) createCurriculumTag(ctx context.Context, tags *domain.CreateTags) (tagResponse *domain.TagResponse, err error) {
 	tx, err := repository.Db.BeginTx(ctx, nil)
 	if err != nil {
 		return nil, noonerror.New(noonerror.ErrInternalServer, "ContextCreationError")
@@ -579,7 +731,7 @@ func (t *AdminTagsServiceStruct) createCurriculumTag(tags *domain.CreateTags) (t
 	if len(tags.Hierarchy) == 0 {
 		return nil, noonerror.New(noonerror.ErrBadRequest, "hierarchyAbsent")
 	}
-	tagHierarchySlice, err := t.ts.GetTagsConcurrent(tags.Hierarchy)
+	tagHierarchySlice, err := t.ts.GetTagsConcurrent(ctx, tags.Hierarchy)
 	if err != nil {
 		return
 	}
@@ -609,7 +761,7 @@ func (t *AdminTagsServiceStruct) createCurriculumTag(tags *domain.CreateTags) (t
 	if len(tags.Access) == 0 {
 		tags.Access = domain.AccessEnum.Global
 	}
-	tagId, err := t.ts.CreateTags(tx, &domain.Tags{Type: tags.Type, Name: tags.Name, CurriculumType: *mappedCurriculumType,
+	tagId, err := t.ts.CreateTags(ctx, tx, &domain.Tags{Type: tags.Type, Name: tags.Name, CurriculumType: *mappedCurriculumType,
 		CreatorId: tags.CreatorId, CreatorType: *tags.CreatorType, Access: tags.Access, TagGroup: *tags.TagGroup, LocaleAvailable: false, CountryId: tags.CountryId,
 		Attributes: tags.Attributes, Publish: true, CreatedAt: time.Now(), UpdatedAt: time.Now()})
 	if err != nil {
@@ -622,32 +774,36 @@ func (t *AdminTagsServiceStruct) createCurriculumTag(tags *domain.CreateTags) (t
 	}
 	rollback := func() {
 		deleteTag := true
-		go func() {
-			_ = t.es.UpdateTag(tagId, &deleteTag, nil)
-		}()
+		go func(ctx context.Context) {
+			_ = t.es.UpdateTag(ctx, tagId, &deleteTag, nil)
+		}(helper.Detach(ctx))
 		_ = tx.Rollback()
 		return
 	}
-	if err = t.es.CreateTag(createElasticEntity); err != nil {
+	if err = t.es.CreateTag(ctx, createElasticEntity); err != nil {
 		rollback()
 	}
-	if err = t.es.HideParentTags(tagId, []*string{parentTags}); err != nil {
+	if err = t.es.HideParentTags(ctx, tagId, []*string{parentTags}); err != nil {
 		rollback()
 	}
 	order := 0
-	if order, err = t.fetchTagOrders(tagHierarchy.IsOrdered, parentTags, tags.Type, rollback); err != nil {
+	if order, err = t.fetchTagOrders(ctx, tagHierarchy.IsOrdered, parentTags, tags.Type, rollback); err != nil {
 		return
 	}
-	if err = t.ts.CreateParentTagMapping(tx, &domain.ParentTagMapping{TagID: tagId, TagType: tags.Type, ParentTagType: &hierarchyType, ParentTagID: parentTags, Order: &order, Hidden: true, Publish: true, CreatedAt: time.Now(), UpdatedAt: time.Now()});
+	if err = t.ts.CreateParentTagMapping(ctx, tx, &domain.ParentTagMapping{TagID: tagId, TagType: tags.Type, ParentTagType: &hierarchyType, ParentTagID: parentTags, Order: &order, Hidden: true, Publish: true, CreatedAt: time.Now(), UpdatedAt: time.Now()});
 		err != nil {
 		rollback()
 		return
 	}
+	if err = t.publishCreated(ctx, tx, tagId, tags); err != nil {
+		rollback()
+		return nil, err
+	}
 	if err = tx.Commit(); err != nil {
 		deleteTag := true
-		go func() {
-			_ = t.es.UpdateTag(tagId, &deleteTag, nil)
-		}()
+		go func(ctx context.Context) {
+			_ = t.es.UpdateTag(ctx, tagId, &deleteTag, nil)
+		}(helper.Detach(ctx))
 		return nil, noonerror.New(noonerror.ErrInternalServer, "dbCommitError")
 	}
 	tagResponse = &domain.TagResponse{
@@ -659,11 +815,11 @@ func (t *AdminTagsServiceStruct) createCurriculumTag(tags *domain.CreateTags) (t
 	return tagResponse, nil
 }
 
-func (t *AdminTagsServiceStruct) fetchTagOrders(ordered bool, parentTags *string, tagType *string, rollback func()) (order int, err error) {
+func (t *AdminTagsServiceStruct) fetchTagOrders(ctx context.Context, ordered bool, parentTags *string, tagType *string, rollback func()) (order int, err error) {
 	if !ordered {
 		return
 	}
-	tagOrders, _ := t.ts.FetchTagOrders(parentTags, tagType)
+	tagOrders, _ := t.ts.FetchTagOrders(ctx, parentTags, tagType)
 	if len(tagOrders) == constant.TagLimit {
 		rollback()
 		return 0, noonerror.New(noonerror.ErrBadRequest, "tagLimitReached")
@@ -678,8 +834,7 @@ func (t *AdminTagsServiceStruct) fetchTagOrders(ordered bool, parentTags *string
 	return order, nil
 }
 
-func (t *AdminTagsServiceStruct) createContentTag(tags *domain.CreateTags) (tagResponse *domain.TagResponse, err error) {
-	ctx := context.Background()
+func (t *AdminTagsServiceStruct) createContentTag(ctx context.Context, tags *domain.CreateTags) (tagResponse *domain.TagResponse, err error) {
 	tx, err := repository.Db.BeginTx(ctx, nil)
 	if err != nil {
 		return nil, noonerror.New(noonerror.ErrInternalServer, "ContextCreationError")
@@ -687,13 +842,13 @@ func (t *AdminTagsServiceStruct) createContentTag(tags *domain.CreateTags) (tagR
 	if len(tags.Hierarchy) == 0 {
 		return nil, noonerror.New(noonerror.ErrBadRequest, "hierarchyAbsent")
 	}
-	tagHierarchySlice, err := t.ts.GetTagsConcurrent(tags.Hierarchy)
+	tagHierarchySlice, err := t.ts.GetTagsConcurrent(ctx, tags.Hierarchy)
 	if err != nil {
 		return
 	}
 	var identifierSlice []*domain.Tags
 	if tags.Identifier != nil && len(tags.Identifier) > 0 {
-		identifierSlice, err = t.ts.GetTagsConcurrent(tags.Identifier)
+		identifierSlice, err = t.ts.GetTagsConcurrent(ctx, tags.Identifier)
 		if err != nil {
 			return
 		}
@@ -716,7 +871,7 @@ func (t *AdminTagsServiceStruct) createContentTag(tags *domain.CreateTags) (tagR
 		return
 	}
 	tags.CountryId = "0"
-	tagId, err := t.ts.CreateTags(tx, &domain.Tags{Type: tags.Type, Name: tags.Name, CurriculumType: *mappedCurriculumType,
+	tagId, err := t.ts.CreateTags(ctx, tx, &domain.Tags{Type: tags.Type, Name: tags.Name, CurriculumType: *mappedCurriculumType,
 		CreatorId: tags.CreatorId, CreatorType: *tags.CreatorType, Access: domain.AccessEnum.Global, TagGroup: *tags.TagGroup, LocaleAvailable: false, CountryId: tags.CountryId,
 		Attributes: tags.Attributes, Publish: true, CreatedAt: time.Now(), UpdatedAt: time.Now()})
 	if err != nil {
@@ -753,26 +908,26 @@ func (t *AdminTagsServiceStruct) createContentTag(tags *domain.CreateTags) (tagR
 	}
 	rollback := func() {
 		deleteTag := true
-		go func() {
-			_ = t.es.UpdateTag(tagId, &deleteTag, nil)
-		}()
+		go func(ctx context.Context) {
+			_ = t.es.UpdateTag(ctx, tagId, &deleteTag, nil)
+		}(helper.Detach(ctx))
 		_ = tx.Rollback()
 	}
 	createElasticEntity, err := dtomapper.CreateElasticTagEntity(tagId, tags, allParentTags, domain.AccessEnum.Global)
 	if err != nil {
 		return
 	}
-	if err = t.es.CreateTag(createElasticEntity); err != nil {
+	if err = t.es.CreateTag(ctx, createElasticEntity); err != nil {
 		rollback()
 		return
 	}
-	err = t.es.HideParentTags(tagId, []*string{parentHideOrderTags})
+	err = t.es.HideParentTags(ctx, tagId, []*string{parentHideOrderTags})
 	if err != nil {
 		rollback()
 		return
 	}
 	order := 0
-	if order, err = t.fetchTagOrders(tagHierarchy.IsOrdered, parentHideOrderTags, tags.Type, rollback); err != nil {
+	if order, err = t.fetchTagOrders(ctx, tagHierarchy.IsOrdered, parentHideOrderTags, tags.Type, rollback); err != nil {
 		return
 	}
 	for k, v := range parentIdMap {
@@ -783,16 +938,20 @@ func (t *AdminTagsServiceStruct) createContentTag(tags *domain.CreateTags) (tagR
 			hidden = true
 			orderToApply = order
 		}
-		if err = t.ts.CreateParentTagMapping(tx, &domain.ParentTagMapping{TagID: tagId, TagType: tags.Type, ParentTagType: &key, ParentTagID: v, Order: &orderToApply, Hidden: hidden, Publish: true, CreatedAt: time.Now(), UpdatedAt: time.Now()}); err != nil {
+		if err = t.ts.CreateParentTagMapping(ctx, tx, &domain.ParentTagMapping{TagID: tagId, TagType: tags.Type, ParentTagType: &key, ParentTagID: v, Order: &orderToApply, Hidden: hidden, Publish: true, CreatedAt: time.Now(), UpdatedAt: time.Now()}); err != nil {
 			rollback()
 			return nil, err
 		}
 	}
+	if err = t.publishCreated(ctx, tx, tagId, tags); err != nil {
+		rollback()
+		return nil, err
+	}
 	if err = tx.Commit(); err != nil {
 		deleteTag := true
-		go func() {
-			_ = t.es.UpdateTag(tagId, &deleteTag, nil)
-		}()
+		go func(ctx context.Context) {
+			_ = t.es.UpdateTag(ctx, tagId, &deleteTag, nil)
+		}(helper.Detach(ctx))
 		return nil, noonerror.New(noonerror.ErrInternalServer, "dbCommitError")
 	}
 	tagResponse = &domain.TagResponse{
@@ -804,8 +963,7 @@ func (t *AdminTagsServiceStruct) createContentTag(tags *domain.CreateTags) (tagR
 	return tagResponse, nil
 }
 
-func (t *AdminTagsServiceStruct) createIdentifierTag(tags *domain.CreateTags) (tagResponse *domain.TagResponse, err error) {
-	ctx := context.Background()
+func (t *AdminTagsServiceStruct) createIdentifierTag(ctx context.Context, tags *domain.CreateTags) (tagResponse *domain.TagResponse, err error) {
 	tx, err := repository.Db.BeginTx(ctx, nil)
 	if err != nil {
 		return nil, noonerror.New(noonerror.ErrInternalServer, "ContextCreationError")
@@ -816,7 +974,7 @@ func (t *AdminTagsServiceStruct) createIdentifierTag(tags *domain.CreateTags) (t
 		return
 	}
 	tags.CountryId = "0"
-	tagId, err := t.ts.CreateTags(tx, &domain.Tags{Type: tags.Type, Name: tags.Name, CurriculumType: *mappedCurriculumType,
+	tagId, err := t.ts.CreateTags(ctx, tx, &domain.Tags{Type: tags.Type, Name: tags.Name, CurriculumType: *mappedCurriculumType,
 		CreatorId: tags.CreatorId, CreatorType: *tags.CreatorType, Access: domain.AccessEnum.Global, TagGroup: *tags.TagGroup, LocaleAvailable: false, CountryId: tags.CountryId,
 		Attributes: tags.Attributes, Publish: true, CreatedAt: time.Now(), UpdatedAt: time.Now()})
 	if err != nil {
@@ -827,19 +985,27 @@ func (t *AdminTagsServiceStruct) createIdentifierTag(tags *domain.CreateTags) (t
 	if err != nil {
 		return
 	}
-	if err = t.es.CreateTag(createElasticEntity); err != nil {
+	if err = t.es.CreateTag(ctx, createElasticEntity); err != nil {
 		deleteTag := true
-		go func() {
-			_ = t.es.UpdateTag(tagId, &deleteTag, nil)
-		}()
+		go func(ctx context.Context) {
+			_ = t.es.UpdateTag(ctx, tagId, &deleteTag, nil)
+		}(helper.Detach(ctx))
 		_ = tx.Rollback()
 		return
 	}
+	if err = t.publishCreated(ctx, tx, tagId, tags); err != nil {
+		deleteTag := true
+		go func(ctx context.Context) {
+			_ = t.es.UpdateTag(ctx, tagId, &deleteTag, nil)
+		}(helper.Detach(ctx))
+		_ = tx.Rollback()
+		return nil, err
+	}
 	if err = tx.Commit(); err != nil {
 		deleteTag := true
-		go func() {
-			_ = t.es.UpdateTag(tagId, &deleteTag, nil)
-		}()
+		go func(ctx context.Context) {
+			_ = t.es.UpdateTag(ctx, tagId, &deleteTag, nil)
+		}(helper.Detach(ctx))
 		return nil, noonerror.New(noonerror.ErrInternalServer, "dbCommitError")
 	}
 	tagResponse = &domain.TagResponse{
@@ -850,8 +1016,7 @@ func (t *AdminTagsServiceStruct) createIdentifierTag(tags *domain.CreateTags) (t
 	return tagResponse, nil
 }
 
-func (t *AdminTagsServiceStruct) updateCurriculumTag(tags *domain.UpdateTags) (tagResponse *domain.TagResponse, err error) {
-	ctx := context.Background()
+func (t *AdminTagsServiceStruct) updateCurriculumTag(ctx context.Context, tags *domain.UpdateTags) (tagResponse *domain.TagResponse, err error) {
 	tx, err := repository.Db.BeginTx(ctx, nil)
 	if err != nil {
 		return nil, noonerror.New(noonerror.ErrInternalServer, "ContextCreationError")
@@ -859,14 +1024,14 @@ func (t *AdminTagsServiceStruct) updateCurriculumTag(tags *domain.UpdateTags) (t
 	if len(tags.Hierarchy) == 0 {
 		return nil, noonerror.New(noonerror.ErrBadRequest, "hierarchyAbsent")
 	}
-	tagData, err := t.ts.FetchTags(tags.ID)
+	tagData, err := t.ts.FetchTags(ctx, tags.ID)
 	if err != nil || tagData == nil {
 		return nil, noonerror.New(noonerror.ErrBadRequest, "tagFetchError")
 	}
 	if *tags.TagGroup != tagData.TagGroup {
 		return nil, noonerror.New(noonerror.ErrBadRequest, "tagGroupMismatch")
 	}
-	tagHierarchySlice, err := t.ts.GetTagsConcurrent(tags.Hierarchy)
+	tagHierarchySlice, err := t.ts.GetTagsConcurrent(ctx, tags.Hierarchy)
 	if err != nil {
 		return
 	}
@@ -886,7 +1051,7 @@ func (t *AdminTagsServiceStruct) updateCurriculumTag(tags *domain.UpdateTags) (t
 		return
 	}
 	hierarchyType := constant.HierarchyCurriculum
-	parentTagMapping, err := t.ts.FetchParentTagMappingByParentTagIdTagId(tags.ID, parentTags)
+	parentTagMapping, err := t.ts.FetchParentTagMappingByParentTagIdTagId(ctx, tags.ID, parentTags)
 	if err != nil {
 		return
 	}
@@ -898,38 +1063,44 @@ func (t *AdminTagsServiceStruct) updateCurriculumTag(tags *domain.UpdateTags) (t
 	}
 	rollback := func() {
 		if len(hierarchyHiddenId) == 0 {
-			go func() {
-				_ = t.es.RemoveParentTags(tags.ID, []*string{parentTags})
-			}()
+			go func(ctx context.Context) {
+				_ = t.es.RemoveParentTags(ctx, tags.ID, []*string{parentTags})
+			}(helper.Detach(ctx))
 		} else {
-			go func() {
-				_ = t.es.HideParentTags(tags.ID, []*string{parentTags})
-			}()
+			go func(ctx context.Context) {
+				_ = t.es.HideParentTags(ctx, tags.ID, []*string{parentTags})
+			}(helper.Detach(ctx))
 		}
 	}
-	if err = t.es.AddParentTags(tags.ID, []*string{parentTags}); err != nil {
+	if err = t.es.AddParentTags(ctx, tags.ID, []*string{parentTags}); err != nil {
 		rollback()
 		return
 	}
 	order := 0
 	if len(hierarchyHiddenId) == 0 {
-		if order, err = t.fetchTagOrders(tagHierarchy.IsOrdered, parentTags, tagData.Type, rollback); err != nil {
+		if order, err = t.fetchTagOrders(ctx, tagHierarchy.IsOrdered, parentTags, tagData.Type, rollback); err != nil {
 			return
 		}
-		if err = t.es.HideParentTags(tags.ID, []*string{parentTags}); err != nil {
+		if err = t.es.HideParentTags(ctx, tags.ID, []*string{parentTags}); err != nil {
 			rollback()
 			return
 		}
-		if err = t.ts.CreateParentTagMapping(tx, &domain.ParentTagMapping{TagID: tags.ID, TagType: tagData.Type, ParentTagType: &hierarchyType, ParentTagID: parentTags, Order: &order, Hidden: true, Publish: true, CreatedAt: time.Now(), UpdatedAt: time.Now()}); err != nil {
+		if err = t.ts.CreateParentTagMapping(ctx, tx, &domain.ParentTagMapping{TagID: tags.ID, TagType: tagData.Type, ParentTagType: &hierarchyType, ParentTagID: parentTags, Order: &order, Hidden: true, Publish: true, CreatedAt: time.Now(), UpdatedAt: time.Now()}); err != nil {
 			rollback()
 			return
 		}
 	} else {
-		if err = t.ts.ToggleHideParentTagMapping(tx, false, tags.ID, &hierarchyHiddenId); err != nil {
+		if err = t.ts.ToggleHideParentTagMapping(ctx, tx, false, tags.ID, &hierarchyHiddenId); err != nil {
 			rollback()
 			return
 		}
 	}
+	if err = t.ep.Publish(ctx, tx, domain.TagEventTypeEnum.HierarchyAdded, tags.ID, tagData.Type, &domain.TagHierarchyEventData{Hierarchy: tags.Hierarchy,
+		Identifier: tags.Identifier}); err != nil {
+		rollback()
+		_ = tx.Rollback()
+		return nil, err
+	}
 	if err = tx.Commit(); err != nil {
 		rollback()
 		return nil, noonerror.New(noonerror.ErrInternalServer, "dbCommitError")
@@ -947,26 +1118,25 @@ func (t *AdminTagsServiceStruct) updateCurriculumTag(tags *domain.UpdateTags) (t
 	return tagResponse, nil
 }
 
-func (t *AdminTagsServiceStruct) updateContentTag(tags *domain.UpdateTags) (tagResponse *domain.TagResponse, err error) {
-	ctx := context.Background()
+func (t *AdminTagsServiceStruct) updateContentTag(ctx context.Context, tags *domain.UpdateTags) (tagResponse *domain.TagResponse, err error) {
 	tx, err := repository.Db.BeginTx(ctx, nil)
 	if err != nil {
 		return nil, noonerror.New(noonerror.ErrInternalServer, "ContextCreationError")
 	}
-	tagData, err := t.ts.FetchTags(tags.ID)
+	tagData, err := t.ts.FetchTags(ctx, tags.ID)
 	if err != nil || tagData == nil {
 		return nil, noonerror.New(noonerror.ErrBadRequest, "tagFetchError")
 	}
 	if *tags.TagGroup != tagData.TagGroup {
 		return nil, noonerror.New(noonerror.ErrBadRequest, "tagGroupMismatch")
 	}
-	tagHierarchySlice, err := t.ts.GetTagsConcurrent(tags.Hierarchy)
+	tagHierarchySlice, err := t.ts.GetTagsConcurrent(ctx, tags.Hierarchy)
 	if err != nil {
 		return
 	}
 	var identifierSlice []*domain.Tags
 	if tags.Identifier != nil && len(tags.Identifier) > 0 {
-		identifierSlice, err = t.ts.GetTagsConcurrent(tags.Identifier)
+		identifierSlice, err = t.ts.GetTagsConcurrent(ctx, tags.Identifier)
 		if err != nil {
 			return
 		}
@@ -976,7 +1146,7 @@ func (t *AdminTagsServiceStruct) updateContentTag(tags *domain.UpdateTags) (tagR
 	if err != nil {
 		return
 	}
-	parentTagMappings, err := t.ts.FetchParentTagMappings(tags.ID)
+	parentTagMappings, err := t.ts.FetchParentTagMappings(ctx, tags.ID)
 	if err != nil {
 		return
 	}
@@ -1048,18 +1218,18 @@ func (t *AdminTagsServiceStruct) updateContentTag(tags *domain.UpdateTags) (tagR
 		allHiddenParentTags = append(allHiddenParentTags, v)
 	}
 	rollback := func() {
-		go func() {
-			_ = t.es.RemoveParentTags(tags.ID, allNonHiddenParentTags)
-			_ = t.es.HideParentTags(tags.ID, allHiddenParentTags)
-		}()
+		go func(ctx context.Context) {
+			_ = t.es.RemoveParentTags(ctx, tags.ID, allNonHiddenParentTags)
+			_ = t.es.HideParentTags(ctx, tags.ID, allHiddenParentTags)
+		}(helper.Detach(ctx))
 	}
-	if err = t.es.AddParentTags(tags.ID, allParentTagIds); err != nil {
+	if err = t.es.AddParentTags(ctx, tags.ID, allParentTagIds); err != nil {
 		rollback()
 		return
 	}
 	order := 0
 	if !orderHierarchyPresent {
-		if order, err = t.fetchTagOrders(tagHierarchy.IsOrdered, parentHideOrderTags, tagData.Type, rollback); err != nil {
+		if order, err = t.fetchTagOrders(ctx, tagHierarchy.IsOrdered, parentHideOrderTags, tagData.Type, rollback); err != nil {
 			return
 		}
 	}
@@ -1071,7 +1241,7 @@ func (t *AdminTagsServiceStruct) updateContentTag(tags *domain.UpdateTags) (tagR
 			parentOrder = order
 			parentHidden = true
 		}
-		if err = t.ts.CreateParentTagMapping(tx, &domain.ParentTagMapping{TagID: tags.ID, TagType: tagData.Type, ParentTagType: &key, Order: &parentOrder, ParentTagID: v, Hidden: parentHidden, Publish: true, CreatedAt: time.Now(), UpdatedAt: time.Now()}); err != nil {
+		if err = t.ts.CreateParentTagMapping(ctx, tx, &domain.ParentTagMapping{TagID: tags.ID, TagType: tagData.Type, ParentTagType: &key, Order: &parentOrder, ParentTagID: v, Hidden: parentHidden, Publish: true, CreatedAt: time.Now(), UpdatedAt: time.Now()}); err != nil {
 			rollback()
 			_ = tx.Rollback()
 			return nil, err
@@ -1079,12 +1249,18 @@ func (t *AdminTagsServiceStruct) updateContentTag(tags *domain.UpdateTags) (tagR
 	}
 	for k := range parentIdHiddenMap {
 		key := k
-		if err = t.ts.ToggleHideParentTagMapping(tx, false, tags.ID, &key); err != nil {
+		if err = t.ts.ToggleHideParentTagMapping(ctx, tx, false, tags.ID, &key); err != nil {
 			rollback()
 			_ = tx.Rollback()
 			return nil, err
 		}
 	}
+	if err = t.ep.Publish(ctx, tx, domain.TagEventTypeEnum.HierarchyAdded, tags.ID, tagData.Type, &domain.TagHierarchyEventData{Hierarchy: tags.Hierarchy,
+		Identifier: tags.Identifier}); err != nil {
+		rollback()
+		_ = tx.Rollback()
+		return nil, err
+	}
 	if err = tx.Commit(); err != nil {
 		rollback()
 		return nil, noonerror.New(noonerror.ErrInternalServer, "dbCommitError")
@@ -1102,8 +1278,7 @@ func (t *AdminTagsServiceStruct) updateContentTag(tags *domain.UpdateTags) (tagR
 	return tagResponse, nil
 }
 
-func (t *AdminTagsServiceStruct) removeCurriculumTagHierarchy(tags *domain.RemoveHierarchy) (tagResponse *domain.TagResponse, err error) {
-	ctx := context.Background()
+func (t *AdminTagsServiceStruct) removeCurriculumTagHierarchy(ctx context.Context, tags *domain.RemoveHierarchy) (tagResponse *domain.TagResponse, err error) {
 	tx, err := repository.Db.BeginTx(ctx, nil)
 	if err != nil {
 		return nil, noonerror.New(noonerror.ErrInternalServer, "ContextCreationError")
@@ -1111,14 +1286,14 @@ func (t *AdminTagsServiceStruct) removeCurriculumTagHierarchy(tags *domain.Remov
 	if len(tags.Hierarchy) == 0 {
 		return nil, noonerror.New(noonerror.ErrBadRequest, "hierarchyAbsent")
 	}
-	tagData, err := t.ts.FetchTags(tags.ID)
+	tagData, err := t.ts.FetchTags(ctx, tags.ID)
 	if err != nil || tagData == nil {
 		return nil, noonerror.New(noonerror.ErrBadRequest, "tagFetchError")
 	}
 	if *tags.TagGroup != tagData.TagGroup {
 		return nil, noonerror.New(noonerror.ErrBadRequest, "tagGroupMismatch")
 	}
-	tagHierarchySlice, err := t.ts.GetTagsConcurrent(tags.Hierarchy)
+	tagHierarchySlice, err := t.ts.GetTagsConcurrent(ctx, tags.Hierarchy)
 	if err != nil {
 		return
 	}
@@ -1139,28 +1314,36 @@ func (t *AdminTagsServiceStruct) removeCurriculumTagHierarchy(tags *domain.Remov
 		return
 	}
 	var allParents []*string
-	parentTagMapping, err := t.ts.FetchParentTagMappingByParentTagIdTagId(tags.ID, parentTags)
+	parentTagMapping, err := t.ts.FetchParentTagMappingByParentTagIdTagId(ctx, tags.ID, parentTags)
 	if err != nil {
 		return
 	}
 	if parentTagMapping != nil {
-		if err = t.ts.ToggleHideParentTagMapping(tx, true, tags.ID, parentTagMapping.ID); err != nil {
+		if err = t.ts.ToggleHideParentTagMapping(ctx, tx, true, tags.ID, parentTagMapping.ID); err != nil {
 			_ = tx.Rollback()
 			return nil, err
 		}
 		allParents = append(allParents, parentTags)
 	}
-	if err = t.es.HideParentTags(tags.ID, allParents); err != nil {
-		go func() {
-			_ = t.es.AddParentTags(tags.ID, allParents)
-		}()
+	if err = t.es.HideParentTags(ctx, tags.ID, allParents); err != nil {
+		go func(ctx context.Context) {
+			_ = t.es.AddParentTags(ctx, tags.ID, allParents)
+		}(helper.Detach(ctx))
+		_ = tx.Rollback()
+		return nil, err
+	}
+	if err = t.ep.Publish(ctx, tx, domain.TagEventTypeEnum.HierarchyRemoved, tags.ID, tagData.Type, &domain.TagHierarchyEventData{Hierarchy: tags.Hierarchy,
+		Identifier: tags.Identifier}); err != nil {
+		go func(ctx context.Context) {
+			_ = t.es.AddParentTags(ctx, tags.ID, allParents)
+		}(helper.Detach(ctx))
 		_ = tx.Rollback()
 		return nil, err
 	}
 	if err = tx.Commit(); err != nil {
-		go func() {
-			_ = t.es.AddParentTags(tags.ID, allParents)
-		}()
+		go func(ctx context.Context) {
+			_ = t.es.AddParentTags(ctx, tags.ID, allParents)
+		}(helper.Detach(ctx))
 		return nil, noonerror.New(noonerror.ErrInternalServer, "dbCommitError")
 	}
 	tagResponse = &domain.TagResponse{
@@ -1172,26 +1355,25 @@ func (t *AdminTagsServiceStruct) removeCurriculumTagHierarchy(tags *domain.Remov
 	return tagResponse, nil
 }
 
-func (t *AdminTagsServiceStruct) removeContentTagHierarchy(tags *domain.RemoveHierarchy) (tagResponse *domain.TagResponse, err error) {
-	ctx := context.Background()
+func (t *AdminTagsServiceStruct) removeContentTagHierarchy(ctx context.Context, tags *domain.RemoveHierarchy) (tagResponse *domain.TagResponse, err error) {
 	tx, err := repository.Db.BeginTx(ctx, nil)
 	if err != nil {
 		return nil, noonerror.New(noonerror.ErrInternalServer, "ContextCreationError")
 	}
-	tagData, err := t.ts.FetchTags(tags.ID)
+	tagData, err := t.ts.FetchTags(ctx, tags.ID)
 	if err != nil || tagData == nil {
 		return nil, noonerror.New(noonerror.ErrBadRequest, "tagFetchError")
 	}
 	if *tags.TagGroup != tagData.TagGroup {
 		return nil, noonerror.New(noonerror.ErrBadRequest, "tagGroupMismatch")
 	}
-	tagHierarchySlice, err := t.ts.GetTagsConcurrent(tags.Hierarchy)
+	tagHierarchySlice, err := t.ts.GetTagsConcurrent(ctx, tags.Hierarchy)
 	if err != nil {
 		return
 	}
 	var identifierSlice []*domain.Tags
 	if len(tags.Identifier) > 0 {
-		identifierSlice, err = t.ts.GetTagsConcurrent(tags.Identifier)
+		identifierSlice, err = t.ts.GetTagsConcurrent(ctx, tags.Identifier)
 		if err != nil {
 			return
 		}
@@ -1201,7 +1383,7 @@ func (t *AdminTagsServiceStruct) removeContentTagHierarchy(tags *domain.RemoveHi
 	if err != nil {
 		return
 	}
-	parentTagMappings, err := t.ts.FetchParentTagMappings(tags.ID)
+	parentTagMappings, err := t.ts.FetchParentTagMappings(ctx, tags.ID)
 	if err != nil {
 		return
 	}
@@ -1218,7 +1400,7 @@ func (t *AdminTagsServiceStruct) removeContentTagHierarchy(tags *domain.RemoveHi
 		for _, parentTagMapping := range parentTagMappings {
 			if *parentTagMapping.ParentTagID == *parentHideOrderTags {
 				allParents = append(allParents, parentTagMapping.ParentTagID)
-				err = t.ts.ToggleHideParentTagMapping(tx, true, tags.ID, parentTagMapping.ID)
+				err = t.ts.ToggleHideParentTagMapping(ctx, tx, true, tags.ID, parentTagMapping.ID)
 				if err != nil {
 					_ = tx.Rollback()
 					return nil, err
@@ -1231,7 +1413,7 @@ func (t *AdminTagsServiceStruct) removeContentTagHierarchy(tags *domain.RemoveHi
 			for _, parentTagMapping := range parentTagMappings {
 				if *parentTagMapping.ParentTagType == *v.Type && *parentTagMapping.ParentTagID == *v.ID {
 					allParents = append(allParents, parentTagMapping.ParentTagID)
-					err = t.ts.ToggleHideParentTagMapping(tx, true, tags.ID, parentTagMapping.ID)
+					err = t.ts.ToggleHideParentTagMapping(ctx, tx, true, tags.ID, parentTagMapping.ID)
 					if err != nil {
 						_ = tx.Rollback()
 						return
@@ -1240,18 +1422,26 @@ func (t *AdminTagsServiceStruct) removeContentTagHierarchy(tags *domain.RemoveHi
 			}
 		}
 	}
-	err = t.es.HideParentTags(tags.ID, allParents)
+	err = t.es.HideParentTags(ctx, tags.ID, allParents)
 	if err != nil {
-		go func() {
-			_ = t.es.AddParentTags(tags.ID, allParents)
-		}()
+		go func(ctx context.Context) {
+			_ = t.es.AddParentTags(ctx, tags.ID, allParents)
+		}(helper.Detach(ctx))
 		_ = tx.Rollback()
 		return
 	}
+	if err = t.ep.Publish(ctx, tx, domain.TagEventTypeEnum.HierarchyRemoved, tags.ID, tagData.Type, &domain.TagHierarchyEventData{Hierarchy: tags.Hierarchy,
+		Identifier: tags.Identifier}); err != nil {
+		go func(ctx context.Context) {
+			_ = t.es.AddParentTags(ctx, tags.ID, allParents)
+		}(helper.Detach(ctx))
+		_ = tx.Rollback()
+		return nil, err
+	}
 	if err = tx.Commit(); err != nil {
-		go func() {
-			_ = t.es.AddParentTags(tags.ID, allParents)
-		}()
+		go func(ctx context.Context) {
+			_ = t.es.AddParentTags(ctx, tags.ID, allParents)
+		}(helper.Detach(ctx))
 		return nil, noonerror.New(noonerror.ErrInternalServer, "dbCommitError")
 	}
 	tagResponse = &domain.TagResponse{
@@ -1263,7 +1453,7 @@ func (t *AdminTagsServiceStruct) removeContentTagHierarchy(tags *domain.RemoveHi
 	return tagResponse, nil
 }
 
-func (t *AdminTagsServiceStruct) getCurriculumTags(tags *domain.GetTags) (getTagResponse *domain.GetTagsResponse, err error) {
+func (t *AdminTagsServiceStruct) getCurriculumTags(ctx context.Context, tags *domain.GetTags) (getTagResponse *domain.GetTagsResponse, err error) {
 	var parents []*string
 	if tags.Hierarchy != nil {
 		parents = append(parents, tags.Hierarchy)
@@ -1272,9 +1462,9 @@ func (t *AdminTagsServiceStruct) getCurriculumTags(tags *domain.GetTags) (getTag
 	if err != nil {
 		return
 	}
-	filteredTags, next, err := t.es.GetTags(createElasticEntity)
+	filteredTags, next, err := t.es.GetTags(ctx, createElasticEntity)
 	hiddenSet := make(map[string]bool)
-	parentTagMappingData, err := t.ts.FetchByInParentTagMappingsByParentTagIdTagIds(filteredTags, tags.Hierarchy)
+	parentTagMappingData, err := t.ts.FetchByInParentTagMappingsByParentTagIdTagIds(ctx, filteredTags, tags.Hierarchy)
 	if err != nil {
 		return
 	}
@@ -1283,26 +1473,26 @@ func (t *AdminTagsServiceStruct) getCurriculumTags(tags *domain.GetTags) (getTag
 			hiddenSet[*v.TagID] = v.Hidden
 		}
 	}
-	tagData, err := t.ts.FetchByInTags(filteredTags)
+	tagData, err := t.ts.FetchByInTags(ctx, filteredTags)
 	if err != nil {
 		return
 	}
 	if *tags.MultiGrade == "false" {
 		tagData = filterMultiGradeTags(tagData)
 	}
-	tagData, err = t.ts.FetchTagLocaleMappingsByLocale(tagData, tags.CountryId, tags.Locale)
+	tagData, err = t.ts.FetchTagLocaleMappingsByLocale(ctx, tagData, tags.CountryId, tags.Locale)
 	if err != nil {
 		return
 	}
-	tagData, err = t.ts.OrderTags(tagData, tags.Type, tags.CurriculumType, tags.Hierarchy)
+	tagData, err = t.ts.OrderTags(ctx, tagData, tags.Type, tags.CurriculumType, tags.Hierarchy, tags.Locale)
 	if err != nil {
 		return
 	}
 	getTagResponse, _ = dtomapper.CreateGetTagResponse(tagData, tags, hiddenSet, next)
-	return getTagResponse, nil
+	return t.withOrderVersion(ctx, getTagResponse, tags)
 }
 
-func (t *AdminTagsServiceStruct) getContentTags(tags *domain.GetTags) (getTagResponse *domain.GetTagsResponse, err error) {
+func (t *AdminTagsServiceStruct) getContentTags(ctx context.Context, tags *domain.GetTags) (getTagResponse *domain.GetTagsResponse, err error) {
 	set := make(map[string][]*string)
 	var identifierTags []*string
 	var parents []*string
@@ -1313,7 +1503,7 @@ func (t *AdminTagsServiceStruct) getContentTags(tags *domain.GetTags) (getTagRes
 	}
 	var identifierSlice []*domain.Tags
 	if tags.Identifier != nil && len(tags.Identifier) > 0 {
-		identifierSlice, err = t.ts.GetTagsConcurrent(tags.Identifier)
+		identifierSlice, err = t.ts.GetTagsConcurrent(ctx, tags.Identifier)
 		if err != nil {
 			return
 		}
@@ -1327,11 +1517,11 @@ func (t *AdminTagsServiceStruct) getContentTags(tags *domain.GetTags) (getTagRes
 	if err != nil {
 		return
 	}
-	tagIds, next, err := t.es.GetTags(createElasticEntity)
+	tagIds, next, err := t.es.GetTags(ctx, createElasticEntity)
 	if err != nil {
 		return
 	}
-	parentTagMappingData, err := t.ts.FetchByInParentTagMappings(tagIds)
+	parentTagMappingData, err := t.ts.FetchByInParentTagMappings(ctx, tagIds)
 	if err != nil {
 		return
 	}
@@ -1354,26 +1544,39 @@ func (t *AdminTagsServiceStruct) getContentTags(tags *domain.GetTags) (getTagRes
 		key := k
 		identifierTags = append(identifierTags, &key)
 	}
-	identifierData, err := t.ts.FetchByInTags(identifierTags)
+	identifierData, err := t.ts.FetchByInTags(ctx, identifierTags)
 	for _, v := range identifierData {
 		setIdentifiers[*v.ID] = v
 	}
 	if err != nil {
 		return
 	}
-	tagData, err := t.ts.FetchByInTags(tagIds)
+	tagData, err := t.ts.FetchByInTags(ctx, tagIds)
 	if err != nil {
 		return
 	}
-	tagData, err = t.ts.OrderTags(tagData, tags.Type, tags.CurriculumType, tags.Hierarchy)
+	tagData, err = t.ts.OrderTags(ctx, tagData, tags.Type, tags.CurriculumType, tags.Hierarchy, tags.Locale)
 	if err != nil {
 		return
 	}
 	getTagResponse, _ = dtomapper.CreateGetTagResponseWithIdentifiers(tagData, tags, setIdentifiers, set, next)
+	return t.withOrderVersion(ctx, getTagResponse, tags)
+}
+
+// withOrderVersion adds the version of the listed sibling set, the one a reorder of the listing has to be made against
+func (t *AdminTagsServiceStruct) withOrderVersion(ctx context.Context, getTagResponse *domain.GetTagsResponse, tags *domain.GetTags) (*domain.GetTagsResponse, error) {
+	if getTagResponse == nil || getTagResponse.Meta == nil || getTagResponse.Meta.IsOrdered == nil || !*getTagResponse.Meta.IsOrdered || tags.Hierarchy == nil {
+		return getTagResponse, nil
+	}
+	version, err := t.ts.FetchTagOrderVersion(ctx, tags.Hierarchy, tags.Type)
+	if err != nil {
+		return nil, err
+	}
+	getTagResponse.Meta.OrderVersion = &version
 	return getTagResponse, nil
 }
 
-func (t *AdminTagsServiceStruct) getCurriculumTagsSearch(tags *domain.GetTags) (getTagResponse *domain.GetTagsResponse, err error) {
+func (t *AdminTagsServiceStruct) getCurriculumTagsSearch(ctx context.Context, tags *domain.GetTags) (getTagResponse *domain.GetTagsResponse, err error) {
 	if tags.Type == nil {
 		return nil, noonerror.New(noonerror.ErrBadRequest, "tagTypeAbsent")
 	}
@@ -1389,7 +1592,7 @@ func (t *AdminTagsServiceStruct) getCurriculumTagsSearch(tags *domain.GetTags) (
 		if err != nil {
 			return nil, err
 		}
-		tagIds, next, err = t.es.GetTags(createElasticEntity)
+		tagIds, next, err = t.es.GetTags(ctx, createElasticEntity)
 		if err != nil {
 			return nil, err
 		}
@@ -1398,16 +1601,16 @@ func (t *AdminTagsServiceStruct) getCurriculumTagsSearch(tags *domain.GetTags) (
 		if err != nil {
 			return nil, err
 		}
-		tagIds, next, err = t.es.GetTagsSearch(createElasticEntity)
+		tagIds, next, err = t.es.GetTagsSearch(ctx, createElasticEntity)
 		if err != nil {
 			return nil, err
 		}
 	}
-	tagData, err = t.ts.FetchByInTags(tagIds)
+	tagData, err = t.ts.FetchByInTags(ctx, tagIds)
 	if err != nil {
 		return nil, err
 	}
-	tagData, err = t.ts.FetchTagLocaleMappingsByLocale(tagData, tags.CountryId, tags.Locale)
+	tagData, err = t.ts.FetchTagLocaleMappingsByLocale(ctx, tagData, tags.CountryId, tags.Locale)
 	if err != nil {
 		return
 	}
@@ -1415,7 +1618,7 @@ func (t *AdminTagsServiceStruct) getCurriculumTagsSearch(tags *domain.GetTags) (
 	return getTagResponse, nil
 }
 
-func (t *AdminTagsServiceStruct) getContentTagsSearch(tags *domain.GetTags) (getTagResponse *domain.GetTagsResponse, err error) {
+func (t *AdminTagsServiceStruct) getContentTagsSearch(ctx context.Context, tags *domain.GetTags) (getTagResponse *domain.GetTagsResponse, err error) {
 	if tags.Type == nil {
 		return nil, noonerror.New(noonerror.ErrBadRequest, "tagTypeAbsent")
 	}
@@ -1438,7 +1641,7 @@ func (t *AdminTagsServiceStruct) getContentTagsSearch(tags *domain.GetTags) (get
 		if err != nil {
 			return nil, err
 		}
-		tagIds, next, err = t.es.GetTags(createElasticEntity)
+		tagIds, next, err = t.es.GetTags(ctx, createElasticEntity)
 		if err != nil {
 			return nil, err
 		}
@@ -1447,12 +1650,12 @@ func (t *AdminTagsServiceStruct) getContentTagsSearch(tags *domain.GetTags) (get
 		if err != nil {
 			return nil, err
 		}
-		tagIds, next, err = t.es.GetTagsSearch(createElasticEntity)
+		tagIds, next, err = t.es.GetTagsSearch(ctx, createElasticEntity)
 		if err != nil {
 			return nil, err
 		}
 	}
-	tagData, err := t.ts.FetchByInTags(tagIds)
+	tagData, err := t.ts.FetchByInTags(ctx, tagIds)
 	if err != nil {
 		return
 	}
@@ -1460,21 +1663,21 @@ func (t *AdminTagsServiceStruct) getContentTagsSearch(tags *domain.GetTags) (get
 	return getTagResponse, nil
 }
 
-func (t *AdminTagsServiceStruct) getIdentifierTagsSearch(tags *domain.GetTags) (getTagResponse *domain.GetTagsResponse, err error) {
+func (t *AdminTagsServiceStruct) getIdentifierTagsSearch(ctx context.Context, tags *domain.GetTags) (getTagResponse *domain.GetTagsResponse, err error) {
 	identifierTagGroup := domain.TagGroupEnum.Identifier
 	var tagData []*domain.Tags
 	createElasticEntity, err := dtomapper.GetElasticTagEntity(tags, nil, nil, "", tags.CurriculumType, "admin", &identifierTagGroup, tags.Start, tags.Limit)
 	if err != nil {
 		return
 	}
-	tagIds, next, err := t.es.GetTagsSearch(createElasticEntity)
+	tagIds, next, err := t.es.GetTagsSearch(ctx, createElasticEntity)
 	if err != nil {
-		tagData, err = t.ts.FetchByTagGroup(&identifierTagGroup, tags.Type)
+		tagData, err = t.ts.FetchByTagGroup(ctx, &identifierTagGroup, tags.Type)
 		if err != nil {
 			return nil, err
 		}
 	} else {
-		tagData, err = t.ts.FetchByInTags(tagIds)
+		tagData, err = t.ts.FetchByInTags(ctx, tagIds)
 		if err != nil {
 			return nil, err
 		}
@@ -1483,9 +1686,9 @@ func (t *AdminTagsServiceStruct) getIdentifierTagsSearch(tags *domain.GetTags) (
 	return getTagResponse, nil
 }
 
-func (t *AdminTagsServiceStruct) getCurriculumTagsForLibrary(gtt *domain.GetAdminTags) (*domain.GetTagsResponse, error) {
+func (t *AdminTagsServiceStruct) getCurriculumTagsForLibrary(ctx context.Context, gtt *domain.GetAdminTags) (*domain.GetTagsResponse, error) {
 
-	tagHierarchySlice, err := t.ts.GetTagsConcurrent(gtt.Hierarchy)
+	tagHierarchySlice, err := t.ts.GetTagsConcurrent(ctx, gtt.Hierarchy)
 	if err != nil {
 		return nil, err
 	}
@@ -1516,12 +1719,12 @@ func (t *AdminTagsServiceStruct) getCurriculumTagsForLibrary(gtt *domain.GetAdmi
 	if err != nil {
 		return nil, err
 	}
-	filteredTags, next, err := t.es.GetTags(createElasticEntity)
+	filteredTags, next, err := t.es.GetTags(ctx, createElasticEntity)
 	if err != nil {
 		return nil, err
 	}
 	hiddenSet := make(map[string]bool)
-	parentTagMappingData, err := t.ts.FetchByInParentTagMappingsByParentTagIdTagIds(filteredTags, parentTags)
+	parentTagMappingData, err := t.ts.FetchByInParentTagMappingsByParentTagIdTagIds(ctx, filteredTags, parentTags)
 	if err != nil {
 		return nil, err
 	}
@@ -1530,15 +1733,15 @@ func (t *AdminTagsServiceStruct) getCurriculumTagsForLibrary(gtt *domain.GetAdmi
 			hiddenSet[*v.TagID] = v.Hidden
 		}
 	}
-	tagData, err := t.ts.FetchByInTags(filteredTags)
+	tagData, err := t.ts.FetchByInTags(ctx, filteredTags)
 	if err != nil {
 		return nil, err
 	}
-	tagData, err = t.ts.FetchTagLocaleMappingsByLocale(tagData, gtt.CountryId, gtt.Locale)
+	tagData, err = t.ts.FetchTagLocaleMappingsByLocale(ctx, tagData, gtt.CountryId, gtt.Locale)
 	if err != nil {
 		return nil, err
 	}
-	tagData, err = t.ts.OrderTags(tagData, gtt.Type, gtt.CurriculumType, parentTags)
+	tagData, err = t.ts.OrderTags(ctx, tagData, gtt.Type, gtt.CurriculumType, parentTags, gtt.Locale)
 	if err != nil {
 		return nil, err
 	}
@@ -1546,8 +1749,8 @@ func (t *AdminTagsServiceStruct) getCurriculumTagsForLibrary(gtt *domain.GetAdmi
 	return getTagResponse, nil
 }
 
-func (t *AdminTagsServiceStruct) getContentTagsForLibrary(gtt *domain.GetAdminTags) (*domain.GetTagsResponse, error) {
-	tagHierarchySlice, err := t.ts.FetchByInTags(gtt.Hierarchy)
+func (t *AdminTagsServiceStruct) getContentTagsForLibrary(ctx context.Context, gtt *domain.GetAdminTags) (*domain.GetTagsResponse, error) {
+	tagHierarchySlice, err := t.ts.FetchByInTags(ctx, gtt.Hierarchy)
 	if err != nil {
 		return nil, err
 	}
@@ -1570,17 +1773,17 @@ func (t *AdminTagsServiceStruct) getContentTagsForLibrary(gtt *domain.GetAdminTa
 		return nil, err
 	}
 
-	tagIds, next, err := t.es.GetTags(adminElasticEntity)
+	tagIds, next, err := t.es.GetTags(ctx, adminElasticEntity)
 	if err != nil {
 		return nil, err
 	}
-	tagData, err := t.ts.FetchByInTags(tagIds)
+	tagData, err := t.ts.FetchByInTags(ctx, tagIds)
 	if err != nil {
 		return nil, err
 	}
 
 	hiddenSet := make(map[string]bool)
-	parentTagMappingData, err := t.ts.FetchByInParentTagMappingsByParentTagIdTagIds(tagIds, parentHideOrderTags)
+	parentTagMappingData, err := t.ts.FetchByInParentTagMappingsByParentTagIdTagIds(ctx, tagIds, parentHideOrderTags)
 	if err != nil {
 		return nil, err
 	}
@@ -1589,7 +1792,7 @@ func (t *AdminTagsServiceStruct) getContentTagsForLibrary(gtt *domain.GetAdminTa
 			hiddenSet[*v.TagID] = v.Hidden
 		}
 	}
-	tagData, err = t.ts.OrderTags(tagData, gtt.Type, gtt.CurriculumType, parentHideOrderTags)
+	tagData, err = t.ts.OrderTags(ctx, tagData, gtt.Type, gtt.CurriculumType, parentHideOrderTags, gtt.Locale)
 	if err != nil {
 		return nil, err
 	}
@@ -1598,9 +1801,9 @@ func (t *AdminTagsServiceStruct) getContentTagsForLibrary(gtt *domain.GetAdminTa
 	return getTagResponse, nil
 }
 
-func (t *AdminTagsServiceStruct) GetTestsSkillsForLibrary(gtt *domain.GetAdminTags) (*domain.GetTagsResponse, error) {
+func (t *AdminTagsServiceStruct) GetTestsSkillsForLibrary(ctx context.Context, gtt *domain.GetAdminTags) (*domain.GetTagsResponse, error) {
 
-	tagHierarchySlice, err := t.ts.GetTagsConcurrent(gtt.Hierarchy)
+	tagHierarchySlice, err := t.ts.GetTagsConcurrent(ctx, gtt.Hierarchy)
 	if err != nil {
 		return nil, err
 	}
@@ -1640,16 +1843,16 @@ func (t *AdminTagsServiceStruct) GetTestsSkillsForLibrary(gtt *domain.GetAdminTa
 	if err != nil {
 		return nil, err
 	}
-	filteredTags, next, err := t.es.GetTags(createElasticEntity)
+	filteredTags, next, err := t.es.GetTags(ctx, createElasticEntity)
 	if err != nil {
 		return nil, err
 	}
-	tagData, err := t.ts.FetchByInTags(filteredTags)
+	tagData, err := t.ts.FetchByInTags(ctx, filteredTags)
 	if err != nil {
 		return nil, err
 	}
 	hiddenSet := make(map[string]bool)
-	parentTagMappingData, err := t.ts.FetchByInParentTagMappingsByParentTagIdTagIds(filteredTags, parentTags)
+	parentTagMappingData, err := t.ts.FetchByInParentTagMappingsByParentTagIdTagIds(ctx, filteredTags, parentTags)
 	if err != nil {
 		return nil, err
 	}
@@ -1658,11 +1861,11 @@ func (t *AdminTagsServiceStruct) GetTestsSkillsForLibrary(gtt *domain.GetAdminTa
 			hiddenSet[*v.TagID] = v.Hidden
 		}
 	}
-	tagData, err = t.ts.FetchTagLocaleMappingsByLocale(tagData, gtt.CountryId, gtt.Locale)
+	tagData, err = t.ts.FetchTagLocaleMappingsByLocale(ctx, tagData, gtt.CountryId, gtt.Locale)
 	if err != nil {
 		return nil, err
 	}
-	tagData, err = t.ts.OrderTags(tagData, gtt.Type, curriculumType, parentTags)
+	tagData, err = t.ts.OrderTags(ctx, tagData, gtt.Type, curriculumType, parentTags, gtt.Locale)
 	if err != nil {
 		return nil, err
 	}
@@ -1670,10 +1873,10 @@ func (t *AdminTagsServiceStruct) GetTestsSkillsForLibrary(gtt *domain.GetAdminTa
 	return getTagResponse, nil
 }
 
-func (t *AdminTagsServiceStruct) GetCountriesTagsNew(tags *domain.GetCountriesNew) (getTagResponse *domain.GetCountriesNewResponse, err error) {
+func (t *AdminTagsServiceStruct) GetCountriesTagsNew(ctx context.Context, tags *domain.GetCountriesNew) (getTagResponse *domain.GetCountriesNewResponse, err error) {
 	rootCurriculumType := domain.CurriculumTypeEnum.Root
 	countryType := domain.TagTypeEnum.Country
-	filteredTagsResponse, err := t.ts.FetchFilteredTagsPaginatedForAdmin(&rootCurriculumType, &countryType, &tags.Start, &tags.Limit)
+	filteredTagsResponse, err := t.ts.FetchFilteredTagsPaginatedForAdmin(ctx, &rootCurriculumType, &countryType, &tags.Start, &tags.Limit)
 	if err != nil {
 		return
 	}
@@ -1681,7 +1884,17 @@ func (t *AdminTagsServiceStruct) GetCountriesTagsNew(tags *domain.GetCountriesNe
 	if len(filteredTagsResponse) >= tags.Limit {
 		next = tags.Start + tags.Limit
 	}
-	getTagResponse, _ = dtomapper.CreateGetCountriesNewResponse(filteredTagsResponse, tags.Locale, nil, &next, true)
+	filteredTagsResponse, err = t.ts.FetchAttributeLocalesByLocale(ctx, filteredTagsResponse, tags.CountryId, tags.Locale)
+	if err != nil {
+		return
+	}
+	getTagResponse, _ = dtomapper.CreateGetCountriesNewResponse(filteredTagsResponse, tags.Locale, nil, config.GetConfig().DefaultCountryCode, &next, true)
+	if tags.GroupBy != nil && *tags.GroupBy == GroupByRegion {
+		getTagResponse.Meta.Regions, err = t.ts.GroupCountriesByRegion(ctx, filteredTagsResponse)
+		if err != nil {
+			return nil, err
+		}
+	}
 	return getTagResponse, nil
 }
 
@@ -1698,3 +1911,19 @@ func filterMultiGradeTags(tagData []*domain.Tags) []*domain.Tags {
 	}
 	return tagData
 }
+
+func (t *AdminTagsServiceStruct) GetTagCache(ctx context.Context, id *string) ([]*domain.CacheEntry, error) {
+	return t.ts.FetchTagCacheEntries(ctx, id)
+}
+
+func (t *AdminTagsServiceStruct) GetHierarchyCache(ctx context.Context, hierarchy *string) ([]*domain.CacheEntry, error) {
+	return t.ts.FetchHierarchyCacheEntries(ctx, hierarchy)
+}
+
+func (t *AdminTagsServiceStruct) EvictCache(ctx context.Context, keys []*string) ([]string, error) {
+	return t.ts.EvictCacheKeys(ctx, keys)
+}
+
+func (t *AdminTagsServiceStruct) FlushHierarchyCache(ctx context.Context, hierarchy *string) ([]string, error) {
+	return t.ts.FlushHierarchyCache(ctx, hierarchy)
+}
@@ -0,0 +1,230 @@
+package service
+
+import (
+	"bitbucket.org/noon-micro/curriculum/pkg/domain"
+	noonerror "bitbucket.org/noon-micro/curriculum/pkg/lib/error"
+	"bitbucket.org/noon-micro/curriculum/pkg/lib/helper"
+	repository "bitbucket.org/noon-micro/curriculum/pkg/repository/mysql"
+	redisrepo "bitbucket.org/noon-micro/curriculum/pkg/repository/redis"
+	"bitbucket.org/noon-micro/curriculum/pkg/service/constant"
+	"context"
+	"database/sql"
+	"encoding/json"
+	"strings"
+	"time"
+)
+
+func (t *TagsServiceStruct) CreateTagAttributeLocale(ctx context.Context, tx *sql.Tx, tagAttributeLocale *domain.TagAttributeLocale) (err error) {
+	if err := redisrepo.Client(ctx).Del(redisrepo.CurriculumAttributeLocalePrefix + *tagAttributeLocale.TagID).Err(); err != nil {
+		return noonerror.New(noonerror.ErrInternalServer, "redisDeleteError")
+	}
+	return t.talr.CreateTagAttributeLocale(ctx, tx, tagAttributeLocale)
+}
+
+func (t *TagsServiceStruct) DeleteTagAttributeLocale(ctx context.Context, tx *sql.Tx, tagAttributeLocale *domain.TagAttributeLocale) (err error) {
+	if err := redisrepo.Client(ctx).Del(redisrepo.CurriculumAttributeLocalePrefix + *tagAttributeLocale.TagID).Err(); err != nil {
+		return noonerror.New(noonerror.ErrInternalServer, "redisDeleteError")
+	}
+	return t.talr.DeleteTagAttributeLocale(ctx, tx, tagAttributeLocale.ID)
+}
+
+// FetchTagAttributeLocales returns every published localized attribute value of the tag
+func (t *TagsServiceStruct) FetchTagAttributeLocales(ctx context.Context, id *string) (tagAttributeLocales []*domain.TagAttributeLocale, err error) {
+	result, err := t.batchTagAttributeLocales(ctx, []string{*id})
+	if err != nil {
+		return nil, err
+	}
+	tagAttributeLocales, _ = result[*id].([]*domain.TagAttributeLocale)
+	return tagAttributeLocales, nil
+}
+
+// FetchAttributeLocalesByLocale resolves the localizable attributes of the tags along the fallback chain of the
+// country and locale into LocaleAttributes, every key falls back on its own. Attributes are looked up for the global
+// country when no country is given.
+func (t *TagsServiceStruct) FetchAttributeLocalesByLocale(ctx context.Context, tagData []*domain.Tags, countryId *string, locale *string) (tagResults []*domain.Tags, err error) {
+	if len(tagData) == 0 || locale == nil {
+		return tagData, nil
+	}
+	var tagIds []string
+	for _, v := range tagData {
+		if v.Type != nil && len(constant.LocalizableAttributes[*v.Type]) > 0 {
+			tagIds = append(tagIds, *v.ID)
+		}
+	}
+	if len(tagIds) == 0 {
+		return tagData, nil
+	}
+	result, err := t.batchTagAttributeLocales(ctx, tagIds)
+	if err != nil {
+		return nil, err
+	}
+	resolveAttributeLocales(tagData, countryId, locale, result)
+	return tagData, nil
+}
+
+// batchTagAttributeLocales caches the published localized attributes per tag, tags without any are cached as an
+// empty list so that they do not hit the database on every read
+func (t *TagsServiceStruct) batchTagAttributeLocales(ctx context.Context, ids []string) (map[string]interface{}, error) {
+	result := make(map[string]interface{}, len(ids))
+	var missing []*string
+	for i, val := range mGet(ctx, redisrepo.CurriculumAttributeLocalePrefix, ids) {
+		var tagAttributeLocales []*domain.TagAttributeLocale
+		if val == nil || json.Unmarshal([]byte(*val), &tagAttributeLocales) != nil {
+			missing = append(missing, &ids[i])
+			continue
+		}
+		result[ids[i]] = tagAttributeLocales
+	}
+	if len(missing) == 0 {
+		return result, nil
+	}
+	tagAttributeLocales, err := t.talr.FetchByInTagAttributeLocales(ctx, missing)
+	if err != nil {
+		return nil, err
+	}
+	grouped := make(map[string][]*domain.TagAttributeLocale, len(missing))
+	for _, id := range missing {
+		grouped[*id] = []*domain.TagAttributeLocale{}
+	}
+	for _, v := range tagAttributeLocales {
+		grouped[*v.TagID] = append(grouped[*v.TagID], v)
+	}
+	for tagId, rows := range grouped {
+		result[tagId] = rows
+		tagByte, err := json.Marshal(rows)
+		if err == nil {
+			redisrepo.Client(ctx).Set(redisrepo.CurriculumAttributeLocalePrefix+tagId, string(tagByte), redisrepo.RedisTtl)
+		}
+	}
+	return result, nil
+}
+
+// resolveAttributeLocales sets LocaleAttributes from the rows loaded per tag id, tags without a localized value
+// for any of their keys are left untouched
+func resolveAttributeLocales(tagData []*domain.Tags, countryId *string, locale *string, result map[string]interface{}) {
+	country := constant.GlobalCountryId
+	if countryId != nil {
+		country = *countryId
+	}
+	chain := localeFallbackChain(country, *locale)
+	for _, v := range tagData {
+		rows, _ := result[*v.ID].([]*domain.TagAttributeLocale)
+		if len(rows) == 0 {
+			continue
+		}
+		values := make(map[string]*domain.TagAttributeLocale, len(rows))
+		for _, row := range rows {
+			values[attributeLocaleKey(*row.Attribute, *row.CountryId, *row.Locale)] = row
+		}
+		localeAttributes := make(map[string]interface{})
+		for _, attribute := range constant.LocalizableAttributes[*v.Type] {
+			for _, candidate := range chain {
+				if row, ok := values[attributeLocaleKey(attribute, candidate.countryId, candidate.locale)]; ok && row.Value != nil {
+					localeAttributes[attribute] = *row.Value
+					break
+				}
+			}
+		}
+		if len(localeAttributes) > 0 {
+			v.LocaleAttributes = localeAttributes
+		}
+	}
+}
+
+// GetTagAttributeLocales lists the localized attribute values of the tag
+func (t *AdminTagsServiceStruct) GetTagAttributeLocales(ctx context.Context, id *string) (tagAttributeLocales []*domain.TagAttributeLocale, err error) {
+	tagData, err := t.ts.FetchTags(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if tagData == nil {
+		return nil, noonerror.New(noonerror.ErrBadRequest, "tagNotFound")
+	}
+	tagAttributeLocales, err = t.ts.FetchTagAttributeLocales(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if tagAttributeLocales == nil {
+		tagAttributeLocales = []*domain.TagAttributeLocale{}
+	}
+	return tagAttributeLocales, nil
+}
+
+// UpdateTagAttributeLocales replaces the values of the given attribute, locale and country triples in a single
+// transaction, only the attributes declared localizable for the tag type are accepted
+func (t *AdminTagsServiceStruct) UpdateTagAttributeLocales(ctx context.Context, id *string, update *domain.UpdateTagAttributeLocales) (tagAttributeLocales []*domain.TagAttributeLocale, err error) {
+	tagData, err := t.ts.FetchTags(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if tagData == nil || tagData.Type == nil {
+		return nil, noonerror.New(noonerror.ErrBadRequest, "tagNotFound")
+	}
+	declared := make(map[string]struct{})
+	for _, v := range constant.LocalizableAttributes[*tagData.Type] {
+		declared[v] = struct{}{}
+	}
+	seen := make(map[string]struct{}, len(update.Attributes))
+	for _, v := range update.Attributes {
+		if _, ok := declared[*v.Attribute]; !ok {
+			return nil, noonerror.New(noonerror.ErrBadRequest, "attributeNotLocalizable")
+		}
+		key := attributeLocaleKey(*v.Attribute, *v.CountryId, *v.Locale)
+		if _, ok := seen[key]; ok {
+			return nil, noonerror.New(noonerror.ErrBadRequest, "attributeLocaleDuplicate")
+		}
+		seen[key] = struct{}{}
+	}
+	current, err := t.ts.FetchTagAttributeLocales(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	currentMap := make(map[string]*domain.TagAttributeLocale, len(current))
+	for _, v := range current {
+		currentMap[attributeLocaleKey(*v.Attribute, *v.CountryId, *v.Locale)] = v
+	}
+	tx, err := repository.Db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, noonerror.New(noonerror.ErrInternalServer, "ContextCreationError")
+	}
+	for _, v := range update.Attributes {
+		if row, ok := currentMap[attributeLocaleKey(*v.Attribute, *v.CountryId, *v.Locale)]; ok {
+			if err = t.ts.DeleteTagAttributeLocale(ctx, tx, row); err != nil {
+				_ = tx.Rollback()
+				return nil, err
+			}
+		}
+		if v.Value == nil || strings.TrimSpace(*v.Value) == "" {
+			continue
+		}
+		locale := helper.NormalizeLocale(*v.Locale)
+		value := strings.TrimSpace(*v.Value)
+		if err = t.ts.CreateTagAttributeLocale(ctx, tx, &domain.TagAttributeLocale{TagID: id, TagType: tagData.Type, Attribute: v.Attribute,
+			CountryId: v.CountryId, Locale: &locale, Value: &value, Publish: true, CreatedAt: time.Now(), UpdatedAt: time.Now()}); err != nil {
+			_ = tx.Rollback()
+			return nil, err
+		}
+	}
+	localeChanged := &domain.TagLocaleChangedEventData{Action: domain.TagLocaleActionEnum.Update}
+	for _, v := range update.Attributes {
+		locale := helper.NormalizeLocale(*v.Locale)
+		eventLocale := &domain.TagEventLocale{Locale: &locale, CountryId: v.CountryId, Attribute: v.Attribute}
+		if v.Value != nil && strings.TrimSpace(*v.Value) != "" {
+			value := strings.TrimSpace(*v.Value)
+			eventLocale.Name = &value
+		}
+		localeChanged.Locales = append(localeChanged.Locales, eventLocale)
+	}
+	if err = t.ep.Publish(ctx, tx, domain.TagEventTypeEnum.LocaleChanged, id, tagData.Type, localeChanged); err != nil {
+		_ = tx.Rollback()
+		return nil, err
+	}
+	if err = tx.Commit(); err != nil {
+		return nil, noonerror.New(noonerror.ErrInternalServer, "dbCommitError")
+	}
+	return t.GetTagAttributeLocales(ctx, id)
+}
+
+func attributeLocaleKey(attribute string, countryId string, locale string) string {
+	return attribute + ":" + countryId + ":" + helper.NormalizeLocale(locale)
+}
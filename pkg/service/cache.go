@@ -0,0 +1,122 @@
+package service
+
+import (
+	noonerror "bitbucket.org/noon-micro/curriculum/pkg/lib/error"
+	"bitbucket.org/noon-micro/curriculum/pkg/lib/logger"
+	repository "bitbucket.org/noon-micro/curriculum/pkg/repository/redis"
+	"context"
+	"encoding/json"
+	"golang.org/x/sync/singleflight"
+	"time"
+)
+
+// cacheGroup coalesces concurrent misses on the same redis key within this process
+var cacheGroup singleflight.Group
+
+// cacheLoader returns the fresh value and whether it is worth caching. ctx is detached from
+// the request which triggered the load, as the result is shared with every waiting caller.
+type cacheLoader func(ctx context.Context) (value interface{}, cache bool, err error)
+
+// readThrough unmarshals the cached value of key into dest and falls back to load on a miss.
+// Only one caller per process loads a missing key, and across processes the holder of a short
+// redis lock loads while the others wait for it to publish. Keys close to expiry are refreshed
+// in the background so that hot keys rarely expire under load. A caller whose ctx is done stops
+// waiting, while the load itself carries on for the others.
+func readThrough(ctx context.Context, key string, ttl time.Duration, dest interface{}, load cacheLoader) error {
+	pipe := repository.Client(ctx).Pipeline()
+	get := pipe.Get(key)
+	pttl := pipe.PTTL(key)
+	_, _ = pipe.Exec()
+	val, err := get.Result()
+	if err == nil && json.Unmarshal([]byte(val), dest) == nil {
+		remaining, err := pttl.Result()
+		if err == nil && remaining > 0 && remaining < ttl/repository.EarlyRefreshRatio {
+			go refreshCache(key, ttl, load)
+		}
+		return nil
+	}
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+	ch := cacheGroup.DoChan(key, func() (interface{}, error) {
+		return fillCache(key, ttl, load)
+	})
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case res := <-ch:
+		if res.Err != nil {
+			return res.Err
+		}
+		return json.Unmarshal(res.Val.([]byte), dest)
+	}
+}
+
+func fillCache(key string, ttl time.Duration, load cacheLoader) ([]byte, error) {
+	// the lock expires after LockTtl, a load running longer would race with the next holder
+	ctx, cancel := context.WithTimeout(context.Background(), repository.LockTtl)
+	defer cancel()
+	lockKey := key + repository.LockSuffix
+	token, locked, err := repository.AcquireLock(ctx, lockKey)
+	if err == nil && !locked {
+		// another instance is loading this key, give it a moment to publish the value
+		for i := 0; i < repository.LockAttempts; i++ {
+			time.Sleep(repository.LockWait)
+			val, err := repository.Client(ctx).Get(key).Bytes()
+			if err == nil {
+				return val, nil
+			}
+		}
+	}
+	if locked {
+		defer releaseCacheLock(lockKey, token)
+	}
+	value, cache, err := load(ctx)
+	if err != nil {
+		return nil, err
+	}
+	out, err := json.Marshal(value)
+	if err != nil {
+		return nil, noonerror.New(noonerror.ErrInternalServer, "cacheMarshallingError")
+	}
+	if cache {
+		repository.Client(ctx).Set(key, string(out), ttl)
+	}
+	return out, nil
+}
+
+func refreshCache(key string, ttl time.Duration, load cacheLoader) {
+	defer func() {
+		if err := recover(); err != nil {
+			logger.Client.Error("refreshCachePanicked:key:"+key, logger.GetErrorStack())
+		}
+	}()
+	_, _, _ = cacheGroup.Do(key+repository.RefreshSuffix, func() (interface{}, error) {
+		ctx, cancel := context.WithTimeout(context.Background(), repository.LockTtl)
+		defer cancel()
+		lockKey := key + repository.LockSuffix
+		token, locked, err := repository.AcquireLock(ctx, lockKey)
+		if err != nil || !locked {
+			return nil, nil
+		}
+		defer releaseCacheLock(lockKey, token)
+		value, cache, err := load(ctx)
+		if err != nil || !cache {
+			return nil, err
+		}
+		out, err := json.Marshal(value)
+		if err == nil {
+			repository.Client(ctx).Set(key, string(out), ttl)
+		}
+		return nil, nil
+	})
+}
+
+// releaseCacheLock runs on its own context, the one of the load may have run out by the time the load returns
+func releaseCacheLock(lockKey string, token string) {
+	ctx, cancel := context.WithTimeout(context.Background(), repository.LockTtl)
+	defer cancel()
+	if err := repository.ReleaseLock(ctx, lockKey, token); err != nil {
+		logger.Client.Error("releaseCacheLockError:key:"+lockKey, err)
+	}
+}
@@ -0,0 +1,70 @@
+package service
+
+import (
+	"bitbucket.org/noon-micro/curriculum/pkg/domain"
+	noonerror "bitbucket.org/noon-micro/curriculum/pkg/lib/error"
+	"bitbucket.org/noon-micro/curriculum/pkg/lib/logger"
+	repository "bitbucket.org/noon-micro/curriculum/pkg/repository/redis"
+	"context"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// cacheGenerations are the families of rows the listings are built from: the tags, their mappings with the orders,
+// and their locales. The generation of a family grows with every committed change of one of its rows.
+var cacheGenerations = []string{domain.TagChangeEntityEnum.Tag, domain.TagChangeEntityEnum.Mapping, domain.TagChangeEntityEnum.Locale}
+
+// FetchCacheGenerations returns the generations of the families, a listing only changes when one of them grows so
+// they stand in for the listing in its ETag
+func (t *TagsServiceStruct) FetchCacheGenerations(ctx context.Context) (string, error) {
+	keys := make([]string, len(cacheGenerations))
+	for i, v := range cacheGenerations {
+		keys[i] = repository.CurriculumGenerationPrefix + v
+	}
+	values, err := repository.Client(ctx).MGet(keys...).Result()
+	if err != nil {
+		return "", noonerror.New(noonerror.ErrInternalServer, "redisGetError")
+	}
+	generations := make([]string, len(values))
+	for i, v := range values {
+		value, ok := v.(string)
+		if !ok {
+			if value, err = seedCacheGeneration(ctx, keys[i]); err != nil {
+				return "", err
+			}
+		}
+		generations[i] = value
+	}
+	return strings.Join(generations, ":"), nil
+}
+
+// bumpCacheGenerations grows the generations of the families once their change is committed, the ETags handed out
+// before stop matching
+func bumpCacheGenerations(ctx context.Context, families ...string) {
+	for _, v := range families {
+		key := repository.CurriculumGenerationPrefix + v
+		if _, err := seedCacheGeneration(ctx, key); err != nil {
+			continue
+		}
+		if err := repository.Client(ctx).Incr(key).Err(); err != nil {
+			logger.Client.Error("bumpCacheGenerationError:"+key, err)
+		}
+	}
+}
+
+// seedCacheGeneration starts a missing generation at the current time rather than at zero, a generation lost with
+// the cache must not come back at a value an ETag was already built from
+func seedCacheGeneration(ctx context.Context, key string) (string, error) {
+	client := repository.Client(ctx)
+	if err := client.SetNX(key, strconv.FormatInt(time.Now().UnixNano(), 10), 0).Err(); err != nil {
+		logger.Client.Error("seedCacheGenerationError:"+key, err)
+		return "", noonerror.New(noonerror.ErrInternalServer, "redisGetError")
+	}
+	value, err := client.Get(key).Result()
+	if err != nil {
+		logger.Client.Error("seedCacheGenerationError:"+key, err)
+		return "", noonerror.New(noonerror.ErrInternalServer, "redisGetError")
+	}
+	return value, nil
+}
@@ -0,0 +1,277 @@
+package service
+
+import (
+	"bitbucket.org/noon-micro/curriculum/pkg/domain"
+	noonerror "bitbucket.org/noon-micro/curriculum/pkg/lib/error"
+	repository "bitbucket.org/noon-micro/curriculum/pkg/repository/redis"
+	"context"
+	"encoding/json"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const cacheScanCount = 100
+
+// FetchTagCacheEntries lists every cached key derived from a tag, including the orders of its parents and children
+func (t *TagsServiceStruct) FetchTagCacheEntries(ctx context.Context, id *string) (cacheEntries []*domain.CacheEntry, err error) {
+	keys, err := t.tagCacheKeys(ctx, *id)
+	if err != nil {
+		return nil, err
+	}
+	parentTagMappings, err := t.ptmr.FetchParentTagMappings(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	for _, v := range parentTagMappings {
+		if v.ParentTagID != nil && v.TagType != nil {
+			keys = append(keys, repository.CurriculumTagOrderPrefix+*v.ParentTagID+":"+*v.TagType)
+		}
+	}
+	childOrderKeys, err := scanKeys(ctx, repository.CurriculumTagOrderPrefix+"*"+*id+":*")
+	if err != nil {
+		return nil, err
+	}
+	for _, key := range childOrderKeys {
+		parentTagIds, _ := splitTagOrderKey(strings.TrimPrefix(key, repository.CurriculumTagOrderPrefix))
+		path := strings.Split(parentTagIds, ".")
+		if path[len(path)-1] == *id {
+			keys = append(keys, key)
+		}
+	}
+	return t.cacheEntries(ctx, keys)
+}
+
+// FetchHierarchyCacheEntries lists the order and multi grade keys of a parent path and everything below it
+func (t *TagsServiceStruct) FetchHierarchyCacheEntries(ctx context.Context, hierarchy *string) (cacheEntries []*domain.CacheEntry, err error) {
+	keys, err := hierarchyCacheKeys(ctx, *hierarchy)
+	if err != nil {
+		return nil, err
+	}
+	return t.cacheEntries(ctx, keys)
+}
+
+func (t *TagsServiceStruct) EvictCacheKeys(ctx context.Context, keys []*string) (evicted []string, err error) {
+	for _, key := range keys {
+		if key == nil || !strings.HasPrefix(*key, "curriculum:") {
+			return nil, noonerror.New(noonerror.ErrBadRequest, "cacheKeyInvalid")
+		}
+		evicted = append(evicted, *key)
+	}
+	return deleteKeys(ctx, evicted)
+}
+
+// FlushHierarchyCache evicts the hierarchy keys and the tag keys of every tag mapped below the path
+func (t *TagsServiceStruct) FlushHierarchyCache(ctx context.Context, hierarchy *string) (evicted []string, err error) {
+	keys, err := hierarchyCacheKeys(ctx, *hierarchy)
+	if err != nil {
+		return nil, err
+	}
+	tagIds, err := t.ptmr.FetchTagIdsByParentTagPrefix(ctx, hierarchy)
+	if err != nil {
+		return nil, err
+	}
+	path := strings.Split(*hierarchy, ".")
+	tagIds = append(tagIds, &path[len(path)-1])
+	for _, tagId := range tagIds {
+		tagKeys, err := t.tagCacheKeys(ctx, *tagId)
+		if err != nil {
+			return nil, err
+		}
+		keys = append(keys, tagKeys...)
+	}
+	return deleteKeys(ctx, keys)
+}
+
+func (t *TagsServiceStruct) tagCacheKeys(ctx context.Context, id string) (keys []string, err error) {
+	keys = []string{repository.CurriculumPrefix + id, repository.CurriculumParentTagMappingPrefix + id, repository.CurriculumAttributeLocalePrefix + id,
+		repository.CurriculumCountryRulePrefix + id, repository.CurriculumRegionCountryPrefix + id}
+	localeKeys, err := scanKeys(ctx, repository.CurriculumTagLocaleMappingPrefix+id+":*")
+	if err != nil {
+		return nil, err
+	}
+	return append(keys, localeKeys...), nil
+}
+
+func hierarchyCacheKeys(ctx context.Context, hierarchy string) (keys []string, err error) {
+	multiGradePath := strings.Replace(hierarchy, ".", ":", -1)
+	for _, pattern := range []string{
+		repository.CurriculumTagOrderPrefix + hierarchy + ":*",
+		repository.CurriculumTagOrderPrefix + hierarchy + ".*",
+		repository.CurriculumMultiGradePrefix + multiGradePath,
+		repository.CurriculumMultiGradePrefix + multiGradePath + ":*",
+	} {
+		matched, err := scanKeys(ctx, pattern)
+		if err != nil {
+			return nil, err
+		}
+		keys = append(keys, matched...)
+	}
+	return keys, nil
+}
+
+func (t *TagsServiceStruct) cacheEntries(ctx context.Context, keys []string) (cacheEntries []*domain.CacheEntry, err error) {
+	visited := make(map[string]struct{})
+	for _, key := range keys {
+		if _, ok := visited[key]; ok {
+			continue
+		}
+		visited[key] = struct{}{}
+		val, err := repository.Client(ctx).Get(key).Result()
+		if err != nil {
+			continue
+		}
+		ttl, _ := repository.Client(ctx).TTL(key).Result()
+		cacheEntry := &domain.CacheEntry{Key: key, Ttl: int64(ttl)}
+		if ttl > 0 {
+			cacheEntry.Ttl = int64(ttl / time.Second)
+		}
+		var cached interface{}
+		if json.Unmarshal([]byte(val), &cached) != nil {
+			cached = val
+		}
+		cacheEntry.Cached = cached
+		source, ok, err := t.cacheSource(ctx, key)
+		if err != nil {
+			return nil, err
+		}
+		if ok {
+			sourceByte, err := json.Marshal(source)
+			if err != nil {
+				return nil, noonerror.New(noonerror.ErrInternalServer, "cacheMarshallingError")
+			}
+			var normalized interface{}
+			_ = json.Unmarshal(sourceByte, &normalized)
+			cacheEntry.Source = normalized
+			cacheEntry.Stale = !reflect.DeepEqual(cached, normalized)
+		}
+		cacheEntries = append(cacheEntries, cacheEntry)
+	}
+	return cacheEntries, nil
+}
+
+// cacheSource reads the value a key would be filled with, ok is false for keys not backed by MySQL alone
+func (t *TagsServiceStruct) cacheSource(ctx context.Context, key string) (source interface{}, ok bool, err error) {
+	switch {
+	case strings.HasPrefix(key, repository.CurriculumPrefix):
+		id := strings.TrimPrefix(key, repository.CurriculumPrefix)
+		source, err = t.tr.FetchTags(ctx, &id)
+	case strings.HasPrefix(key, repository.CurriculumParentTagMappingPrefix):
+		id := strings.TrimPrefix(key, repository.CurriculumParentTagMappingPrefix)
+		source, err = t.ptmr.FetchParentTagMappings(ctx, &id)
+	case strings.HasPrefix(key, repository.CurriculumTagLocaleMappingPrefix):
+		parts := strings.SplitN(strings.TrimPrefix(key, repository.CurriculumTagLocaleMappingPrefix), ":", 3)
+		if len(parts) != 3 {
+			return nil, false, nil
+		}
+		source, err = t.tlmr.FetchTagLocaleMappingByLocale(ctx, &parts[0], &parts[1], &parts[2])
+	case strings.HasPrefix(key, repository.CurriculumAttributeLocalePrefix):
+		id := strings.TrimPrefix(key, repository.CurriculumAttributeLocalePrefix)
+		tagAttributeLocales, err := t.talr.FetchByInTagAttributeLocales(ctx, []*string{&id})
+		if err != nil {
+			return nil, false, err
+		}
+		if tagAttributeLocales == nil {
+			tagAttributeLocales = []*domain.TagAttributeLocale{}
+		}
+		source = tagAttributeLocales
+	case strings.HasPrefix(key, repository.CurriculumCountryRulePrefix):
+		id := strings.TrimPrefix(key, repository.CurriculumCountryRulePrefix)
+		tagCountryRules, err := t.tcrr.FetchByInTagCountryRules(ctx, []*string{&id})
+		if err != nil {
+			return nil, false, err
+		}
+		if tagCountryRules == nil {
+			tagCountryRules = []*domain.TagCountryRule{}
+		}
+		source = tagCountryRules
+	case strings.HasPrefix(key, repository.CurriculumRegionCountryPrefix):
+		id := strings.TrimPrefix(key, repository.CurriculumRegionCountryPrefix)
+		regionCountryMappings, err := t.rcmr.FetchByInRegionCountryMappings(ctx, []*string{&id})
+		if err != nil {
+			return nil, false, err
+		}
+		if regionCountryMappings == nil {
+			regionCountryMappings = []*domain.RegionCountryMapping{}
+		}
+		source = regionCountryMappings
+	case strings.HasPrefix(key, repository.CurriculumTagOrderPrefix):
+		parentTagIds, tagType := splitTagOrderKey(strings.TrimPrefix(key, repository.CurriculumTagOrderPrefix))
+		source, err = t.ptmr.FetchParentTagMappingsByParentTagIds(ctx, &parentTagIds, &tagType)
+	case strings.HasPrefix(key, repository.CurriculumGradeProductPrefix):
+		productId := strings.TrimPrefix(key, repository.CurriculumGradeProductPrefix)
+		source, err = t.gpr.FetchGradesFromProductId(ctx, &productId)
+	case strings.HasPrefix(key, repository.CurriculumCountryAdminPrefix):
+		return t.countryCacheSource(ctx, strings.TrimPrefix(key, repository.CurriculumCountryAdminPrefix), t.tr.FetchFilteredTagsPaginatedForAdmin)
+	case strings.HasPrefix(key, repository.CurriculumCountryPrefix):
+		return t.countryCacheSource(ctx, strings.TrimPrefix(key, repository.CurriculumCountryPrefix), t.tr.FetchFilteredTagsPaginated)
+	default:
+		return nil, false, nil
+	}
+	if err != nil {
+		return nil, false, err
+	}
+	return source, true, nil
+}
+
+func (t *TagsServiceStruct) countryCacheSource(ctx context.Context, suffix string, fetch func(context.Context, *string, *string, *int, *int) ([]*domain.Tags, error)) (source interface{}, ok bool, err error) {
+	parts := strings.Split(suffix, ":")
+	if len(parts) != 4 {
+		return nil, false, nil
+	}
+	start, err1 := strconv.Atoi(parts[2])
+	limit, err2 := strconv.Atoi(parts[3])
+	if err1 != nil || err2 != nil {
+		return nil, false, nil
+	}
+	source, err = fetch(ctx, &parts[0], &parts[1], &start, &limit)
+	if err != nil {
+		return nil, false, err
+	}
+	return source, true, nil
+}
+
+// splitTagOrderKey splits parentTagIds:tagType, parent ids never contain a colon
+func splitTagOrderKey(suffix string) (parentTagIds string, tagType string) {
+	separator := strings.LastIndex(suffix, ":")
+	if separator < 0 {
+		return suffix, ""
+	}
+	return suffix[:separator], suffix[separator+1:]
+}
+
+func scanKeys(ctx context.Context, pattern string) (keys []string, err error) {
+	var cursor uint64
+	for {
+		var batch []string
+		batch, cursor, err = repository.Client(ctx).Scan(cursor, pattern, cacheScanCount).Result()
+		if err != nil {
+			return nil, noonerror.New(noonerror.ErrInternalServer, "redisScanError")
+		}
+		keys = append(keys, batch...)
+		if cursor == 0 {
+			return keys, nil
+		}
+	}
+}
+
+func deleteKeys(ctx context.Context, keys []string) (deleted []string, err error) {
+	visited := make(map[string]struct{})
+	for _, key := range keys {
+		if _, ok := visited[key]; ok {
+			continue
+		}
+		visited[key] = struct{}{}
+		deleted = append(deleted, key)
+	}
+	if len(deleted) == 0 {
+		return deleted, nil
+	}
+	if err := repository.Client(ctx).Del(deleted...).Err(); err != nil {
+		return nil, noonerror.New(noonerror.ErrInternalServer, "redisDeleteError")
+	}
+	// the keys are evicted when the rows changed behind the cache, listings built from them are stale as well
+	bumpCacheGenerations(ctx, cacheGenerations...)
+	return deleted, nil
+}
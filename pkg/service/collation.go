@@ -0,0 +1,38 @@
+package service
+
+import (
+	"bitbucket.org/noon-micro/curriculum/pkg/domain"
+	"bitbucket.org/noon-micro/curriculum/pkg/service/constant"
+	"golang.org/x/text/collate"
+	"golang.org/x/text/language"
+	"sort"
+	"strings"
+)
+
+// newCollator compares names the way readers of locale expect them, ignoring case and ordering runs of digits by
+// their numeric value so that Grade 2 comes before Grade 10. A collator is not safe for concurrent use.
+func newCollator(locale *string) *collate.Collator {
+	tag := language.Make(constant.DefaultLocale)
+	if locale != nil && *locale != "" {
+		if parsed, err := language.Parse(strings.Replace(*locale, "_", "-", -1)); err == nil {
+			tag = parsed
+		}
+	}
+	return collate.New(tag, collate.IgnoreCase, collate.Numeric)
+}
+
+// sortTagsByName orders tags alphabetically in the collation of locale by the name shown to the reader, which is the
+// localized name when one was loaded. Tags with equal names keep their order.
+func sortTagsByName(tags []*domain.Tags, locale *string) {
+	collator := newCollator(locale)
+	sort.SliceStable(tags, func(i, j int) bool {
+		return collator.CompareString(displayName(tags[i]), displayName(tags[j])) < 0
+	})
+}
+
+func displayName(tag *domain.Tags) string {
+	if tag.LocaleName != nil && *tag.LocaleName != "" {
+		return *tag.LocaleName
+	}
+	return *tag.Name
+}
@@ -1,5 +1,7 @@
 package constant
 
+import "time"
+
 const (
 	RootCurriculum      = "root"
 	DerivedCurriculum   = "derived"
@@ -9,7 +11,16 @@ const (
 	TagLimit            = 50
 	OrderMax            = 1000
 	DefaultLocale       = "en"
-	DefaultGrade        = 99
+	// StudentDefaultLocale is served to students when neither the request nor the country settle the locale
+	StudentDefaultLocale = "ar"
+	GlobalCountryId      = "0"
+	DefaultGrade         = 99
+	LoaderMaxBatch       = 100
+	LoaderWait           = 2 * time.Millisecond
+	// AcademicCalendarAttribute is the country tag attribute holding the academic calendar
+	AcademicCalendarAttribute = "academic_calendar"
+	CalendarDateLayout        = "2006-01-02"
+	PromotionDateLayout       = "01-02"
 )
 
 var (
@@ -41,4 +52,12 @@ var (
 	MultiGradeMap = map[string]struct{}{
 		"9": {},
 	}
+	// LocalizableAttributes declares per tag type the attribute keys which may carry per locale and country values
+	LocalizableAttributes = map[string][]string{
+		"country": {"full_name", "currency", "currency_sub_unit", "currency_symbol"},
+		"subject": {"description"},
+		"test":    {"description"},
+		"skill":   {"description"},
+		"course":  {"description"},
+	}
 )
@@ -0,0 +1,47 @@
+package service
+
+import (
+	"bitbucket.org/noon-micro/curriculum/pkg/domain"
+	dtomapper "bitbucket.org/noon-micro/curriculum/pkg/service/mapper"
+	"context"
+	"strings"
+)
+
+// resolveCountryCode fills the iso code of the client from the geo ip providers unless it is already known, the
+// country header is handed to them as the country the client declared
+func resolveCountryCode(ctx context.Context, ts domain.TagsService, geo domain.GeoIp, tags *domain.GetCountriesNew) error {
+	if tags.ISOCode != "" {
+		return nil
+	}
+	getGeoIpRequest := dtomapper.GetGeoRequestEntity(&tags.IpAddress)
+	getGeoIpRequest.Country = declaredCountryCode(ctx, ts, tags.CountryId)
+	isoCode, err := geo.GetGeoIp(ctx, getGeoIpRequest)
+	if err != nil {
+		return err
+	}
+	if isoCode != nil {
+		tags.ISOCode = *isoCode
+	}
+	return nil
+}
+
+// declaredCountryCode reads the country header, which carries either an iso code or the id of a country tag. The
+// header is only a hint, a country which can not be read is ignored.
+func declaredCountryCode(ctx context.Context, ts domain.TagsService, countryId *string) *string {
+	if countryId == nil || strings.TrimSpace(*countryId) == "" {
+		return nil
+	}
+	country := strings.TrimSpace(*countryId)
+	if strings.Trim(country, "0123456789") != "" {
+		return &country
+	}
+	tag, err := ts.FetchTags(ctx, &country)
+	if err != nil || tag == nil || !tag.Publish || tag.Type == nil || *tag.Type != domain.TagTypeEnum.Country {
+		return nil
+	}
+	isoCode, _ := tag.Attributes["iso_code"].(string)
+	if isoCode == "" {
+		return nil
+	}
+	return &isoCode
+}
@@ -0,0 +1,54 @@
+package service
+
+import (
+	"bitbucket.org/noon-micro/curriculum/config"
+	"bitbucket.org/noon-micro/curriculum/pkg/external"
+	"bitbucket.org/noon-micro/curriculum/pkg/lib/logger"
+	memory "bitbucket.org/noon-micro/curriculum/pkg/repository/memory"
+	repository "bitbucket.org/noon-micro/curriculum/pkg/repository/redis"
+	"github.com/alicebob/miniredis/v2"
+	"github.com/go-redis/redis"
+	redistrace "gopkg.in/DataDog/dd-trace-go.v1/contrib/go-redis/redis"
+	"testing"
+)
+
+type testServices struct {
+	tags      *TagsServiceStruct
+	admin     *AdminTagsServiceStruct
+	publisher *TagEventPublisherStruct
+	broker    *external.MemoryTagEventBroker
+	changes   *TagChangeServiceStruct
+	webhooks  *WebhookServiceStruct
+}
+
+// newTestServices wires the services on a memory store seeded from the fixture of the memory environment and on a
+// fresh miniredis
+func newTestServices(t *testing.T) *testServices {
+	if logger.Client == nil {
+		logger.New()
+	}
+	if config.GetConfig() == nil {
+		config.LoadConfiguration("memory")
+	}
+	fixture, err := memory.LoadFixture("../../fixtures/memory.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	server, err := miniredis.Run()
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(server.Close)
+	repository.RedisClient = redistrace.NewClient(&redis.Options{Addr: server.Addr()})
+	repo := memory.InitializeMemory(fixture)
+	tagsService := NewTagsService(repo.Tags, repo.ParentTagMapping, repo.TagLocaleMapping, repo.TagAttributeLocale, repo.TagCountryRule, repo.RegionCountry, repo.LegacyTagMapping, repo.GradeProduct)
+	broker := external.NewMemoryTagEventBroker(100)
+	publisher := NewTagEventPublisher(tagsService, repo.TagEventOutbox, repo.TagChange, broker)
+	adminService := NewAdminTagsService(tagsService, external.NewMemoryElasticExternal(fixture), nil, publisher)
+	return &testServices{tags: tagsService, admin: adminService, publisher: publisher, broker: broker,
+		changes: NewTagChangeService(repo.TagChange), webhooks: NewWebhookService(repo.WebhookSubscription, repo.WebhookDelivery, nil)}
+}
+
+func str(s string) *string {
+	return &s
+}
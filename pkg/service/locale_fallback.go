@@ -0,0 +1,121 @@
+package service
+
+import (
+	"bitbucket.org/noon-micro/curriculum/config"
+	"bitbucket.org/noon-micro/curriculum/pkg/domain"
+	"bitbucket.org/noon-micro/curriculum/pkg/lib/helper"
+	"bitbucket.org/noon-micro/curriculum/pkg/service/constant"
+	"context"
+	"strings"
+	"sync"
+)
+
+// localeCandidate is one (country, locale) row looked up in tag_locale_mapping
+type localeCandidate struct {
+	countryId string
+	locale    string
+}
+
+var (
+	localeFallbacksOnce sync.Once
+	localeFallbacks     map[string]string
+)
+
+// localeFallbackChain lists the lookups for a requested country and locale, best match first. Every locale of
+// the chain is tried for the country and then for the global country, starting with the requested locale and
+// walking up its parent languages down to the default locale. The base tag name is served when the chain is
+// exhausted.
+func localeFallbackChain(countryId string, locale string) (chain []localeCandidate) {
+	visited := make(map[string]struct{})
+	for current := locale; current != ""; current = parentLocale(current) {
+		if _, ok := visited[current]; ok {
+			break
+		}
+		visited[current] = struct{}{}
+		chain = appendLocaleCandidates(chain, countryId, current)
+	}
+	if _, ok := visited[constant.DefaultLocale]; !ok {
+		chain = appendLocaleCandidates(chain, countryId, constant.DefaultLocale)
+	}
+	return chain
+}
+
+func appendLocaleCandidates(chain []localeCandidate, countryId string, locale string) []localeCandidate {
+	chain = append(chain, localeCandidate{countryId: countryId, locale: locale})
+	if countryId != constant.GlobalCountryId {
+		chain = append(chain, localeCandidate{countryId: constant.GlobalCountryId, locale: locale})
+	}
+	return chain
+}
+
+// parentLocale returns the configured fallback of locale, or its language when locale carries a region
+func parentLocale(locale string) string {
+	localeFallbacksOnce.Do(func() {
+		localeFallbacks = parseLocaleFallbacks(config.GetConfig().LocaleFallbacks)
+	})
+	if parent, ok := localeFallbacks[helper.NormalizeLocale(locale)]; ok {
+		return parent
+	}
+	if i := strings.IndexAny(locale, "-_"); i > 0 {
+		return locale[:i]
+	}
+	return ""
+}
+
+// parseLocaleFallbacks reads comma separated locale:fallback pairs such as "ar-EG:ar,ur:ar"
+func parseLocaleFallbacks(value string) map[string]string {
+	fallbacks := make(map[string]string)
+	for _, pair := range strings.Split(value, ",") {
+		parts := strings.SplitN(strings.TrimSpace(pair), ":", 2)
+		if len(parts) != 2 || parts[0] == "" {
+			continue
+		}
+		fallbacks[helper.NormalizeLocale(parts[0])] = helper.NormalizeLocale(parts[1])
+	}
+	return fallbacks
+}
+
+// servedLocales picks for every tag the first row of the chain found in rows, rows are keyed by
+// tagId:countryId:locale like the redis keys
+func servedLocales(tagIds []*string, chain []localeCandidate, rows map[string]*domain.TagLocaleMapping) (tagLocaleMap map[string]*domain.TagLocaleMapping) {
+	tagLocaleMap = make(map[string]*domain.TagLocaleMapping)
+	for _, tagId := range tagIds {
+		for _, candidate := range chain {
+			if row, ok := rows[*tagId+":"+candidate.countryId+":"+candidate.locale]; ok && row != nil && row.Name != nil {
+				tagLocaleMap[*tagId] = row
+				break
+			}
+		}
+	}
+	return tagLocaleMap
+}
+
+// servedLocale is the locale of the translation served, the default locale when the base name is served
+func servedLocale(tagLocale *domain.TagLocaleMapping) *string {
+	locale := constant.DefaultLocale
+	if tagLocale != nil && tagLocale.Locale != nil {
+		locale = *tagLocale.Locale
+	}
+	return &locale
+}
+
+// FetchCountryLocales reads the allowed_locales and locale attributes of a country tag, the locale attribute is
+// the default of the country. Tags which are not published countries have no allowed locales.
+func (t *TagsServiceStruct) FetchCountryLocales(ctx context.Context, countryId string) (allowed []string, defaultLocale string, err error) {
+	tag, err := t.FetchTags(ctx, &countryId)
+	if err != nil {
+		return nil, "", err
+	}
+	if tag == nil || !tag.Publish || tag.Type == nil || *tag.Type != domain.TagTypeEnum.Country {
+		return nil, "", nil
+	}
+	allowedLocales, _ := tag.Attributes["allowed_locales"].([]interface{})
+	for _, v := range allowedLocales {
+		allowedLocale, _ := v.(map[string]interface{})
+		if locale, ok := allowedLocale["locale"].(string); ok && locale != "" {
+			allowed = append(allowed, locale)
+		}
+	}
+	defaultLocale, _ = tag.Attributes["locale"].(string)
+	return allowed, defaultLocale, nil
+}
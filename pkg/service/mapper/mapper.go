@@ -44,6 +44,8 @@ func GetTagResponse(tagData []*domain.Tags, tagType *string, curriculumType *str
 		tagResponse.Attributes = v.Attributes
 		tagResponse.Name = v.Name
 		tagResponse.LocaleName = v.LocaleName
+		tagResponse.ServedLocale = v.ServedLocale
+		tagResponse.LocaleAttributes = v.LocaleAttributes
 		tagResponse.CurriculumType = &v.CurriculumType
 		tagResponses = append(tagResponses, tagResponse)
 	}
@@ -84,6 +86,8 @@ func GetTagResponseForProduct(tagData []*domain.Tags, tagType *string, curriculu
 		tagResponse.Type = v.Type
 		tagResponse.Name = v.Name
 		tagResponse.LocaleName = v.LocaleName
+		tagResponse.ServedLocale = v.ServedLocale
+		tagResponse.LocaleAttributes = v.LocaleAttributes
 		tagResponse.CurriculumType = &v.CurriculumType
 		tagResponses = append(tagResponses, tagResponse)
 	}
@@ -130,7 +134,7 @@ func CreateGetCountriesResponse(tagData []*domain.Tags, tags *domain.GetCountrie
 	return getTagResponse, nil
 }
 
-func CreateGetCountriesNewResponse(tagData []*domain.Tags, locale *string, ipDomain *string, next *int, admin bool) (*domain.GetCountriesNewResponse, error) {
+func CreateGetCountriesNewResponse(tagData []*domain.Tags, locale *string, ipDomain *string, defaultIsoCode string, next *int, admin bool) (*domain.GetCountriesNewResponse, error) {
 	meta := new(domain.CountriesNewMetaResponse)
 	var countriesResponses []*domain.CountriesAttributesResponse
 	getTagResponse := new(domain.GetCountriesNewResponse)
@@ -138,55 +142,56 @@ func CreateGetCountriesNewResponse(tagData []*domain.Tags, locale *string, ipDom
 	var defaultSelectedCountry = new(domain.CountriesAttributesResponse)
 	for _, v := range tagData {
 		countriesAttributeResponse := new(domain.CountriesAttributesResponse)
+		attributes := localizedAttributes(v)
 		defaultPaymentEnabled := false
 		countriesAttributeResponse.PaymentEnabled = &defaultPaymentEnabled
-		if v.Attributes["full_name"] != nil {
-			fullNameAttribute := v.Attributes["full_name"].(string)
+		if attributes["full_name"] != nil {
+			fullNameAttribute := attributes["full_name"].(string)
 			countriesAttributeResponse.FullName = &fullNameAttribute
 		}
 
-		if v.Attributes["payment_enabled"] != nil {
-			paymentEnabledAttribute := v.Attributes["payment_enabled"].(bool)
+		if attributes["payment_enabled"] != nil {
+			paymentEnabledAttribute := attributes["payment_enabled"].(bool)
 			countriesAttributeResponse.PaymentEnabled = &paymentEnabledAttribute
 		}
 
-		if v.Attributes["locale"] != nil {
-			localeAttribute := v.Attributes["locale"].(string)
+		if attributes["locale"] != nil {
+			localeAttribute := attributes["locale"].(string)
 			countriesAttributeResponse.Locale = &localeAttribute
 		}
 
-		if v.Attributes["calling_code"] != nil {
-			callingCodeAttribute := v.Attributes["calling_code"].(string)
+		if attributes["calling_code"] != nil {
+			callingCodeAttribute := attributes["calling_code"].(string)
 			countriesAttributeResponse.CallingCode = &callingCodeAttribute
 		}
 
-		if v.Attributes["currency"] != nil {
-			currencyAttribute := v.Attributes["currency"].(string)
+		if attributes["currency"] != nil {
+			currencyAttribute := attributes["currency"].(string)
 			countriesAttributeResponse.Currency = &currencyAttribute
 		}
 
-		if v.Attributes["flag"] != nil {
-			flagAttribute := v.Attributes["flag"].(string)
+		if attributes["flag"] != nil {
+			flagAttribute := attributes["flag"].(string)
 			countriesAttributeResponse.Flag = &flagAttribute
 		}
 
-		if v.Attributes["currency_sub_unit"] != nil {
-			currencySubUnitAttribute := v.Attributes["currency_sub_unit"].(string)
+		if attributes["currency_sub_unit"] != nil {
+			currencySubUnitAttribute := attributes["currency_sub_unit"].(string)
 			countriesAttributeResponse.CurrencySubUnit = &currencySubUnitAttribute
 		}
 
-		if v.Attributes["currency_symbol"] != nil {
-			currencySymbolAttribute := v.Attributes["currency_symbol"].(string)
+		if attributes["currency_symbol"] != nil {
+			currencySymbolAttribute := attributes["currency_symbol"].(string)
 			countriesAttributeResponse.CurrencySymbol = &currencySymbolAttribute
 		}
 
-		if v.Attributes["can_update_curriculum_country"] != nil {
-			canUpdateCurriculumCountry := v.Attributes["can_update_curriculum_country"].(bool)
+		if attributes["can_update_curriculum_country"] != nil {
+			canUpdateCurriculumCountry := attributes["can_update_curriculum_country"].(bool)
 			countriesAttributeResponse.CanUpdateCurriculumCountry = &canUpdateCurriculumCountry
 		}
 
-		if v.Attributes["onboarding"] != nil {
-			foomap := v.Attributes["onboarding"]
+		if attributes["onboarding"] != nil {
+			foomap := attributes["onboarding"]
 			aa := foomap.(map[string]interface{})
 			onboardingAttributes := new(domain.OnboardingAttributesResponse)
 			smsAttribute := aa["sms"].(bool)
@@ -198,8 +203,8 @@ func CreateGetCountriesNewResponse(tagData []*domain.Tags, locale *string, ipDom
 			countriesAttributeResponse.OnboardingAttributesResponse = onboardingAttributes
 		}
 
-		if v.Attributes["audio_config"] != nil {
-			audioConfig := v.Attributes["audio_config"]
+		if attributes["audio_config"] != nil {
+			audioConfig := attributes["audio_config"]
 			aa, ok := audioConfig.(map[string]interface{})
 			if ok {
 				audioConfigAttributes := new(domain.AudioConfigResponse)
@@ -219,8 +224,8 @@ func CreateGetCountriesNewResponse(tagData []*domain.Tags, locale *string, ipDom
 			}
 		}
 
-		if v.Attributes["phone_validation"] != nil {
-			foomap := v.Attributes["phone_validation"]
+		if attributes["phone_validation"] != nil {
+			foomap := attributes["phone_validation"]
 			phoneValidationKeys := foomap.(map[string]interface{})
 			phoneValidationAttributes := new(domain.PhoneValidationAttributes)
 			startValues := phoneValidationKeys["start_values"].([]interface{})
@@ -239,28 +244,29 @@ func CreateGetCountriesNewResponse(tagData []*domain.Tags, locale *string, ipDom
 			countriesAttributeResponse.PhoneValidation = phoneValidationAttributes
 		}
 
-		if v.Attributes["allowed_locales"] != nil {
-			foomap := v.Attributes["allowed_locales"]
+		if attributes["allowed_locales"] != nil {
+			foomap := attributes["allowed_locales"]
 			phoneValidationAttributes := []domain.AllowedLocaleAttributesResponse{}
 
 			mapstructure.Decode(foomap, &phoneValidationAttributes)
 			countriesAttributeResponse.AllowedLocales = phoneValidationAttributes
 		}
 
-		if v.Attributes["iso_code"] != nil {
-			isoCodeAttribute := v.Attributes["iso_code"].(string)
+		if attributes["iso_code"] != nil {
+			isoCodeAttribute := attributes["iso_code"].(string)
 			countriesAttributeResponse.IsoCode = &isoCodeAttribute
 			if ipDomain != nil && *countriesAttributeResponse.IsoCode == *ipDomain {
 				meta.SelectedCountry = countriesAttributeResponse
 			}
-			if *countriesAttributeResponse.IsoCode == "SA" {
+			if strings.EqualFold(*countriesAttributeResponse.IsoCode, defaultIsoCode) {
 				defaultSelectedCountry = countriesAttributeResponse
 			}
 		}
 
 		countriesAttributeResponse.ID = v.ID
 		countriesAttributeResponse.Name = v.Name
-		if locale != nil && *locale == constant.DefaultLocale && countriesAttributeResponse.FullName != nil && len(*countriesAttributeResponse.FullName) > 0 {
+		_, localizedFullName := v.LocaleAttributes["full_name"]
+		if locale != nil && (*locale == constant.DefaultLocale || localizedFullName) && countriesAttributeResponse.FullName != nil && len(*countriesAttributeResponse.FullName) > 0 {
 			countriesAttributeResponse.Name = countriesAttributeResponse.FullName
 		}
 		if admin {
@@ -278,6 +284,21 @@ func CreateGetCountriesNewResponse(tagData []*domain.Tags, locale *string, ipDom
 	return getTagResponse, nil
 }
 
+// localizedAttributes overlays the localized values resolved for the tag on its attributes
+func localizedAttributes(tag *domain.Tags) map[string]interface{} {
+	if len(tag.LocaleAttributes) == 0 {
+		return tag.Attributes
+	}
+	attributes := make(map[string]interface{}, len(tag.Attributes)+len(tag.LocaleAttributes))
+	for k, v := range tag.Attributes {
+		attributes[k] = v
+	}
+	for k, v := range tag.LocaleAttributes {
+		attributes[k] = v
+	}
+	return attributes
+}
+
 func CreateGetTagResponseWithIdentifiers(tagData []*domain.Tags, tags *domain.GetTags, setIdentifiers map[string]*domain.Tags, setTagIdentifiers map[string][]*string, next *int) (*domain.GetTagsResponse, error) {
 	curriculumHierarchy, err := flow.GetCurriculum(tags.CurriculumType)
 	if err != nil {
@@ -464,6 +485,7 @@ func CreateGetGradesResponse(tagData []*domain.Tags, hasCollege *bool) (*domain.
 		gradeResponse := new(domain.GradesAttributesResponse)
 		gradeResponse.ID = v.ID
 		gradeResponse.Name = v.Name
+		gradeResponse.ServedLocale = v.ServedLocale
 		if val, ok := GradeTagMap[*gradeResponse.ID]; ok {
 			gradeResponse.Grade = &val
 		}
@@ -486,6 +508,7 @@ func CreateGetDegreesResponse(tagData []*domain.Tags, next *int) (*domain.GetDeg
 		degreeResponse := new(domain.DegreesAttributesResponse)
 		degreeResponse.ID = v.ID
 		degreeResponse.Name = v.Name
+		degreeResponse.ServedLocale = v.ServedLocale
 		degreesResponses = append(degreesResponses, degreeResponse)
 	}
 	getDegreesResponse.Degrees = degreesResponses
@@ -501,6 +524,7 @@ func CreateGetBoardsResponse(tagData []*domain.Tags, hasCollege *bool) (*domain.
 		boardResponse := new(domain.BoardsAttributesResponse)
 		boardResponse.ID = v.ID
 		boardResponse.Name = v.Name
+		boardResponse.ServedLocale = v.ServedLocale
 		boardsResponses = append(boardsResponses, boardResponse)
 	}
 	getBoardsResponse.Boards = boardsResponses
@@ -518,6 +542,7 @@ func CreateGetMajorsResponse(tagData []*domain.Tags, next *int) (*domain.GetMajo
 		majorResponse := new(domain.MajorsAttributesResponse)
 		majorResponse.ID = v.ID
 		majorResponse.Name = v.Name
+		majorResponse.ServedLocale = v.ServedLocale
 		majorsResponses = append(majorsResponses, majorResponse)
 	}
 	meta := new(domain.MajorsMetaResponse)
@@ -0,0 +1,350 @@
+package service
+
+import (
+	"bitbucket.org/noon-micro/curriculum/pkg/domain"
+	noonerror "bitbucket.org/noon-micro/curriculum/pkg/lib/error"
+	repository "bitbucket.org/noon-micro/curriculum/pkg/repository/mysql"
+	redisrepo "bitbucket.org/noon-micro/curriculum/pkg/repository/redis"
+	"context"
+	"database/sql"
+	"encoding/json"
+	"strings"
+	"time"
+)
+
+const (
+	// GroupByRegion groups the country listings by the regions their countries belong to
+	GroupByRegion = "region"
+)
+
+func (t *TagsServiceStruct) CreateRegionCountryMapping(ctx context.Context, tx *sql.Tx, regionCountryMapping *domain.RegionCountryMapping) (err error) {
+	if err := redisrepo.Client(ctx).Del(redisrepo.CurriculumRegionCountryPrefix + *regionCountryMapping.RegionID).Err(); err != nil {
+		return noonerror.New(noonerror.ErrInternalServer, "redisDeleteError")
+	}
+	return t.rcmr.CreateRegionCountryMapping(ctx, tx, regionCountryMapping)
+}
+
+func (t *TagsServiceStruct) DeleteRegionCountryMapping(ctx context.Context, tx *sql.Tx, regionCountryMapping *domain.RegionCountryMapping) (err error) {
+	if err := redisrepo.Client(ctx).Del(redisrepo.CurriculumRegionCountryPrefix + *regionCountryMapping.RegionID).Err(); err != nil {
+		return noonerror.New(noonerror.ErrInternalServer, "redisDeleteError")
+	}
+	return t.rcmr.DeleteRegionCountryMapping(ctx, tx, regionCountryMapping.ID)
+}
+
+// FetchRegionCountryMappings returns the published member countries of the region in order
+func (t *TagsServiceStruct) FetchRegionCountryMappings(ctx context.Context, id *string) (regionCountryMappings []*domain.RegionCountryMapping, err error) {
+	result, err := t.batchRegionCountryMappings(ctx, []string{*id})
+	if err != nil {
+		return nil, err
+	}
+	regionCountryMappings, _ = result[*id].([]*domain.RegionCountryMapping)
+	return regionCountryMappings, nil
+}
+
+// FetchRegions returns the published regions with their member countries
+func (t *TagsServiceStruct) FetchRegions(ctx context.Context) ([]*domain.Region, error) {
+	rootCurriculumType := domain.CurriculumTypeEnum.Root
+	regionType := domain.TagTypeEnum.Region
+	regionTags, err := t.FetchFilteredTags(ctx, &rootCurriculumType, &regionType)
+	if err != nil {
+		return nil, err
+	}
+	regionIds := make([]string, 0, len(regionTags))
+	for _, v := range regionTags {
+		regionIds = append(regionIds, *v.ID)
+	}
+	result, err := t.batchRegionCountryMappings(ctx, regionIds)
+	if err != nil {
+		return nil, err
+	}
+	regions := make([]*domain.Region, 0, len(regionTags))
+	for _, v := range regionTags {
+		region := &domain.Region{ID: v.ID, Name: v.Name, Countries: []*string{}}
+		mappings, _ := result[*v.ID].([]*domain.RegionCountryMapping)
+		for _, mapping := range mappings {
+			region.Countries = append(region.Countries, mapping.CountryId)
+		}
+		regions = append(regions, region)
+	}
+	return regions, nil
+}
+
+// GroupCountriesByRegion returns the regions having any of the countries as a member, limited to those countries
+func (t *TagsServiceStruct) GroupCountriesByRegion(ctx context.Context, countries []*domain.Tags) ([]*domain.Region, error) {
+	regions, err := t.FetchRegions(ctx)
+	if err != nil {
+		return nil, err
+	}
+	listed := make(map[string]struct{}, len(countries))
+	for _, v := range countries {
+		listed[*v.ID] = struct{}{}
+	}
+	grouped := []*domain.Region{}
+	for _, v := range regions {
+		region := &domain.Region{ID: v.ID, Name: v.Name, Countries: []*string{}}
+		for _, countryId := range v.Countries {
+			if _, ok := listed[*countryId]; ok {
+				region.Countries = append(region.Countries, countryId)
+			}
+		}
+		if len(region.Countries) > 0 {
+			grouped = append(grouped, region)
+		}
+	}
+	return grouped, nil
+}
+
+// ResolveRegionParents replaces a region at the root of each parent path by each of its member countries in turn,
+// paths starting with any other tag are kept as they are
+func (t *TagsServiceStruct) ResolveRegionParents(ctx context.Context, parents []*string) ([]*string, error) {
+	var rootIds []*string
+	roots := make(map[string]struct{}, len(parents))
+	for _, v := range parents {
+		if v == nil {
+			continue
+		}
+		root := strings.SplitN(*v, ".", 2)[0]
+		if _, ok := roots[root]; !ok {
+			roots[root] = struct{}{}
+			rootIds = append(rootIds, &root)
+		}
+	}
+	if len(rootIds) == 0 {
+		return parents, nil
+	}
+	rootTags, err := t.FetchByInTags(ctx, rootIds)
+	if err != nil {
+		return nil, err
+	}
+	var regionIds []string
+	for _, v := range rootTags {
+		if v != nil && v.Type != nil && *v.Type == domain.TagTypeEnum.Region {
+			regionIds = append(regionIds, *v.ID)
+		}
+	}
+	if len(regionIds) == 0 {
+		return parents, nil
+	}
+	result, err := t.batchRegionCountryMappings(ctx, regionIds)
+	if err != nil {
+		return nil, err
+	}
+	var resolved []*string
+	seen := make(map[string]struct{}, len(parents))
+	add := func(parent string) {
+		if _, ok := seen[parent]; !ok {
+			seen[parent] = struct{}{}
+			resolved = append(resolved, &parent)
+		}
+	}
+	for _, v := range parents {
+		if v == nil {
+			continue
+		}
+		parts := strings.SplitN(*v, ".", 2)
+		mappings, ok := result[parts[0]].([]*domain.RegionCountryMapping)
+		if !ok {
+			add(*v)
+			continue
+		}
+		for _, mapping := range mappings {
+			parts[0] = *mapping.CountryId
+			add(strings.Join(parts, "."))
+		}
+	}
+	return resolved, nil
+}
+
+// batchRegionCountryMappings caches the published member countries per region, regions without any are cached as an
+// empty list
+func (t *TagsServiceStruct) batchRegionCountryMappings(ctx context.Context, ids []string) (map[string]interface{}, error) {
+	result := make(map[string]interface{}, len(ids))
+	var missing []*string
+	for i, val := range mGet(ctx, redisrepo.CurriculumRegionCountryPrefix, ids) {
+		var regionCountryMappings []*domain.RegionCountryMapping
+		if val == nil || json.Unmarshal([]byte(*val), &regionCountryMappings) != nil {
+			missing = append(missing, &ids[i])
+			continue
+		}
+		result[ids[i]] = regionCountryMappings
+	}
+	if len(missing) == 0 {
+		return result, nil
+	}
+	regionCountryMappings, err := t.rcmr.FetchByInRegionCountryMappings(ctx, missing)
+	if err != nil {
+		return nil, err
+	}
+	grouped := make(map[string][]*domain.RegionCountryMapping, len(missing))
+	for _, id := range missing {
+		grouped[*id] = []*domain.RegionCountryMapping{}
+	}
+	for _, v := range regionCountryMappings {
+		grouped[*v.RegionID] = append(grouped[*v.RegionID], v)
+	}
+	for regionId, rows := range grouped {
+		result[regionId] = rows
+		tagByte, err := json.Marshal(rows)
+		if err == nil {
+			redisrepo.Client(ctx).Set(redisrepo.CurriculumRegionCountryPrefix+regionId, string(tagByte), redisrepo.RedisTtl)
+		}
+	}
+	return result, nil
+}
+
+// regionAsCountry lets a region stand at the country level of a hierarchy, the parent path built from it is then
+// resolved to the member countries through ResolveRegionParents
+func regionAsCountry(tagHierarchySlice []*domain.Tags) []*domain.Tags {
+	resolved := make([]*domain.Tags, len(tagHierarchySlice))
+	for i, v := range tagHierarchySlice {
+		resolved[i] = v
+		if v != nil && v.Type != nil && *v.Type == domain.TagTypeEnum.Region {
+			country := *v
+			countryType := domain.TagTypeEnum.Country
+			country.Type = &countryType
+			resolved[i] = &country
+		}
+	}
+	return resolved
+}
+
+// regionSearchWindow bounds how deep the merged search of several parents pages, as every page searches each
+// parent again from its first result
+const regionSearchWindow = 1000
+
+// searchParents runs the search under each parent and merges the ids in the order of the parents without
+// duplicates. Every parent is searched from its first result so that the merged list pages like a single parent,
+// the pages stop at regionSearchWindow results to keep the cost of a page bounded.
+func searchParents(ctx context.Context, search func(context.Context, *domain.GetTagsElastic) ([]*string, *int, error), query *domain.GetTagsElastic, parents []*string) ([]*string, *int, error) {
+	if len(parents) <= 1 {
+		query.Parents = parents
+		return search(ctx, query)
+	}
+	if query.Limit == 0 {
+		query.Limit = defaultSearchLimit
+	}
+	next := -1
+	end := query.Start + query.Limit
+	if end > regionSearchWindow {
+		end = regionSearchWindow
+	}
+	if query.Start >= end {
+		return nil, &next, nil
+	}
+	more := false
+	var merged []*string
+	seen := make(map[string]struct{})
+	for _, parent := range parents {
+		parentQuery := *query
+		parentQuery.Parents = []*string{parent}
+		parentQuery.Start, parentQuery.Limit = 0, end
+		if parentQuery.CountryId != "" {
+			parentQuery.CountryId = strings.SplitN(*parent, ".", 2)[0]
+		}
+		tagIds, next, err := search(ctx, &parentQuery)
+		if err != nil {
+			return nil, nil, err
+		}
+		more = more || (next != nil && *next != -1)
+		for _, v := range tagIds {
+			if _, ok := seen[*v]; !ok {
+				seen[*v] = struct{}{}
+				merged = append(merged, v)
+			}
+		}
+	}
+	if (more || len(merged) > end) && end < regionSearchWindow {
+		next = end
+	}
+	if query.Start >= len(merged) {
+		return nil, &next, nil
+	}
+	if end > len(merged) {
+		end = len(merged)
+	}
+	return merged[query.Start:end], &next, nil
+}
+
+// GetRegions lists every published region with its member countries
+func (t *AdminTagsServiceStruct) GetRegions(ctx context.Context) ([]*domain.Region, error) {
+	return t.ts.FetchRegions(ctx)
+}
+
+// UpdateRegionCountries replaces the member countries of the region in a single transaction, the countries keep the
+// order they are given in
+func (t *AdminTagsServiceStruct) UpdateRegionCountries(ctx context.Context, id *string, update *domain.UpdateRegionCountries) (*domain.Region, error) {
+	regionTag, err := t.ts.FetchTags(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if regionTag == nil || regionTag.Type == nil || *regionTag.Type != domain.TagTypeEnum.Region {
+		return nil, noonerror.New(noonerror.ErrBadRequest, "regionNotFound")
+	}
+	var countryIds []*string
+	seen := make(map[string]struct{}, len(update.Countries))
+	for _, v := range update.Countries {
+		countryId := strings.TrimSpace(*v)
+		if _, ok := seen[countryId]; ok {
+			return nil, noonerror.New(noonerror.ErrBadRequest, "regionCountryDuplicate")
+		}
+		seen[countryId] = struct{}{}
+		countryIds = append(countryIds, &countryId)
+	}
+	countries, err := t.ts.FetchByInTags(ctx, countryIds)
+	if err != nil {
+		return nil, err
+	}
+	published := make(map[string]struct{}, len(countries))
+	for _, v := range countries {
+		if v.Publish && v.Type != nil && *v.Type == domain.TagTypeEnum.Country {
+			published[*v.ID] = struct{}{}
+		}
+	}
+	for countryId := range seen {
+		if _, ok := published[countryId]; !ok {
+			return nil, noonerror.New(noonerror.ErrBadRequest, "countryNotFound")
+		}
+	}
+	current, err := t.ts.FetchRegionCountryMappings(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	tx, err := repository.Db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, noonerror.New(noonerror.ErrInternalServer, "ContextCreationError")
+	}
+	for _, v := range current {
+		if err = t.ts.DeleteRegionCountryMapping(ctx, tx, v); err != nil {
+			_ = tx.Rollback()
+			return nil, err
+		}
+	}
+	for i, countryId := range countryIds {
+		order := i + 1
+		if err = t.ts.CreateRegionCountryMapping(ctx, tx, &domain.RegionCountryMapping{RegionID: id, CountryId: countryId, Order: &order,
+			Publish: true, CreatedAt: time.Now(), UpdatedAt: time.Now()}); err != nil {
+			_ = tx.Rollback()
+			return nil, err
+		}
+	}
+	if err = tx.Commit(); err != nil {
+		return nil, noonerror.New(noonerror.ErrInternalServer, "dbCommitError")
+	}
+	bumpCacheGenerations(ctx, domain.TagChangeEntityEnum.Tag)
+	mappings, err := t.ts.FetchRegionCountryMappings(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	region := &domain.Region{ID: regionTag.ID, Name: regionTag.Name, Countries: []*string{}}
+	for _, v := range mappings {
+		region.Countries = append(region.Countries, v.CountryId)
+	}
+	return region, nil
+}
+
+// parentSearch binds searchParents to the parents for searchAvailableTags
+func parentSearch(search tagSearch, parents []*string) tagSearch {
+	return func(ctx context.Context, query *domain.GetTagsElastic) ([]*string, *int, error) {
+		return searchParents(ctx, search, query, parents)
+	}
+}
@@ -0,0 +1,74 @@
+package service
+
+import (
+	"bitbucket.org/noon-micro/curriculum/pkg/domain"
+	"context"
+	"reflect"
+	"strconv"
+	"testing"
+)
+
+// parentPagedSearch serves the ids of the parent of the query in pages and records the deepest result asked for
+func parentPagedSearch(ids map[string][]string, deepest *int) tagSearch {
+	return func(ctx context.Context, query *domain.GetTagsElastic) ([]*string, *int, error) {
+		if query.Start+query.Limit > *deepest {
+			*deepest = query.Start + query.Limit
+		}
+		return pagedSearch(ids[*query.Parents[0]]...)(ctx, query)
+	}
+}
+
+func TestSearchParentsPages(t *testing.T) {
+	deepest := 0
+	search := parentPagedSearch(map[string][]string{"9": {"1", "2", "3"}, "10": {"3", "4", "5"}}, &deepest)
+	tests := []struct {
+		start int
+		limit int
+		want  []string
+		next  int
+	}{
+		{start: 0, limit: 2, want: []string{"1", "2"}, next: 2},
+		{start: 2, limit: 2, want: []string{"3", "4"}, next: 4},
+		{start: 4, limit: 2, want: []string{"5"}, next: -1},
+		{start: 6, limit: 2, next: -1},
+	}
+	for _, test := range tests {
+		tagIds, next, err := searchParents(context.Background(), search, &domain.GetTagsElastic{Start: test.start, Limit: test.limit}, []*string{str("9"), str("10")})
+		if err != nil {
+			t.Fatal(err)
+		}
+		var ids []string
+		for _, v := range tagIds {
+			ids = append(ids, *v)
+		}
+		if !reflect.DeepEqual(ids, test.want) || next == nil || *next != test.next {
+			t.Errorf("start %d: got %v next %v, want %v next %d", test.start, ids, next, test.want, test.next)
+		}
+	}
+}
+
+func TestSearchParentsStopsAtWindow(t *testing.T) {
+	ids := make(map[string][]string)
+	for _, parent := range []string{"9", "10"} {
+		for i := 0; i < 2*regionSearchWindow; i++ {
+			ids[parent] = append(ids[parent], parent+"-"+strconv.Itoa(i))
+		}
+	}
+	deepest := 0
+	search := parentPagedSearch(ids, &deepest)
+	parents := []*string{str("9"), str("10")}
+	tagIds, next, err := searchParents(context.Background(), search, &domain.GetTagsElastic{Start: regionSearchWindow - 50, Limit: 100}, parents)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(tagIds) != 50 || next == nil || *next != -1 {
+		t.Errorf("last page of the window returned %d ids next %v, want 50 ids next -1", len(tagIds), next)
+	}
+	tagIds, next, err = searchParents(context.Background(), search, &domain.GetTagsElastic{Start: regionSearchWindow, Limit: 100}, parents)
+	if err != nil || len(tagIds) != 0 || next == nil || *next != -1 {
+		t.Errorf("page past the window returned %d ids next %v err %v", len(tagIds), next, err)
+	}
+	if deepest > regionSearchWindow {
+		t.Errorf("a parent was searched %d results deep, past the window of %d", deepest, regionSearchWindow)
+	}
+}
@@ -5,13 +5,13 @@ import (
 	"bitbucket.org/noon-micro/curriculum/pkg/domain"
 	noonerror "bitbucket.org/noon-micro/curriculum/pkg/lib/error"
 	"bitbucket.org/noon-micro/curriculum/pkg/lib/flow"
+	"bitbucket.org/noon-micro/curriculum/pkg/lib/helper"
 	"bitbucket.org/noon-micro/curriculum/pkg/lib/logger"
 	repository "bitbucket.org/noon-micro/curriculum/pkg/repository/mysql"
 	redisrepo "bitbucket.org/noon-micro/curriculum/pkg/repository/redis"
 	"bitbucket.org/noon-micro/curriculum/pkg/service/constant"
 	dtomapper "bitbucket.org/noon-micro/curriculum/pkg/service/mapper"
 	"context"
-	"encoding/json"
 	"github.com/jinzhu/copier"
 	"strconv"
 	"sync"
@@ -21,17 +21,18 @@ import (
 type RpcTagsServiceStruct struct {
 	ts domain.TagsService
 	es domain.Elastic
+	ep domain.TagEventPublisher
 }
 
-func NewRpcTagsService(ts domain.TagsService, es domain.Elastic) *RpcTagsServiceStruct {
-	return &RpcTagsServiceStruct{ts: ts, es: es}
+func NewRpcTagsService(ts domain.TagsService, es domain.Elastic, ep domain.TagEventPublisher) *RpcTagsServiceStruct {
+	return &RpcTagsServiceStruct{ts: ts, es: es, ep: ep}
 }
 
-func (t *RpcTagsServiceStruct) CreateTags(tags *domain.CreateMultipleTags) (tagResponses []*domain.TagResponse, err error) {
+func (t *RpcTagsServiceStruct) CreateTags(ctx context.Context, tags *domain.CreateMultipleTags) (tagResponses []*domain.TagResponse, err error) {
 	if len(tags.Hierarchy) == 0 {
 		return nil, noonerror.New(noonerror.ErrBadRequest, "hierarchyAbsent")
 	}
-	tagHierarchySlice, err := t.ts.FetchByInTags(tags.Hierarchy)
+	tagHierarchySlice, err := t.ts.FetchByInTags(ctx, tags.Hierarchy)
 	if err != nil {
 		return
 	}
@@ -43,7 +44,7 @@ func (t *RpcTagsServiceStruct) CreateTags(tags *domain.CreateMultipleTags) (tagR
 	}
 	var identifierSlice []*domain.Tags
 	if len(tags.Identifier) > 0 {
-		identifierSlice, err = t.ts.FetchByInTags(tags.Identifier)
+		identifierSlice, err = t.ts.FetchByInTags(ctx, tags.Identifier)
 	}
 	curriculumHierarchy, err := flow.GetCurriculum(tags.CurriculumType)
 	if err != nil {
@@ -75,7 +76,7 @@ func (t *RpcTagsServiceStruct) CreateTags(tags *domain.CreateMultipleTags) (tagR
 					errChan <- noonerror.New(noonerror.ErrInternalServer, "createTagPanicked")
 				}
 			}()
-			tagData, err := t.createTag(val, tags, parentTags, parentHideOrderTags, parentIdentifierTagIds, identifierSlice)
+			tagData, err := t.createTag(ctx, val, tags, parentTags, parentHideOrderTags, parentIdentifierTagIds, identifierSlice)
 			if err != nil {
 				errChan <- err
 			}
@@ -107,114 +108,97 @@ func (t *RpcTagsServiceStruct) CreateTags(tags *domain.CreateMultipleTags) (tagR
 	return tagResponses, nil
 }
 
-func (t *RpcTagsServiceStruct) GetTagsByIds(tags *domain.GetTagsByIds, locale bool) (tagResponses []*domain.TagResponse, err error) {
-
-	errChan := make(chan error)
-	wgDone := make(chan bool)
-	tagDataChan := make(chan *domain.TagResponse, len(tags.TagIds))
-	var wg sync.WaitGroup
+// tagLoader returns the loader attached to the request, or a fresh one when called outside of http
+func (t *RpcTagsServiceStruct) tagLoader(ctx context.Context) domain.TagLoader {
+	if ldr, ok := domain.TagLoaderFromContext(ctx); ok {
+		return ldr
+	}
+	return t.ts.NewTagLoader(ctx)
+}
 
-	for _, v := range tags.TagIds {
-		wg.Add(1)
-		go func(v *string) {
-			if v == nil {
-				wg.Done()
-				return
+func (t *RpcTagsServiceStruct) GetTagsByIds(ctx context.Context, tags *domain.GetTagsByIds, locale bool) (tagResponses []*domain.TagResponse, err error) {
+	ldr := t.tagLoader(ctx)
+	tagDataSlice, err := ldr.LoadTags(tags.TagIds)
+	if err != nil {
+		return nil, err
+	}
+	tagDataSlice, err = ldr.LoadTagLocales(tagDataSlice, tags.CountryId, tags.Locale)
+	if err != nil {
+		return nil, err
+	}
+	tagDataSlice, err = ldr.LoadTagAvailability(tagDataSlice, tags.CountryId)
+	if err != nil {
+		return nil, err
+	}
+	tagLocaleMap := make(map[string][]*domain.TagLocaleMapping)
+	if locale {
+		var localeTagIds []*string
+		for _, v := range tagDataSlice {
+			if v.LocaleAvailable {
+				localeTagIds = append(localeTagIds, v.ID)
 			}
-			defer func() {
-				if err := recover(); err != nil {
-					logger.Client.Error("getTags:id:"+*v, logger.GetErrorStack())
-					errChan <- noonerror.New(noonerror.ErrInternalServer, "getTagsPanicked")
+		}
+		tagLocaleData, err := ldr.LoadTagLocaleMappings(localeTagIds)
+		if err != nil {
+			return nil, err
+		}
+		for _, v := range tagLocaleData {
+			tagLocaleMap[*v.TagID] = append(tagLocaleMap[*v.TagID], v)
+		}
+	}
+	for _, tagData := range tagDataSlice {
+		tagResponse := new(domain.TagResponse)
+		tagResponse.ID = tagData.ID
+		tagResponse.Type = tagData.Type
+		tagResponse.Name = tagData.Name
+		if tagData.LocaleName != nil {
+			tagResponse.Name = tagData.LocaleName
+		}
+		tagResponse.ServedLocale = tagData.ServedLocale
+		tagResponse.Available = tagData.Available
+		if *tagData.Type == domain.TagTypeEnum.Grade {
+			for k, v := range constant.GradeTagMap {
+				if *tagData.ID == v {
+					grade, _ := strconv.Atoi(k)
+					tagResponse.Grade = &grade
 				}
-			}()
-			tagData, err := t.ts.FetchTags(v)
-			if err != nil {
-				errChan <- err
-				return
-			}
-			if tagData == nil {
-				wg.Done()
-				return
-			}
-			tagDataSlice, err := t.ts.FetchTagLocaleMappingsByLocale([]*domain.Tags{tagData}, tags.CountryId, tags.Locale)
-			if err != nil {
-				errChan <- err
-				return
-			}
-			tagData = tagDataSlice[0]
-			tagResponse := new(domain.TagResponse)
-			var tagLocaleData []*domain.TagLocaleMapping
-			tagResponse.ID = v
-			tagResponse.Type = tagData.Type
-			tagResponse.Name = tagData.Name
-			if tagData.LocaleName != nil {
-				tagResponse.Name = tagData.LocaleName
-			}
-			if *tagData.Type == domain.TagTypeEnum.Grade {
-				for k, v := range constant.GradeTagMap {
-					if *tagData.ID == v {
-						grade, _ := strconv.Atoi(k)
-						tagResponse.Grade = &grade
-					}
-					if tagResponse.Grade == nil {
-						defaultGrade := constant.DefaultGrade
-						tagResponse.Grade = &defaultGrade
-					}
+				if tagResponse.Grade == nil {
+					defaultGrade := constant.DefaultGrade
+					tagResponse.Grade = &defaultGrade
 				}
 			}
-			//for board tag data type
-			if *tagData.Type == domain.TagTypeEnum.Board && *tagData.ID == config.GetConfig().BoardTagId {
-				var boardAttributes = make(map[string]interface{})
-				boardAttributes["is_default"]=true
-				tagData.Attributes=boardAttributes
-			}
-			if *tagData.Type == domain.TagTypeEnum.Country && tags.Locale != nil && *tags.Locale == constant.DefaultLocale {
-				fullNameInterface, ok := tagData.Attributes["full_name"]
-				if ok {
-					fullName, okAssertion := fullNameInterface.(string)
-					if okAssertion {
-						*tagData.Name = fullName
-					}
+		}
+		//for board tag data type
+		if *tagData.Type == domain.TagTypeEnum.Board && *tagData.ID == config.GetConfig().BoardTagId {
+			var boardAttributes = make(map[string]interface{})
+			boardAttributes["is_default"]=true
+			tagData.Attributes=boardAttributes
+		}
+		if *tagData.Type == domain.TagTypeEnum.Country && tags.Locale != nil && *tags.Locale == constant.DefaultLocale {
+			fullNameInterface, ok := tagData.Attributes["full_name"]
+			if ok {
+				fullName, okAssertion := fullNameInterface.(string)
+				if okAssertion {
+					*tagData.Name = fullName
 				}
 			}
+		}
 
-			tagResponse.CurriculumType = &tagData.CurriculumType
-			tagResponse.Attributes = tagData.Attributes
-			if tagData.LocaleAvailable && locale {
-				tagLocaleData, err = t.ts.FetchTagLocaleMappings(v)
-				if err != nil {
-					errChan <- err
-					return
-				}
-				for _, val := range tagLocaleData {
-					var locale domain.LocaleResponse
-					locale.Locale = val.Locale
-					locale.Name = val.Name
-					locale.CountryId = val.CountryId
-					tagResponse.Locale = append(tagResponse.Locale, &locale)
-				}
-			}
-			tagDataChan <- tagResponse
-			wg.Done()
-		}(v)
-	}
-	go func() {
-		wg.Wait()
-		close(tagDataChan)
-		close(wgDone)
-	}()
-	select {
-	case <-wgDone:
-		for v := range tagDataChan {
-			tagResponses = append(tagResponses, v)
+		tagResponse.CurriculumType = &tagData.CurriculumType
+		tagResponse.Attributes = tagData.Attributes
+		for _, val := range tagLocaleMap[*tagData.ID] {
+			var locale domain.LocaleResponse
+			locale.Locale = val.Locale
+			locale.Name = val.Name
+			locale.CountryId = val.CountryId
+			tagResponse.Locale = append(tagResponse.Locale, &locale)
 		}
-	case err = <-errChan:
-		return nil, err
+		tagResponses = append(tagResponses, tagResponse)
 	}
 	return tagResponses, nil
 }
 
-func (t *RpcTagsServiceStruct) ValidateHierarchy(validateHierarchy *domain.ValidateHierarchy) (err error) {
+func (t *RpcTagsServiceStruct) ValidateHierarchy(ctx context.Context, validateHierarchy *domain.ValidateHierarchy) (err error) {
 	tagIdMap := make(map[string]*domain.Tags)
 	var allTagIds []*string
 	for _, v := range validateHierarchy.Hierarchies {
@@ -229,7 +213,7 @@ func (t *RpcTagsServiceStruct) ValidateHierarchy(validateHierarchy *domain.Valid
 			}
 		}
 	}
-	allTags, err := t.ts.FetchByInTags(allTagIds)
+	allTags, err := t.ts.FetchByInTags(ctx, allTagIds)
 	if err != nil {
 		return err
 	}
@@ -255,7 +239,7 @@ func (t *RpcTagsServiceStruct) ValidateHierarchy(validateHierarchy *domain.Valid
 					errChan <- noonerror.New(noonerror.ErrInternalServer, "validateHierarchyPanicked")
 				}
 			}()
-			err := t.validateHierarchySingle(v, validateHierarchy.CurriculumType, tagIdMap)
+			err := t.validateHierarchySingle(ctx, v, validateHierarchy.CurriculumType, tagIdMap)
 			if err != nil {
 				errChan <- err
 			}
@@ -274,7 +258,7 @@ func (t *RpcTagsServiceStruct) ValidateHierarchy(validateHierarchy *domain.Valid
 	}
 }
 
-func (t *RpcTagsServiceStruct) validateHierarchySingle(hierarchy []*string, curriculumType *string, tagMap map[string]*domain.Tags) (err error) {
+func (t *RpcTagsServiceStruct) validateHierarchySingle(ctx context.Context, hierarchy []*string, curriculumType *string, tagMap map[string]*domain.Tags) (err error) {
 	if curriculumType == nil {
 		return noonerror.New(noonerror.ErrBadRequest, "hierarchyInvalid")
 	}
@@ -318,7 +302,7 @@ func (t *RpcTagsServiceStruct) validateHierarchySingle(hierarchy []*string, curr
 		if err != nil {
 			return err
 		}
-		parentTagMapping, err := t.ts.FetchParentTagMappingByParentTagIdTagId(&tagId, parentHideOrderTags)
+		parentTagMapping, err := t.ts.FetchParentTagMappingByParentTagIdTagId(ctx, &tagId, parentHideOrderTags)
 		if err != nil {
 			return err
 		}
@@ -332,8 +316,7 @@ func (t *RpcTagsServiceStruct) validateHierarchySingle(hierarchy []*string, curr
 	return
 }
 
-func (t *RpcTagsServiceStruct) createTag(createTag *domain.CreateTag, tags *domain.CreateMultipleTags, parentTags *string, parentHideOrderTags *string, parentIdentifierTagIds map[string]string, identifierSlice []*domain.Tags) (tagResponse *domain.TagResponse, err error) {
-	ctx := context.Background()
+func (t *RpcTagsServiceStruct) createTag(ctx context.Context, createTag *domain.CreateTag, tags *domain.CreateMultipleTags, parentTags *string, parentHideOrderTags *string, parentIdentifierTagIds map[string]string, identifierSlice []*domain.Tags) (tagResponse *domain.TagResponse, err error) {
 	tx, err := repository.Db.BeginTx(ctx, nil)
 	if err != nil {
 		return nil, noonerror.New(noonerror.ErrInternalServer, "ContextCreationError")
@@ -355,7 +338,7 @@ func (t *RpcTagsServiceStruct) createTag(createTag *domain.CreateTag, tags *doma
 	if err != nil {
 		return nil, err
 	}
-	tagId, err := t.ts.CreateTags(tx, &domain.Tags{Type: tags.Type, Name: createTag.Name, CurriculumType: *mappedCurriculumType,
+	tagId, err := t.ts.CreateTags(ctx, tx, &domain.Tags{Type: tags.Type, Name: createTag.Name, CurriculumType: *mappedCurriculumType,
 		CreatorId: tags.CreatorId, CreatorType: *tags.CreatorType, Access: domain.AccessEnum.Teacher, TagGroup: *tags.TagGroup, LocaleAvailable: false, CountryId: createTag.CountryId,
 		Attributes: tags.Attributes, Publish: true, CreatedAt: time.Now(), UpdatedAt: time.Now()})
 	if err != nil {
@@ -392,15 +375,15 @@ func (t *RpcTagsServiceStruct) createTag(createTag *domain.CreateTag, tags *doma
 	}
 	rollback := func() {
 		deleteTag := false
-		go func() {
-			_ = t.es.UpdateTag(tagId, &deleteTag, nil)
-		}()
+		go func(ctx context.Context) {
+			_ = t.es.UpdateTag(ctx, tagId, &deleteTag, nil)
+		}(helper.Detach(ctx))
 	}
 	createElasticEntity, err := dtomapper.CreateElasticTagEntity(tagId, &createTags, allParentTags, domain.AccessEnum.Teacher)
 	if err != nil {
 		return
 	}
-	err = t.es.CreateTag(createElasticEntity)
+	err = t.es.CreateTag(ctx, createElasticEntity)
 	if err != nil {
 		rollback()
 		_ = tx.Rollback()
@@ -409,13 +392,20 @@ func (t *RpcTagsServiceStruct) createTag(createTag *domain.CreateTag, tags *doma
 	for k, v := range parentIdMap {
 		key := k
 		order := 0
-		err = t.ts.CreateParentTagMapping(tx, &domain.ParentTagMapping{TagID: tagId, TagType: tags.Type, ParentTagType: &key, ParentTagID: v, Order: &order, Hidden: false, Publish: true, CreatedAt: time.Now(), UpdatedAt: time.Now()})
+		err = t.ts.CreateParentTagMapping(ctx, tx, &domain.ParentTagMapping{TagID: tagId, TagType: tags.Type, ParentTagType: &key, ParentTagID: v, Order: &order, Hidden: false, Publish: true, CreatedAt: time.Now(), UpdatedAt: time.Now()})
 		if err != nil {
 			rollback()
 			_ = tx.Rollback()
 			return nil, err
 		}
 	}
+	err = t.ep.Publish(ctx, tx, domain.TagEventTypeEnum.Created, tagId, tags.Type, &domain.TagCreatedEventData{Name: createTag.Name,
+		TagGroup: tags.TagGroup, CurriculumType: tags.CurriculumType, Hierarchy: tags.Hierarchy})
+	if err != nil {
+		rollback()
+		_ = tx.Rollback()
+		return
+	}
 	err = tx.Commit()
 	if err != nil {
 		rollback()
@@ -429,20 +419,20 @@ func (t *RpcTagsServiceStruct) createTag(createTag *domain.CreateTag, tags *doma
 	}, nil
 }
 
-func (t *RpcTagsServiceStruct) GetTags(tags *domain.GetTags) (getTagResponse *domain.GetTagsResponse, err error) {
+func (t *RpcTagsServiceStruct) GetTags(ctx context.Context, tags *domain.GetTags) (getTagResponse *domain.GetTagsResponse, err error) {
 	switch *tags.TagGroup {
 	case domain.TagGroupEnum.Curriculum:
-		return t.getCurriculumTags(tags)
+		return t.getCurriculumTags(ctx, tags)
 	}
 	return
 }
 
-func (t *RpcTagsServiceStruct) GetDefaultTags() (*domain.DefaultTags, error) {
+func (t *RpcTagsServiceStruct) GetDefaultTags(ctx context.Context) (*domain.DefaultTags, error) {
 	miscTagId := config.GetConfig().MiscTagId
 	resourceTagid := config.GetConfig().ResourceTagId
 	defaultTagIds := []*string{&miscTagId, &resourceTagid}
 
-	tagData, err := t.ts.FetchByInTags(defaultTagIds)
+	tagData, err := t.ts.FetchByInTags(ctx, defaultTagIds)
 	if err != nil {
 		return nil, err
 	}
@@ -454,14 +444,19 @@ func (t *RpcTagsServiceStruct) GetDefaultTags() (*domain.DefaultTags, error) {
 	return dc, nil
 }
 
-func (t *RpcTagsServiceStruct) getCurriculumTags(tags *domain.GetTags) (getTagResponse *domain.GetTagsResponse, err error) {
-	parents := []*string{tags.Hierarchy}
+func (t *RpcTagsServiceStruct) getCurriculumTags(ctx context.Context, tags *domain.GetTags) (getTagResponse *domain.GetTagsResponse, err error) {
+	parents, err := t.ts.ResolveRegionParents(ctx, []*string{tags.Hierarchy})
+	if err != nil {
+		return
+	}
+	if len(parents) == 0 {
+		return dtomapper.CreateGetTagResponse(nil, tags, map[string]bool{}, nil)
+	}
 	createElasticEntity, err := dtomapper.GetElasticTagEntity(tags, parents, nil, domain.AccessEnum.Global, tags.CurriculumType, "admin", tags.TagGroup, 0, 100)
 	if err != nil {
 		return
 	}
-	filteredTags, next, err := t.es.GetTags(createElasticEntity)
-	tagData, err := t.ts.FetchByInTags(filteredTags)
+	tagData, next, err := searchAvailableTags(ctx, t.ts, parentSearch(t.es.GetTags, parents), createElasticEntity, tags.CountryId)
 	if err != nil {
 		return
 	}
@@ -469,9 +464,9 @@ func (t *RpcTagsServiceStruct) getCurriculumTags(tags *domain.GetTags) (getTagRe
 	return getTagResponse, nil
 }
 
-func (t *RpcTagsServiceStruct) GetSuggestedCurriculum(getSuggestedTags *domain.GetSuggestedTags) ([]*domain.SuggestedTags, error) {
-
-	tagHierarchySlice, err := t.ts.FetchByInTags(getSuggestedTags.TagIds)
+func (t *RpcTagsServiceStruct) GetSuggestedCurriculum(ctx context.Context, getSuggestedTags *domain.GetSuggestedTags) ([]*domain.SuggestedTags, error) {
+	ldr := t.tagLoader(ctx)
+	tagHierarchySlice, err := ldr.LoadTags(getSuggestedTags.TagIds)
 	if err != nil {
 		return nil, err
 	}
@@ -493,7 +488,7 @@ func (t *RpcTagsServiceStruct) GetSuggestedCurriculum(getSuggestedTags *domain.G
 	if err != nil {
 		return nil, err
 	}
-	chapterTags, err := t.getChildTags(getSuggestedTags, parentHideOrderTags, &contentType)
+	chapterTags, err := t.getChildTags(ctx, ldr, getSuggestedTags, parentHideOrderTags, &contentType)
 
 	if err != nil {
 		return nil, noonerror.New(noonerror.ErrBadRequest, "invalidChapter")
@@ -519,7 +514,7 @@ func (t *RpcTagsServiceStruct) GetSuggestedCurriculum(getSuggestedTags *domain.G
 			}()
 			chapterHideOrderTags := *parentHideOrderTags + "." + *v.ID
 			topicContentType := domain.TagTypeEnum.Topic
-			topicSuggestedTags, err := t.getChildTags(getSuggestedTags, &chapterHideOrderTags, &topicContentType)
+			topicSuggestedTags, err := t.getChildTags(ctx, ldr, getSuggestedTags, &chapterHideOrderTags, &topicContentType)
 			if err != nil {
 				errChan <- err
 			}
@@ -553,8 +548,8 @@ func (t *RpcTagsServiceStruct) GetSuggestedCurriculum(getSuggestedTags *domain.G
 	return chapterTags, nil
 }
 
-func (t *RpcTagsServiceStruct) GetLegacyDataFromTagId(tagId *string) (legacyResponse []*domain.LegacyResponse, err error) {
-	legacyTagMappings, err := t.ts.FetchLegacyIdFromTagId(tagId)
+func (t *RpcTagsServiceStruct) GetLegacyDataFromTagId(ctx context.Context, tagId *string) (legacyResponse []*domain.LegacyResponse, err error) {
+	legacyTagMappings, err := t.ts.FetchLegacyIdFromTagId(ctx, tagId)
 	if err != nil {
 		return
 	}
@@ -564,7 +559,7 @@ func (t *RpcTagsServiceStruct) GetLegacyDataFromTagId(tagId *string) (legacyResp
 	return legacyResponse, nil
 }
 
-func (t *RpcTagsServiceStruct) GetLegacyDataFromTagIds(tagIds []*string) (legacyResponse []*domain.LegacyResponse, err error) {
+func (t *RpcTagsServiceStruct) GetLegacyDataFromTagIds(ctx context.Context, tagIds []*string) (legacyResponse []*domain.LegacyResponse, err error) {
 	visited := make(map[string]struct{})
 	var nonDuplicatedTagIds []*string
 	for _, v := range tagIds {
@@ -574,7 +569,7 @@ func (t *RpcTagsServiceStruct) GetLegacyDataFromTagIds(tagIds []*string) (legacy
 			visited[*v] = struct{}{}
 		}
 	}
-	legacyTagMappings, err := t.ts.FetchLegacyIdFromTagIds(nonDuplicatedTagIds)
+	legacyTagMappings, err := t.ts.FetchLegacyIdFromTagIds(ctx, nonDuplicatedTagIds)
 	if err != nil {
 		return
 	}
@@ -584,7 +579,7 @@ func (t *RpcTagsServiceStruct) GetLegacyDataFromTagIds(tagIds []*string) (legacy
 	return legacyResponse, nil
 }
 
-func (t *RpcTagsServiceStruct) GetTagDataFromLegacyId(legacyType *string, legacyId *string) (legacyResponse []*domain.LegacyResponse, err error) {
+func (t *RpcTagsServiceStruct) GetTagDataFromLegacyId(ctx context.Context, legacyType *string, legacyId *string) (legacyResponse []*domain.LegacyResponse, err error) {
 	if *legacyType == "product" {
 		_, ok := constant.UniversityProductsMap[*legacyId]
 		if ok {
@@ -597,7 +592,7 @@ func (t *RpcTagsServiceStruct) GetTagDataFromLegacyId(legacyType *string, legacy
 			}, nil
 		}
 	}
-	legacyTagMappings, err := t.ts.FetchTagIdFromLegacyId(legacyType, legacyId)
+	legacyTagMappings, err := t.ts.FetchTagIdFromLegacyId(ctx, legacyType, legacyId)
 	if err != nil {
 		return
 	}
@@ -607,7 +602,7 @@ func (t *RpcTagsServiceStruct) GetTagDataFromLegacyId(legacyType *string, legacy
 	return legacyResponse, nil
 }
 
-func (t *RpcTagsServiceStruct) GetGradeTags(grade *string, productId *string) (legacyResponse []*domain.LegacyResponse, err error) {
+func (t *RpcTagsServiceStruct) GetGradeTags(ctx context.Context, grade *string, productId *string) (legacyResponse []*domain.LegacyResponse, err error) {
 	_, ok := constant.UniversityProductsMap[*productId]
 	if *grade == "13" && ok {
 		return
@@ -615,7 +610,7 @@ func (t *RpcTagsServiceStruct) GetGradeTags(grade *string, productId *string) (l
 		return
 	}
 	gradeType := "grade"
-	gradeProducts, err := t.ts.FetchGradesFromProductId(productId)
+	gradeProducts, err := t.ts.FetchGradesFromProductId(ctx, productId)
 	if err != nil {
 		return
 	}
@@ -632,7 +627,7 @@ func (t *RpcTagsServiceStruct) GetGradeTags(grade *string, productId *string) (l
 			}
 		}
 		if folderId != nil {
-			legacyResponse, err = t.legacyGradeResponse(folderId, legacyResponse)
+			legacyResponse, err = t.legacyGradeResponse(ctx, folderId, legacyResponse)
 			if err != nil {
 				return nil, err
 			}
@@ -644,7 +639,7 @@ func (t *RpcTagsServiceStruct) GetGradeTags(grade *string, productId *string) (l
 	} else if *grade == "0" {
 		for _, v := range gradeProducts {
 			if v.FolderId != nil {
-				legacyResponse, err = t.legacyGradeResponse(v.FolderId, legacyResponse)
+				legacyResponse, err = t.legacyGradeResponse(ctx, v.FolderId, legacyResponse)
 				if err != nil {
 					return nil, err
 				}
@@ -658,7 +653,7 @@ func (t *RpcTagsServiceStruct) GetGradeTags(grade *string, productId *string) (l
 	} else if len(gradeProducts) > 0 {
 		for _, v := range gradeProducts {
 			if v.FolderId != nil && v.Grade != nil && *v.Grade == *grade {
-				legacyResponse, err = t.legacyGradeResponse(v.FolderId, legacyResponse)
+				legacyResponse, err = t.legacyGradeResponse(ctx, v.FolderId, legacyResponse)
 				if err != nil {
 					return nil, err
 				}
@@ -684,9 +679,9 @@ func (t *RpcTagsServiceStruct) GetGradeTags(grade *string, productId *string) (l
 	return legacyResponse, nil
 }
 
-func (t *RpcTagsServiceStruct) legacyGradeResponse(folderId *string, legacyResponseInput []*domain.LegacyResponse) (legacyResponse []*domain.LegacyResponse, err error) {
+func (t *RpcTagsServiceStruct) legacyGradeResponse(ctx context.Context, folderId *string, legacyResponseInput []*domain.LegacyResponse) (legacyResponse []*domain.LegacyResponse, err error) {
 	folderType := "folder"
-	legacyTagMappings, err := t.ts.FetchTagIdFromLegacyId(&folderType, folderId)
+	legacyTagMappings, err := t.ts.FetchTagIdFromLegacyId(ctx, &folderType, folderId)
 	if err != nil {
 		return nil, err
 	}
@@ -696,7 +691,7 @@ func (t *RpcTagsServiceStruct) legacyGradeResponse(folderId *string, legacyRespo
 	return legacyResponseInput, nil
 }
 
-func (t *RpcTagsServiceStruct) getChildTags(getSuggestedTags *domain.GetSuggestedTags, parentTags *string, contentType *string) ([]*domain.SuggestedTags, error) {
+func (t *RpcTagsServiceStruct) getChildTags(ctx context.Context, ldr domain.TagLoader, getSuggestedTags *domain.GetSuggestedTags, parentTags *string, contentType *string) ([]*domain.SuggestedTags, error) {
 
 	tagGroup := domain.TagGroupEnum.Content
 	getTag := domain.GetTags{TagGroup: &tagGroup, Type: contentType}
@@ -706,16 +701,21 @@ func (t *RpcTagsServiceStruct) getChildTags(getSuggestedTags *domain.GetSuggeste
 	if err != nil {
 		return nil, err
 	}
-	tagIds, _, err := t.es.GetTags(createElasticEntity)
+	tagIds, _, err := t.es.GetTags(ctx, createElasticEntity)
 	if err != nil {
 		return nil, err
 	}
-	tagData, err := t.ts.FetchByInTags(tagIds)
+	tagData, err := ldr.LoadTags(tagIds)
 	if err != nil {
 		return nil, err
 	}
+	tagData, err = ldr.LoadTagAvailability(tagData, getSuggestedTags.CountryId)
+	if err != nil {
+		return nil, err
+	}
+	tagData = availableTags(tagData)
 
-	tagData, err = t.ts.OrderTags(tagData, contentType, getSuggestedTags.CurriculumType, parentTags)
+	tagData, err = ldr.OrderTags(tagData, contentType, getSuggestedTags.CurriculumType, parentTags, getSuggestedTags.Locale)
 	if err != nil {
 		return nil, err
 	}
@@ -723,19 +723,19 @@ func (t *RpcTagsServiceStruct) getChildTags(getSuggestedTags *domain.GetSuggeste
 	return str, nil
 }
 
-func (t *RpcTagsServiceStruct) GetRpcTags(tags *domain.GetRpcTags) (getTagResponse *domain.GetTagsResponseForProduct, err error) {
+func (t *RpcTagsServiceStruct) GetRpcTags(ctx context.Context, tags *domain.GetRpcTags) (getTagResponse *domain.GetTagsResponseForProduct, err error) {
 
 	switch *tags.TagGroup {
 	case domain.TagGroupEnum.Curriculum:
-		return t.getCurriculumTagsForProducts(tags)
+		return t.getCurriculumTagsForProducts(ctx, t.tagLoader(ctx), tags)
 	}
 	return
 
 }
 
-func (t *RpcTagsServiceStruct) getCurriculumTagsForProducts(gtt *domain.GetRpcTags) (*domain.GetTagsResponseForProduct, error) {
+func (t *RpcTagsServiceStruct) getCurriculumTagsForProducts(ctx context.Context, ldr domain.TagLoader, gtt *domain.GetRpcTags) (*domain.GetTagsResponseForProduct, error) {
 
-	tagHierarchySlice, err := t.ts.GetTagsConcurrent(gtt.Hierarchy)
+	tagHierarchySlice, err := ldr.LoadTags(gtt.Hierarchy)
 	if err != nil {
 		return nil, err
 	}
@@ -768,7 +768,7 @@ func (t *RpcTagsServiceStruct) getCurriculumTagsForProducts(gtt *domain.GetRpcTa
 	var parentTags []*string
 	//multi grade scenario
 	if gradeTag != nil {
-		gradeTags, err = t.getMultiGrades(*gtt.CountryId, boardTag,gradeTag)
+		gradeTags, err = t.getMultiGrades(ctx, ldr, *gtt.CountryId, boardTag,gradeTag)
 		if err != nil {
 			return nil, err
 		}
@@ -781,12 +781,16 @@ func (t *RpcTagsServiceStruct) getCurriculumTagsForProducts(gtt *domain.GetRpcTa
 		parentTags = append(parentTags, &parent)
 	}
 	if len(gradeTags) == 0 {
-		parent, err := verifyAndFetchParentCurriculumTags(gtt.CurriculumType, tagHierarchySlice, tagHierarchy.Level)
+		parent, err := verifyAndFetchParentCurriculumTags(gtt.CurriculumType, regionAsCountry(tagHierarchySlice), tagHierarchy.Level)
 		if err != nil {
 			return nil, err
 		}
 		parentTags = append(parentTags, parent)
 	}
+	parentTags, err = t.ts.ResolveRegionParents(ctx, parentTags)
+	if err != nil {
+		return nil, err
+	}
 	if len(parentTags) == 0 {
 		return new(domain.GetTagsResponseForProduct), nil
 	}
@@ -811,7 +815,7 @@ func (t *RpcTagsServiceStruct) getCurriculumTagsForProducts(gtt *domain.GetRpcTa
 			if err != nil {
 				errChan <- err
 			}
-			ids, _, err := t.es.GetTags(createElasticEntity)
+			ids, _, err := t.es.GetTags(ctx, createElasticEntity)
 			if err != nil {
 				errChan <- err
 			}
@@ -840,15 +844,20 @@ func (t *RpcTagsServiceStruct) getCurriculumTagsForProducts(gtt *domain.GetRpcTa
 		return nil, err
 	}
 
-	tagData, err := t.ts.FetchByInTags(filteredTags)
+	tagData, err := ldr.LoadTags(filteredTags)
 	if err != nil {
 		return nil, err
 	}
-	tagData, err = t.ts.FetchTagLocaleMappingsByLocale(tagData, gtt.CountryId, gtt.Locale)
+	tagData, err = ldr.LoadTagLocales(tagData, gtt.CountryId, gtt.Locale)
 	if err != nil {
 		return nil, err
 	}
-	tagData, err = t.ts.OrderTags(tagData, gtt.Type, gtt.CurriculumType, nil)
+	tagData, err = ldr.LoadTagAvailability(tagData, gtt.CountryId)
+	if err != nil {
+		return nil, err
+	}
+	tagData = availableTags(tagData)
+	tagData, err = ldr.OrderTags(tagData, gtt.Type, gtt.CurriculumType, nil, gtt.Locale)
 	if err != nil {
 		return nil, err
 	}
@@ -856,24 +865,29 @@ func (t *RpcTagsServiceStruct) getCurriculumTagsForProducts(gtt *domain.GetRpcTa
 	return getTagResponse, nil
 }
 
-func (t *RpcTagsServiceStruct) getMultiGrades(countryId string,boardTag *domain.Tags, gradeTag *domain.Tags) (finalTagData []*domain.Tags, err error) {
+func (t *RpcTagsServiceStruct) getMultiGrades(ctx context.Context, ldr domain.TagLoader, countryId string,boardTag *domain.Tags, gradeTag *domain.Tags) (finalTagData []*domain.Tags, err error) {
 	_, ok := constant.MultiGradeMap[countryId]
 	if !ok {
 		return []*domain.Tags{gradeTag}, nil
 	}
-	tagGroup := domain.TagGroupEnum.Curriculum
-	tagType := domain.TagTypeEnum.Grade
-	curriculumType := domain.CurriculumTypeEnum.K12
-
 	redisKey := redisrepo.CurriculumMultiGradePrefix + countryId + ":" + *gradeTag.ID
 	if boardTag!=nil{
 		redisKey = redisrepo.CurriculumMultiGradePrefix + countryId +":"+*boardTag.ID+ ":" + *gradeTag.ID
 	}
-	val, err := redisrepo.RedisClient.Get(redisKey).Result()
-	err1 := json.Unmarshal([]byte(val), &finalTagData)
-	if err == nil && err1 == nil {
-		return finalTagData, err
+	err = readThrough(ctx, redisKey, redisrepo.MultiGradeTtl, &finalTagData, func(ctx context.Context) (interface{}, bool, error) {
+		tagData, err := t.fetchMultiGrades(ctx, t.ts.NewTagLoader(ctx), countryId, boardTag, gradeTag)
+		return tagData, len(tagData) > 0, err
+	})
+	if err != nil {
+		return nil, err
 	}
+	return finalTagData, nil
+}
+
+func (t *RpcTagsServiceStruct) fetchMultiGrades(ctx context.Context, ldr domain.TagLoader, countryId string, boardTag *domain.Tags, gradeTag *domain.Tags) (finalTagData []*domain.Tags, err error) {
+	tagGroup := domain.TagGroupEnum.Curriculum
+	tagType := domain.TagTypeEnum.Grade
+	curriculumType := domain.CurriculumTypeEnum.K12
 	getTag := domain.GetTeacherTags{Text: nil, TagGroup: &tagGroup, Type: &tagType}
 	parents := []*string{&countryId}
 	if boardTag!=nil{
@@ -884,11 +898,11 @@ func (t *RpcTagsServiceStruct) getMultiGrades(countryId string,boardTag *domain.
 	if err != nil {
 		return nil, err
 	}
-	filteredTags, _, err := t.es.GetTags(createElasticEntity)
+	filteredTags, _, err := t.es.GetTags(ctx, createElasticEntity)
 	if err != nil {
 		return nil, err
 	}
-	tagData, err := t.ts.FetchByInTags(filteredTags)
+	tagData, err := ldr.LoadTags(filteredTags)
 	if err != nil {
 		return nil, err
 	}
@@ -932,11 +946,5 @@ func (t *RpcTagsServiceStruct) getMultiGrades(countryId string,boardTag *domain.
 			}
 		}
 	}
-	if len(finalTagData) > 0 {
-		tagByte, err := json.Marshal(finalTagData)
-		if err == nil {
-			redisrepo.RedisClient.Set(redisKey, string(tagByte), redisrepo.MultiGradeTtl)
-		}
-	}
 	return finalTagData, nil
 }
@@ -0,0 +1,53 @@
+package service
+
+import (
+	"bitbucket.org/noon-micro/curriculum/pkg/domain"
+	"bitbucket.org/noon-micro/curriculum/pkg/lib/searchkey"
+	"context"
+)
+
+// SearchElasticStruct indexes the search key of every tag name next to the name and searches for the search key of
+// the text, so that hamza, teh marbuta, tatweel, diacritics and accents do not decide whether a tag is found. Keys
+// travel as names of their own, the search backend needs no change to match them. The text is only searched by
+// its key once keyQueries is set, as documents indexed before the keys existed only match the text as typed until
+// MigrateToElastic pushed their names again.
+type SearchElasticStruct struct {
+	domain.Elastic
+	keyQueries bool
+}
+
+func NewSearchElastic(es domain.Elastic, keyQueries bool) *SearchElasticStruct {
+	return &SearchElasticStruct{Elastic: es, keyQueries: keyQueries}
+}
+
+func (e *SearchElasticStruct) CreateTag(ctx context.Context, createTagElastic *domain.CreateTagElastic) error {
+	tag := *createTagElastic
+	tag.Name = searchNames(createTagElastic.Name)
+	return e.Elastic.CreateTag(ctx, &tag)
+}
+
+func (e *SearchElasticStruct) UpdateTag(ctx context.Context, tagId *string, delete *bool, names []*domain.TagName) error {
+	return e.Elastic.UpdateTag(ctx, tagId, delete, searchNames(names))
+}
+
+func (e *SearchElasticStruct) GetTags(ctx context.Context, getTagsElastic *domain.GetTagsElastic) ([]*string, *int, error) {
+	return e.Elastic.GetTags(ctx, e.searchQuery(getTagsElastic))
+}
+
+func (e *SearchElasticStruct) GetTagsSearch(ctx context.Context, getTagsElastic *domain.GetTagsElastic) ([]*string, *int, error) {
+	return e.Elastic.GetTagsSearch(ctx, e.searchQuery(getTagsElastic))
+}
+
+// searchNames copies the names with their search keys, the names of the caller are left untouched
+func searchNames(names []*domain.TagName) []*domain.TagName {
+	return searchkey.Names(names)
+}
+
+func (e *SearchElasticStruct) searchQuery(getTagsElastic *domain.GetTagsElastic) *domain.GetTagsElastic {
+	query := *getTagsElastic
+	if e.keyQueries && query.Text != nil {
+		text := searchkey.Normalize(*query.Text)
+		query.Text = &text
+	}
+	return &query
+}
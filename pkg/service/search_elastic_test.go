@@ -0,0 +1,79 @@
+package service
+
+import (
+	"bitbucket.org/noon-micro/curriculum/pkg/domain"
+	"bitbucket.org/noon-micro/curriculum/pkg/external"
+	memory "bitbucket.org/noon-micro/curriculum/pkg/repository/memory"
+	"context"
+	"reflect"
+	"sort"
+	"testing"
+)
+
+func searchIds(t *testing.T, es domain.Elastic, text string) []string {
+	tagType := "chapter"
+	ids, _, err := es.GetTagsSearch(context.Background(), &domain.GetTagsElastic{Text: &text, Type: &tagType, Limit: 10})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var result []string
+	for _, v := range ids {
+		result = append(result, *v)
+	}
+	sort.Strings(result)
+	return result
+}
+
+func TestSearchElasticMatchesNormalizedNames(t *testing.T) {
+	fixture, err := memory.LoadFixture("../../fixtures/memory.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	es := NewSearchElastic(external.NewMemoryElasticExternal(fixture), true)
+	tests := []struct {
+		text string
+		want []string
+	}{
+		{text: "الأعداد", want: []string{"50"}},
+		{text: "الاعداد", want: []string{"50"}},
+		{text: "الهندسة", want: []string{"52"}},
+		{text: "geometrie & MESURE", want: []string{"52"}},
+		{text: "Géométrie", want: []string{"52"}},
+	}
+	for _, test := range tests {
+		if got := searchIds(t, es, test.text); !reflect.DeepEqual(got, test.want) {
+			t.Errorf("search %q found %v, want %v", test.text, got, test.want)
+		}
+	}
+	locale := "ar"
+	name := "الأرقام"
+	if err = es.UpdateTag(context.Background(), str("50"), nil, []*domain.TagName{{Value: &name, Locale: &locale}}); err != nil {
+		t.Fatal(err)
+	}
+	if got := searchIds(t, es, "الاعداد"); len(got) != 0 {
+		t.Errorf("search key of the replaced name still found %v", got)
+	}
+	if got := searchIds(t, es, "الارقام"); !reflect.DeepEqual(got, []string{"50"}) {
+		t.Errorf("search key of the new name found %v, want [50]", got)
+	}
+}
+
+func TestSearchElasticSearchesTypedTextUntilBackfilled(t *testing.T) {
+	fixture, err := memory.LoadFixture("../../fixtures/memory.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	es := NewSearchElastic(external.NewMemoryElasticExternal(fixture), false)
+	tests := []struct {
+		text string
+		want []string
+	}{
+		{text: "Géométrie", want: []string{"52"}},
+		{text: "geometrie & MESURE", want: nil},
+	}
+	for _, test := range tests {
+		if got := searchIds(t, es, test.text); !reflect.DeepEqual(got, test.want) {
+			t.Errorf("search %q found %v, want %v", test.text, got, test.want)
+		}
+	}
+}
@@ -1,8 +1,10 @@
 package service
 
 import (
+	"bitbucket.org/noon-micro/curriculum/config"
 	"bitbucket.org/noon-micro/curriculum/pkg/domain"
 	dtomapper "bitbucket.org/noon-micro/curriculum/pkg/service/mapper"
+	"context"
 	"fmt"
 )
 
@@ -16,48 +18,48 @@ func NewStudentTagsService(ts domain.TagsService, es domain.Elastic, geo domain.
 	return &StudentTagsServiceStruct{ts: ts, es: es, geo: geo}
 }
 
-func (t *StudentTagsServiceStruct) GetCountries(tags *domain.GetCountries) (getTagResponse *domain.GetCountriesResponse, err error) {
+func (t *StudentTagsServiceStruct) GetCountries(ctx context.Context, tags *domain.GetCountries) (getTagResponse *domain.GetCountriesResponse, err error) {
 	switch *tags.TagGroup {
 	case domain.TagGroupEnum.Curriculum:
-		return t.getCurriculumTags(tags)
+		return t.getCurriculumTags(ctx, tags)
 	}
 	return
 }
 
-func (t *StudentTagsServiceStruct) GetCountriesNew(tags *domain.GetCountriesNew) (getTagResponse *domain.GetCountriesNewResponse, err error) {
-	return t.getCountriesTagsNew(tags)
+func (t *StudentTagsServiceStruct) GetCountriesNew(ctx context.Context, tags *domain.GetCountriesNew) (getTagResponse *domain.GetCountriesNewResponse, err error) {
+	return t.getCountriesTagsNew(ctx, tags)
 }
 
-func (t *StudentTagsServiceStruct) GetBoards(request *domain.GetTags) (getBoardsResponse *domain.GetBoardsResponse, err error) {
+func (t *StudentTagsServiceStruct) GetBoards(ctx context.Context, request *domain.GetTags) (getBoardsResponse *domain.GetBoardsResponse, err error) {
 	var tagData []*domain.Tags
-	tagData, next, err := t.getTagsHandler(request)
+	tagData, next, err := t.getTagsHandler(ctx, request)
 	fmt.Println(next)
 	if err != nil {
 		return nil, err
 	}
 
 	tagType := "degree"
-	hasCollege, err := t.ts.IsCollegePresent(&tagType, request.Hierarchy)
+	hasCollege, err := t.ts.IsCollegePresent(ctx, &tagType, request.Hierarchy)
 	getBoardsResponse, _ = dtomapper.CreateGetBoardsResponse(tagData, &hasCollege)
 	return getBoardsResponse, nil
 }
-func (t *StudentTagsServiceStruct) GetGrades(request *domain.GetTags) (getGradesResponse *domain.GetGradesResponse, err error) {
+func (t *StudentTagsServiceStruct) GetGrades(ctx context.Context, request *domain.GetTags) (getGradesResponse *domain.GetGradesResponse, err error) {
 	var tagData []*domain.Tags
-	tagData, next, err := t.getTagsHandler(request)
+	tagData, next, err := t.getTagsHandler(ctx, request)
 	fmt.Println(next)
 	if err != nil {
 		return nil, err
 	}
 
 	tagType := "degree"
-	hasCollege, err := t.ts.IsCollegePresent(&tagType, request.Hierarchy)
+	hasCollege, err := t.ts.IsCollegePresent(ctx, &tagType, request.Hierarchy)
 	getGradesResponse, _ = dtomapper.CreateGetGradesResponse(tagData,&hasCollege)
 	return getGradesResponse, nil
 }
 
-func (t *StudentTagsServiceStruct) GetDegrees(request *domain.GetTags) (getGradesResponse *domain.GetDegreesResponse, err error) {
+func (t *StudentTagsServiceStruct) GetDegrees(ctx context.Context, request *domain.GetTags) (getGradesResponse *domain.GetDegreesResponse, err error) {
 	var tagData []*domain.Tags
-	tagData, next, err := t.getTagsHandler(request)
+	tagData, next, err := t.getTagsHandler(ctx, request)
 	if err != nil {
 		return nil, err
 	}
@@ -65,9 +67,9 @@ func (t *StudentTagsServiceStruct) GetDegrees(request *domain.GetTags) (getGrade
 	return getGradesResponse, nil
 }
 
-func (t *StudentTagsServiceStruct) GetMajors(request *domain.GetTags) (getMajorsResponse *domain.GetMajorsResponse, err error) {
+func (t *StudentTagsServiceStruct) GetMajors(ctx context.Context, request *domain.GetTags) (getMajorsResponse *domain.GetMajorsResponse, err error) {
 	var tagData []*domain.Tags
-	tagData, next, err := t.getTagsHandler(request)
+	tagData, next, err := t.getTagsHandler(ctx, request)
 	if err != nil {
 		return nil, err
 	}
@@ -75,7 +77,7 @@ func (t *StudentTagsServiceStruct) GetMajors(request *domain.GetTags) (getMajors
 	return getMajorsResponse, nil
 }
 
-func (t *StudentTagsServiceStruct) getCurriculumTags(tags *domain.GetCountries) (getTagResponse *domain.GetCountriesResponse, err error) {
+func (t *StudentTagsServiceStruct) getCurriculumTags(ctx context.Context, tags *domain.GetCountries) (getTagResponse *domain.GetCountriesResponse, err error) {
 	var filteredTags []*string
 	var parents []*string
 	if tags.Hierarchy != nil {
@@ -85,9 +87,9 @@ func (t *StudentTagsServiceStruct) getCurriculumTags(tags *domain.GetCountries)
 	if err != nil {
 		return
 	}
-	tagIds, next, err := t.es.GetTags(createElasticEntity)
+	tagIds, next, err := t.es.GetTags(ctx, createElasticEntity)
 	if err != nil {
-		parentTagMappingData, err := t.ts.FetchFilteredParentTagMappings(tags.Type, tags.Hierarchy)
+		parentTagMappingData, err := t.ts.FetchFilteredParentTagMappings(ctx, tags.Type, tags.Hierarchy)
 		if err != nil {
 			return nil, err
 		}
@@ -103,7 +105,7 @@ func (t *StudentTagsServiceStruct) getCurriculumTags(tags *domain.GetCountries)
 		filteredTags = tagIds
 	}
 	hiddenSet := make(map[string]bool)
-	parentTagMappingData, err := t.ts.FetchByInParentTagMappings(filteredTags)
+	parentTagMappingData, err := t.ts.FetchByInParentTagMappings(ctx, filteredTags)
 	if err != nil {
 		return
 	}
@@ -112,7 +114,7 @@ func (t *StudentTagsServiceStruct) getCurriculumTags(tags *domain.GetCountries)
 			hiddenSet[*v.TagID] = v.Hidden
 		}
 	}
-	tagData, err := t.ts.FetchByInTags(filteredTags)
+	tagData, err := t.ts.FetchByInTags(ctx, filteredTags)
 	if err != nil {
 		return
 	}
@@ -120,58 +122,83 @@ func (t *StudentTagsServiceStruct) getCurriculumTags(tags *domain.GetCountries)
 	return getTagResponse, nil
 }
 
-func (t *StudentTagsServiceStruct) getCountriesTagsNew(tags *domain.GetCountriesNew) (getTagResponse *domain.GetCountriesNewResponse, err error) {
+func (t *StudentTagsServiceStruct) getCountriesTagsNew(ctx context.Context, tags *domain.GetCountriesNew) (getTagResponse *domain.GetCountriesNewResponse, err error) {
 	rootCurriculumType := "root"
 	countryType := "country"
-	filteredTagsResponse, err := t.ts.FetchFilteredTagsPaginated(&rootCurriculumType, &countryType, &tags.Start, &tags.Limit)
+	filteredTagsResponse, err := t.ts.FetchFilteredTagsPaginated(ctx, &rootCurriculumType, &countryType, &tags.Start, &tags.Limit)
 	if err != nil {
 		return
 	}
 	next := tags.Start + tags.Limit
-	getTagResponse, _ = dtomapper.CreateGetCountriesNewResponse(filteredTagsResponse, tags.Locale, &tags.ISOCode, &next, false)
+	filteredTagsResponse, err = t.ts.FetchAttributeLocalesByLocale(ctx, filteredTagsResponse, tags.CountryId, tags.Locale)
+	if err != nil {
+		return
+	}
+	if err = resolveCountryCode(ctx, t.ts, t.geo, tags); err != nil {
+		return
+	}
+	getTagResponse, _ = dtomapper.CreateGetCountriesNewResponse(filteredTagsResponse, tags.Locale, &tags.ISOCode, config.GetConfig().DefaultCountryCode, &next, false)
+	if tags.GroupBy != nil && *tags.GroupBy == GroupByRegion {
+		getTagResponse.Meta.Regions, err = t.ts.GroupCountriesByRegion(ctx, filteredTagsResponse)
+		if err != nil {
+			return nil, err
+		}
+	}
 	return getTagResponse, nil
 }
 
-func (t *StudentTagsServiceStruct) getTagsHandler(tags *domain.GetTags) (tagsResponse []*domain.Tags, next *int, err error) {
+func (t *StudentTagsServiceStruct) getTagsHandler(ctx context.Context, tags *domain.GetTags) (tagsResponse []*domain.Tags, next *int, err error) {
 	var filteredTags []*string
 	var parents []*string
 	if tags.Hierarchy != nil {
 		parents = append(parents, tags.Hierarchy)
 	}
+	parents, err = t.ts.ResolveRegionParents(ctx, parents)
+	if err != nil {
+		return
+	}
+	if tags.Hierarchy != nil && len(parents) == 0 {
+		noNext := -1
+		return nil, &noNext, nil
+	}
 	var nextVal *int
 	fmt.Println(nextVal)
-	if tags.Text == nil || *tags.Text == "" {
-		createElasticEntity, err1 := dtomapper.GetElasticTagEntity(tags, parents, nil, domain.AccessEnum.Global, tags.CurriculumType, "admin", tags.TagGroup, tags.Start, tags.Limit)
-		if err1 != nil {
-			return
-		}
-		filteredTags, next, err = t.es.GetTags(createElasticEntity)
-	} else {
-		createElasticEntity, err1 := dtomapper.GetElasticTagEntity(tags, parents, nil, domain.AccessEnum.Global, tags.CurriculumType, "admin", tags.TagGroup, tags.Start, tags.Limit)
-		if err1 != nil {
-			return
-		}
-		filteredTags, next, err = t.es.GetTagsSearch(createElasticEntity)
+	search := t.es.GetTags
+	if tags.Text != nil && *tags.Text != "" {
+		search = t.es.GetTagsSearch
 	}
-	hiddenSet := make(map[string]bool)
-	parentTagMappingData, err := t.ts.FetchByInParentTagMappingsByParentTagIdTagIds(filteredTags, tags.Hierarchy)
-	if err != nil {
+	createElasticEntity, err1 := dtomapper.GetElasticTagEntity(tags, parents, nil, domain.AccessEnum.Global, tags.CurriculumType, "admin", tags.TagGroup, tags.Start, tags.Limit)
+	if err1 != nil {
 		return
 	}
-	for _, v := range parentTagMappingData {
-		if *v.ParentTagID == *tags.Hierarchy {
-			hiddenSet[*v.TagID] = v.Hidden
-		}
-	}
-	tagData, err := t.ts.FetchByInTags(filteredTags)
+	tagData, next, err := searchAvailableTags(ctx, t.ts, parentSearch(search, parents), createElasticEntity, tags.CountryId)
 	if err != nil {
 		return
 	}
-	tagData, err = t.ts.FetchTagLocaleMappingsByLocaleForContext(tagData, tags.CountryId, tags.Locale)
+	for _, v := range tagData {
+		filteredTags = append(filteredTags, v.ID)
+	}
+	hiddenSet := make(map[string]bool)
+	hierarchies := parents
+	if len(hierarchies) == 0 {
+		hierarchies = []*string{tags.Hierarchy}
+	}
+	for _, hierarchy := range hierarchies {
+		parentTagMappingData, err := t.ts.FetchByInParentTagMappingsByParentTagIdTagIds(ctx, filteredTags, hierarchy)
+		if err != nil {
+			return nil, nil, err
+		}
+		for _, v := range parentTagMappingData {
+			if *v.ParentTagID == *hierarchy {
+				hiddenSet[*v.TagID] = v.Hidden
+			}
+		}
+	}
+	tagData, err = t.ts.FetchTagLocaleMappingsByLocaleForContext(ctx, tagData, tags.CountryId, tags.Locale)
 	if err != nil {
 		return
 	}
-	tagData, err = t.ts.OrderTags(tagData, tags.Type, tags.CurriculumType, tags.Hierarchy)
+	tagData, err = t.ts.OrderTags(ctx, tagData, tags.Type, tags.CurriculumType, tags.Hierarchy, tags.Locale)
 	if err != nil {
 		return
 	}
@@ -0,0 +1,293 @@
+package service
+
+import (
+	"bitbucket.org/noon-micro/curriculum/pkg/domain"
+	noonerror "bitbucket.org/noon-micro/curriculum/pkg/lib/error"
+	repository "bitbucket.org/noon-micro/curriculum/pkg/repository/mysql"
+	redisrepo "bitbucket.org/noon-micro/curriculum/pkg/repository/redis"
+	"bitbucket.org/noon-micro/curriculum/pkg/service/constant"
+	"context"
+	"database/sql"
+	"encoding/json"
+	"strings"
+	"time"
+)
+
+const (
+	// defaultSearchLimit mirrors the page size elastic applies when no limit is given
+	defaultSearchLimit = 100
+	// availableSearchRounds bounds the searches filling a page with tags available in the country
+	availableSearchRounds = 5
+)
+
+func (t *TagsServiceStruct) CreateTagCountryRule(ctx context.Context, tx *sql.Tx, tagCountryRule *domain.TagCountryRule) (err error) {
+	if err := redisrepo.Client(ctx).Del(redisrepo.CurriculumCountryRulePrefix + *tagCountryRule.TagID).Err(); err != nil {
+		return noonerror.New(noonerror.ErrInternalServer, "redisDeleteError")
+	}
+	return t.tcrr.CreateTagCountryRule(ctx, tx, tagCountryRule)
+}
+
+func (t *TagsServiceStruct) DeleteTagCountryRule(ctx context.Context, tx *sql.Tx, tagCountryRule *domain.TagCountryRule) (err error) {
+	if err := redisrepo.Client(ctx).Del(redisrepo.CurriculumCountryRulePrefix + *tagCountryRule.TagID).Err(); err != nil {
+		return noonerror.New(noonerror.ErrInternalServer, "redisDeleteError")
+	}
+	return t.tcrr.DeleteTagCountryRule(ctx, tx, tagCountryRule.ID)
+}
+
+// FetchTagCountryRules returns every published country rule of the tag
+func (t *TagsServiceStruct) FetchTagCountryRules(ctx context.Context, id *string) (tagCountryRules []*domain.TagCountryRule, err error) {
+	result, err := t.batchTagCountryRules(ctx, []string{*id})
+	if err != nil {
+		return nil, err
+	}
+	tagCountryRules, _ = result[*id].([]*domain.TagCountryRule)
+	return tagCountryRules, nil
+}
+
+// FetchTagAvailability sets Available on the tags for the country of the caller, it is left unset when the caller
+// has no country
+func (t *TagsServiceStruct) FetchTagAvailability(ctx context.Context, tagData []*domain.Tags, countryId *string) (tagResults []*domain.Tags, err error) {
+	if len(tagData) == 0 || !callerCountry(countryId) {
+		return tagData, nil
+	}
+	tagIds := make([]string, 0, len(tagData))
+	for _, v := range tagData {
+		tagIds = append(tagIds, *v.ID)
+	}
+	result, err := t.batchTagCountryRules(ctx, tagIds)
+	if err != nil {
+		return nil, err
+	}
+	resolveTagAvailability(tagData, *countryId, result)
+	return tagData, nil
+}
+
+// FilterAvailableTags drops the tags which are not available in the country of the caller
+func (t *TagsServiceStruct) FilterAvailableTags(ctx context.Context, tagData []*domain.Tags, countryId *string) (tagResults []*domain.Tags, err error) {
+	tagData, err = t.FetchTagAvailability(ctx, tagData, countryId)
+	if err != nil {
+		return nil, err
+	}
+	return availableTags(tagData), nil
+}
+
+// batchTagCountryRules caches the published country rules per tag, tags without any are cached as an empty list so
+// that they do not hit the database on every read
+func (t *TagsServiceStruct) batchTagCountryRules(ctx context.Context, ids []string) (map[string]interface{}, error) {
+	result := make(map[string]interface{}, len(ids))
+	var missing []*string
+	for i, val := range mGet(ctx, redisrepo.CurriculumCountryRulePrefix, ids) {
+		var tagCountryRules []*domain.TagCountryRule
+		if val == nil || json.Unmarshal([]byte(*val), &tagCountryRules) != nil {
+			missing = append(missing, &ids[i])
+			continue
+		}
+		result[ids[i]] = tagCountryRules
+	}
+	if len(missing) == 0 {
+		return result, nil
+	}
+	tagCountryRules, err := t.tcrr.FetchByInTagCountryRules(ctx, missing)
+	if err != nil {
+		return nil, err
+	}
+	grouped := make(map[string][]*domain.TagCountryRule, len(missing))
+	for _, id := range missing {
+		grouped[*id] = []*domain.TagCountryRule{}
+	}
+	for _, v := range tagCountryRules {
+		grouped[*v.TagID] = append(grouped[*v.TagID], v)
+	}
+	for tagId, rows := range grouped {
+		result[tagId] = rows
+		tagByte, err := json.Marshal(rows)
+		if err == nil {
+			redisrepo.Client(ctx).Set(redisrepo.CurriculumCountryRulePrefix+tagId, string(tagByte), redisrepo.RedisTtl)
+		}
+	}
+	return result, nil
+}
+
+// resolveTagAvailability sets Available from the rules loaded per tag id
+func resolveTagAvailability(tagData []*domain.Tags, countryId string, result map[string]interface{}) {
+	for _, v := range tagData {
+		rows, _ := result[*v.ID].([]*domain.TagCountryRule)
+		available := availableIn(rows, countryId)
+		v.Available = &available
+	}
+}
+
+// availableIn evaluates the rules of a tag, an excluded country always loses and include rules restrict the tag to
+// the included countries
+func availableIn(rules []*domain.TagCountryRule, countryId string) bool {
+	included := false
+	restricted := false
+	for _, v := range rules {
+		switch *v.Rule {
+		case domain.TagCountryRuleEnum.Exclude:
+			if *v.CountryId == countryId {
+				return false
+			}
+		case domain.TagCountryRuleEnum.Include:
+			restricted = true
+			included = included || *v.CountryId == countryId
+		}
+	}
+	return !restricted || included
+}
+
+func availableTags(tagData []*domain.Tags) []*domain.Tags {
+	var tagResults []*domain.Tags
+	for _, v := range tagData {
+		if v.Available == nil || *v.Available {
+			tagResults = append(tagResults, v)
+		}
+	}
+	return tagResults
+}
+
+// callerCountry tells whether rules apply, global callers see every tag
+func callerCountry(countryId *string) bool {
+	return countryId != nil && *countryId != "" && *countryId != constant.GlobalCountryId
+}
+
+// GetTagAvailability lists the countries included and excluded for the tag
+func (t *AdminTagsServiceStruct) GetTagAvailability(ctx context.Context, id *string) (*domain.TagAvailability, error) {
+	tagData, err := t.ts.FetchTags(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if tagData == nil {
+		return nil, noonerror.New(noonerror.ErrBadRequest, "tagNotFound")
+	}
+	tagCountryRules, err := t.ts.FetchTagCountryRules(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	availability := &domain.TagAvailability{Include: []*string{}, Exclude: []*string{}}
+	for _, v := range tagCountryRules {
+		if *v.Rule == domain.TagCountryRuleEnum.Include {
+			availability.Include = append(availability.Include, v.CountryId)
+		} else {
+			availability.Exclude = append(availability.Exclude, v.CountryId)
+		}
+	}
+	return availability, nil
+}
+
+// UpdateTagAvailability replaces the country rules of the tag in a single transaction, every country has to be a
+// published country tag and may only be listed once
+func (t *AdminTagsServiceStruct) UpdateTagAvailability(ctx context.Context, id *string, update *domain.TagAvailability) (*domain.TagAvailability, error) {
+	tagData, err := t.ts.FetchTags(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if tagData == nil || tagData.Type == nil {
+		return nil, noonerror.New(noonerror.ErrBadRequest, "tagNotFound")
+	}
+	rules := make(map[string]string, len(update.Include)+len(update.Exclude))
+	var countryIds []*string
+	groups := []struct {
+		rule      string
+		countries []*string
+	}{{domain.TagCountryRuleEnum.Include, update.Include}, {domain.TagCountryRuleEnum.Exclude, update.Exclude}}
+	for _, group := range groups {
+		for _, v := range group.countries {
+			countryId := strings.TrimSpace(*v)
+			if _, ok := rules[countryId]; ok {
+				return nil, noonerror.New(noonerror.ErrBadRequest, "availabilityCountryDuplicate")
+			}
+			rules[countryId] = group.rule
+			countryIds = append(countryIds, &countryId)
+		}
+	}
+	countries, err := t.ts.FetchByInTags(ctx, countryIds)
+	if err != nil {
+		return nil, err
+	}
+	published := make(map[string]struct{}, len(countries))
+	for _, v := range countries {
+		if v.Publish && v.Type != nil && *v.Type == domain.TagTypeEnum.Country {
+			published[*v.ID] = struct{}{}
+		}
+	}
+	for countryId := range rules {
+		if _, ok := published[countryId]; !ok {
+			return nil, noonerror.New(noonerror.ErrBadRequest, "countryNotFound")
+		}
+	}
+	current, err := t.ts.FetchTagCountryRules(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	tx, err := repository.Db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, noonerror.New(noonerror.ErrInternalServer, "ContextCreationError")
+	}
+	for _, v := range current {
+		if err = t.ts.DeleteTagCountryRule(ctx, tx, v); err != nil {
+			_ = tx.Rollback()
+			return nil, err
+		}
+	}
+	for _, countryId := range countryIds {
+		rule := rules[*countryId]
+		if err = t.ts.CreateTagCountryRule(ctx, tx, &domain.TagCountryRule{TagID: id, TagType: tagData.Type, CountryId: countryId,
+			Rule: &rule, Publish: true, CreatedAt: time.Now(), UpdatedAt: time.Now()}); err != nil {
+			_ = tx.Rollback()
+			return nil, err
+		}
+	}
+	if err = tx.Commit(); err != nil {
+		return nil, noonerror.New(noonerror.ErrInternalServer, "dbCommitError")
+	}
+	bumpCacheGenerations(ctx, domain.TagChangeEntityEnum.Tag)
+	return t.GetTagAvailability(ctx, id)
+}
+
+// tagSearch is the signature of the elastic searches, or of searchParents bound to its parents
+type tagSearch func(context.Context, *domain.GetTagsElastic) ([]*string, *int, error)
+
+// searchAvailableTags pages through the search until the limit of the query is filled with tags available in the
+// country, so that the country rules do not cut a page short while next is still set. The tags keep the order of
+// the search. The search is asked availableSearchRounds times at most, the page then comes back short with next
+// pointing past the last tag looked at.
+func searchAvailableTags(ctx context.Context, ts domain.TagsService, search tagSearch, query *domain.GetTagsElastic, countryId *string) (tagData []*domain.Tags, next *int, err error) {
+	page := *query
+	if page.Limit == 0 {
+		page.Limit = defaultSearchLimit
+	}
+	for round := 1; ; round++ {
+		tagIds, pageNext, err := search(ctx, &page)
+		if err != nil {
+			return nil, nil, err
+		}
+		rows, err := ts.FetchByInTags(ctx, tagIds)
+		if err != nil {
+			return nil, nil, err
+		}
+		rows, err = ts.FilterAvailableTags(ctx, rows, countryId)
+		if err != nil {
+			return nil, nil, err
+		}
+		available := make(map[string]*domain.Tags, len(rows))
+		for _, v := range rows {
+			available[*v.ID] = v
+		}
+		for i, id := range tagIds {
+			tag, ok := available[*id]
+			if !ok {
+				continue
+			}
+			if len(tagData) == page.Limit {
+				rest := page.Start + i
+				return tagData, &rest, nil
+			}
+			tagData = append(tagData, tag)
+		}
+		if len(tagData) == page.Limit || round == availableSearchRounds || pageNext == nil || *pageNext < 0 || len(tagIds) == 0 {
+			return tagData, pageNext, nil
+		}
+		page.Start = *pageNext
+	}
+}
@@ -0,0 +1,71 @@
+package service
+
+import (
+	"bitbucket.org/noon-micro/curriculum/pkg/domain"
+	"context"
+	"reflect"
+	"testing"
+)
+
+// pagedSearch serves ids in pages like elastic, next is -1 on the last page
+func pagedSearch(ids ...string) tagSearch {
+	return func(ctx context.Context, query *domain.GetTagsElastic) ([]*string, *int, error) {
+		var page []*string
+		for i := query.Start; i < len(ids) && i < query.Start+query.Limit; i++ {
+			page = append(page, str(ids[i]))
+		}
+		next := -1
+		if query.Start+query.Limit < len(ids) {
+			next = query.Start + query.Limit
+		}
+		return page, &next, nil
+	}
+}
+
+func TestSearchAvailableTagsFillsPage(t *testing.T) {
+	services := newTestServices(t)
+	search := pagedSearch("52", "50", "51", "53", "900")
+	tests := []struct {
+		name    string
+		country string
+		start   int
+		limit   int
+		want    []string
+		next    int
+	}{
+		{name: "refilled past the unavailable tag", country: "9", limit: 2, want: []string{"50", "51"}, next: 3},
+		{name: "next page", country: "9", start: 3, limit: 2, want: []string{"53", "900"}, next: -1},
+		{name: "included country", country: "10", limit: 2, want: []string{"52", "50"}, next: 2},
+		{name: "last page", country: "9", start: 2, limit: 5, want: []string{"51", "53", "900"}, next: -1},
+	}
+	for _, test := range tests {
+		tagData, next, err := searchAvailableTags(context.Background(), services.tags, search,
+			&domain.GetTagsElastic{Start: test.start, Limit: test.limit}, str(test.country))
+		if err != nil {
+			t.Fatal(err)
+		}
+		var ids []string
+		for _, v := range tagData {
+			ids = append(ids, *v.ID)
+		}
+		if !reflect.DeepEqual(ids, test.want) || next == nil || *next != test.next {
+			t.Errorf("%s: got %v next %v, want %v next %d", test.name, ids, next, test.want, test.next)
+		}
+	}
+}
+
+func TestSearchAvailableTagsStopsAfterRounds(t *testing.T) {
+	services := newTestServices(t)
+	ids := make([]string, 0, availableSearchRounds+1)
+	for i := 0; i <= availableSearchRounds; i++ {
+		ids = append(ids, "52")
+	}
+	tagData, next, err := searchAvailableTags(context.Background(), services.tags, pagedSearch(ids...),
+		&domain.GetTagsElastic{Limit: 1}, str("9"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(tagData) != 0 || next == nil || *next != availableSearchRounds {
+		t.Errorf("got %d tags next %v, want none next %d", len(tagData), next, availableSearchRounds)
+	}
+}
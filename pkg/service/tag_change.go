@@ -0,0 +1,162 @@
+package service
+
+import (
+	"bitbucket.org/noon-micro/curriculum/config"
+	"bitbucket.org/noon-micro/curriculum/pkg/domain"
+	noonerror "bitbucket.org/noon-micro/curriculum/pkg/lib/error"
+	"bitbucket.org/noon-micro/curriculum/pkg/lib/helper"
+	"bitbucket.org/noon-micro/curriculum/pkg/lib/logger"
+	"context"
+	"encoding/base64"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	tagChangeDefaultLimit  = 100
+	tagChangeMaxLimit      = 500
+	tagChangeMaxWait       = 30 * time.Second
+	tagChangePruneInterval = time.Hour
+)
+
+// TagChangeServiceStruct serves the changes feed to the mirrors, the relay of the tag events records the feed
+type TagChangeServiceStruct struct {
+	tcr domain.TagChangeRepository
+}
+
+func NewTagChangeService(tcr domain.TagChangeRepository) *TagChangeServiceStruct {
+	return &TagChangeServiceStruct{tcr: tcr}
+}
+
+// Start prunes the changes older than the retention in the background
+func (t *TagChangeServiceStruct) Start() {
+	go func() {
+		ticker := time.NewTicker(tagChangePruneInterval)
+		defer ticker.Stop()
+		for ; true; <-ticker.C {
+			retention := helper.Timeout(config.GetConfig().TagChangeRetention, config.DefaultTagChangeRetention)
+			if err := t.tcr.DeleteTagChanges(context.Background(), time.Now().Add(-retention)); err != nil {
+				logger.Client.Error("pruneTagChangesError", err)
+			}
+		}
+	}()
+}
+
+// GetTagChanges returns the changes after the cursor, oldest first. An empty page waits up to Wait for changes of any
+// instance, which makes the call a long poll.
+func (t *TagChangeServiceStruct) GetTagChanges(ctx context.Context, getChanges *domain.GetTagChanges) (*domain.TagChanges, error) {
+	sequence, complete, err := decodeTagChangeCursor(getChanges.Cursor)
+	if err != nil {
+		return nil, err
+	}
+	retention := helper.Timeout(config.GetConfig().TagChangeRetention, config.DefaultTagChangeRetention)
+	if getChanges.Cursor != nil && complete.Before(time.Now().Add(-retention)) {
+		return nil, noonerror.New(noonerror.ErrBadRequest, "cursorExpired")
+	}
+	limit := getChanges.Limit
+	if limit <= 0 {
+		limit = tagChangeDefaultLimit
+	}
+	if limit > tagChangeMaxLimit {
+		return nil, noonerror.New(noonerror.ErrBadRequest, "limitInvalid")
+	}
+	wait := getChanges.Wait
+	if wait > tagChangeMaxWait {
+		wait = tagChangeMaxWait
+	}
+	deadline := time.Now().Add(wait)
+	pollInterval := helper.Timeout(config.GetConfig().TagChangePollInterval, config.DefaultTagChangePollInterval)
+	for {
+		// the changes commit in the order of their sequence, every change committed by now is in the page
+		checked := time.Now()
+		changes, err := t.tcr.FetchTagChanges(ctx, sequence, limit+1)
+		if err != nil {
+			return nil, err
+		}
+		if len(changes) > 0 || !time.Now().Before(deadline) {
+			return tagChangesPage(changes, limit, sequence, checked), nil
+		}
+		timer := time.NewTimer(pollInterval)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return tagChangesPage(nil, limit, sequence, checked), nil
+		case <-timer.C:
+		}
+	}
+}
+
+// tagChangesPage builds the cursor after the page, it carries the time up to which the reader has seen every change
+// so that a reader keeping up with an idle feed does not see its cursor expire
+func tagChangesPage(changes []*domain.TagChange, limit int, sequence int64, checked time.Time) *domain.TagChanges {
+	page := &domain.TagChanges{Changes: []*domain.TagChange{}}
+	complete := checked
+	if len(changes) > limit {
+		changes = changes[:limit]
+		page.HasMore = true
+		complete = changes[limit-1].RecordedAt
+	}
+	if len(changes) > 0 {
+		sequence = changes[len(changes)-1].Sequence
+		page.Changes = changes
+	}
+	page.Cursor = encodeTagChangeCursor(sequence, complete)
+	return page
+}
+
+func encodeTagChangeCursor(sequence int64, complete time.Time) string {
+	value := strconv.FormatInt(sequence, 10) + ":" + strconv.FormatInt(complete.UnixNano()/1000000, 10)
+	return base64.RawURLEncoding.EncodeToString([]byte(value))
+}
+
+// decodeTagChangeCursor reads the sequence the cursor resumes after, an unset cursor starts before the first change
+func decodeTagChangeCursor(cursor *string) (sequence int64, complete time.Time, err error) {
+	if cursor == nil {
+		return 0, time.Now(), nil
+	}
+	value, err := base64.RawURLEncoding.DecodeString(*cursor)
+	if err != nil {
+		return 0, time.Time{}, noonerror.New(noonerror.ErrBadRequest, "cursorInvalid")
+	}
+	parts := strings.Split(string(value), ":")
+	if len(parts) != 2 {
+		return 0, time.Time{}, noonerror.New(noonerror.ErrBadRequest, "cursorInvalid")
+	}
+	sequence, err = strconv.ParseInt(parts[0], 10, 64)
+	if err != nil || sequence < 0 {
+		return 0, time.Time{}, noonerror.New(noonerror.ErrBadRequest, "cursorInvalid")
+	}
+	completeMilli, err := strconv.ParseInt(parts[1], 10, 64)
+	if err != nil {
+		return 0, time.Time{}, noonerror.New(noonerror.ErrBadRequest, "cursorInvalid")
+	}
+	return sequence, time.Unix(0, completeMilli*int64(time.Millisecond)), nil
+}
+
+// newTagChange is the entry of the changes feed for a relayed event
+func newTagChange(event *domain.TagEvent, recordedAt time.Time) *domain.TagChange {
+	return &domain.TagChange{
+		EventID:    &event.ID,
+		EventType:  &event.Type,
+		Entity:     tagChangeEntity(event.Type),
+		TagId:      &event.TagId,
+		TagType:    &event.TagType,
+		ParentTags: event.ParentTags,
+		ChangedAt:  event.OccurredAt,
+		RecordedAt: recordedAt.UTC(),
+	}
+}
+
+// tagChangeEntity tells which rows an event changed
+func tagChangeEntity(eventType string) *string {
+	entity := domain.TagChangeEntityEnum.Tag
+	switch eventType {
+	case domain.TagEventTypeEnum.Hidden, domain.TagEventTypeEnum.Unhidden, domain.TagEventTypeEnum.Reordered,
+		domain.TagEventTypeEnum.HierarchyAdded, domain.TagEventTypeEnum.HierarchyRemoved:
+		entity = domain.TagChangeEntityEnum.Mapping
+	case domain.TagEventTypeEnum.LocaleChanged:
+		entity = domain.TagChangeEntityEnum.Locale
+	}
+	return &entity
+}
@@ -0,0 +1,92 @@
+package service
+
+import (
+	"bitbucket.org/noon-micro/curriculum/pkg/domain"
+	repository "bitbucket.org/noon-micro/curriculum/pkg/repository/mysql"
+	"context"
+	"testing"
+	"time"
+)
+
+func TestTagChangesAreRecordedByTheRelay(t *testing.T) {
+	services := newTestServices(t)
+	ctx := context.Background()
+	for _, name := range []string{"Whole numbers", "Natural numbers"} {
+		if _, err := services.admin.UpdateTag(ctx, &domain.UpdateTag{ID: str("50"), Name: str(name)}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	page, err := services.changes.GetTagChanges(ctx, &domain.GetTagChanges{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(page.Changes) != 0 {
+		t.Fatalf("feed has %d changes before the relay", len(page.Changes))
+	}
+	if relayed := services.publisher.relay(); relayed != 2 {
+		t.Fatalf("relayed %d events, want 2", relayed)
+	}
+	// the changes are served as soon as the relay commits them
+	page, err = services.changes.GetTagChanges(ctx, &domain.GetTagChanges{Cursor: &page.Cursor})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(page.Changes) != 2 {
+		t.Fatalf("feed has %d changes, want 2", len(page.Changes))
+	}
+	first, second := page.Changes[0], page.Changes[1]
+	if *first.TagId != "50" || *first.EventType != domain.TagEventTypeEnum.Renamed || len(first.ParentTags) == 0 {
+		t.Errorf("recorded %s of %s under %v", *first.EventType, *first.TagId, first.ParentTags)
+	}
+	if second.Sequence <= first.Sequence {
+		t.Errorf("sequences %d and %d are out of order", first.Sequence, second.Sequence)
+	}
+	if relayed := services.publisher.relay(); relayed != 0 {
+		t.Fatalf("relayed %d events again", relayed)
+	}
+	page, err = services.changes.GetTagChanges(ctx, &domain.GetTagChanges{Cursor: &page.Cursor})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(page.Changes) != 0 {
+		t.Errorf("feed has %d changes after the cursor", len(page.Changes))
+	}
+}
+
+func TestTagChangesOfARolledBackRelayAreNotRecorded(t *testing.T) {
+	services := newTestServices(t)
+	ctx := context.Background()
+	if _, err := services.admin.UpdateTag(ctx, &domain.UpdateTag{ID: str("50"), Name: str("Whole numbers")}); err != nil {
+		t.Fatal(err)
+	}
+	tx, err := repository.Db.BeginTx(ctx, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	events, err := services.publisher.outbox.ClaimPendingTagEvents(ctx, tx, services.publisher.relayId, time.Now(), time.Now().Add(tagEventClaimTtl), tagEventRelayBatch)
+	if err != nil || len(events) != 1 {
+		t.Fatalf("fetched %d events: %v", len(events), err)
+	}
+	if err = services.publisher.changes.CreateTagChange(ctx, tx, newTagChange(events[0], events[0].OccurredAt)); err != nil {
+		t.Fatal(err)
+	}
+	_ = tx.Rollback()
+	page, err := services.changes.GetTagChanges(ctx, &domain.GetTagChanges{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(page.Changes) != 0 {
+		t.Fatalf("feed has %d changes of a rolled back relay", len(page.Changes))
+	}
+	// the event is still pending, the next relay records it once
+	if relayed := services.publisher.relay(); relayed != 1 {
+		t.Fatalf("relayed %d events, want 1", relayed)
+	}
+	page, err = services.changes.GetTagChanges(ctx, &domain.GetTagChanges{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(page.Changes) != 1 || *page.Changes[0].EventID != events[0].ID {
+		t.Errorf("feed has %d changes after the relay, want the rolled back one", len(page.Changes))
+	}
+}
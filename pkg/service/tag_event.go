@@ -0,0 +1,206 @@
+package service
+
+import (
+	"bitbucket.org/noon-micro/curriculum/config"
+	"bitbucket.org/noon-micro/curriculum/pkg/domain"
+	noonerror "bitbucket.org/noon-micro/curriculum/pkg/lib/error"
+	"bitbucket.org/noon-micro/curriculum/pkg/lib/helper"
+	"bitbucket.org/noon-micro/curriculum/pkg/lib/logger"
+	repository "bitbucket.org/noon-micro/curriculum/pkg/repository/mysql"
+	"context"
+	"database/sql"
+	"github.com/google/uuid"
+	"reflect"
+	"sort"
+	"time"
+)
+
+const (
+	// tagEventRelayBatch is the number of events claimed at once
+	tagEventRelayBatch = 100
+	tagEventAttempts   = 3
+	tagEventBackoff    = 100 * time.Millisecond
+	// tagEventClaimTtl is how long the claimed events wait for their relay, another instance takes them over after
+	tagEventClaimTtl = time.Minute
+	// tagEventRetention is how long relayed events stay in the outbox
+	tagEventRetention     = 24 * time.Hour
+	tagEventPruneInterval = time.Hour
+)
+
+type TagEventPublisherStruct struct {
+	// relayId names the claims of the relay of this instance
+	relayId string
+	ts      domain.TagsService
+	outbox  domain.TagEventOutboxRepository
+	changes domain.TagChangeRepository
+	broker  domain.TagEventBroker
+}
+
+func NewTagEventPublisher(ts domain.TagsService, outbox domain.TagEventOutboxRepository, changes domain.TagChangeRepository, broker domain.TagEventBroker) *TagEventPublisherStruct {
+	return &TagEventPublisherStruct{relayId: uuid.New().String(), ts: ts, outbox: outbox, changes: changes, broker: broker}
+}
+
+// Publish writes the event to the outbox through tx, which makes the event exist exactly when the change does. It
+// is the last write of the transaction: the rows of the tag are locked by then, so a later change of the tag waits
+// for the commit and takes a later sequence.
+func (p *TagEventPublisherStruct) Publish(ctx context.Context, tx *sql.Tx, eventType string, tagId *string, tagType *string, data interface{}) error {
+	if tagId == nil {
+		return nil
+	}
+	event := &domain.TagEvent{ID: uuid.New().String(), Type: eventType, SchemaVersion: domain.TagEventSchemaVersion, TagId: *tagId,
+		OccurredAt: time.Now().UTC(), Data: data}
+	if tagType != nil {
+		event.TagType = *tagType
+	}
+	return p.outbox.CreateTagEvent(ctx, tx, event)
+}
+
+// Start relays the committed events to the broker in the background and prunes the relayed ones
+func (p *TagEventPublisherStruct) Start() {
+	go p.run(helper.Timeout(config.GetConfig().TagEventRelayInterval, config.DefaultTagEventRelayInterval))
+}
+
+func (p *TagEventPublisherStruct) run(relayInterval time.Duration) {
+	ticker := time.NewTicker(relayInterval)
+	defer ticker.Stop()
+	var pruned time.Time
+	for range ticker.C {
+		// a full batch leaves more events pending
+		for p.relay() == tagEventRelayBatch {
+		}
+		if time.Since(pruned) < tagEventPruneInterval {
+			continue
+		}
+		if err := p.outbox.DeleteRelayedTagEvents(context.Background(), time.Now().Add(-tagEventRetention)); err != nil {
+			logger.Client.Error("pruneTagEventsError", err)
+		}
+		pruned = time.Now()
+	}
+}
+
+// relay claims the oldest pending events and hands them to the broker in the order of their sequence once the claim
+// is committed, then marks them relayed and releases the rest of the claim, it returns the number of relayed events.
+// The claims make the relays of the instances take turns without holding a lock while the broker is called. The relay
+// stops at an event the broker keeps failing, so that the events after it wait instead of overtaking it, and at the
+// end of its claim, after which another instance relays the rest again. Once marked the cache generations of the
+// relayed changes grow.
+func (p *TagEventPublisherStruct) relay() (relayed int) {
+	ctx := context.Background()
+	claimedUntil := time.Now().Add(tagEventClaimTtl)
+	events, err := p.claim(ctx, claimedUntil)
+	if err != nil || len(events) == 0 {
+		return
+	}
+	var sequences []int64
+	for _, event := range events {
+		if !time.Now().Before(claimedUntil) {
+			break
+		}
+		if err = p.deliver(ctx, event); err != nil {
+			break
+		}
+		sequences = append(sequences, event.Sequence)
+	}
+	tx, err := repository.Db.BeginTx(ctx, nil)
+	if err != nil {
+		logger.Client.Error("tagEventRelayContextCreationError", err)
+		return
+	}
+	if err = p.outbox.MarkTagEventsRelayed(ctx, tx, p.relayId, sequences, time.Now()); err != nil {
+		_ = tx.Rollback()
+		return
+	}
+	if err = tx.Commit(); err != nil {
+		logger.Client.Error("tagEventRelayCommitError", err)
+		return
+	}
+	families := make(map[string]struct{})
+	for _, event := range events[:len(sequences)] {
+		families[*tagChangeEntity(event.Type)] = struct{}{}
+	}
+	for family := range families {
+		bumpCacheGenerations(ctx, family)
+	}
+	return len(sequences)
+}
+
+// claim records the claimed events in the changes feed in the transaction of the claim. The claims are made one relay
+// at a time, so the changes feed commits in the order of its sequence.
+func (p *TagEventPublisherStruct) claim(ctx context.Context, claimedUntil time.Time) ([]*domain.TagEvent, error) {
+	tx, err := repository.Db.BeginTx(ctx, nil)
+	if err != nil {
+		logger.Client.Error("tagEventRelayContextCreationError", err)
+		return nil, err
+	}
+	events, err := p.outbox.ClaimPendingTagEvents(ctx, tx, p.relayId, time.Now(), claimedUntil, tagEventRelayBatch)
+	if err != nil {
+		_ = tx.Rollback()
+		return nil, err
+	}
+	recordedAt := time.Now()
+	for _, event := range events {
+		p.loadParentTags(ctx, event)
+		if err = p.changes.CreateTagChange(ctx, tx, newTagChange(event, recordedAt)); err != nil {
+			_ = tx.Rollback()
+			return nil, err
+		}
+	}
+	if err = tx.Commit(); err != nil {
+		logger.Client.Error("tagEventClaimCommitError", err)
+		return nil, err
+	}
+	return events, nil
+}
+
+// loadParentTags runs outside of the request, the parent tags are read once the change is committed so they are
+// current
+func (p *TagEventPublisherStruct) loadParentTags(ctx context.Context, event *domain.TagEvent) {
+	event.ParentTags = []string{}
+	parentTagMappings, err := p.ts.FetchParentTagMappings(ctx, &event.TagId)
+	if err != nil {
+		logger.Client.Error("tagEventParentTagsError:id:"+event.ID, err)
+	}
+	for _, v := range parentTagMappings {
+		if v.Publish && v.ParentTagID != nil {
+			event.ParentTags = append(event.ParentTags, *v.ParentTagID)
+		}
+	}
+}
+
+func (p *TagEventPublisherStruct) deliver(ctx context.Context, event *domain.TagEvent) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			logger.Client.Error("tagEventPanicked:id:"+event.ID, logger.GetErrorStack())
+			err = noonerror.New(noonerror.ErrInternalServer, "tagEventPanicked")
+		}
+	}()
+	backoff := tagEventBackoff
+	for attempt := 1; ; attempt++ {
+		if err = p.broker.Publish(ctx, event); err == nil {
+			return
+		}
+		if attempt == tagEventAttempts {
+			logger.Client.Error("tagEventPublishError:broker:"+p.broker.Name()+":id:"+event.ID, err)
+			return
+		}
+		time.Sleep(backoff)
+		backoff *= 2
+	}
+}
+
+// changedAttributes lists the keys whose values differ between the attributes before and after an update
+func changedAttributes(before map[string]interface{}, after map[string]interface{}) []string {
+	var keys []string
+	for k, v := range after {
+		if previous, ok := before[k]; !ok || !reflect.DeepEqual(previous, v) {
+			keys = append(keys, k)
+		}
+	}
+	for k := range before {
+		if _, ok := after[k]; !ok {
+			keys = append(keys, k)
+		}
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,163 @@
+package service
+
+import (
+	"bitbucket.org/noon-micro/curriculum/pkg/domain"
+	repository "bitbucket.org/noon-micro/curriculum/pkg/repository/mysql"
+	"context"
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestTagEventsAreRelayedOnceCommitted(t *testing.T) {
+	services := newTestServices(t)
+	ctx := context.Background()
+	if _, err := services.admin.UpdateTag(ctx, &domain.UpdateTag{ID: str("50"), Name: str("Whole numbers")}); err != nil {
+		t.Fatal(err)
+	}
+	if events := services.broker.Events("50"); len(events) != 0 {
+		t.Fatalf("%d events reached the broker before the relay", len(events))
+	}
+	if relayed := services.publisher.relay(); relayed != 1 {
+		t.Fatalf("relayed %d events, want 1", relayed)
+	}
+	events := services.broker.Events("50")
+	if len(events) != 1 {
+		t.Fatalf("broker received %d events, want 1", len(events))
+	}
+	event := events[0]
+	if event.Type != domain.TagEventTypeEnum.Renamed || event.Sequence == 0 || len(event.ParentTags) == 0 {
+		t.Errorf("relayed %s with sequence %d under %v", event.Type, event.Sequence, event.ParentTags)
+	}
+	data := new(domain.TagRenamedEventData)
+	if err := json.Unmarshal(event.Data.(json.RawMessage), data); err != nil || data.Name == nil || *data.Name != "Whole numbers" {
+		t.Errorf("relayed data %s", event.Data)
+	}
+	if relayed := services.publisher.relay(); relayed != 0 {
+		t.Errorf("relayed %d events again", relayed)
+	}
+}
+
+func TestTagEventsOfRolledBackChangesAreNotRelayed(t *testing.T) {
+	services := newTestServices(t)
+	ctx := context.Background()
+	tx, err := repository.Db.BeginTx(ctx, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = services.publisher.Publish(ctx, tx, domain.TagEventTypeEnum.Deleted, str("50"), str("chapter"), nil); err != nil {
+		t.Fatal(err)
+	}
+	_ = tx.Rollback()
+	stale := -1
+	if _, err = services.admin.UpdateTag(ctx, &domain.UpdateTag{ID: str("50"), Name: str("Whole numbers"), Version: &stale}); !isConflict(err) {
+		t.Fatalf("update against a stale version returned %v", err)
+	}
+	if relayed := services.publisher.relay(); relayed != 0 {
+		t.Errorf("relayed %d events of rolled back changes", relayed)
+	}
+}
+
+func TestCacheGenerationsGrowOnceTheRelayCommits(t *testing.T) {
+	services := newTestServices(t)
+	ctx := context.Background()
+	before, err := services.tags.FetchCacheGenerations(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tx, err := repository.Db.BeginTx(ctx, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = services.publisher.Publish(ctx, tx, domain.TagEventTypeEnum.Reordered, str("50"), str("chapter"), nil); err != nil {
+		t.Fatal(err)
+	}
+	if err = tx.Commit(); err != nil {
+		t.Fatal(err)
+	}
+	if current, _ := services.tags.FetchCacheGenerations(ctx); current != before {
+		t.Fatalf("generations moved from %s to %s before the relay", before, current)
+	}
+	services.publisher.relay()
+	after, err := services.tags.FetchCacheGenerations(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// a reorder moves the generation of the mappings only
+	beforeParts, afterParts := strings.Split(before, ":"), strings.Split(after, ":")
+	if beforeParts[0] != afterParts[0] || beforeParts[1] == afterParts[1] || beforeParts[2] != afterParts[2] {
+		t.Errorf("generations moved from %s to %s after the relay", before, after)
+	}
+}
+
+type failingTagEventBroker struct {
+	attempts int
+}
+
+func (b *failingTagEventBroker) Name() string {
+	return "failing"
+}
+
+func (b *failingTagEventBroker) Publish(ctx context.Context, event *domain.TagEvent) error {
+	b.attempts++
+	return errors.New("broker unavailable")
+}
+
+func TestTagEventsClaimedByAnotherRelayWait(t *testing.T) {
+	services := newTestServices(t)
+	ctx := context.Background()
+	if _, err := services.admin.UpdateTag(ctx, &domain.UpdateTag{ID: str("50"), Name: str("Whole numbers")}); err != nil {
+		t.Fatal(err)
+	}
+	claim := func(claimedUntil time.Time) {
+		tx, err := repository.Db.BeginTx(ctx, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		events, err := services.publisher.outbox.ClaimPendingTagEvents(ctx, tx, "other", time.Now(), claimedUntil, tagEventRelayBatch)
+		if err != nil || len(events) != 1 {
+			t.Fatalf("other relay claimed %d events: %v", len(events), err)
+		}
+		if err = tx.Commit(); err != nil {
+			t.Fatal(err)
+		}
+	}
+	claim(time.Now().Add(tagEventClaimTtl))
+	if relayed := services.publisher.relay(); relayed != 0 {
+		t.Fatalf("relayed %d events claimed by another relay", relayed)
+	}
+	// the other relay stopped before its claim ran out
+	claim(time.Now().Add(-time.Second))
+	if relayed := services.publisher.relay(); relayed != 1 {
+		t.Fatalf("relayed %d events after the claim ran out, want 1", relayed)
+	}
+	if events := services.broker.Events("50"); len(events) != 1 {
+		t.Errorf("broker received %d events, want 1", len(events))
+	}
+}
+
+func TestTagEventsTheBrokerFailsAreReleased(t *testing.T) {
+	services := newTestServices(t)
+	ctx := context.Background()
+	if _, err := services.admin.UpdateTag(ctx, &domain.UpdateTag{ID: str("50"), Name: str("Whole numbers")}); err != nil {
+		t.Fatal(err)
+	}
+	broker := &failingTagEventBroker{}
+	failing := NewTagEventPublisher(services.tags, services.publisher.outbox, services.publisher.changes, broker)
+	if relayed := failing.relay(); relayed != 0 || broker.attempts != tagEventAttempts {
+		t.Fatalf("relayed %d events after %d attempts", relayed, broker.attempts)
+	}
+	// the claim of the failed relay is released, the next relay does not wait for it to run out
+	if relayed := services.publisher.relay(); relayed != 1 {
+		t.Fatalf("relayed %d events after the failure, want 1", relayed)
+	}
+	page, err := services.changes.GetTagChanges(ctx, &domain.GetTagChanges{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(page.Changes) != 1 {
+		t.Errorf("feed has %d changes, want the event recorded once", len(page.Changes))
+	}
+}
@@ -0,0 +1,298 @@
+package service
+
+import (
+	"bitbucket.org/noon-micro/curriculum/pkg/domain"
+	"bitbucket.org/noon-micro/curriculum/pkg/lib/flow"
+	"context"
+	"strings"
+)
+
+type TagGraphServiceStruct struct {
+	ts domain.TagsService
+	es domain.Elastic
+}
+
+func NewTagGraphService(ts domain.TagsService, es domain.Elastic) *TagGraphServiceStruct {
+	return &TagGraphServiceStruct{ts: ts, es: es}
+}
+
+// tagLoader returns the loader attached to the request, or a fresh one when called outside of http
+func (t *TagGraphServiceStruct) tagLoader(ctx context.Context) domain.TagLoader {
+	if ldr, ok := domain.TagLoaderFromContext(ctx); ok {
+		return ldr
+	}
+	return t.ts.NewTagLoader(ctx)
+}
+
+// GetTagNodes loads the tags with their names in the locale of the country, missing ids are skipped. With a parent
+// only the tags mapped under it are kept, without one the path is known for root tags and tags with a single parent.
+func (t *TagGraphServiceStruct) GetTagNodes(ctx context.Context, ids []*string, parent *string, countryId *string, locale *string) ([]*domain.TagNode, error) {
+	tags, err := t.loadTags(ctx, ids, countryId, locale)
+	if err != nil {
+		return nil, err
+	}
+	parentTagMappings, err := t.tagLoader(ctx).LoadParentTagMappings(ids)
+	if err != nil {
+		return nil, err
+	}
+	mappingMap := make(map[string][]*domain.ParentTagMapping)
+	for _, v := range parentTagMappings {
+		if v.Publish && v.ParentTagID != nil {
+			mappingMap[*v.TagID] = append(mappingMap[*v.TagID], v)
+		}
+	}
+	var nodes []*domain.TagNode
+	for _, v := range tags {
+		mappings := mappingMap[*v.ID]
+		node := &domain.TagNode{Tag: v}
+		if parent != nil {
+			for _, mapping := range mappings {
+				if *mapping.ParentTagID == *parent {
+					node.Path, node.Hidden = childPath(parent, v.ID), mapping.Hidden
+				}
+			}
+			if node.Path == nil {
+				continue
+			}
+		} else if len(mappings) == 0 {
+			node.Path = childPath(nil, v.ID)
+		} else if len(mappings) == 1 {
+			node.Path, node.Hidden = childPath(mappings[0].ParentTagID, v.ID), mappings[0].Hidden
+		}
+		nodes = append(nodes, node)
+	}
+	return nodes, nil
+}
+
+// GetTagChildren searches the tags mapped under the parent path, a region path searches under its member countries
+func (t *TagGraphServiceStruct) GetTagChildren(ctx context.Context, getChildren *domain.GetTagChildren) ([]*domain.TagNode, *int, error) {
+	parents, err := t.ts.ResolveRegionParents(ctx, []*string{getChildren.Parent})
+	if err != nil {
+		return nil, nil, err
+	}
+	access := domain.AccessEnum.Global
+	creatorType := "admin"
+	query := &domain.GetTagsElastic{
+		Type:        getChildren.Type,
+		Access:      &access,
+		CreatorType: &creatorType,
+		Start:       getChildren.Start,
+		Limit:       getChildren.Limit,
+	}
+	tagData, next, err := searchAvailableTags(ctx, t.ts, parentSearch(t.es.GetTags, parents), query, getChildren.CountryId)
+	if err != nil {
+		return nil, nil, err
+	}
+	if len(tagData) == 0 {
+		return nil, next, nil
+	}
+	tagIds := make([]*string, 0, len(tagData))
+	for _, v := range tagData {
+		tagIds = append(tagIds, v.ID)
+	}
+	tags, err := t.loadTags(ctx, tagIds, getChildren.CountryId, getChildren.Locale)
+	if err != nil {
+		return nil, nil, err
+	}
+	if getChildren.Type != nil && len(tags) > 0 {
+		curriculumType := tags[0].CurriculumType
+		if curriculum, err := flow.GetCurriculum(&curriculumType); err == nil {
+			if _, ok := curriculum[*getChildren.Type]; ok {
+				tags, err = t.tagLoader(ctx).OrderTags(tags, getChildren.Type, &curriculumType, getChildren.Parent, getChildren.Locale)
+				if err != nil {
+					return nil, nil, err
+				}
+			}
+		}
+	}
+	hiddenSet, err := t.hiddenUnder(ctx, tagIds, parents)
+	if err != nil {
+		return nil, nil, err
+	}
+	nodes := make([]*domain.TagNode, 0, len(tags))
+	for _, v := range tags {
+		nodes = append(nodes, &domain.TagNode{Tag: v, Path: childPath(getChildren.Parent, v.ID), Hidden: hiddenSet[*v.ID]})
+	}
+	return nodes, next, nil
+}
+
+// GetTagParents returns the parent at the end of every published mapping of the tag
+func (t *TagGraphServiceStruct) GetTagParents(ctx context.Context, tagId *string, countryId *string, locale *string) ([]*domain.TagNode, error) {
+	parentTagMappings, err := t.tagLoader(ctx).LoadParentTagMappings([]*string{tagId})
+	if err != nil {
+		return nil, err
+	}
+	var paths []*string
+	var parentIds []*string
+	var grandParents []*string
+	for _, v := range parentTagMappings {
+		if !v.Publish || v.ParentTagID == nil {
+			continue
+		}
+		path := *v.ParentTagID
+		parentId := path
+		var grandParent *string
+		if i := strings.LastIndex(path, "."); i >= 0 {
+			parentId = path[i+1:]
+			prefix := path[:i]
+			grandParent = &prefix
+		}
+		paths = append(paths, &path)
+		parentIds = append(parentIds, &parentId)
+		grandParents = append(grandParents, grandParent)
+	}
+	if len(parentIds) == 0 {
+		return nil, nil
+	}
+	tags, err := t.loadTags(ctx, parentIds, countryId, locale)
+	if err != nil {
+		return nil, err
+	}
+	tagMap := make(map[string]*domain.Tags, len(tags))
+	for _, v := range tags {
+		tagMap[*v.ID] = v
+	}
+	hiddenSet, err := t.hiddenUnder(ctx, parentIds, grandParents)
+	if err != nil {
+		return nil, err
+	}
+	var nodes []*domain.TagNode
+	for i, v := range parentIds {
+		tag, ok := tagMap[*v]
+		if !ok {
+			continue
+		}
+		nodes = append(nodes, &domain.TagNode{Tag: tag, Path: paths[i], Hidden: hiddenSet[*v]})
+	}
+	return nodes, nil
+}
+
+// GetTagIdentifiers returns the identifier tags found on the parent paths of the tag
+func (t *TagGraphServiceStruct) GetTagIdentifiers(ctx context.Context, tagId *string, countryId *string, locale *string) ([]*domain.Tags, error) {
+	parentTagMappings, err := t.tagLoader(ctx).LoadParentTagMappings([]*string{tagId})
+	if err != nil {
+		return nil, err
+	}
+	var ids []*string
+	seen := make(map[string]struct{})
+	for _, v := range parentTagMappings {
+		if !v.Publish || v.ParentTagID == nil {
+			continue
+		}
+		for _, id := range strings.Split(*v.ParentTagID, ".") {
+			if _, ok := seen[id]; !ok {
+				seen[id] = struct{}{}
+				id := id
+				ids = append(ids, &id)
+			}
+		}
+	}
+	if len(ids) == 0 {
+		return nil, nil
+	}
+	tags, err := t.loadTags(ctx, ids, countryId, locale)
+	if err != nil {
+		return nil, err
+	}
+	var identifiers []*domain.Tags
+	for _, v := range tags {
+		if v.TagGroup == domain.TagGroupEnum.Identifier {
+			identifiers = append(identifiers, v)
+		}
+	}
+	return identifiers, nil
+}
+
+func (t *TagGraphServiceStruct) GetTagLocales(ctx context.Context, tagId *string) ([]*domain.LocaleResponse, error) {
+	tagLocaleMappings, err := t.tagLoader(ctx).LoadTagLocaleMappings([]*string{tagId})
+	if err != nil {
+		return nil, err
+	}
+	var locales []*domain.LocaleResponse
+	for _, v := range tagLocaleMappings {
+		if v.Draft {
+			continue
+		}
+		locales = append(locales, &domain.LocaleResponse{Locale: v.Locale, Name: v.Name, CountryId: v.CountryId})
+	}
+	return locales, nil
+}
+
+// GetCountryNodes pages through the countries, next is -1 on the last page
+func (t *TagGraphServiceStruct) GetCountryNodes(ctx context.Context, countryId *string, locale *string, start int, limit int) ([]*domain.Tags, *int, error) {
+	rootCurriculumType := "root"
+	countryType := domain.TagTypeEnum.Country
+	countries, err := t.ts.FetchFilteredTagsPaginated(ctx, &rootCurriculumType, &countryType, &start, &limit)
+	if err != nil {
+		return nil, nil, err
+	}
+	next := -1
+	if len(countries) == limit {
+		next = start + limit
+	}
+	countryIds := make([]*string, 0, len(countries))
+	for _, v := range countries {
+		countryIds = append(countryIds, v.ID)
+	}
+	tags, err := t.loadTags(ctx, countryIds, countryId, locale)
+	if err != nil {
+		return nil, nil, err
+	}
+	return tags, &next, nil
+}
+
+// hiddenUnder reports the tags which are hidden under any of the parents, nil parents are skipped
+func (t *TagGraphServiceStruct) hiddenUnder(ctx context.Context, tagIds []*string, parents []*string) (map[string]bool, error) {
+	parentSet := make(map[string]struct{}, len(parents))
+	for _, v := range parents {
+		if v != nil {
+			parentSet[*v] = struct{}{}
+		}
+	}
+	hiddenSet := make(map[string]bool)
+	if len(parentSet) == 0 {
+		return hiddenSet, nil
+	}
+	parentTagMappings, err := t.tagLoader(ctx).LoadParentTagMappings(tagIds)
+	if err != nil {
+		return nil, err
+	}
+	for _, v := range parentTagMappings {
+		if v.ParentTagID == nil || !v.Publish {
+			continue
+		}
+		if _, ok := parentSet[*v.ParentTagID]; ok && v.Hidden {
+			hiddenSet[*v.TagID] = true
+		}
+	}
+	return hiddenSet, nil
+}
+
+// loadTags keeps the published tags available in the country, the student route serves them without authentication
+func (t *TagGraphServiceStruct) loadTags(ctx context.Context, ids []*string, countryId *string, locale *string) ([]*domain.Tags, error) {
+	ldr := t.tagLoader(ctx)
+	tags, err := ldr.LoadTags(ids)
+	if err != nil {
+		return nil, err
+	}
+	published := make([]*domain.Tags, 0, len(tags))
+	for _, v := range tags {
+		if v.Publish {
+			published = append(published, v)
+		}
+	}
+	tags, err = t.ts.FilterAvailableTags(ctx, published, countryId)
+	if err != nil {
+		return nil, err
+	}
+	return ldr.LoadTagLocales(tags, countryId, locale)
+}
+
+// childPath is the hierarchy of the tag under the parent path, a root tag is its own hierarchy
+func childPath(parent *string, tagId *string) *string {
+	path := *tagId
+	if parent != nil {
+		path = *parent + "." + *tagId
+	}
+	return &path
+}
@@ -0,0 +1,46 @@
+package service
+
+import (
+	"bitbucket.org/noon-micro/curriculum/pkg/domain"
+	"bitbucket.org/noon-micro/curriculum/pkg/external"
+	"context"
+	"testing"
+)
+
+func TestTagNodesKeepPublishedTagsAvailableInTheCountry(t *testing.T) {
+	services := newTestServices(t)
+	ctx := context.Background()
+	hidden := true
+	if err := services.tags.UpdateTag(ctx, nil, &domain.UpdateTag{ID: str("51"), Type: str("topic"), Hidden: &hidden}); err != nil {
+		t.Fatal(err)
+	}
+	graph := NewTagGraphService(services.tags, external.NewMemoryElasticExternal(nil))
+	tests := []struct {
+		countryId *string
+		want      []string
+	}{
+		{countryId: str("9"), want: []string{"50", "53"}},
+		{countryId: str("10"), want: []string{"50", "52", "53"}},
+		{want: []string{"50", "52", "53"}},
+	}
+	for _, test := range tests {
+		nodes, err := graph.GetTagNodes(ctx, []*string{str("50"), str("51"), str("52"), str("53")}, nil, test.countryId, str("en"))
+		if err != nil {
+			t.Fatal(err)
+		}
+		var got []string
+		for _, v := range nodes {
+			got = append(got, *v.Tag.ID)
+		}
+		if len(got) != len(test.want) {
+			t.Errorf("country %v: nodes %v, want %v", test.countryId, got, test.want)
+			continue
+		}
+		for i := range got {
+			if got[i] != test.want[i] {
+				t.Errorf("country %v: nodes %v, want %v", test.countryId, got, test.want)
+				break
+			}
+		}
+	}
+}
@@ -0,0 +1,400 @@
+package service
+
+import (
+	"bitbucket.org/noon-micro/curriculum/pkg/domain"
+	"bitbucket.org/noon-micro/curriculum/pkg/lib/loader"
+	repository "bitbucket.org/noon-micro/curriculum/pkg/repository/redis"
+	"bitbucket.org/noon-micro/curriculum/pkg/service/constant"
+	"context"
+	"encoding/json"
+	"strings"
+)
+
+type TagLoaderStruct struct {
+	ts      *TagsServiceStruct
+	tags    *loader.Loader
+	ptms    *loader.Loader
+	orders  *loader.Loader
+	locales *loader.Loader
+	tlms    *loader.Loader
+	attrs   *loader.Loader
+	rules   *loader.Loader
+}
+
+// NewTagLoader returns a loader which should be scoped to a single request
+func (t *TagsServiceStruct) NewTagLoader(ctx context.Context) domain.TagLoader {
+	l := &TagLoaderStruct{ts: t}
+	l.tags = loader.New(ctx, t.batchTags, constant.LoaderWait, constant.LoaderMaxBatch)
+	l.ptms = loader.New(ctx, t.batchParentTagMappings, constant.LoaderWait, constant.LoaderMaxBatch)
+	l.orders = loader.New(ctx, t.batchTagOrders, constant.LoaderWait, constant.LoaderMaxBatch)
+	l.locales = loader.New(ctx, t.batchTagLocales, constant.LoaderWait, constant.LoaderMaxBatch)
+	l.tlms = loader.New(ctx, t.batchTagLocaleMappings, constant.LoaderWait, constant.LoaderMaxBatch)
+	l.attrs = loader.New(ctx, t.batchTagAttributeLocales, constant.LoaderWait, constant.LoaderMaxBatch)
+	l.rules = loader.New(ctx, t.batchTagCountryRules, constant.LoaderWait, constant.LoaderMaxBatch)
+	return l
+}
+
+// LoadTags behaves like FetchByInTags, missing ids are skipped and the input order is kept
+func (l *TagLoaderStruct) LoadTags(ids []*string) (tags []*domain.Tags, err error) {
+	values, err := l.tags.LoadMany(derefIds(ids))
+	if err != nil {
+		return nil, err
+	}
+	for _, v := range values {
+		tag, ok := v.(*domain.Tags)
+		if ok && tag != nil {
+			tags = append(tags, cloneTag(tag))
+		}
+	}
+	return tags, nil
+}
+
+func (l *TagLoaderStruct) LoadParentTagMappings(ids []*string) (parentTagMappings []*domain.ParentTagMapping, err error) {
+	values, err := l.ptms.LoadMany(derefIds(ids))
+	if err != nil {
+		return nil, err
+	}
+	for _, v := range values {
+		mappings, ok := v.([]*domain.ParentTagMapping)
+		if ok {
+			parentTagMappings = append(parentTagMappings, mappings...)
+		}
+	}
+	return parentTagMappings, nil
+}
+
+func (l *TagLoaderStruct) LoadTagOrders(parentTagIds *string, tagType *string) (tagOrders []*domain.ParentTagMapping, err error) {
+	if parentTagIds == nil || tagType == nil {
+		return
+	}
+	value, err := l.orders.Load(*parentTagIds + ":" + *tagType)
+	if err != nil {
+		return nil, err
+	}
+	tagOrders, _ = value.([]*domain.ParentTagMapping)
+	return tagOrders, nil
+}
+
+// LoadTagLocales behaves like FetchTagLocaleMappingsByLocale
+func (l *TagLoaderStruct) LoadTagLocales(tagData []*domain.Tags, countryId *string, locale *string) (tagResults []*domain.Tags, err error) {
+	if len(tagData) == 0 || countryId == nil || locale == nil {
+		return tagData, nil
+	}
+	var tagIds []*string
+	for _, v := range tagData {
+		if v.LocaleAvailable {
+			tagIds = append(tagIds, v.ID)
+		}
+	}
+	tagLocaleMap, err := l.LoadServedLocales(tagIds, countryId, locale)
+	if err != nil {
+		return
+	}
+	for _, v := range tagData {
+		v.ServedLocale = servedLocale(tagLocaleMap[*v.ID])
+		if tagLocale, ok := tagLocaleMap[*v.ID]; ok {
+			v.LocaleName = tagLocale.Name
+		}
+	}
+	return l.loadAttributeLocales(tagData, countryId, locale)
+}
+
+// LoadServedLocales returns for every tag the first translation found along the fallback chain of the country and
+// locale, every step of the chain is loaded for all tags in the same batch
+func (l *TagLoaderStruct) LoadServedLocales(tagIds []*string, countryId *string, locale *string) (tagLocaleMap map[string]*domain.TagLocaleMapping, err error) {
+	if len(tagIds) == 0 || countryId == nil || locale == nil {
+		return make(map[string]*domain.TagLocaleMapping), nil
+	}
+	chain := localeFallbackChain(*countryId, *locale)
+	var keys []string
+	for _, tagId := range tagIds {
+		for _, candidate := range chain {
+			keys = append(keys, *tagId+":"+candidate.countryId+":"+candidate.locale)
+		}
+	}
+	values, err := l.locales.LoadMany(keys)
+	if err != nil {
+		return nil, err
+	}
+	rows := make(map[string]*domain.TagLocaleMapping, len(keys))
+	for i, v := range values {
+		if tagLocale, ok := v.(*domain.TagLocaleMapping); ok {
+			rows[keys[i]] = tagLocale
+		}
+	}
+	return servedLocales(tagIds, chain, rows), nil
+}
+
+// loadAttributeLocales behaves like FetchAttributeLocalesByLocale
+func (l *TagLoaderStruct) loadAttributeLocales(tagData []*domain.Tags, countryId *string, locale *string) (tagResults []*domain.Tags, err error) {
+	var tagIds []string
+	for _, v := range tagData {
+		if v.Type != nil && len(constant.LocalizableAttributes[*v.Type]) > 0 {
+			tagIds = append(tagIds, *v.ID)
+		}
+	}
+	if len(tagIds) == 0 {
+		return tagData, nil
+	}
+	values, err := l.attrs.LoadMany(tagIds)
+	if err != nil {
+		return nil, err
+	}
+	result := make(map[string]interface{}, len(tagIds))
+	for i, v := range values {
+		result[tagIds[i]] = v
+	}
+	resolveAttributeLocales(tagData, countryId, locale, result)
+	return tagData, nil
+}
+
+// LoadTagAvailability behaves like FetchTagAvailability
+func (l *TagLoaderStruct) LoadTagAvailability(tagData []*domain.Tags, countryId *string) (tagResults []*domain.Tags, err error) {
+	if len(tagData) == 0 || !callerCountry(countryId) {
+		return tagData, nil
+	}
+	tagIds := make([]string, 0, len(tagData))
+	for _, v := range tagData {
+		tagIds = append(tagIds, *v.ID)
+	}
+	values, err := l.rules.LoadMany(tagIds)
+	if err != nil {
+		return nil, err
+	}
+	result := make(map[string]interface{}, len(tagIds))
+	for i, v := range values {
+		result[tagIds[i]] = v
+	}
+	resolveTagAvailability(tagData, *countryId, result)
+	return tagData, nil
+}
+
+// LoadTagLocaleMappings returns every published locale of the given tags
+func (l *TagLoaderStruct) LoadTagLocaleMappings(ids []*string) (tagLocaleMappings []*domain.TagLocaleMapping, err error) {
+	values, err := l.tlms.LoadMany(derefIds(ids))
+	if err != nil {
+		return nil, err
+	}
+	for _, v := range values {
+		mappings, ok := v.([]*domain.TagLocaleMapping)
+		if ok {
+			tagLocaleMappings = append(tagLocaleMappings, mappings...)
+		}
+	}
+	return tagLocaleMappings, nil
+}
+
+func (l *TagLoaderStruct) OrderTags(tags []*domain.Tags, tagType *string, curriculumType *string, hierarchy *string, locale *string) ([]*domain.Tags, error) {
+	return orderTags(l.LoadTagOrders, tags, tagType, curriculumType, hierarchy, locale)
+}
+
+func (t *TagsServiceStruct) batchTags(ctx context.Context, ids []string) (map[string]interface{}, error) {
+	result := make(map[string]interface{}, len(ids))
+	var missing []*string
+	for i, val := range mGet(ctx, repository.CurriculumPrefix, ids) {
+		var tagData *domain.Tags
+		if val == nil || json.Unmarshal([]byte(*val), &tagData) != nil || tagData == nil {
+			missing = append(missing, &ids[i])
+			continue
+		}
+		result[ids[i]] = tagData
+	}
+	if len(missing) == 0 {
+		return result, nil
+	}
+	tags, err := t.tr.FetchByInTags(ctx, missing)
+	if err != nil {
+		return nil, err
+	}
+	for _, tag := range tags {
+		result[*tag.ID] = tag
+		tagByte, err := json.Marshal(*tag)
+		if err == nil {
+			repository.Client(ctx).Set(repository.CurriculumPrefix+*tag.ID, string(tagByte), repository.RedisTtl)
+		}
+	}
+	return result, nil
+}
+
+func (t *TagsServiceStruct) batchParentTagMappings(ctx context.Context, ids []string) (map[string]interface{}, error) {
+	result := make(map[string]interface{}, len(ids))
+	var missing []*string
+	for i, val := range mGet(ctx, repository.CurriculumParentTagMappingPrefix, ids) {
+		var parentTagMappings []*domain.ParentTagMapping
+		if val == nil || json.Unmarshal([]byte(*val), &parentTagMappings) != nil {
+			missing = append(missing, &ids[i])
+			continue
+		}
+		result[ids[i]] = parentTagMappings
+	}
+	if len(missing) == 0 {
+		return result, nil
+	}
+	parentTagMappings, err := t.ptmr.FetchByInParentTagMappings(ctx, missing)
+	if err != nil {
+		return nil, err
+	}
+	grouped := make(map[string][]*domain.ParentTagMapping)
+	for _, v := range parentTagMappings {
+		grouped[*v.TagID] = append(grouped[*v.TagID], v)
+	}
+	for tagId, mappings := range grouped {
+		result[tagId] = mappings
+		tagByte, err := json.Marshal(mappings)
+		if err == nil {
+			repository.Client(ctx).Set(repository.CurriculumParentTagMappingPrefix+tagId, string(tagByte), repository.RedisTtl)
+		}
+	}
+	return result, nil
+}
+
+func (t *TagsServiceStruct) batchTagOrders(ctx context.Context, keys []string) (map[string]interface{}, error) {
+	result := make(map[string]interface{}, len(keys))
+	for i, val := range mGet(ctx, repository.CurriculumTagOrderPrefix, keys) {
+		var tagOrders []*domain.ParentTagMapping
+		if val != nil && json.Unmarshal([]byte(*val), &tagOrders) == nil {
+			result[keys[i]] = tagOrders
+			continue
+		}
+		// there is no batched query for orders, misses are rare once the cache is warm
+		parentTagIds, tagType := splitTagOrderKey(keys[i])
+		tagOrders, err := t.ptmr.FetchParentTagMappingsByParentTagIds(ctx, &parentTagIds, &tagType)
+		if err != nil {
+			return nil, err
+		}
+		if len(tagOrders) > 0 {
+			tagByte, err := json.Marshal(tagOrders)
+			if err == nil {
+				repository.Client(ctx).Set(repository.CurriculumTagOrderPrefix+keys[i], string(tagByte), repository.RedisTtl)
+			}
+		}
+		result[keys[i]] = tagOrders
+	}
+	return result, nil
+}
+
+// batchTagLocales expects keys of the form tagId:countryId:locale, the same suffix used for redis. A tag without a
+// translation for the country and locale is cached as null, so that untranslated listings stay off MySQL.
+func (t *TagsServiceStruct) batchTagLocales(ctx context.Context, keys []string) (map[string]interface{}, error) {
+	result := make(map[string]interface{}, len(keys))
+	missing := make(map[string][]*string)
+	for i, val := range mGet(ctx, repository.CurriculumTagLocaleMappingPrefix, keys) {
+		var tagLocale *domain.TagLocaleMapping
+		if val != nil && json.Unmarshal([]byte(*val), &tagLocale) == nil {
+			if tagLocale != nil {
+				result[keys[i]] = tagLocale
+			}
+			continue
+		}
+		parts := strings.SplitN(keys[i], ":", 3)
+		if len(parts) != 3 {
+			continue
+		}
+		group := parts[1] + ":" + parts[2]
+		missing[group] = append(missing[group], &parts[0])
+	}
+	for group, tagIds := range missing {
+		parts := strings.SplitN(group, ":", 2)
+		countryId, locale := parts[0], parts[1]
+		tagLocales, err := t.tlmr.FetchTagLocalesByTagIds(ctx, tagIds, &locale, &countryId)
+		if err != nil {
+			return nil, err
+		}
+		pipe := repository.Client(ctx).Pipeline()
+		for _, v := range tagLocales {
+			key := *v.TagID + ":" + group
+			result[key] = v
+			tagByte, err := json.Marshal(*v)
+			if err == nil {
+				pipe.Set(repository.CurriculumTagLocaleMappingPrefix+key, string(tagByte), repository.RedisTtl)
+			}
+		}
+		for _, tagId := range tagIds {
+			key := *tagId + ":" + group
+			if _, ok := result[key]; !ok {
+				pipe.Set(repository.CurriculumTagLocaleMappingPrefix+key, "null", repository.NegativeTtl)
+			}
+		}
+		_, _ = pipe.Exec()
+	}
+	return result, nil
+}
+
+func (t *TagsServiceStruct) batchTagLocaleMappings(ctx context.Context, ids []string) (map[string]interface{}, error) {
+	tagIds := make([]*string, len(ids))
+	for i := range ids {
+		tagIds[i] = &ids[i]
+	}
+	tagLocaleMappings, err := t.tlmr.FetchByInTagLocaleMappings(ctx, tagIds)
+	if err != nil {
+		return nil, err
+	}
+	result := make(map[string]interface{}, len(ids))
+	grouped := make(map[string][]*domain.TagLocaleMapping)
+	for _, v := range tagLocaleMappings {
+		grouped[*v.TagID] = append(grouped[*v.TagID], v)
+	}
+	for tagId, mappings := range grouped {
+		result[tagId] = mappings
+	}
+	return result, nil
+}
+
+// mGet reads prefix+key for every key, a redis failure is reported as a miss for all keys
+func mGet(ctx context.Context, prefix string, keys []string) []*string {
+	values := make([]*string, len(keys))
+	if len(keys) == 0 {
+		return values
+	}
+	redisKeys := make([]string, len(keys))
+	for i, key := range keys {
+		redisKeys[i] = prefix + key
+	}
+	result, err := repository.Client(ctx).MGet(redisKeys...).Result()
+	if err != nil {
+		return values
+	}
+	for i, val := range result {
+		if str, ok := val.(string); ok {
+			values[i] = &str
+		}
+	}
+	return values
+}
+
+func derefIds(ids []*string) []string {
+	keys := make([]string, 0, len(ids))
+	for _, id := range ids {
+		if id != nil {
+			keys = append(keys, *id)
+		}
+	}
+	return keys
+}
+
+// cloneTag keeps callers from mutating the copy shared through the loader cache
+func cloneTag(tag *domain.Tags) *domain.Tags {
+	clone := *tag
+	if tag.Name != nil {
+		name := *tag.Name
+		clone.Name = &name
+	}
+	if tag.LocaleName != nil {
+		localeName := *tag.LocaleName
+		clone.LocaleName = &localeName
+	}
+	clone.Attributes = cloneAttributes(tag.Attributes)
+	clone.LocaleAttributes = cloneAttributes(tag.LocaleAttributes)
+	return &clone
+}
+
+func cloneAttributes(attributes map[string]interface{}) map[string]interface{} {
+	if attributes == nil {
+		return nil
+	}
+	clone := make(map[string]interface{}, len(attributes))
+	for k, v := range attributes {
+		clone[k] = v
+	}
+	return clone
+}
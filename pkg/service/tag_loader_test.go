@@ -0,0 +1,24 @@
+package service
+
+import (
+	"context"
+	"testing"
+)
+
+func TestLoadTagsReturnsCopies(t *testing.T) {
+	services := newTestServices(t)
+	ldr := services.tags.NewTagLoader(context.Background())
+	tags, err := ldr.LoadTags([]*string{str("20")})
+	if err != nil || len(tags) != 1 {
+		t.Fatalf("load returned %v %v", tags, err)
+	}
+	tags[0].Attributes["color"] = "#000000"
+	tags[0].LocaleAttributes = map[string]interface{}{"color": "#000000"}
+	tags, err = ldr.LoadTags([]*string{str("20")})
+	if err != nil || len(tags) != 1 {
+		t.Fatalf("reload returned %v %v", tags, err)
+	}
+	if tags[0].Attributes["color"] != "#5bb9f2" || tags[0].LocaleAttributes != nil {
+		t.Errorf("loader cache was mutated through a returned tag: %v %v", tags[0].Attributes, tags[0].LocaleAttributes)
+	}
+}
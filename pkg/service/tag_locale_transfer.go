@@ -0,0 +1,196 @@
+package service
+
+import (
+	"bitbucket.org/noon-micro/curriculum/pkg/domain"
+	noonerror "bitbucket.org/noon-micro/curriculum/pkg/lib/error"
+	"bitbucket.org/noon-micro/curriculum/pkg/lib/helper"
+	"bitbucket.org/noon-micro/curriculum/pkg/lib/logger"
+	repository "bitbucket.org/noon-micro/curriculum/pkg/repository/mysql"
+	"bitbucket.org/noon-micro/curriculum/pkg/service/constant"
+	"context"
+	"database/sql"
+	"time"
+)
+
+// ExportTagLocales lists every published tag of the subtree without a translation for the locale and country
+func (t *AdminTagsServiceStruct) ExportTagLocales(ctx context.Context, export *domain.TranslationExport) (units []*domain.TranslationUnit, err error) {
+	locale := helper.NormalizeLocale(*export.Locale)
+	tagData, err := t.ts.FetchSubtreeTags(ctx, export.Hierarchy)
+	if err != nil {
+		return nil, err
+	}
+	var tagIds []*string
+	for _, v := range tagData {
+		tagIds = append(tagIds, v.ID)
+	}
+	tagLocales, err := t.ts.FetchTagLocalesByTagIds(ctx, tagIds, &locale, export.CountryId)
+	if err != nil {
+		return nil, err
+	}
+	translated := make(map[string]struct{}, len(tagLocales))
+	for _, v := range tagLocales {
+		translated[*v.TagID] = struct{}{}
+	}
+	for _, v := range tagData {
+		if _, ok := translated[*v.ID]; ok || !v.Publish {
+			continue
+		}
+		units = append(units, &domain.TranslationUnit{TagID: v.ID, TagType: v.Type, Source: v.Name})
+	}
+	return units, nil
+}
+
+// ImportTagLocales diffs the units against the current translations of the locale and country. The import is
+// applied in a single transaction unless it is a dry run or a unit is invalid, the localized names of every
+// changed tag are then pushed to the search backend.
+func (t *AdminTagsServiceStruct) ImportTagLocales(ctx context.Context, translationImport *domain.TranslationImport) (result *domain.TranslationImportResult, err error) {
+	locale := helper.NormalizeLocale(*translationImport.Locale)
+	result = &domain.TranslationImportResult{Created: []*domain.TranslationChange{}, Updated: []*domain.TranslationChange{}, Invalid: []*domain.TranslationError{}}
+	seen := make(map[string]struct{})
+	var units []*domain.TranslationUnit
+	var tagIds []*string
+	for _, v := range translationImport.Units {
+		if v.Target == nil || *v.Target == "" {
+			result.Skipped++
+			continue
+		}
+		message := ""
+		if v.TagID == nil || *v.TagID == "" {
+			message = "tagIdMissing"
+		} else if _, ok := seen[*v.TagID]; ok {
+			message = "tagIdDuplicate"
+		}
+		if message != "" {
+			result.Invalid = append(result.Invalid, &domain.TranslationError{TagID: v.TagID, Line: v.Line, Message: message})
+			continue
+		}
+		seen[*v.TagID] = struct{}{}
+		units = append(units, v)
+		tagIds = append(tagIds, v.TagID)
+	}
+	if len(units) == 0 {
+		return result, nil
+	}
+	tagData, err := t.ts.FetchByInTags(ctx, tagIds)
+	if err != nil {
+		return nil, err
+	}
+	tagMap := make(map[string]*domain.Tags, len(tagData))
+	for _, v := range tagData {
+		tagMap[*v.ID] = v
+	}
+	tagLocales, err := t.ts.FetchTagLocalesByTagIds(ctx, tagIds, &locale, translationImport.CountryId)
+	if err != nil {
+		return nil, err
+	}
+	tagLocaleMap := make(map[string]*domain.TagLocaleMapping, len(tagLocales))
+	for _, v := range tagLocales {
+		tagLocaleMap[*v.TagID] = v
+	}
+	var changes []*domain.TranslationChange
+	for _, v := range units {
+		tag, ok := tagMap[*v.TagID]
+		message := ""
+		if !ok || !tag.Publish {
+			message = "tagNotFound"
+		} else if v.TagType != nil && *v.TagType != "" && *v.TagType != *tag.Type {
+			message = "tagTypeMismatch"
+		} else if v.Source != nil && *v.Source != "" && *v.Source != *tag.Name {
+			message = "sourceChanged"
+		}
+		if message != "" {
+			result.Invalid = append(result.Invalid, &domain.TranslationError{TagID: v.TagID, Line: v.Line, Message: message})
+			continue
+		}
+		tagLocale, ok := tagLocaleMap[*v.TagID]
+		change := &domain.TranslationChange{TagID: v.TagID, Name: v.Target}
+		if !ok {
+			result.Created = append(result.Created, change)
+		} else if *tagLocale.Name != *v.Target {
+			change.Previous = tagLocale.Name
+			result.Updated = append(result.Updated, change)
+		} else {
+			result.Unchanged++
+			continue
+		}
+		changes = append(changes, change)
+	}
+	if len(result.Invalid) > 0 || translationImport.DryRun || len(changes) == 0 {
+		return result, nil
+	}
+	if err = t.applyTagLocales(ctx, locale, translationImport.CountryId, changes, tagMap, tagLocaleMap); err != nil {
+		return nil, err
+	}
+	result.Applied = true
+	result.SearchFailed = t.pushTagLocales(ctx, changes, tagMap)
+	return result, nil
+}
+
+func (t *AdminTagsServiceStruct) applyTagLocales(ctx context.Context, locale string, countryId *string, changes []*domain.TranslationChange, tagMap map[string]*domain.Tags, tagLocaleMap map[string]*domain.TagLocaleMapping) (err error) {
+	tx, err := repository.Db.BeginTx(ctx, nil)
+	if err != nil {
+		return noonerror.New(noonerror.ErrInternalServer, "ContextCreationError")
+	}
+	for _, v := range changes {
+		tag := tagMap[*v.TagID]
+		if tagLocale, ok := tagLocaleMap[*v.TagID]; ok {
+			if err = t.ts.DeleteTagLocaleMapping(ctx, tx, tagLocale); err != nil {
+				_ = tx.Rollback()
+				return err
+			}
+		}
+		if err = t.ts.CreateTagLocaleMapping(ctx, tx, &domain.TagLocaleMapping{Locale: &locale, CountryId: countryId, TagID: v.TagID,
+			Name: v.Name, TagType: tag.Type, Publish: true, CreatedAt: time.Now(), UpdatedAt: time.Now()}); err != nil {
+			_ = tx.Rollback()
+			return err
+		}
+		if !tag.LocaleAvailable {
+			if err = t.ts.UpdateLocale(ctx, tx, true, v.TagID); err != nil {
+				_ = tx.Rollback()
+				return err
+			}
+		}
+	}
+	if err = t.publishTagLocales(ctx, tx, locale, countryId, changes, tagMap); err != nil {
+		_ = tx.Rollback()
+		return err
+	}
+	if err = tx.Commit(); err != nil {
+		return noonerror.New(noonerror.ErrInternalServer, "dbCommitError")
+	}
+	return nil
+}
+
+func (t *AdminTagsServiceStruct) publishTagLocales(ctx context.Context, tx *sql.Tx, locale string, countryId *string, changes []*domain.TranslationChange, tagMap map[string]*domain.Tags) error {
+	for _, v := range changes {
+		if err := t.ep.Publish(ctx, tx, domain.TagEventTypeEnum.LocaleChanged, v.TagID, tagMap[*v.TagID].Type, &domain.TagLocaleChangedEventData{
+			Action:  domain.TagLocaleActionEnum.Update,
+			Locales: []*domain.TagEventLocale{{Locale: &locale, CountryId: countryId, Name: v.Name}},
+		}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// pushTagLocales sends the base name and every published translation of the changed tags to the search backend,
+// it returns the tags that could not be updated
+func (t *AdminTagsServiceStruct) pushTagLocales(ctx context.Context, changes []*domain.TranslationChange, tagMap map[string]*domain.Tags) (failed []*string) {
+	defaultLocale := constant.DefaultLocale
+	for _, v := range changes {
+		names := []*domain.TagName{{Value: tagMap[*v.TagID].Name, Locale: &defaultLocale}}
+		tagLocales, err := t.ts.FetchTagLocaleMappings(ctx, v.TagID)
+		if err == nil {
+			for _, tagLocale := range tagLocales {
+				locale := helper.NormalizeLocale(*tagLocale.Locale)
+				names = append(names, &domain.TagName{Value: tagLocale.Name, Locale: &locale})
+			}
+			err = t.es.UpdateTag(ctx, v.TagID, nil, names)
+		}
+		if err != nil {
+			logger.Client.Error("pushTagLocalesError:id:"+*v.TagID, logger.GetErrorStack())
+			failed = append(failed, v.TagID)
+		}
+	}
+	return failed
+}
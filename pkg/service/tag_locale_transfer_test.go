@@ -0,0 +1,147 @@
+package service
+
+import (
+	"bitbucket.org/noon-micro/curriculum/pkg/domain"
+	"context"
+	"errors"
+	"strconv"
+	"testing"
+)
+
+func importUnit(tagId string, tagType string, source string, target string, line int) *domain.TranslationUnit {
+	return &domain.TranslationUnit{TagID: str(tagId), TagType: str(tagType), Source: str(source), Target: str(target), Line: line}
+}
+
+func invalidMessages(result *domain.TranslationImportResult) map[int]string {
+	messages := make(map[int]string, len(result.Invalid))
+	for _, v := range result.Invalid {
+		messages[v.Line] = v.Message
+	}
+	return messages
+}
+
+func TestImportTagLocalesRejectsInvalidUnits(t *testing.T) {
+	services := newTestServices(t)
+	ctx := context.Background()
+	result, err := services.admin.ImportTagLocales(ctx, &domain.TranslationImport{CountryId: str("9"), Locale: str("AR"), Units: []*domain.TranslationUnit{
+		importUnit("51", "topic", "Counting to ten", "العد إلى عشرة", 2),
+		importUnit("51", "topic", "Counting to ten", "العد حتى عشرة", 3),
+		importUnit("21", "subject", "Physics", "الفيزياء", 4),
+		importUnit("30", "chapter", "Ministry of Education", "وزارة التعليم", 5),
+		importUnit("404", "topic", "Missing", "مفقود", 6),
+		importUnit("", "topic", "Counting to ten", "العد", 7),
+		importUnit("53", "topic", "Reading numbers", "", 8),
+	}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := map[int]string{3: "tagIdDuplicate", 4: "sourceChanged", 5: "tagTypeMismatch", 6: "tagNotFound", 7: "tagIdMissing"}
+	messages := invalidMessages(result)
+	if len(messages) != len(want) {
+		t.Errorf("invalid %v, want %v", messages, want)
+	}
+	for line, message := range want {
+		if messages[line] != message {
+			t.Errorf("line %d: message %q, want %q", line, messages[line], message)
+		}
+	}
+	if result.Skipped != 1 || len(result.Created) != 1 {
+		t.Errorf("skipped %d and created %d, want 1 and 1", result.Skipped, len(result.Created))
+	}
+	if result.Applied {
+		t.Error("import with invalid units was applied")
+	}
+	tagLocales, err := services.tags.FetchTagLocalesByTagIds(ctx, []*string{str("51")}, str("ar"), str("9"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(tagLocales) != 0 {
+		t.Errorf("valid unit of a rejected import was stored as %q", *tagLocales[0].Name)
+	}
+}
+
+func TestImportTagLocalesDiff(t *testing.T) {
+	services := newTestServices(t)
+	ctx := context.Background()
+	units := []*domain.TranslationUnit{
+		importUnit("51", "topic", "Counting to ten", "العد إلى عشرة", 2),
+		importUnit("50", "chapter", "Numbers", "الأرقام", 3),
+		importUnit("251", "grade", "Grade 1", "الصف الأول", 4),
+	}
+	for _, dryRun := range []bool{true, false} {
+		result, err := services.admin.ImportTagLocales(ctx, &domain.TranslationImport{CountryId: str("9"), Locale: str("ar"), DryRun: dryRun, Units: units})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(result.Invalid) != 0 {
+			t.Fatalf("dry run %v: invalid %v", dryRun, invalidMessages(result))
+		}
+		if len(result.Created) != 1 || *result.Created[0].TagID != "51" {
+			t.Errorf("dry run %v: created %d, want tag 51", dryRun, len(result.Created))
+		}
+		if len(result.Updated) != 1 || *result.Updated[0].TagID != "50" || *result.Updated[0].Previous != "الأعداد" {
+			t.Errorf("dry run %v: updated %d, want tag 50 from الأعداد", dryRun, len(result.Updated))
+		}
+		if result.Unchanged != 1 {
+			t.Errorf("dry run %v: unchanged %d, want 1", dryRun, result.Unchanged)
+		}
+		if result.Applied == dryRun {
+			t.Errorf("dry run %v: applied %v", dryRun, result.Applied)
+		}
+		tagLocales, err := services.tags.FetchTagLocalesByTagIds(ctx, []*string{str("50"), str("51")}, str("ar"), str("9"))
+		if err != nil {
+			t.Fatal(err)
+		}
+		names := make(map[string]string, len(tagLocales))
+		for _, v := range tagLocales {
+			names[*v.TagID] = *v.Name
+		}
+		if dryRun && (names["50"] != "الأعداد" || names["51"] != "") {
+			t.Errorf("dry run stored %v", names)
+		}
+		if !dryRun && (names["50"] != "الأرقام" || names["51"] != "العد إلى عشرة") {
+			t.Errorf("import stored %v", names)
+		}
+	}
+}
+
+func TestInBatches(t *testing.T) {
+	ids := make([]*string, 0, 2*subtreeBatchSize+1)
+	for i := 0; i < cap(ids); i++ {
+		ids = append(ids, str(strconv.Itoa(i)))
+	}
+	tests := []struct {
+		name  string
+		ids   []*string
+		sizes []int
+	}{
+		{name: "empty", ids: nil},
+		{name: "single batch", ids: ids[:subtreeBatchSize], sizes: []int{subtreeBatchSize}},
+		{name: "remainder", ids: ids, sizes: []int{subtreeBatchSize, subtreeBatchSize, 1}},
+	}
+	for _, test := range tests {
+		var sizes []int
+		seen := 0
+		err := inBatches(test.ids, func(batch []*string) error {
+			if *batch[0] != *test.ids[seen] {
+				t.Errorf("%s: batch starts at %s, want %s", test.name, *batch[0], *test.ids[seen])
+			}
+			sizes = append(sizes, len(batch))
+			seen += len(batch)
+			return nil
+		})
+		if err != nil || len(sizes) != len(test.sizes) {
+			t.Fatalf("%s: got batches %v err %v, want %v", test.name, sizes, err, test.sizes)
+		}
+		for i := range sizes {
+			if sizes[i] != test.sizes[i] {
+				t.Errorf("%s: got batches %v, want %v", test.name, sizes, test.sizes)
+			}
+		}
+	}
+	calls := 0
+	failure := errors.New("fetch failed")
+	if err := inBatches(ids, func([]*string) error { calls++; return failure }); err != failure || calls != 1 {
+		t.Errorf("got err %v after %d calls, want the first error", err, calls)
+	}
+}
@@ -0,0 +1,75 @@
+package service
+
+import (
+	"bitbucket.org/noon-micro/curriculum/pkg/domain"
+	repository "bitbucket.org/noon-micro/curriculum/pkg/repository/redis"
+	"context"
+	"encoding/json"
+	"testing"
+)
+
+func TestAcademicCalendarMergedIntoAStaleCountryConflicts(t *testing.T) {
+	services := newTestServices(t)
+	ctx := context.Background()
+	read, err := services.tags.FetchTags(ctx, str("9"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	stale, err := json.Marshal(read)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err = services.admin.UpdateTag(ctx, &domain.UpdateTag{ID: str("9"), Name: str("Egypt"), Version: &read.Version}); err != nil {
+		t.Fatal(err)
+	}
+	// the calendar update reads the country as it was before the rename
+	repository.RedisClient.Set(repository.CurriculumPrefix+"9", string(stale), repository.RedisTtl)
+	calendar := &domain.AcademicCalendar{PromotionDate: str("06-30"), Years: []*domain.AcademicYear{}}
+	current, err := services.admin.UpdateAcademicCalendar(ctx, str("9"), calendar)
+	if !isConflict(err) {
+		t.Fatalf("calendar update against a stale country returned %v", err)
+	}
+	if current == nil {
+		t.Fatal("conflict came without the current calendar")
+	}
+	tag, err := services.tags.FetchTags(ctx, str("9"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if *tag.Name != "Egypt" || tag.Version != read.Version+1 {
+		t.Errorf("country is %q at version %d after the conflict", *tag.Name, tag.Version)
+	}
+	if _, err = services.admin.UpdateAcademicCalendar(ctx, str("9"), calendar); err != nil {
+		t.Errorf("calendar update against the current country returned %v", err)
+	}
+}
+
+func TestTagOrderMadeBeforeANewSiblingConflicts(t *testing.T) {
+	services := newTestServices(t)
+	ctx := context.Background()
+	parentTags := str("9.2.251.20.30")
+	read, err := services.tags.FetchCurrentTagOrders(ctx, parentTags, str("chapter"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	order := len(read.Orders) + 1
+	if err = services.tags.CreateParentTagMapping(ctx, nil, &domain.ParentTagMapping{TagID: str("60"), TagType: str("chapter"),
+		ParentTagType: str("curriculum"), ParentTagID: parentTags, Order: &order, Publish: true}); err != nil {
+		t.Fatal(err)
+	}
+	one, two := 1, 2
+	update := &domain.UpdateTagOrder{Type: str("chapter"), CurriculumType: str("k12"), TagGroup: str("curriculum"),
+		Hierarchy: []*string{str("9"), str("2"), str("251"), str("20"), str("30")},
+		Orders:    []*domain.Order{{ID: str("52"), Order: &one}, {ID: str("50"), Order: &two}}, Version: &read.Version}
+	current, err := services.admin.UpdateTagOrder(ctx, update.TagGroup, update)
+	if !isConflict(err) {
+		t.Fatalf("reorder made before the new sibling returned %v", err)
+	}
+	if current == nil || current.Version == read.Version || len(current.Orders) != len(read.Orders)+1 {
+		t.Fatalf("conflict came with %+v", current)
+	}
+	update.Version = &current.Version
+	if _, err = services.admin.UpdateTagOrder(ctx, update.TagGroup, update); err != nil {
+		t.Errorf("reorder against the current set returned %v", err)
+	}
+}
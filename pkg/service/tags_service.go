@@ -5,149 +5,121 @@ import (
 	"bitbucket.org/noon-micro/curriculum/pkg/domain"
 	noonerror "bitbucket.org/noon-micro/curriculum/pkg/lib/error"
 	"bitbucket.org/noon-micro/curriculum/pkg/lib/flow"
-	"bitbucket.org/noon-micro/curriculum/pkg/lib/logger"
+	"bitbucket.org/noon-micro/curriculum/pkg/lib/helper"
 	repository "bitbucket.org/noon-micro/curriculum/pkg/repository/redis"
 	"bitbucket.org/noon-micro/curriculum/pkg/service/constant"
+	"context"
 	"database/sql"
 	"encoding/json"
 	"sort"
 	"strconv"
 	"strings"
-	"sync"
 )
 
+// subtreeBatchSize bounds the tag ids of one IN lookup made for a subtree
+const subtreeBatchSize = 500
+
 type TagsServiceStruct struct {
 	tr   domain.TagsRepository
 	ptmr domain.ParentTagMappingRepository
 	tlmr domain.TagLocaleMappingRepository
+	talr domain.TagAttributeLocaleRepository
+	tcrr domain.TagCountryRuleRepository
+	rcmr domain.RegionCountryMappingRepository
 	ltmr domain.LegacyTagMappingRepository
 	gpr  domain.GradeProductRepository
 }
 
-func NewTagsService(tr domain.TagsRepository, ptmr domain.ParentTagMappingRepository, tlmr domain.TagLocaleMappingRepository, ltmr domain.LegacyTagMappingRepository, gpr domain.GradeProductRepository) *TagsServiceStruct {
-	return &TagsServiceStruct{tr: tr, ptmr: ptmr, tlmr: tlmr, ltmr: ltmr, gpr: gpr}
+func NewTagsService(tr domain.TagsRepository, ptmr domain.ParentTagMappingRepository, tlmr domain.TagLocaleMappingRepository, talr domain.TagAttributeLocaleRepository, tcrr domain.TagCountryRuleRepository, rcmr domain.RegionCountryMappingRepository, ltmr domain.LegacyTagMappingRepository, gpr domain.GradeProductRepository) *TagsServiceStruct {
+	return &TagsServiceStruct{tr: tr, ptmr: ptmr, tlmr: tlmr, talr: talr, tcrr: tcrr, rcmr: rcmr, ltmr: ltmr, gpr: gpr}
 }
 
-func (t *TagsServiceStruct) FetchTags(id *string) (tag *domain.Tags, err error) {
-	var tagData domain.Tags
+func (t *TagsServiceStruct) FetchTags(ctx context.Context, id *string) (tag *domain.Tags, err error) {
 	redisKey := repository.CurriculumPrefix + *id
-	val, err := repository.RedisClient.Get(redisKey).Result()
-	err1 := json.Unmarshal([]byte(val), &tagData)
-	if err != nil || err1 != nil {
-		tag, err := t.tr.FetchTags(id)
-		if err != nil {
-			return nil, err
-		}
-		if tag != nil {
-			tagByte, err := json.Marshal(*tag)
-			if err == nil {
-				repository.RedisClient.Set(redisKey, string(tagByte), repository.RedisTtl)
-			}
-		}
-		return tag, nil
+	err = readThrough(ctx, redisKey, repository.RedisTtl, &tag, func(ctx context.Context) (interface{}, bool, error) {
+		tag, err := t.tr.FetchTags(ctx, id)
+		return tag, tag != nil, err
+	})
+	if err != nil {
+		return nil, err
 	}
-	return &tagData, nil
+	return tag, nil
 }
 
-func (t *TagsServiceStruct) GetTagsConcurrent(tagIds []*string) (tagData []*domain.Tags, err error) {
+// GetTagsConcurrent loads the tags through the loader of the request, missing tags are nil and the input order is kept
+func (t *TagsServiceStruct) GetTagsConcurrent(ctx context.Context, tagIds []*string) (tagData []*domain.Tags, err error) {
 	if len(tagIds) == 0 {
 		return
 	}
-	errChan := make(chan error)
-	wgDone := make(chan bool)
-	tagDataChan := make(chan *domain.Tags, len(tagIds))
-	var wg sync.WaitGroup
+	tags, err := t.tagLoader(ctx).LoadTags(tagIds)
+	if err != nil {
+		return nil, err
+	}
+	tagMap := make(map[string]*domain.Tags, len(tags))
+	for _, v := range tags {
+		tagMap[*v.ID] = v
+	}
 	for _, tagId := range tagIds {
-		wg.Add(1)
-		go func(tagId *string) {
-			defer func() {
-				if err := recover(); err != nil {
-					logger.Client.Error("getTagsPanicked:id:"+*tagId, logger.GetErrorStack())
-					errChan <- noonerror.New(noonerror.ErrInternalServer, "getTagsPanicked")
-				}
-			}()
-			tagData, err := t.FetchTags(tagId)
-			if err != nil {
-				errChan <- err
-			}
-			tagDataChan <- tagData
-			wg.Done()
-		}(tagId)
-	}
-	go func() {
-		wg.Wait()
-		close(wgDone)
-	}()
-	select {
-	case <-wgDone:
-		for range tagIds {
-			tagData = append(tagData, <-tagDataChan)
-		}
-	case err = <-errChan:
-		return
+		if tagId == nil {
+			tagData = append(tagData, nil)
+			continue
+		}
+		tagData = append(tagData, tagMap[*tagId])
 	}
-	return
+	return tagData, nil
 }
 
-func (t *TagsServiceStruct) FetchFilteredTags(curriculumType *string, tagType *string) (tags []*domain.Tags, err error) {
-	return t.tr.FetchFilteredTags(curriculumType, tagType)
+// tagLoader returns the loader attached to the request, or a fresh one when called outside of http
+func (t *TagsServiceStruct) tagLoader(ctx context.Context) domain.TagLoader {
+	if ldr, ok := domain.TagLoaderFromContext(ctx); ok {
+		return ldr
+	}
+	return t.NewTagLoader(ctx)
 }
 
-func (t *TagsServiceStruct) FetchFilteredTagsPaginated(curriculumType *string, tagType *string, start *int, limit *int) (tags []*domain.Tags, err error) {
+func (t *TagsServiceStruct) FetchFilteredTags(ctx context.Context, curriculumType *string, tagType *string) (tags []*domain.Tags, err error) {
+	return t.tr.FetchFilteredTags(ctx, curriculumType, tagType)
+}
+
+func (t *TagsServiceStruct) FetchFilteredTagsPaginated(ctx context.Context, curriculumType *string, tagType *string, start *int, limit *int) (tags []*domain.Tags, err error) {
 	if *tagType == domain.TagTypeEnum.Country {
 		redisKey := repository.CurriculumCountryPrefix + *curriculumType + ":" + *tagType + ":" + strconv.Itoa(*start) + ":" + strconv.Itoa(*limit)
-		val, err := repository.RedisClient.Get(redisKey).Result()
-		err1 := json.Unmarshal([]byte(val), &tags)
-		if err != nil || err1 != nil {
-			tag, err := t.tr.FetchFilteredTagsPaginated(curriculumType, tagType, start, limit)
-			if err != nil {
-				return nil, err
-			}
-			if tag != nil {
-				tagByte, err := json.Marshal(tag)
-				if err == nil {
-					repository.RedisClient.Set(redisKey, string(tagByte), repository.RedisTtl)
-				}
-			}
-			return tag, nil
+		err = readThrough(ctx, redisKey, repository.RedisTtl, &tags, func(ctx context.Context) (interface{}, bool, error) {
+			tags, err := t.tr.FetchFilteredTagsPaginated(ctx, curriculumType, tagType, start, limit)
+			return tags, tags != nil, err
+		})
+		if err != nil {
+			return nil, err
 		}
 		return tags, nil
 	}
-	return t.tr.FetchFilteredTagsPaginated(curriculumType, tagType, start, limit)
+	return t.tr.FetchFilteredTagsPaginated(ctx, curriculumType, tagType, start, limit)
 }
 
-func (t *TagsServiceStruct) FetchFilteredTagsPaginatedForAdmin(curriculumType *string, tagType *string, start *int, limit *int) (tags []*domain.Tags, err error) {
+func (t *TagsServiceStruct) FetchFilteredTagsPaginatedForAdmin(ctx context.Context, curriculumType *string, tagType *string, start *int, limit *int) (tags []*domain.Tags, err error) {
 	if *tagType == domain.TagTypeEnum.Country {
 		redisKey := repository.CurriculumCountryAdminPrefix + *curriculumType + ":" + *tagType + ":" + strconv.Itoa(*start) + ":" + strconv.Itoa(*limit)
-		val, err := repository.RedisClient.Get(redisKey).Result()
-		err1 := json.Unmarshal([]byte(val), &tags)
-		if err != nil || err1 != nil {
-			tag, err := t.tr.FetchFilteredTagsPaginatedForAdmin(curriculumType, tagType, start, limit)
-			if err != nil {
-				return nil, err
-			}
-			if tag != nil {
-				tagByte, err := json.Marshal(tag)
-				if err == nil {
-					repository.RedisClient.Set(redisKey, string(tagByte), repository.RedisTtl)
-				}
-			}
-			return tag, nil
+		err = readThrough(ctx, redisKey, repository.RedisTtl, &tags, func(ctx context.Context) (interface{}, bool, error) {
+			tags, err := t.tr.FetchFilteredTagsPaginatedForAdmin(ctx, curriculumType, tagType, start, limit)
+			return tags, tags != nil, err
+		})
+		if err != nil {
+			return nil, err
 		}
 		return tags, nil
 	}
-	return t.tr.FetchFilteredTagsPaginatedForAdmin(curriculumType, tagType, start, limit)
+	return t.tr.FetchFilteredTagsPaginatedForAdmin(ctx, curriculumType, tagType, start, limit)
 }
 
-func (t *TagsServiceStruct) IsCollegePresent(tagType *string, tagId *string) (hasCollege bool, err error) {
-	return t.ptmr.IsCollegePresent(tagType, tagId)
+func (t *TagsServiceStruct) IsCollegePresent(ctx context.Context, tagType *string, tagId *string) (hasCollege bool, err error) {
+	return t.ptmr.IsCollegePresent(ctx, tagType, tagId)
 }
 
-func (t *TagsServiceStruct) FetchByTagGroup(tagGroup *string, tagType *string) (tags []*domain.Tags, err error) {
-	return t.tr.FetchByTagGroup(tagGroup, tagType)
+func (t *TagsServiceStruct) FetchByTagGroup(ctx context.Context, tagGroup *string, tagType *string) (tags []*domain.Tags, err error) {
+	return t.tr.FetchByTagGroup(ctx, tagGroup, tagType)
 }
 
-func (t *TagsServiceStruct) FetchByInTags(ids []*string) (tags []*domain.Tags, err error) {
+func (t *TagsServiceStruct) FetchByInTags(ctx context.Context, ids []*string) (tags []*domain.Tags, err error) {
 	if len(ids) == 0 {
 		return
 	}
@@ -157,21 +129,21 @@ func (t *TagsServiceStruct) FetchByInTags(ids []*string) (tags []*domain.Tags, e
 		redisKeys[i] = repository.CurriculumPrefix + *id
 	}
 	if len(ids) > 0 {
-		values, _ := repository.RedisClient.MGet(redisKeys...).Result()
+		values, _ := repository.Client(ctx).MGet(redisKeys...).Result()
 		for i, val := range values {
 			var tagData *domain.Tags
 			if val != nil {
 				err = json.Unmarshal([]byte(val.(string)), &tagData)
 			}
 			if err != nil || val == nil {
-				tag, err := t.tr.FetchTags(ids[i])
+				tag, err := t.tr.FetchTags(ctx, ids[i])
 				if err != nil || tag == nil {
 					continue
 				}
 				tagData = tag
 				tagByte, err := json.Marshal(*tag)
 				if err == nil {
-					repository.RedisClient.Set(redisKeys[i], string(tagByte), repository.RedisTtl)
+					repository.Client(ctx).Set(redisKeys[i], string(tagByte), repository.RedisTtl)
 				}
 			}
 			allTagData = append(allTagData, tagData)
@@ -180,60 +152,63 @@ func (t *TagsServiceStruct) FetchByInTags(ids []*string) (tags []*domain.Tags, e
 			return allTagData, nil
 		}
 	}
-	return t.tr.FetchByInTags(ids)
+	return t.tr.FetchByInTags(ctx, ids)
 }
 
-func (t *TagsServiceStruct) CreateTags(tx *sql.Tx, tags *domain.Tags) (id *string, err error) {
-	return t.tr.CreateTags(tx, tags)
+func (t *TagsServiceStruct) CreateTags(ctx context.Context, tx *sql.Tx, tags *domain.Tags) (id *string, err error) {
+	return t.tr.CreateTags(ctx, tx, tags)
 }
 
-func (t *TagsServiceStruct) CreateParentTagMapping(tx *sql.Tx, parentTagMapping *domain.ParentTagMapping) (err error) {
+func (t *TagsServiceStruct) CreateParentTagMapping(ctx context.Context, tx *sql.Tx, parentTagMapping *domain.ParentTagMapping) (err error) {
 	redisKey := repository.CurriculumParentTagMappingPrefix + *parentTagMapping.TagID
-	if err := repository.RedisClient.Del(redisKey).Err(); err != nil {
+	if err := repository.Client(ctx).Del(redisKey).Err(); err != nil {
 		return noonerror.New(noonerror.ErrInternalServer, "redisDeleteError")
 	}
-	if *parentTagMapping.Order > 0 {
-		redisKey = repository.CurriculumTagOrderPrefix + *parentTagMapping.ParentTagID + ":" + *parentTagMapping.TagType
-		if err := repository.RedisClient.Del(redisKey).Err(); err != nil {
-			return noonerror.New(noonerror.ErrInternalServer, "redisDeleteError")
-		}
+	// orders are served from cache, so every new mapping under a parent has to drop it
+	redisKey = repository.CurriculumTagOrderPrefix + *parentTagMapping.ParentTagID + ":" + *parentTagMapping.TagType
+	if err := repository.Client(ctx).Del(redisKey).Err(); err != nil {
+		return noonerror.New(noonerror.ErrInternalServer, "redisDeleteError")
+	}
+	// a new sibling changes the set, a reorder made against the set without it is stale
+	if err = t.ptmr.UpdateTagOrderVersion(ctx, tx, parentTagMapping.ParentTagID, parentTagMapping.TagType, nil); err != nil {
+		return
 	}
-	return t.ptmr.CreateParentTagMapping(tx, parentTagMapping)
+	return t.ptmr.CreateParentTagMapping(ctx, tx, parentTagMapping)
 }
 
-func (t *TagsServiceStruct) FetchParentTagMappings(id *string) (parentTagMappings []*domain.ParentTagMapping, err error) {
+func (t *TagsServiceStruct) FetchParentTagMappings(ctx context.Context, id *string) (parentTagMappings []*domain.ParentTagMapping, err error) {
 	redisKey := repository.CurriculumParentTagMappingPrefix + *id
-	val, err := repository.RedisClient.Get(redisKey).Result()
+	val, err := repository.Client(ctx).Get(redisKey).Result()
 	err1 := json.Unmarshal([]byte(val), &parentTagMappings)
 	if err != nil || err1 != nil {
-		parentTagMappings, err = t.ptmr.FetchParentTagMappings(id)
+		parentTagMappings, err = t.ptmr.FetchParentTagMappings(ctx, id)
 		if err != nil {
 			return nil, err
 		}
 		if len(parentTagMappings) > 0 {
 			tagByte, err := json.Marshal(parentTagMappings)
 			if err == nil {
-				repository.RedisClient.Set(redisKey, string(tagByte), repository.RedisTtl)
+				repository.Client(ctx).Set(redisKey, string(tagByte), repository.RedisTtl)
 			}
 		}
 		return parentTagMappings, nil
 	}
-	return t.ptmr.FetchParentTagMappings(id)
+	return t.ptmr.FetchParentTagMappings(ctx, id)
 }
 
-func (t *TagsServiceStruct) ToggleHideParentTagMapping(tx *sql.Tx, hidden bool, tagId *string, id *string) (err error) {
+func (t *TagsServiceStruct) ToggleHideParentTagMapping(ctx context.Context, tx *sql.Tx, hidden bool, tagId *string, id *string) (err error) {
 	redisKey := repository.CurriculumParentTagMappingPrefix + *tagId
-	if err := repository.RedisClient.Del(redisKey).Err(); err != nil {
+	if err := repository.Client(ctx).Del(redisKey).Err(); err != nil {
 		return noonerror.New(noonerror.ErrInternalServer, "redisDeleteError")
 	}
-	return t.ptmr.ToggleHideParentTagMapping(tx, hidden, id)
+	return t.ptmr.ToggleHideParentTagMapping(ctx, tx, hidden, id)
 }
 
-func (t *TagsServiceStruct) DeleteParentTagMapping(tx *sql.Tx, id *string) (err error) {
-	return t.ptmr.DeleteParentTagMapping(tx, id)
+func (t *TagsServiceStruct) DeleteParentTagMapping(ctx context.Context, tx *sql.Tx, id *string) (err error) {
+	return t.ptmr.DeleteParentTagMapping(ctx, tx, id)
 }
 
-func (t *TagsServiceStruct) FetchByInParentTagMappings(ids []*string) (parentTagMappings []*domain.ParentTagMapping, err error) {
+func (t *TagsServiceStruct) FetchByInParentTagMappings(ctx context.Context, ids []*string) (parentTagMappings []*domain.ParentTagMapping, err error) {
 	if len(ids) == 0 {
 		return
 	}
@@ -242,14 +217,14 @@ func (t *TagsServiceStruct) FetchByInParentTagMappings(ids []*string) (parentTag
 		redisKeys[i] = repository.CurriculumParentTagMappingPrefix + *id
 	}
 	if len(ids) > 0 {
-		values, _ := repository.RedisClient.MGet(redisKeys...).Result()
+		values, _ := repository.Client(ctx).MGet(redisKeys...).Result()
 		for i, val := range values {
 			var parentTagMappingData []*domain.ParentTagMapping
 			if val != nil {
 				err = json.Unmarshal([]byte(val.(string)), &parentTagMappingData)
 			}
 			if err != nil || val == nil {
-				parentTagMappingsSlice, err := t.ptmr.FetchParentTagMappings(ids[i])
+				parentTagMappingsSlice, err := t.ptmr.FetchParentTagMappings(ctx, ids[i])
 				if err != nil || parentTagMappingsSlice == nil {
 					return nil, err
 				}
@@ -257,7 +232,7 @@ func (t *TagsServiceStruct) FetchByInParentTagMappings(ids []*string) (parentTag
 					parentTagMappingData = parentTagMappingsSlice
 					tagByte, err := json.Marshal(parentTagMappingsSlice)
 					if err == nil {
-						repository.RedisClient.Set(redisKeys[i], string(tagByte), repository.RedisTtl)
+						repository.Client(ctx).Set(redisKeys[i], string(tagByte), repository.RedisTtl)
 					}
 				}
 			}
@@ -267,93 +242,182 @@ func (t *TagsServiceStruct) FetchByInParentTagMappings(ids []*string) (parentTag
 			return parentTagMappings, nil
 		}
 	}
-	return t.ptmr.FetchByInParentTagMappings(ids)
+	return t.ptmr.FetchByInParentTagMappings(ctx, ids)
 }
 
-func (t *TagsServiceStruct) FetchFilteredParentTagMappings(tagType *string, id *string) (parentTagMappings []*domain.ParentTagMapping, err error) {
-	return t.ptmr.FetchFilteredParentTagMappings(tagType, id)
+func (t *TagsServiceStruct) FetchFilteredParentTagMappings(ctx context.Context, tagType *string, id *string) (parentTagMappings []*domain.ParentTagMapping, err error) {
+	return t.ptmr.FetchFilteredParentTagMappings(ctx, tagType, id)
 }
 
-func (t *TagsServiceStruct) FetchParentTagMappingByParentTagIdTagId(tagId *string, parentTagId *string) (parentTagMappings *domain.ParentTagMapping, err error) {
-	return t.ptmr.FetchParentTagMappingByParentTagIdTagId(tagId, parentTagId)
+func (t *TagsServiceStruct) FetchParentTagMappingByParentTagIdTagId(ctx context.Context, tagId *string, parentTagId *string) (parentTagMappings *domain.ParentTagMapping, err error) {
+	return t.ptmr.FetchParentTagMappingByParentTagIdTagId(ctx, tagId, parentTagId)
 }
 
-func (t *TagsServiceStruct) FetchByInParentTagMappingsByParentTagIdTagIds(ids []*string, parentTagId *string) (parentTagMappings []*domain.ParentTagMapping, err error) {
-	return t.ptmr.FetchByInParentTagMappingsByParentTagIdTagIds(ids, parentTagId)
+func (t *TagsServiceStruct) FetchByInParentTagMappingsByParentTagIdTagIds(ctx context.Context, ids []*string, parentTagId *string) (parentTagMappings []*domain.ParentTagMapping, err error) {
+	return t.ptmr.FetchByInParentTagMappingsByParentTagIdTagIds(ctx, ids, parentTagId)
 }
 
-func (t *TagsServiceStruct) DeleteTags(tx *sql.Tx, id *string) (err error) {
+func (t *TagsServiceStruct) DeleteTags(ctx context.Context, tx *sql.Tx, id *string) (err error) {
 	redisKey := repository.CurriculumPrefix + *id
-	if err := repository.RedisClient.Del(redisKey).Err(); err != nil {
+	if err := repository.Client(ctx).Del(redisKey).Err(); err != nil {
 		return noonerror.New(noonerror.ErrInternalServer, "redisDeleteError")
 	}
-	return t.tr.DeleteTags(tx, id)
+	return t.tr.DeleteTags(ctx, tx, id)
 }
 
-func (t *TagsServiceStruct) UpdateLocale(tx *sql.Tx, localeAvailable bool, id *string) (err error) {
+func (t *TagsServiceStruct) UpdateLocale(ctx context.Context, tx *sql.Tx, localeAvailable bool, id *string) (err error) {
 	redisKey := repository.CurriculumPrefix + *id
-	if err := repository.RedisClient.Del(redisKey).Err(); err != nil {
+	if err := repository.Client(ctx).Del(redisKey).Err(); err != nil {
 		return noonerror.New(noonerror.ErrInternalServer, "redisDeleteError")
 	}
-	return t.tr.UpdateLocale(tx, localeAvailable, id)
+	return t.tr.UpdateLocale(ctx, tx, localeAvailable, id)
 }
 
-func (t *TagsServiceStruct) UpdateTag(updateTag *domain.UpdateTag) (err error) {
+func (t *TagsServiceStruct) UpdateTag(ctx context.Context, tx *sql.Tx, updateTag *domain.UpdateTag) (err error) {
 	redisKey := repository.CurriculumPrefix + *updateTag.ID
 	if *updateTag.Type == domain.TagTypeEnum.Country {
 		redisPattern := repository.CurriculumCountryPrefix + "*"
-		keys, err := repository.RedisClient.Keys(redisPattern).Result()
+		keys, err := repository.Client(ctx).Keys(redisPattern).Result()
 		if err != nil {
 			return noonerror.New(noonerror.ErrInternalServer, "redisDeleteError")
 		}
 		if len(keys) > 0 {
-			err = repository.RedisClient.Del(keys...).Err()
+			err = repository.Client(ctx).Del(keys...).Err()
 		}
 		if err != nil {
 			return noonerror.New(noonerror.ErrInternalServer, "redisDeleteError")
 		}
 	}
-	if err := repository.RedisClient.Del(redisKey).Err(); err != nil {
+	if err := repository.Client(ctx).Del(redisKey).Err(); err != nil {
+		return noonerror.New(noonerror.ErrInternalServer, "redisDeleteError")
+	}
+	if err = t.tr.UpdateTag(ctx, tx, updateTag); err != nil {
+		return
+	}
+	// a read racing the update may have cached the previous version of the tag
+	if err := repository.Client(ctx).Del(redisKey).Err(); err != nil {
 		return noonerror.New(noonerror.ErrInternalServer, "redisDeleteError")
 	}
-	return t.tr.UpdateTag(updateTag)
+	return
 }
 
-func (t *TagsServiceStruct) ToggleTags(publish bool, ids []*string) (err error) {
+func (t *TagsServiceStruct) ToggleTags(ctx context.Context, publish bool, ids []*string) (err error) {
 	for _, id := range ids {
 		redisKey := repository.CurriculumPrefix + *id
-		if err := repository.RedisClient.Del(redisKey).Err(); err != nil {
+		if err := repository.Client(ctx).Del(redisKey).Err(); err != nil {
 			return noonerror.New(noonerror.ErrInternalServer, "redisDeleteError")
 		}
 	}
-	return t.tr.ToggleTags(publish, ids)
+	return t.tr.ToggleTags(ctx, publish, ids)
 }
 
-func (t *TagsServiceStruct) CreateTagLocaleMapping(tx *sql.Tx, tagLocaleMapping *domain.TagLocaleMapping) (err error) {
-	redisKey := repository.CurriculumTagLocaleMappingPrefix + *tagLocaleMapping.TagID + ":" + *tagLocaleMapping.CountryId + ":" + *tagLocaleMapping.Locale
-	if err := repository.RedisClient.Del(redisKey).Err(); err != nil {
+func (t *TagsServiceStruct) CreateTagLocaleMapping(ctx context.Context, tx *sql.Tx, tagLocaleMapping *domain.TagLocaleMapping) (err error) {
+	redisKey := repository.CurriculumTagLocaleMappingPrefix + *tagLocaleMapping.TagID + ":" + *tagLocaleMapping.CountryId + ":" + helper.NormalizeLocale(*tagLocaleMapping.Locale)
+	if err := repository.Client(ctx).Del(redisKey).Err(); err != nil {
 		return noonerror.New(noonerror.ErrInternalServer, "redisDeleteError")
 	}
-	return t.tlmr.CreateTagLocaleMapping(tx, tagLocaleMapping)
+	return t.tlmr.CreateTagLocaleMapping(ctx, tx, tagLocaleMapping)
 }
 
-func (t *TagsServiceStruct) FetchTagLocaleMappings(id *string) (tagLocaleMappings []*domain.TagLocaleMapping, err error) {
-	return t.tlmr.FetchTagLocaleMappings(id)
+func (t *TagsServiceStruct) FetchTagLocaleMappings(ctx context.Context, id *string) (tagLocaleMappings []*domain.TagLocaleMapping, err error) {
+	return t.tlmr.FetchTagLocaleMappings(ctx, id)
+}
+
+func (t *TagsServiceStruct) FetchByInTagLocaleMappings(ctx context.Context, ids []*string) (tagLocaleMappings []*domain.TagLocaleMapping, err error) {
+	return t.tlmr.FetchByInTagLocaleMappings(ctx, ids)
+}
+
+func (t *TagsServiceStruct) FetchTagLocalesByTagIds(ctx context.Context, ids []*string, locale *string, countryId *string) (tagLocaleMappings []*domain.TagLocaleMapping, err error) {
+	err = inBatches(ids, func(batch []*string) error {
+		rows, err := t.tlmr.FetchTagLocalesByTagIds(ctx, batch, locale, countryId)
+		tagLocaleMappings = append(tagLocaleMappings, rows...)
+		return err
+	})
+	return tagLocaleMappings, err
 }
 
-func (t *TagsServiceStruct) FetchTagLocalesByTagIds(ids []*string, locale *string, countryId *string) (tagLocaleMappings []*domain.TagLocaleMapping, err error) {
-	return t.tlmr.FetchTagLocalesByTagIds(ids, locale, countryId)
+func (t *TagsServiceStruct) CreateTagLocaleDraft(ctx context.Context, tx *sql.Tx, tagLocaleMapping *domain.TagLocaleMapping) (err error) {
+	return t.tlmr.CreateTagLocaleDraft(ctx, tx, tagLocaleMapping)
 }
 
-func (t *TagsServiceStruct) DeleteTagLocaleMapping(tx *sql.Tx, tagLocaleMapping *domain.TagLocaleMapping) (err error) {
-	redisKey := repository.CurriculumTagLocaleMappingPrefix + *tagLocaleMapping.TagID + ":" + *tagLocaleMapping.CountryId + ":" + *tagLocaleMapping.Locale
-	if err := repository.RedisClient.Del(redisKey).Err(); err != nil {
+func (t *TagsServiceStruct) FetchTagLocaleDrafts(ctx context.Context, ids []*string, locale *string, countryId *string) (tagLocaleMappings []*domain.TagLocaleMapping, err error) {
+	err = inBatches(ids, func(batch []*string) error {
+		rows, err := t.tlmr.FetchTagLocaleDrafts(ctx, batch, locale, countryId)
+		tagLocaleMappings = append(tagLocaleMappings, rows...)
+		return err
+	})
+	return tagLocaleMappings, err
+}
+
+func (t *TagsServiceStruct) FetchTagLocaleDraftsByIds(ctx context.Context, ids []*string) (tagLocaleMappings []*domain.TagLocaleMapping, err error) {
+	return t.tlmr.FetchTagLocaleDraftsByIds(ctx, ids)
+}
+
+// PublishTagLocaleDraft turns the draft into the served translation of its tag, locale and country
+func (t *TagsServiceStruct) PublishTagLocaleDraft(ctx context.Context, tx *sql.Tx, tagLocaleMapping *domain.TagLocaleMapping, name *string) (err error) {
+	redisKey := repository.CurriculumTagLocaleMappingPrefix + *tagLocaleMapping.TagID + ":" + *tagLocaleMapping.CountryId + ":" + helper.NormalizeLocale(*tagLocaleMapping.Locale)
+	if err := repository.Client(ctx).Del(redisKey).Err(); err != nil {
 		return noonerror.New(noonerror.ErrInternalServer, "redisDeleteError")
 	}
-	return t.tlmr.DeleteTagLocaleMapping(tx, tagLocaleMapping.ID)
+	return t.tlmr.PublishTagLocaleDraft(ctx, tx, tagLocaleMapping.ID, name)
 }
 
-func (t *TagsServiceStruct) FetchTagLocaleMappingsByLocale(tagData []*domain.Tags, countryId *string, locale *string) (tagResults []*domain.Tags, err error) {
+func (t *TagsServiceStruct) DiscardTagLocaleDraft(ctx context.Context, tx *sql.Tx, id *string) (err error) {
+	return t.tlmr.DiscardTagLocaleDraft(ctx, tx, id)
+}
+
+// FetchSubtreeTags returns the last tag of the parent path followed by every tag mapped below it
+func (t *TagsServiceStruct) FetchSubtreeTags(ctx context.Context, hierarchy *string) (tags []*domain.Tags, err error) {
+	tagIds, err := t.ptmr.FetchTagIdsByParentTagPrefix(ctx, hierarchy)
+	if err != nil {
+		return nil, err
+	}
+	path := strings.Split(*hierarchy, ".")
+	tagIds = append([]*string{&path[len(path)-1]}, tagIds...)
+	tagMap := make(map[string]*domain.Tags, len(tagIds))
+	err = inBatches(tagIds, func(batch []*string) error {
+		tagData, err := t.FetchByInTags(ctx, batch)
+		for _, v := range tagData {
+			tagMap[*v.ID] = v
+		}
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	for _, id := range tagIds {
+		if tag, ok := tagMap[*id]; ok {
+			tags = append(tags, tag)
+			delete(tagMap, *id)
+		}
+	}
+	return tags, nil
+}
+
+// inBatches calls fetch with slices of at most subtreeBatchSize ids so that the IN lists built for a subtree stay
+// bounded, it stops at the first error
+func inBatches(ids []*string, fetch func([]*string) error) error {
+	for start := 0; start < len(ids); start += subtreeBatchSize {
+		end := start + subtreeBatchSize
+		if end > len(ids) {
+			end = len(ids)
+		}
+		if err := fetch(ids[start:end]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (t *TagsServiceStruct) DeleteTagLocaleMapping(ctx context.Context, tx *sql.Tx, tagLocaleMapping *domain.TagLocaleMapping) (err error) {
+	redisKey := repository.CurriculumTagLocaleMappingPrefix + *tagLocaleMapping.TagID + ":" + *tagLocaleMapping.CountryId + ":" + helper.NormalizeLocale(*tagLocaleMapping.Locale)
+	if err := repository.Client(ctx).Del(redisKey).Err(); err != nil {
+		return noonerror.New(noonerror.ErrInternalServer, "redisDeleteError")
+	}
+	return t.tlmr.DeleteTagLocaleMapping(ctx, tx, tagLocaleMapping.ID)
+}
+
+func (t *TagsServiceStruct) FetchTagLocaleMappingsByLocale(ctx context.Context, tagData []*domain.Tags, countryId *string, locale *string) (tagResults []*domain.Tags, err error) {
 	if len(tagData) == 0 || countryId == nil || locale == nil {
 		return tagData, nil
 	}
@@ -363,22 +427,20 @@ func (t *TagsServiceStruct) FetchTagLocaleMappingsByLocale(tagData []*domain.Tag
 			tagLocaleIds = append(tagLocaleIds, v.ID)
 		}
 	}
-	tagLocaleMap, err := t.fetchTagLocaleMappingsDataByLocale(tagLocaleIds, countryId, locale)
+	tagLocaleMap, err := t.fetchTagLocaleMappingsDataByLocale(ctx, tagLocaleIds, countryId, locale)
 	if err != nil {
 		return
 	}
-	if tagLocaleMap != nil {
-		for _, v := range tagData {
-			locale, ok := tagLocaleMap[*v.ID]
-			if ok {
-				v.LocaleName = &locale
-			}
+	for _, v := range tagData {
+		v.ServedLocale = servedLocale(tagLocaleMap[*v.ID])
+		if tagLocale, ok := tagLocaleMap[*v.ID]; ok {
+			v.LocaleName = tagLocale.Name
 		}
 	}
-	return tagData, nil
+	return t.FetchAttributeLocalesByLocale(ctx, tagData, countryId, locale)
 }
 
-func (t *TagsServiceStruct) FetchTagLocaleMappingsByLocaleForContext(tagData []*domain.Tags, countryId *string, locale *string) (tagResults []*domain.Tags, err error) {
+func (t *TagsServiceStruct) FetchTagLocaleMappingsByLocaleForContext(ctx context.Context, tagData []*domain.Tags, countryId *string, locale *string) (tagResults []*domain.Tags, err error) {
 	if len(tagData) == 0 || countryId == nil || locale == nil {
 		return tagData, nil
 	}
@@ -388,133 +450,82 @@ func (t *TagsServiceStruct) FetchTagLocaleMappingsByLocaleForContext(tagData []*
 			tagLocaleIds = append(tagLocaleIds, v.ID)
 		}
 	}
-	tagLocaleMap, err := t.fetchTagLocaleMappingsDataByLocale(tagLocaleIds, countryId, locale)
+	tagLocaleMap, err := t.fetchTagLocaleMappingsDataByLocale(ctx, tagLocaleIds, countryId, locale)
 	if err != nil {
 		return
 	}
-	if tagLocaleMap != nil {
-		for _, v := range tagData {
-			locale, ok := tagLocaleMap[*v.ID]
-			if ok {
-				v.Name = &locale
-			}
+	for _, v := range tagData {
+		v.ServedLocale = servedLocale(tagLocaleMap[*v.ID])
+		if tagLocale, ok := tagLocaleMap[*v.ID]; ok {
+			v.Name = tagLocale.Name
 		}
 	}
-	return tagData, nil
+	return t.FetchAttributeLocalesByLocale(ctx, tagData, countryId, locale)
 }
 
-func (t *TagsServiceStruct) fetchTagLocaleMappingsDataByLocale(tagIds []*string, countryId *string, locale *string) (tagLocaleMap map[string]string, err error) {
-	tagLocaleMap = make(map[string]string)
+// fetchTagLocaleMappingsDataByLocale returns for every tag the first translation found along the fallback chain of
+// the country and locale, tags without any translation are left out
+func (t *TagsServiceStruct) fetchTagLocaleMappingsDataByLocale(ctx context.Context, tagIds []*string, countryId *string, locale *string) (tagLocaleMap map[string]*domain.TagLocaleMapping, err error) {
 	if len(tagIds) == 0 {
-		return
-	}
-	wgDone := make(chan bool)
-	tagDataChan := make(chan *domain.TagLocaleMapping, len(tagIds))
-	var wg sync.WaitGroup
-	for _, tagId := range tagIds {
-		wg.Add(1)
-		go func(tagId *string) {
-			defer func() {
-				if err := recover(); err != nil {
-					logger.Client.Error("getLocaleTagMappingPanicked:id:"+*tagId, logger.GetErrorStack())
-					wg.Done()
-				}
-			}()
-			var tagData domain.TagLocaleMapping
-			redisKey := repository.CurriculumTagLocaleMappingPrefix + *tagId + ":" + *countryId + ":" + *locale
-			val, err := repository.RedisClient.Get(redisKey).Result()
-			err1 := json.Unmarshal([]byte(val), &tagData)
-			if err != nil || err1 != nil {
-				tag, err := t.tlmr.FetchTagLocaleMappingByLocale(tagId, countryId, locale)
-				if err != nil {
-					wg.Done()
-					return
-				}
-				if tag != nil {
-					tagByte, err := json.Marshal(*tag)
-					if err == nil {
-						repository.RedisClient.Set(redisKey, string(tagByte), repository.RedisTtl)
-					}
-					tagData = *tag
-				}
-			}
-			tagDataChan <- &tagData
-			wg.Done()
-		}(tagId)
-	}
-	go func() {
-		wg.Wait()
-		close(tagDataChan)
-		close(wgDone)
-	}()
-	select {
-	case <-wgDone:
-		for v := range tagDataChan {
-			if v.TagID != nil {
-				tagLocaleMap[*v.TagID] = *v.Name
-			}
-		}
-		return tagLocaleMap, nil
+		return make(map[string]*domain.TagLocaleMapping), nil
 	}
+	return t.tagLoader(ctx).LoadServedLocales(tagIds, countryId, locale)
 }
 
-func (t *TagsServiceStruct) FetchTagOrders(parentTagIds *string, tagType *string) (tagOrders []*domain.ParentTagMapping, err error) {
+func (t *TagsServiceStruct) FetchTagOrders(ctx context.Context, parentTagIds *string, tagType *string) (tagOrders []*domain.ParentTagMapping, err error) {
 	redisKey := repository.CurriculumTagOrderPrefix + *parentTagIds + ":" + *tagType
-	val, err := repository.RedisClient.Get(redisKey).Result()
-	err1 := json.Unmarshal([]byte(val), &tagOrders)
-	if err != nil || err1 != nil {
-		tagOrders, err = t.ptmr.FetchParentTagMappingsByParentTagIds(parentTagIds, tagType)
-		if err != nil {
-			return nil, err
-		}
-		if len(tagOrders) > 0 {
-			tagByte, err := json.Marshal(tagOrders)
-			if err == nil {
-				repository.RedisClient.Set(redisKey, string(tagByte), repository.RedisTtl)
-			}
-		}
-		return tagOrders, nil
+	err = readThrough(ctx, redisKey, repository.RedisTtl, &tagOrders, func(ctx context.Context) (interface{}, bool, error) {
+		tagOrders, err := t.ptmr.FetchParentTagMappingsByParentTagIds(ctx, parentTagIds, tagType)
+		return tagOrders, len(tagOrders) > 0, err
+	})
+	if err != nil {
+		return nil, err
 	}
-	return t.ptmr.FetchParentTagMappingsByParentTagIds(parentTagIds, tagType)
+	return tagOrders, nil
 }
 
-func (t *TagsServiceStruct) UpdateTagOrders(tx *sql.Tx, orders []*domain.Order, parentTagIds *string, tagType *string) (err error) {
+// UpdateTagOrders bumps the version of the sibling set and applies the orders one after the other on tx, with a version
+// given the orders are rejected once the set moved past it. A nil version reorders whatever the set is at.
+func (t *TagsServiceStruct) UpdateTagOrders(ctx context.Context, tx *sql.Tx, orders []*domain.Order, parentTagIds *string, tagType *string, version *int) (err error) {
 	redisKey := repository.CurriculumTagOrderPrefix + *parentTagIds + ":" + *tagType
-	if err := repository.RedisClient.Del(redisKey).Err(); err != nil {
+	if err := repository.Client(ctx).Del(redisKey).Err(); err != nil {
 		return noonerror.New(noonerror.ErrInternalServer, "redisDeleteError")
 	}
-	errChan := make(chan error)
-	wgDone := make(chan bool)
-	var wg sync.WaitGroup
-	for _, order := range orders {
-		wg.Add(1)
-		go func(order *domain.Order) {
-			defer func() {
-				if err := recover(); err != nil {
-					logger.Client.Error("updateTagOrdersPanicked:id:"+*order.ID, logger.GetErrorStack())
-					errChan <- noonerror.New(noonerror.ErrInternalServer, "updateTagOrdersPanicked")
-				}
-			}()
-			err := t.ptmr.UpdateTagOrder(tx, order.Order, order.SqlId)
-			if err != nil {
-				errChan <- err
-			}
-			wg.Done()
-		}(order)
-	}
-	go func() {
-		wg.Wait()
-		close(wgDone)
-	}()
-	select {
-	case <-wgDone:
-		break
-	case err = <-errChan:
+	if err = t.ptmr.UpdateTagOrderVersion(ctx, tx, parentTagIds, tagType, version); err != nil {
 		return
 	}
+	for _, order := range orders {
+		if err = t.ptmr.UpdateTagOrder(ctx, tx, order.Order, order.SqlId); err != nil {
+			return
+		}
+	}
 	return
 }
 
+func (t *TagsServiceStruct) FetchTagOrderVersion(ctx context.Context, parentTagIds *string, tagType *string) (version int, err error) {
+	return t.ptmr.FetchTagOrderVersion(ctx, parentTagIds, tagType)
+}
+
+// FetchCurrentTagOrders reads the sibling set past the cache, it is what a rejected reorder is answered with
+func (t *TagsServiceStruct) FetchCurrentTagOrders(ctx context.Context, parentTagIds *string, tagType *string) (tagOrders *domain.TagOrders, err error) {
+	version, err := t.ptmr.FetchTagOrderVersion(ctx, parentTagIds, tagType)
+	if err != nil {
+		return
+	}
+	parentTagMappings, err := t.ptmr.FetchParentTagMappingsByParentTagIds(ctx, parentTagIds, tagType)
+	if err != nil {
+		return
+	}
+	tagOrders = &domain.TagOrders{Version: version, Orders: []*domain.Order{}}
+	for _, v := range parentTagMappings {
+		tagOrders.Orders = append(tagOrders.Orders, &domain.Order{ID: v.TagID, Order: v.Order})
+	}
+	sort.SliceStable(tagOrders.Orders, func(i, j int) bool {
+		return tagOrders.Orders[i].Order != nil && (tagOrders.Orders[j].Order == nil || *tagOrders.Orders[i].Order < *tagOrders.Orders[j].Order)
+	})
+	return tagOrders, nil
+}
+
 func verifyAndFetchParentCurriculumTags(curriculumType *string, tagHierarchySlice []*domain.Tags, tagLevel int) (parentTags *string, err error) {
 	curriculumHierarchy, err := flow.GetCurriculum(curriculumType)
 	if err != nil {
@@ -657,39 +668,37 @@ func assignDefaults(attributes map[string]interface{}, tagType string) map[strin
 	return attributes
 }
 
-func (t *TagsServiceStruct) FetchTagIdFromLegacyId(legacyType *string, id *string) (legacyTagMappings []*domain.LegacyTagMapping, err error) {
-	return t.ltmr.FetchTagIdFromLegacyId(legacyType, id)
+func (t *TagsServiceStruct) FetchTagIdFromLegacyId(ctx context.Context, legacyType *string, id *string) (legacyTagMappings []*domain.LegacyTagMapping, err error) {
+	return t.ltmr.FetchTagIdFromLegacyId(ctx, legacyType, id)
 }
 
-func (t *TagsServiceStruct) FetchLegacyIdFromTagId(tagId *string) (legacyTagMappings []*domain.LegacyTagMapping, err error) {
-	return t.ltmr.FetchLegacyIdFromTagId(tagId)
+func (t *TagsServiceStruct) FetchLegacyIdFromTagId(ctx context.Context, tagId *string) (legacyTagMappings []*domain.LegacyTagMapping, err error) {
+	return t.ltmr.FetchLegacyIdFromTagId(ctx, tagId)
 }
 
-func (t *TagsServiceStruct) FetchLegacyIdFromTagIds(tagIds []*string) (legacyTagMappings []*domain.LegacyTagMapping, err error) {
-	return t.ltmr.FetchLegacyIdFromTagIds(tagIds)
+func (t *TagsServiceStruct) FetchLegacyIdFromTagIds(ctx context.Context, tagIds []*string) (legacyTagMappings []*domain.LegacyTagMapping, err error) {
+	return t.ltmr.FetchLegacyIdFromTagIds(ctx, tagIds)
 }
 
-func (t *TagsServiceStruct) FetchGradesFromProductId(productId *string) (gradeProducts []*domain.GradeProduct, err error) {
+func (t *TagsServiceStruct) FetchGradesFromProductId(ctx context.Context, productId *string) (gradeProducts []*domain.GradeProduct, err error) {
 	redisKey := repository.CurriculumGradeProductPrefix + *productId
-	val, err := repository.RedisClient.Get(redisKey).Result()
-	err1 := json.Unmarshal([]byte(val), &gradeProducts)
-	if err != nil || err1 != nil {
-		gradeProducts, err = t.gpr.FetchGradesFromProductId(productId)
-		if err != nil {
-			return nil, err
-		}
-		if len(gradeProducts) > 0 {
-			tagByte, err := json.Marshal(gradeProducts)
-			if err == nil {
-				repository.RedisClient.Set(redisKey, string(tagByte), repository.RedisTtl)
-			}
-		}
-		return gradeProducts, nil
+	err = readThrough(ctx, redisKey, repository.RedisTtl, &gradeProducts, func(ctx context.Context) (interface{}, bool, error) {
+		gradeProducts, err := t.gpr.FetchGradesFromProductId(ctx, productId)
+		return gradeProducts, len(gradeProducts) > 0, err
+	})
+	if err != nil {
+		return nil, err
 	}
-	return t.gpr.FetchGradesFromProductId(productId)
+	return gradeProducts, nil
+}
+
+func (t *TagsServiceStruct) OrderTags(ctx context.Context, tags []*domain.Tags, tagType *string, curriculumType *string, hierarchy *string, locale *string) ([]*domain.Tags, error) {
+	return orderTags(func(parentTagIds *string, tagType *string) ([]*domain.ParentTagMapping, error) {
+		return t.FetchTagOrders(ctx, parentTagIds, tagType)
+	}, tags, tagType, curriculumType, hierarchy, locale)
 }
 
-func (t *TagsServiceStruct) OrderTags(tags []*domain.Tags, tagType *string, curriculumType *string, hierarchy *string) ([]*domain.Tags, error) {
+func orderTags(fetchTagOrders func(*string, *string) ([]*domain.ParentTagMapping, error), tags []*domain.Tags, tagType *string, curriculumType *string, hierarchy *string, locale *string) ([]*domain.Tags, error) {
 	var tagOrders []*domain.ParentTagMapping
 	curriculum, err := flow.GetCurriculum(curriculumType)
 	if err != nil {
@@ -700,7 +709,7 @@ func (t *TagsServiceStruct) OrderTags(tags []*domain.Tags, tagType *string, curr
 		return nil, noonerror.New(noonerror.ErrBadRequest, "typeInvalid")
 	}
 	if curriculumInfo.IsOrdered {
-		tagOrders, _ = t.FetchTagOrders(hierarchy, tagType)
+		tagOrders, _ = fetchTagOrders(hierarchy, tagType)
 		if len(tags) > len(tagOrders) && len(tagOrders) > 0 {
 			return nil, noonerror.New(noonerror.ErrInternalServer, "tagLengthMismatch")
 		}
@@ -713,9 +722,7 @@ func (t *TagsServiceStruct) OrderTags(tags []*domain.Tags, tagType *string, curr
 	//}
 	//tagOrders = tagOrderWithoutZeroOrders
 	if len(tagOrders) == 0 {
-		sort.SliceStable(tags, func(i, j int) bool {
-			return strings.ToLower(*tags[i].Name) < strings.ToLower(*tags[j].Name)
-		})
+		sortTagsByName(tags, locale)
 		return tags, nil}
 	//} else {
 	//	sort.SliceStable(tagOrders, func(i, j int) bool {
@@ -1,13 +1,13 @@
 package service
 
 import (
+	"bitbucket.org/noon-micro/curriculum/config"
 	"bitbucket.org/noon-micro/curriculum/pkg/domain"
 	noonerror "bitbucket.org/noon-micro/curriculum/pkg/lib/error"
 	"bitbucket.org/noon-micro/curriculum/pkg/lib/flow"
 	"bitbucket.org/noon-micro/curriculum/pkg/service/constant"
 	dtomapper "bitbucket.org/noon-micro/curriculum/pkg/service/mapper"
-	"sort"
-	"strings"
+	"context"
 )
 
 type TeacherTagsServiceStruct struct {
@@ -20,24 +20,24 @@ func NewTeacherTagsService(ts domain.TagsService, es domain.Elastic, geo domain.
 	return &TeacherTagsServiceStruct{ts: ts, es: es, geo: geo}
 }
 
-func (t *TeacherTagsServiceStruct) GetTeacherTags(tags *domain.GetTeacherTags) (getTagResponse *domain.GetTagsResponse, err error) {
+func (t *TeacherTagsServiceStruct) GetTeacherTags(ctx context.Context, tags *domain.GetTeacherTags) (getTagResponse *domain.GetTagsResponse, err error) {
 
 	switch *tags.TagGroup {
 	case domain.TagGroupEnum.Curriculum:
-		return t.getCurriculumTags(tags)
+		return t.getCurriculumTags(ctx, tags)
 	case domain.TagGroupEnum.Content:
-		return t.getContentTags(tags)
+		return t.getContentTags(ctx, tags)
 	}
 	return
 
 }
-func (t *TeacherTagsServiceStruct) SearchTeacherTags(_ *domain.GetTeacherTags) ([]*domain.TagResponse, error) {
+func (t *TeacherTagsServiceStruct) SearchTeacherTags(ctx context.Context, _ *domain.GetTeacherTags) ([]*domain.TagResponse, error) {
 	return nil, nil
 }
 
-func (t *TeacherTagsServiceStruct) getCurriculumTags(gtt *domain.GetTeacherTags) (*domain.GetTagsResponse, error) {
+func (t *TeacherTagsServiceStruct) getCurriculumTags(ctx context.Context, gtt *domain.GetTeacherTags) (*domain.GetTagsResponse, error) {
 
-	tagHierarchySlice, err := t.ts.GetTagsConcurrent(gtt.Hierarchy)
+	tagHierarchySlice, err := t.ts.GetTagsConcurrent(ctx, gtt.Hierarchy)
 	if err != nil {
 		return nil, err
 	}
@@ -56,31 +56,33 @@ func (t *TeacherTagsServiceStruct) getCurriculumTags(gtt *domain.GetTeacherTags)
 	if tagHierarchy.Level == 1 {
 		return nil, noonerror.New(noonerror.ErrBadRequest, "tagTypeInvalid")
 	}
-	parentTags, err := verifyAndFetchParentCurriculumTags(gtt.CurriculumType, tagHierarchySlice, tagHierarchy.Level)
+	parentTags, err := verifyAndFetchParentCurriculumTags(gtt.CurriculumType, regionAsCountry(tagHierarchySlice), tagHierarchy.Level)
 	if err != nil {
 		return nil, err
 	}
 
 	getTag := domain.GetTeacherTags{Text: gtt.Text, TagGroup: gtt.TagGroup, Type: gtt.Type}
-	parents := []*string{parentTags}
-
-	createElasticEntity, err := dtomapper.GetElasticTagEntity(getTag, parents, nil, "", gtt.CurriculumType, "admin", gtt.TagGroup, gtt.Start, gtt.Limit)
+	parents, err := t.ts.ResolveRegionParents(ctx, []*string{parentTags})
 	if err != nil {
 		return nil, err
 	}
-	filteredTags, next, err := t.es.GetTags(createElasticEntity)
+	if len(parents) == 0 {
+		return dtomapper.GetTagResponse(nil, gtt.Type, gtt.CurriculumType, make(map[string]bool), nil)
+	}
+
+	createElasticEntity, err := dtomapper.GetElasticTagEntity(getTag, parents, nil, "", gtt.CurriculumType, "admin", gtt.TagGroup, gtt.Start, gtt.Limit)
 	if err != nil {
 		return nil, err
 	}
-	tagData, err := t.ts.FetchByInTags(filteredTags)
+	tagData, next, err := searchAvailableTags(ctx, t.ts, parentSearch(t.es.GetTags, parents), createElasticEntity, gtt.CountryId)
 	if err != nil {
 		return nil, err
 	}
-	tagData, err = t.ts.FetchTagLocaleMappingsByLocale(tagData, gtt.CountryId, gtt.Locale)
+	tagData, err = t.ts.FetchTagLocaleMappingsByLocale(ctx, tagData, gtt.CountryId, gtt.Locale)
 	if err != nil {
 		return nil, err
 	}
-	tagData, err = t.ts.OrderTags(tagData, gtt.Type, gtt.CurriculumType, parentTags)
+	tagData, err = t.ts.OrderTags(ctx, tagData, gtt.Type, gtt.CurriculumType, parentTags, gtt.Locale)
 	if err != nil {
 		return nil, err
 	}
@@ -88,8 +90,8 @@ func (t *TeacherTagsServiceStruct) getCurriculumTags(gtt *domain.GetTeacherTags)
 	return getTagResponse, nil
 }
 
-func (t *TeacherTagsServiceStruct) getContentTags(gtt *domain.GetTeacherTags) (*domain.GetTagsResponse, error) {
-	tagHierarchySlice, err := t.ts.FetchByInTags(gtt.Hierarchy)
+func (t *TeacherTagsServiceStruct) getContentTags(ctx context.Context, gtt *domain.GetTeacherTags) (*domain.GetTagsResponse, error) {
+	tagHierarchySlice, err := t.ts.FetchByInTags(ctx, gtt.Hierarchy)
 	if err != nil {
 		return nil, err
 	}
@@ -116,41 +118,30 @@ func (t *TeacherTagsServiceStruct) getContentTags(gtt *domain.GetTeacherTags) (*
 	if err != nil {
 		return nil, err
 	}
-	tagIds, next, err := t.es.GetTags(adminElasticEntity)
-	if err != nil {
-		return nil, err
-	}
-	tagData, err := t.ts.FetchByInTags(tagIds)
-	if err != nil {
-		return nil, err
-	}
-	tagData, err = t.ts.OrderTags(tagData, gtt.Type, gtt.CurriculumType, parentHideOrderTags)
+	tagData, next, err := searchAvailableTags(ctx, t.ts, t.es.GetTags, adminElasticEntity, gtt.CountryId)
 	if err != nil {
 		return nil, err
 	}
-	userTagIds, _, err := t.es.GetTags(userElasticEntity)
+	tagData, err = t.ts.OrderTags(ctx, tagData, gtt.Type, gtt.CurriculumType, parentHideOrderTags, gtt.Locale)
 	if err != nil {
 		return nil, err
 	}
-
-	userTagData, err := t.ts.FetchByInTags(userTagIds)
+	userTagData, _, err := searchAvailableTags(ctx, t.ts, t.es.GetTags, userElasticEntity, gtt.CountryId)
 	if err != nil {
 		return nil, err
 	}
 	if len(userTagData) > 0 {
 		tagData = append(tagData, userTagData...)
-		sort.SliceStable(tagData, func(i, j int) bool {
-			return strings.ToLower(*tagData[i].Name) < strings.ToLower(*tagData[j].Name)
-		})
+		sortTagsByName(tagData, gtt.Locale)
 	}
 
 	getTagResponse, _ := dtomapper.GetTagResponse(tagData, gtt.Type, gtt.CurriculumType, make(map[string]bool), next)
 	return getTagResponse, nil
 }
 
-func (t *TeacherTagsServiceStruct) GetTestsSkillsForLibrary(gtt *domain.GetTeacherTags) (*domain.GetTagsResponse, error) {
+func (t *TeacherTagsServiceStruct) GetTestsSkillsForLibrary(ctx context.Context, gtt *domain.GetTeacherTags) (*domain.GetTagsResponse, error) {
 
-	tagHierarchySlice, err := t.ts.GetTagsConcurrent(gtt.Hierarchy)
+	tagHierarchySlice, err := t.ts.GetTagsConcurrent(ctx, gtt.Hierarchy)
 	if err != nil {
 		return nil, err
 	}
@@ -178,31 +169,33 @@ func (t *TeacherTagsServiceStruct) GetTestsSkillsForLibrary(gtt *domain.GetTeach
 	if tagHierarchy.Level == 1 {
 		return nil, noonerror.New(noonerror.ErrBadRequest, "tagTypeInvalid")
 	}
-	parentTags, err := verifyAndFetchParentCurriculumTags(curriculumType, tagHierarchySlice, tagHierarchy.Level)
+	parentTags, err := verifyAndFetchParentCurriculumTags(curriculumType, regionAsCountry(tagHierarchySlice), tagHierarchy.Level)
 	if err != nil {
 		return nil, err
 	}
 
 	getTag := domain.GetTeacherTags{Text: gtt.Text, TagGroup: gtt.TagGroup, Type: gtt.Type}
-	parents := []*string{parentTags}
-
-	createElasticEntity, err := dtomapper.GetElasticTagEntityWithoutCurriculumType(getTag, parents, nil, "", "admin", gtt.TagGroup, gtt.Start, gtt.Limit)
+	parents, err := t.ts.ResolveRegionParents(ctx, []*string{parentTags})
 	if err != nil {
 		return nil, err
 	}
-	filteredTags, next, err := t.es.GetTags(createElasticEntity)
+	if len(parents) == 0 {
+		return dtomapper.GetTagResponse(nil, gtt.Type, curriculumType, make(map[string]bool), nil)
+	}
+
+	createElasticEntity, err := dtomapper.GetElasticTagEntityWithoutCurriculumType(getTag, parents, nil, "", "admin", gtt.TagGroup, gtt.Start, gtt.Limit)
 	if err != nil {
 		return nil, err
 	}
-	tagData, err := t.ts.FetchByInTags(filteredTags)
+	tagData, next, err := searchAvailableTags(ctx, t.ts, parentSearch(t.es.GetTags, parents), createElasticEntity, gtt.CountryId)
 	if err != nil {
 		return nil, err
 	}
-	tagData, err = t.ts.FetchTagLocaleMappingsByLocale(tagData, gtt.CountryId, gtt.Locale)
+	tagData, err = t.ts.FetchTagLocaleMappingsByLocale(ctx, tagData, gtt.CountryId, gtt.Locale)
 	if err != nil {
 		return nil, err
 	}
-	tagData, err = t.ts.OrderTags(tagData, gtt.Type, curriculumType, parentTags)
+	tagData, err = t.ts.OrderTags(ctx, tagData, gtt.Type, curriculumType, parentTags, gtt.Locale)
 	if err != nil {
 		return nil, err
 	}
@@ -210,10 +203,10 @@ func (t *TeacherTagsServiceStruct) GetTestsSkillsForLibrary(gtt *domain.GetTeach
 	return getTagResponse, nil
 }
 
-func (t *TeacherTagsServiceStruct) GetCountriesTagsNew(tags *domain.GetCountriesNew) (getTagResponse *domain.GetCountriesNewResponse, err error) {
+func (t *TeacherTagsServiceStruct) GetCountriesTagsNew(ctx context.Context, tags *domain.GetCountriesNew) (getTagResponse *domain.GetCountriesNewResponse, err error) {
 	rootCurriculumType := domain.CurriculumTypeEnum.Root
 	countryType := domain.TagTypeEnum.Country
-	filteredTagsResponse, err := t.ts.FetchFilteredTagsPaginated(&rootCurriculumType, &countryType, &tags.Start, &tags.Limit)
+	filteredTagsResponse, err := t.ts.FetchFilteredTagsPaginated(ctx, &rootCurriculumType, &countryType, &tags.Start, &tags.Limit)
 	if err != nil {
 		return
 	}
@@ -221,6 +214,19 @@ func (t *TeacherTagsServiceStruct) GetCountriesTagsNew(tags *domain.GetCountries
 	if len(filteredTagsResponse) >= tags.Limit {
 		next = tags.Start + tags.Limit
 	}
-	getTagResponse, _ = dtomapper.CreateGetCountriesNewResponse(filteredTagsResponse, tags.Locale, &tags.ISOCode, &next, false)
+	filteredTagsResponse, err = t.ts.FetchAttributeLocalesByLocale(ctx, filteredTagsResponse, tags.CountryId, tags.Locale)
+	if err != nil {
+		return
+	}
+	if err = resolveCountryCode(ctx, t.ts, t.geo, tags); err != nil {
+		return
+	}
+	getTagResponse, _ = dtomapper.CreateGetCountriesNewResponse(filteredTagsResponse, tags.Locale, &tags.ISOCode, config.GetConfig().DefaultCountryCode, &next, false)
+	if tags.GroupBy != nil && *tags.GroupBy == GroupByRegion {
+		getTagResponse.Meta.Regions, err = t.ts.GroupCountriesByRegion(ctx, filteredTagsResponse)
+		if err != nil {
+			return nil, err
+		}
+	}
 	return getTagResponse, nil
 }
@@ -0,0 +1,114 @@
+package service
+
+import (
+	"bitbucket.org/noon-micro/curriculum/pkg/domain"
+	"bitbucket.org/noon-micro/curriculum/pkg/lib/helper"
+	"context"
+	"math"
+	"sort"
+	"strings"
+)
+
+// GetTranslationCoverage walks the subtree and counts its published tags translated for every locale and country
+// pair found in tag_locale_mapping, or only for the requested pair. Missing tags are listed when asked for.
+func (t *AdminTagsServiceStruct) GetTranslationCoverage(ctx context.Context, coverageRequest *domain.TranslationCoverageRequest) (coverage *domain.TranslationCoverage, err error) {
+	tagData, err := t.ts.FetchSubtreeTags(ctx, coverageRequest.Hierarchy)
+	if err != nil {
+		return nil, err
+	}
+	var tags []*domain.Tags
+	var tagIds []*string
+	for _, v := range tagData {
+		if v.Publish && v.Type != nil {
+			tags = append(tags, v)
+			tagIds = append(tagIds, v.ID)
+		}
+	}
+	translated := make(map[string]map[string]struct{})
+	err = inBatches(tagIds, func(batch []*string) error {
+		tagLocales, err := t.ts.FetchByInTagLocaleMappings(ctx, batch)
+		for _, v := range tagLocales {
+			if v.Locale == nil || v.CountryId == nil {
+				continue
+			}
+			key := coverageKey(helper.NormalizeLocale(*v.Locale), *v.CountryId)
+			if translated[key] == nil {
+				translated[key] = make(map[string]struct{})
+			}
+			translated[key][*v.TagID] = struct{}{}
+		}
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	var keys []string
+	if coverageRequest.Locale != nil && coverageRequest.CountryId != nil {
+		keys = append(keys, coverageKey(helper.NormalizeLocale(*coverageRequest.Locale), *coverageRequest.CountryId))
+	} else if coverageRequest.Locale != nil {
+		// the requested locale is reported for every country translating the subtree, a country without a single row
+		// of the locale then shows up at 0% instead of being left out
+		countries := make(map[string]struct{})
+		for key := range translated {
+			_, countryId := splitCoverageKey(key)
+			countries[countryId] = struct{}{}
+		}
+		if len(countries) == 0 {
+			countries[""] = struct{}{}
+		}
+		for countryId := range countries {
+			keys = append(keys, coverageKey(helper.NormalizeLocale(*coverageRequest.Locale), countryId))
+		}
+		sort.Strings(keys)
+	} else {
+		for key := range translated {
+			_, countryId := splitCoverageKey(key)
+			if coverageRequest.CountryId == nil || *coverageRequest.CountryId == countryId {
+				keys = append(keys, key)
+			}
+		}
+		sort.Strings(keys)
+	}
+	coverage = &domain.TranslationCoverage{Hierarchy: coverageRequest.Hierarchy, Total: len(tags), Locales: []*domain.TranslationCoverageLocale{}}
+	for _, key := range keys {
+		coverage.Locales = append(coverage.Locales, localeCoverage(key, tags, translated[key], coverageRequest))
+	}
+	return coverage, nil
+}
+
+func localeCoverage(key string, tags []*domain.Tags, translated map[string]struct{}, coverageRequest *domain.TranslationCoverageRequest) *domain.TranslationCoverageLocale {
+	locale, countryId := splitCoverageKey(key)
+	localeCoverage := &domain.TranslationCoverageLocale{Locale: locale, CountryId: countryId}
+	typeCoverage := make(map[string]*domain.TranslationCoverageType)
+	for _, v := range tags {
+		count, ok := typeCoverage[*v.Type]
+		if !ok {
+			count = &domain.TranslationCoverageType{TagType: *v.Type}
+			typeCoverage[*v.Type] = count
+			localeCoverage.Types = append(localeCoverage.Types, count)
+		}
+		if _, ok := translated[*v.ID]; ok {
+			count.Translated++
+			localeCoverage.Translated++
+			continue
+		}
+		count.Untranslated++
+		localeCoverage.Untranslated++
+		if coverageRequest.Missing && (coverageRequest.TagType == nil || *coverageRequest.TagType == *v.Type) {
+			localeCoverage.Missing = append(localeCoverage.Missing, &domain.TranslationUnit{TagID: v.ID, TagType: v.Type, Source: v.Name})
+		}
+	}
+	if len(tags) > 0 {
+		localeCoverage.Percent = math.Round(float64(localeCoverage.Translated)*10000/float64(len(tags))) / 100
+	}
+	return localeCoverage
+}
+
+func coverageKey(locale string, countryId string) string {
+	return locale + ":" + countryId
+}
+
+func splitCoverageKey(key string) (locale string, countryId string) {
+	parts := strings.SplitN(key, ":", 2)
+	return parts[0], parts[1]
+}
@@ -0,0 +1,43 @@
+package service
+
+import (
+	"bitbucket.org/noon-micro/curriculum/pkg/domain"
+	"context"
+	"testing"
+)
+
+func TestGetTranslationCoverageRequestedLocale(t *testing.T) {
+	services := newTestServices(t)
+	tests := []struct {
+		name      string
+		locale    *string
+		countryId *string
+		want      map[string]float64
+	}{
+		{name: "locale and country", locale: str("ar"), countryId: str("9"), want: map[string]float64{"ar:9": 70}},
+		{name: "locale without rows and country", locale: str("fr"), countryId: str("9"), want: map[string]float64{"fr:9": 0}},
+		{name: "locale without rows", locale: str("fr"), want: map[string]float64{"fr:0": 0, "fr:9": 0}},
+		{name: "locale in every country", locale: str("AR"), want: map[string]float64{"ar:0": 10, "ar:9": 70}},
+		{name: "every locale of the country", countryId: str("0"), want: map[string]float64{"ar:0": 10}},
+	}
+	for _, test := range tests {
+		coverage, err := services.admin.GetTranslationCoverage(context.Background(),
+			&domain.TranslationCoverageRequest{Hierarchy: str("9.2"), Locale: test.locale, CountryId: test.countryId})
+		if err != nil {
+			t.Fatal(err)
+		}
+		got := make(map[string]float64, len(coverage.Locales))
+		for _, v := range coverage.Locales {
+			got[coverageKey(v.Locale, v.CountryId)] = v.Percent
+		}
+		if len(got) != len(test.want) {
+			t.Errorf("%s: got %v, want %v", test.name, got, test.want)
+			continue
+		}
+		for key, percent := range test.want {
+			if value, ok := got[key]; !ok || value != percent {
+				t.Errorf("%s: got %v, want %v", test.name, got, test.want)
+			}
+		}
+	}
+}
@@ -0,0 +1,235 @@
+package service
+
+import (
+	"bitbucket.org/noon-micro/curriculum/pkg/domain"
+	noonerror "bitbucket.org/noon-micro/curriculum/pkg/lib/error"
+	"bitbucket.org/noon-micro/curriculum/pkg/lib/helper"
+	repository "bitbucket.org/noon-micro/curriculum/pkg/repository/mysql"
+	"bitbucket.org/noon-micro/curriculum/pkg/service/constant"
+	"context"
+	"strings"
+	"time"
+)
+
+// SuggestTagLocales asks the translation provider for the names of the untranslated tags of the subtree and stores
+// them as unpublished drafts, tags which already have a draft for the locale and country are left alone
+func (t *AdminTagsServiceStruct) SuggestTagLocales(ctx context.Context, suggest *domain.TranslationSuggest) (result *domain.TranslationSuggestResult, err error) {
+	locale := helper.NormalizeLocale(*suggest.Locale)
+	units, err := t.ExportTagLocales(ctx, &domain.TranslationExport{Hierarchy: suggest.Hierarchy, CountryId: suggest.CountryId, Locale: &locale})
+	if err != nil {
+		return nil, err
+	}
+	var tagIds []*string
+	for _, v := range units {
+		tagIds = append(tagIds, v.TagID)
+	}
+	drafts, err := t.ts.FetchTagLocaleDrafts(ctx, tagIds, &locale, suggest.CountryId)
+	if err != nil {
+		return nil, err
+	}
+	drafted := make(map[string]struct{}, len(drafts))
+	for _, v := range drafts {
+		drafted[*v.TagID] = struct{}{}
+	}
+	result = &domain.TranslationSuggestResult{Created: []*domain.TranslationDraft{}}
+	var pending []*domain.TranslationUnit
+	var texts []string
+	for _, v := range units {
+		if suggest.TagType != nil && *suggest.TagType != *v.TagType {
+			continue
+		}
+		if _, ok := drafted[*v.TagID]; ok {
+			result.Pending++
+			continue
+		}
+		pending = append(pending, v)
+		texts = append(texts, *v.Source)
+	}
+	if len(pending) == 0 {
+		return result, nil
+	}
+	suggestions, err := t.tp.Suggest(ctx, &domain.TranslationRequest{SourceLocale: constant.DefaultLocale, TargetLocale: locale, Texts: texts})
+	if err != nil {
+		return nil, err
+	}
+	tx, err := repository.Db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, noonerror.New(noonerror.ErrInternalServer, "ContextCreationError")
+	}
+	for i, v := range pending {
+		if suggestions[i] == nil {
+			result.Unsuggested++
+			continue
+		}
+		provider := suggestions[i].Provider
+		if err = t.ts.CreateTagLocaleDraft(ctx, tx, &domain.TagLocaleMapping{Locale: &locale, CountryId: suggest.CountryId, TagID: v.TagID,
+			Name: suggestions[i].Text, TagType: v.TagType, Draft: true, SuggestedBy: &provider, CreatedAt: time.Now(), UpdatedAt: time.Now()}); err != nil {
+			_ = tx.Rollback()
+			return nil, err
+		}
+		result.Created = append(result.Created, &domain.TranslationDraft{TagID: v.TagID, TagType: v.TagType, Source: v.Source,
+			Name: suggestions[i].Text, SuggestedBy: &provider})
+	}
+	if err = tx.Commit(); err != nil {
+		return nil, noonerror.New(noonerror.ErrInternalServer, "dbCommitError")
+	}
+	return result, nil
+}
+
+// GetTagLocaleDrafts lists the drafts of the subtree for the locale and country next to the translation they replace
+func (t *AdminTagsServiceStruct) GetTagLocaleDrafts(ctx context.Context, draftRequest *domain.TranslationDraftRequest) (drafts []*domain.TranslationDraft, err error) {
+	locale := helper.NormalizeLocale(*draftRequest.Locale)
+	tagData, err := t.ts.FetchSubtreeTags(ctx, draftRequest.Hierarchy)
+	if err != nil {
+		return nil, err
+	}
+	var tagIds []*string
+	for _, v := range tagData {
+		tagIds = append(tagIds, v.ID)
+	}
+	tagDrafts, err := t.ts.FetchTagLocaleDrafts(ctx, tagIds, &locale, draftRequest.CountryId)
+	if err != nil {
+		return nil, err
+	}
+	tagLocales, err := t.ts.FetchTagLocalesByTagIds(ctx, tagIds, &locale, draftRequest.CountryId)
+	if err != nil {
+		return nil, err
+	}
+	tagDraftMap := make(map[string][]*domain.TagLocaleMapping, len(tagDrafts))
+	for _, v := range tagDrafts {
+		tagDraftMap[*v.TagID] = append(tagDraftMap[*v.TagID], v)
+	}
+	tagLocaleMap := make(map[string]*domain.TagLocaleMapping, len(tagLocales))
+	for _, v := range tagLocales {
+		tagLocaleMap[*v.TagID] = v
+	}
+	drafts = []*domain.TranslationDraft{}
+	for _, tag := range tagData {
+		for _, v := range tagDraftMap[*tag.ID] {
+			draft := &domain.TranslationDraft{ID: v.ID, TagID: v.TagID, TagType: tag.Type, Source: tag.Name, Name: v.Name, SuggestedBy: v.SuggestedBy}
+			if tagLocale, ok := tagLocaleMap[*tag.ID]; ok {
+				draft.Current = tagLocale.Name
+			}
+			drafts = append(drafts, draft)
+		}
+	}
+	return drafts, nil
+}
+
+// ReviewTagLocaleDrafts publishes the approved drafts in place of the current translations and discards the
+// rejected ones in a single transaction, the localized names of the approved tags are then pushed to search
+func (t *AdminTagsServiceStruct) ReviewTagLocaleDrafts(ctx context.Context, review *domain.TranslationReview) (result *domain.TranslationReviewResult, err error) {
+	locale := helper.NormalizeLocale(*review.Locale)
+	result = &domain.TranslationReviewResult{Approved: []*domain.TranslationChange{}, Invalid: []*domain.TranslationError{}}
+	var ids []*string
+	for _, v := range review.Approve {
+		ids = append(ids, v.ID)
+	}
+	ids = append(ids, review.Reject...)
+	drafts, err := t.ts.FetchTagLocaleDraftsByIds(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+	draftMap := make(map[string]*domain.TagLocaleMapping, len(drafts))
+	var tagIds []*string
+	for _, v := range drafts {
+		if strings.EqualFold(*v.Locale, locale) && *v.CountryId == *review.CountryId {
+			draftMap[*v.ID] = v
+			tagIds = append(tagIds, v.TagID)
+		}
+	}
+	tagData, err := t.ts.FetchByInTags(ctx, tagIds)
+	if err != nil {
+		return nil, err
+	}
+	tagMap := make(map[string]*domain.Tags, len(tagData))
+	for _, v := range tagData {
+		tagMap[*v.ID] = v
+	}
+	seen := make(map[string]struct{}, len(ids))
+	approvedTags := make(map[string]struct{}, len(review.Approve))
+	for i, id := range ids {
+		draft, ok := draftMap[*id]
+		message := ""
+		if _, dup := seen[*id]; dup {
+			message = "draftIdDuplicate"
+		} else if !ok {
+			message = "draftNotFound"
+		} else if tag, found := tagMap[*draft.TagID]; i < len(review.Approve) && (!found || !tag.Publish) {
+			message = "tagNotFound"
+		} else if i < len(review.Approve) {
+			if _, dup := approvedTags[*draft.TagID]; dup {
+				message = "tagIdDuplicate"
+			}
+			approvedTags[*draft.TagID] = struct{}{}
+		}
+		seen[*id] = struct{}{}
+		if message != "" {
+			translationError := &domain.TranslationError{DraftID: id, Message: message}
+			if ok {
+				translationError.TagID = draft.TagID
+			}
+			result.Invalid = append(result.Invalid, translationError)
+		}
+	}
+	if len(result.Invalid) > 0 {
+		return result, nil
+	}
+	tagLocales, err := t.ts.FetchTagLocalesByTagIds(ctx, tagIds, &locale, review.CountryId)
+	if err != nil {
+		return nil, err
+	}
+	tagLocaleMap := make(map[string]*domain.TagLocaleMapping, len(tagLocales))
+	for _, v := range tagLocales {
+		tagLocaleMap[*v.TagID] = v
+	}
+	tx, err := repository.Db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, noonerror.New(noonerror.ErrInternalServer, "ContextCreationError")
+	}
+	var changes []*domain.TranslationChange
+	for _, v := range review.Approve {
+		draft := draftMap[*v.ID]
+		name := draft.Name
+		if v.Name != nil && strings.TrimSpace(*v.Name) != "" {
+			name = v.Name
+		}
+		change := &domain.TranslationChange{TagID: draft.TagID, Name: name}
+		if tagLocale, ok := tagLocaleMap[*draft.TagID]; ok {
+			change.Previous = tagLocale.Name
+			if err = t.ts.DeleteTagLocaleMapping(ctx, tx, tagLocale); err != nil {
+				_ = tx.Rollback()
+				return nil, err
+			}
+		}
+		if err = t.ts.PublishTagLocaleDraft(ctx, tx, draft, name); err != nil {
+			_ = tx.Rollback()
+			return nil, err
+		}
+		if !tagMap[*draft.TagID].LocaleAvailable {
+			if err = t.ts.UpdateLocale(ctx, tx, true, draft.TagID); err != nil {
+				_ = tx.Rollback()
+				return nil, err
+			}
+		}
+		changes = append(changes, change)
+	}
+	for _, id := range review.Reject {
+		if err = t.ts.DiscardTagLocaleDraft(ctx, tx, id); err != nil {
+			_ = tx.Rollback()
+			return nil, err
+		}
+		result.Rejected++
+	}
+	if err = t.publishTagLocales(ctx, tx, locale, review.CountryId, changes, tagMap); err != nil {
+		_ = tx.Rollback()
+		return nil, err
+	}
+	if err = tx.Commit(); err != nil {
+		return nil, noonerror.New(noonerror.ErrInternalServer, "dbCommitError")
+	}
+	result.Approved = append(result.Approved, changes...)
+	result.Applied = true
+	result.SearchFailed = t.pushTagLocales(ctx, changes, tagMap)
+	return result, nil
+}
@@ -0,0 +1,418 @@
+package service
+
+import (
+	"bitbucket.org/noon-micro/curriculum/config"
+	"bitbucket.org/noon-micro/curriculum/pkg/domain"
+	noonerror "bitbucket.org/noon-micro/curriculum/pkg/lib/error"
+	"bitbucket.org/noon-micro/curriculum/pkg/lib/helper"
+	"bitbucket.org/noon-micro/curriculum/pkg/lib/logger"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	WebhookTagEventBrokerName = "webhook"
+
+	WebhookEventHeader     = "X-Curriculum-Event"
+	WebhookDeliveryHeader  = "X-Curriculum-Delivery"
+	WebhookSignatureHeader = "X-Curriculum-Signature"
+
+	// webhookBatchSize is the number of due deliveries read at once, webhookWorkers of them are sent in parallel
+	webhookBatchSize = 50
+	webhookWorkers   = 4
+	// webhookClaimDuration keeps a delivery from the other instances while it is sent, it outlasts an attempt
+	webhookClaimDuration = 2 * time.Minute
+	// webhookErrorLength bounds the error kept in the delivery log
+	webhookErrorLength   = 255
+	defaultWebhookLimit  = 20
+	maxWebhookLimit      = 100
+	minWebhookSecretSize = 16
+)
+
+var subtreePrefixPattern = regexp.MustCompile(`^[0-9]+(\.[0-9]+)*$`)
+
+// WebhookServiceStruct matches the published tag events against the subscriptions and keeps a delivery for every
+// match, a background worker then posts the deliveries so that the writes publishing the events never wait on a
+// subscriber
+type WebhookServiceStruct struct {
+	subscriptions domain.WebhookSubscriptionRepository
+	deliveries    domain.WebhookDeliveryRepository
+	sender        domain.WebhookSender
+	wake          chan struct{}
+}
+
+func NewWebhookService(subscriptions domain.WebhookSubscriptionRepository, deliveries domain.WebhookDeliveryRepository, sender domain.WebhookSender) *WebhookServiceStruct {
+	return &WebhookServiceStruct{subscriptions: subscriptions, deliveries: deliveries, sender: sender, wake: make(chan struct{}, 1)}
+}
+
+// Start runs the delivery worker, it polls every WebhookPollInterval and as soon as a delivery is queued
+func (w *WebhookServiceStruct) Start() {
+	go w.run(helper.Timeout(config.GetConfig().WebhookPollInterval, config.DefaultWebhookPollInterval))
+}
+
+func (w *WebhookServiceStruct) Name() string {
+	return WebhookTagEventBrokerName
+}
+
+// Publish queues a delivery for every active subscription matching the event. Only failing to read the
+// subscriptions is returned, a failed insert is logged and a retry of the event skips the deliveries already queued.
+func (w *WebhookServiceStruct) Publish(ctx context.Context, event *domain.TagEvent) error {
+	subscriptions, err := w.subscriptions.FetchWebhookSubscriptions(ctx)
+	if err != nil {
+		return err
+	}
+	var payload []byte
+	queued := false
+	for _, v := range subscriptions {
+		if !webhookMatches(v, event) {
+			continue
+		}
+		if payload == nil {
+			if payload, err = json.Marshal(event); err != nil {
+				return err
+			}
+		}
+		payloadString := string(payload)
+		status := domain.WebhookDeliveryStatusEnum.Pending
+		now := time.Now()
+		id, err := w.deliveries.CreateWebhookDelivery(ctx, &domain.WebhookDelivery{SubscriptionID: v.ID, EventID: &event.ID, EventType: &event.Type,
+			TagId: &event.TagId, Payload: &payloadString, Status: &status, NextAttemptAt: now, CreatedAt: now,
+			UpdatedAt: now})
+		if err != nil {
+			logger.Client.Error("webhookDeliveryQueueError:subscription:"+*v.ID+":event:"+event.ID, err)
+			continue
+		}
+		queued = queued || id != nil
+	}
+	if queued {
+		w.notify()
+	}
+	return nil
+}
+
+// webhookMatches checks the filters of the subscription, the subtree matches when the path of the tag under any of
+// its parents is the prefix or sits under it
+func webhookMatches(subscription *domain.WebhookSubscription, event *domain.TagEvent) bool {
+	if !subscription.Active || !containsOrEmpty(subscription.EventTypes, event.Type) || !containsOrEmpty(subscription.TagTypes, event.TagType) {
+		return false
+	}
+	if subscription.SubtreePrefix == nil {
+		return true
+	}
+	prefix := *subscription.SubtreePrefix
+	paths := []string{event.TagId}
+	if len(event.ParentTags) > 0 {
+		paths = paths[:0]
+		for _, v := range event.ParentTags {
+			paths = append(paths, v+"."+event.TagId)
+		}
+	}
+	for _, v := range paths {
+		if v == prefix || strings.HasPrefix(v, prefix+".") {
+			return true
+		}
+	}
+	return false
+}
+
+func containsOrEmpty(values []string, value string) bool {
+	if len(values) == 0 {
+		return true
+	}
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+func (w *WebhookServiceStruct) notify() {
+	select {
+	case w.wake <- struct{}{}:
+	default:
+	}
+}
+
+func (w *WebhookServiceStruct) run(pollInterval time.Duration) {
+	ticker := time.NewTicker(pollInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+		case <-w.wake:
+		}
+		w.deliverDue()
+	}
+}
+
+// deliverDue sends the due deliveries batch by batch, a delivery claimed by another instance is skipped
+func (w *WebhookServiceStruct) deliverDue() {
+	defer func() {
+		if r := recover(); r != nil {
+			logger.Client.Error("webhookWorkerPanicked", logger.GetErrorStack())
+		}
+	}()
+	ctx := context.Background()
+	for {
+		now := time.Now()
+		deliveries, err := w.deliveries.FetchDueWebhookDeliveries(ctx, now, webhookBatchSize)
+		if err != nil {
+			return
+		}
+		workers := make(chan struct{}, webhookWorkers)
+		var wg sync.WaitGroup
+		for _, v := range deliveries {
+			claimed, err := w.deliveries.ClaimWebhookDelivery(ctx, v, now.Add(webhookClaimDuration))
+			if err != nil || !claimed {
+				continue
+			}
+			workers <- struct{}{}
+			wg.Add(1)
+			go func(delivery *domain.WebhookDelivery) {
+				defer func() {
+					<-workers
+					wg.Done()
+				}()
+				w.attempt(ctx, delivery)
+			}(v)
+		}
+		wg.Wait()
+		if len(deliveries) < webhookBatchSize {
+			return
+		}
+	}
+}
+
+// attempt posts the delivery once and records the outcome, a failed attempt is retried after the retry backoff
+// doubled for every previous attempt until WebhookMaxAttempts is reached
+func (w *WebhookServiceStruct) attempt(ctx context.Context, delivery *domain.WebhookDelivery) {
+	defer func() {
+		if r := recover(); r != nil {
+			logger.Client.Error("webhookDeliveryPanicked:id:"+*delivery.ID, logger.GetErrorStack())
+		}
+	}()
+	delivery.Attempts++
+	delivery.ResponseStatus = nil
+	delivery.Error = nil
+	// a removed or paused subscription fails the delivery straight away, it can still be redelivered by hand
+	giveUp := false
+	subscription, err := w.subscriptions.FetchWebhookSubscription(ctx, delivery.SubscriptionID)
+	switch {
+	case err != nil:
+		delivery.Error = webhookError(err.Error())
+	case subscription == nil:
+		delivery.Error = webhookError("webhookSubscriptionNotFound")
+		giveUp = true
+	case !subscription.Active:
+		delivery.Error = webhookError("webhookSubscriptionInactive")
+		giveUp = true
+	default:
+		status, err := w.sender.Send(ctx, *subscription.Url, signWebhook(subscription, delivery), []byte(*delivery.Payload))
+		if status != 0 {
+			delivery.ResponseStatus = &status
+		}
+		if err != nil {
+			delivery.Error = webhookError(err.Error())
+		} else if status < 200 || status > 299 {
+			delivery.Error = webhookError("unexpectedStatus:" + strconv.Itoa(status))
+		}
+	}
+	now := time.Now()
+	status := domain.WebhookDeliveryStatusEnum.Pending
+	delivery.Status = &status
+	delivery.UpdatedAt = now
+	delivery.NextAttemptAt = now
+	switch {
+	case delivery.Error == nil:
+		status = domain.WebhookDeliveryStatusEnum.Delivered
+	case giveUp || delivery.Attempts >= webhookMaxAttempts():
+		status = domain.WebhookDeliveryStatusEnum.Failed
+	default:
+		backoff := helper.Timeout(config.GetConfig().WebhookRetryBackoff, config.DefaultWebhookRetryBackoff)
+		delivery.NextAttemptAt = now.Add(backoff << uint(delivery.Attempts-1))
+	}
+	if err = w.deliveries.UpdateWebhookDelivery(ctx, delivery); err != nil {
+		logger.Client.Error("webhookDeliveryUpdateError:id:"+*delivery.ID, err)
+	}
+}
+
+// signWebhook signs "timestamp.payload" with the secret of the subscription, subscribers recompute the hmac and
+// reject old timestamps to stop replays
+func signWebhook(subscription *domain.WebhookSubscription, delivery *domain.WebhookDelivery) map[string]string {
+	timestamp := strconv.FormatInt(time.Now().Unix(), 10)
+	mac := hmac.New(sha256.New, []byte(*subscription.Secret))
+	_, _ = mac.Write([]byte(timestamp + "." + *delivery.Payload))
+	return map[string]string{
+		"Content-Type":         "application/json",
+		WebhookEventHeader:     *delivery.EventType,
+		WebhookDeliveryHeader:  *delivery.ID,
+		WebhookSignatureHeader: "t=" + timestamp + ",v1=" + hex.EncodeToString(mac.Sum(nil)),
+	}
+}
+
+func webhookMaxAttempts() int {
+	if config.GetConfig().WebhookMaxAttempts > 0 {
+		return config.GetConfig().WebhookMaxAttempts
+	}
+	return config.DefaultWebhookMaxAttempts
+}
+
+func webhookError(message string) *string {
+	if len(message) > webhookErrorLength {
+		message = message[:webhookErrorLength]
+	}
+	return &message
+}
+
+func (w *WebhookServiceStruct) CreateWebhookSubscription(ctx context.Context, save *domain.SaveWebhookSubscription) (*domain.WebhookSubscription, error) {
+	now := time.Now()
+	subscription := &domain.WebhookSubscription{Active: true, Publish: true, CreatedAt: now}
+	if err := applyWebhookSubscription(subscription, save); err != nil {
+		return nil, err
+	}
+	id, err := w.subscriptions.CreateWebhookSubscription(ctx, subscription)
+	if err != nil {
+		return nil, err
+	}
+	return w.GetWebhookSubscription(ctx, id)
+}
+
+func (w *WebhookServiceStruct) UpdateWebhookSubscription(ctx context.Context, id *string, save *domain.SaveWebhookSubscription) (*domain.WebhookSubscription, error) {
+	subscription, err := w.GetWebhookSubscription(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if err = applyWebhookSubscription(subscription, save); err != nil {
+		return nil, err
+	}
+	if err = w.subscriptions.UpdateWebhookSubscription(ctx, subscription); err != nil {
+		return nil, err
+	}
+	return w.GetWebhookSubscription(ctx, id)
+}
+
+// applyWebhookSubscription validates and copies the set fields of save onto the subscription
+func applyWebhookSubscription(subscription *domain.WebhookSubscription, save *domain.SaveWebhookSubscription) error {
+	if save.Url != nil {
+		subscription.Url = save.Url
+	}
+	if save.Secret != nil {
+		if len(*save.Secret) < minWebhookSecretSize {
+			return noonerror.New(noonerror.ErrBadRequest, "webhookSecretTooShort")
+		}
+		subscription.Secret = save.Secret
+	}
+	if subscription.Url == nil || subscription.Secret == nil {
+		return noonerror.New(noonerror.ErrBadRequest, "webhookUrlOrSecretMissing")
+	}
+	if save.SubtreePrefix != nil {
+		subscription.SubtreePrefix = nil
+		if *save.SubtreePrefix != "" {
+			if !subtreePrefixPattern.MatchString(*save.SubtreePrefix) {
+				return noonerror.New(noonerror.ErrBadRequest, "subtreePrefixInvalid")
+			}
+			subscription.SubtreePrefix = save.SubtreePrefix
+		}
+	}
+	if save.TagTypes != nil {
+		subscription.TagTypes = save.TagTypes
+	}
+	if save.EventTypes != nil {
+		for _, v := range save.EventTypes {
+			if !validTagEventType(v) {
+				return noonerror.New(noonerror.ErrBadRequest, "eventTypeInvalid")
+			}
+		}
+		subscription.EventTypes = save.EventTypes
+	}
+	if save.Active != nil {
+		subscription.Active = *save.Active
+	}
+	subscription.UpdatedAt = time.Now()
+	return nil
+}
+
+func validTagEventType(eventType string) bool {
+	switch eventType {
+	case domain.TagEventTypeEnum.Created, domain.TagEventTypeEnum.Renamed, domain.TagEventTypeEnum.AttributesChanged, domain.TagEventTypeEnum.Hidden,
+		domain.TagEventTypeEnum.Unhidden, domain.TagEventTypeEnum.Reordered, domain.TagEventTypeEnum.HierarchyAdded, domain.TagEventTypeEnum.HierarchyRemoved,
+		domain.TagEventTypeEnum.LocaleChanged, domain.TagEventTypeEnum.Deleted:
+		return true
+	}
+	return false
+}
+
+func (w *WebhookServiceStruct) GetWebhookSubscription(ctx context.Context, id *string) (*domain.WebhookSubscription, error) {
+	subscription, err := w.subscriptions.FetchWebhookSubscription(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if subscription == nil {
+		return nil, noonerror.New(noonerror.ErrBadRequest, "webhookSubscriptionNotFound")
+	}
+	return subscription, nil
+}
+
+func (w *WebhookServiceStruct) GetWebhookSubscriptions(ctx context.Context) ([]*domain.WebhookSubscription, error) {
+	return w.subscriptions.FetchWebhookSubscriptions(ctx)
+}
+
+// DeleteWebhookSubscription stops new deliveries, the pending ones fail on their next attempt
+func (w *WebhookServiceStruct) DeleteWebhookSubscription(ctx context.Context, id *string) error {
+	if _, err := w.GetWebhookSubscription(ctx, id); err != nil {
+		return err
+	}
+	return w.subscriptions.DeleteWebhookSubscription(ctx, id)
+}
+
+func (w *WebhookServiceStruct) GetWebhookDeliveries(ctx context.Context, getDeliveries *domain.GetWebhookDeliveries) ([]*domain.WebhookDelivery, error) {
+	if _, err := w.GetWebhookSubscription(ctx, getDeliveries.SubscriptionID); err != nil {
+		return nil, err
+	}
+	if getDeliveries.Limit <= 0 {
+		getDeliveries.Limit = defaultWebhookLimit
+	}
+	if getDeliveries.Limit > maxWebhookLimit {
+		getDeliveries.Limit = maxWebhookLimit
+	}
+	if getDeliveries.Start < 0 {
+		getDeliveries.Start = 0
+	}
+	return w.deliveries.FetchWebhookDeliveries(ctx, getDeliveries)
+}
+
+// RedeliverWebhook queues the payload of a delivery again as a new delivery, the log of the original one is kept. A
+// delivery is redelivered once, it is sent again by redelivering its redelivery.
+func (w *WebhookServiceStruct) RedeliverWebhook(ctx context.Context, id *string) (*domain.WebhookDelivery, error) {
+	delivery, err := w.deliveries.FetchWebhookDelivery(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if delivery == nil {
+		return nil, noonerror.New(noonerror.ErrBadRequest, "webhookDeliveryNotFound")
+	}
+	if _, err = w.GetWebhookSubscription(ctx, delivery.SubscriptionID); err != nil {
+		return nil, err
+	}
+	status := domain.WebhookDeliveryStatusEnum.Pending
+	now := time.Now()
+	redelivery := &domain.WebhookDelivery{SubscriptionID: delivery.SubscriptionID, EventID: delivery.EventID, EventType: delivery.EventType, TagId: delivery.TagId,
+		Payload: delivery.Payload, RedeliveryOf: delivery.ID, Status: &status, NextAttemptAt: now, CreatedAt: now, UpdatedAt: now}
+	if redelivery.ID, err = w.deliveries.CreateWebhookDelivery(ctx, redelivery); err != nil {
+		return nil, err
+	}
+	if redelivery.ID == nil {
+		return nil, noonerror.New(noonerror.ErrBadRequest, "webhookDeliveryRedelivered")
+	}
+	w.notify()
+	return redelivery, nil
+}
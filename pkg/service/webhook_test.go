@@ -0,0 +1,52 @@
+package service
+
+import (
+	"bitbucket.org/noon-micro/curriculum/pkg/domain"
+	"context"
+	"testing"
+)
+
+func TestWebhookDeliveriesOfARetriedEventAreQueuedOnce(t *testing.T) {
+	services := newTestServices(t)
+	ctx := context.Background()
+	subscription, err := services.webhooks.CreateWebhookSubscription(ctx, &domain.SaveWebhookSubscription{Url: str("https://hooks.example.com/tags"),
+		Secret: str("0123456789abcdef0123456789abcdef")})
+	if err != nil {
+		t.Fatal(err)
+	}
+	event := &domain.TagEvent{ID: "8d0f4a", Type: domain.TagEventTypeEnum.Renamed, SchemaVersion: domain.TagEventSchemaVersion, TagId: "50", TagType: "chapter"}
+	// the relay publishes the event again when another broker failed it
+	for i := 0; i < 2; i++ {
+		if err = services.webhooks.Publish(ctx, event); err != nil {
+			t.Fatal(err)
+		}
+	}
+	deliveries, err := services.webhooks.GetWebhookDeliveries(ctx, &domain.GetWebhookDeliveries{SubscriptionID: subscription.ID, Limit: 10})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(deliveries) != 1 {
+		t.Fatalf("queued %d deliveries of the event, want 1", len(deliveries))
+	}
+	original := deliveries[0]
+	redelivery, err := services.webhooks.RedeliverWebhook(ctx, original.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if redelivery.RedeliveryOf == nil || *redelivery.RedeliveryOf != *original.ID {
+		t.Errorf("redelivery repeats %v, want %s", redelivery.RedeliveryOf, *original.ID)
+	}
+	if _, err = services.webhooks.RedeliverWebhook(ctx, original.ID); errorMessage(err) != "webhookDeliveryRedelivered" {
+		t.Errorf("second redelivery of the same delivery returned %v", err)
+	}
+	if _, err = services.webhooks.RedeliverWebhook(ctx, redelivery.ID); err != nil {
+		t.Errorf("redelivery of the redelivery returned %v", err)
+	}
+	deliveries, err = services.webhooks.GetWebhookDeliveries(ctx, &domain.GetWebhookDeliveries{SubscriptionID: subscription.ID, Limit: 10})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(deliveries) != 3 {
+		t.Errorf("subscription has %d deliveries, want 3", len(deliveries))
+	}
+}